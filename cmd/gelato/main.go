@@ -7,6 +7,7 @@ import (
 	"github.com/mitchellh/cli"
 
 	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/command/add"
 	"github.com/moorara/gelato/internal/command/app"
 	"github.com/moorara/gelato/internal/command/build"
 	"github.com/moorara/gelato/internal/command/gen"
@@ -46,6 +47,9 @@ func main() {
 	c := cli.NewCLI("gelato", version.String())
 	c.Args = os.Args[1:]
 	c.Commands = map[string]cli.CommandFactory{
+		"add": func() (cli.Command, error) {
+			return add.NewCommand(ui)
+		},
 		"app": func() (cli.Command, error) {
 			return app.NewCommand(ui, spec)
 		},
@@ -53,7 +57,7 @@ func main() {
 			return build.NewCommand(ui, spec)
 		},
 		"gen": func() (cli.Command, error) {
-			return gen.NewCommand(ui)
+			return gen.NewCommand(ui, spec)
 		},
 		"release": func() (cli.Command, error) {
 			return release.NewCommand(ui, spec)
@@ -62,14 +66,22 @@ func main() {
 			return semver.NewCommand(ui)
 		},
 		"update": func() (cli.Command, error) {
-			return update.NewCommand(ui)
+			return update.NewCommand(ui, spec)
 		},
 	}
 
+	// Check for a new version in the background (except when updating)
+	checker := update.NewChecker(ui, spec)
+	if c.Subcommand() != "update" {
+		checker.Start()
+	}
+
 	code, err := c.Run()
 	if err != nil {
 		ui.Error(err.Error())
 	}
 
+	checker.Notify()
+
 	os.Exit(code)
 }
@@ -0,0 +1,176 @@
+package add
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/log"
+	"github.com/moorara/gelato/internal/service/scaffold"
+)
+
+const (
+	addTimeout  = time.Minute
+	addSynopsis = `Add a new resource to an application`
+	addHelp     = `
+  Use this command for adding a new resource to an application with horizontal layout.
+  The resource is generated across all layers of the application (entity, idl, mapper, repository, gateway, controller, and handler)
+  and it is wired into the main function and the HTTP server.
+  Existing files are never overwritten.
+
+  Usage:  gelato add [flags]
+
+  Flags:
+    -name:    the resource name (e.g. order or purchase-order)
+
+  Examples:
+    gelato add
+    gelato add -name=order
+  `
+)
+
+type (
+	findModuleFunc func(string, string) (command.Module, error)
+	layersFunc     func(string) map[string]bool
+)
+
+type scaffoldService interface {
+	Add(string, scaffold.Resource) ([]string, error)
+}
+
+// Command is the cli.Command implementation for add command.
+type Command struct {
+	ui       cli.Ui
+	services struct {
+		scaffold scaffoldService
+	}
+	funcs struct {
+		findModule findModuleFunc
+		layers     layersFunc
+	}
+	outputs struct{}
+}
+
+// NewCommand creates an add command.
+func NewCommand(ui cli.Ui) (*Command, error) {
+	return &Command{
+		ui: ui,
+	}, nil
+}
+
+// Synopsis returns a short one-line synopsis of the command.
+func (c *Command) Synopsis() string {
+	return addSynopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *Command) Help() string {
+	return addHelp
+}
+
+// Run runs the actual command with the given command-line arguments.
+// This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
+func (c *Command) Run(args []string) int {
+	c.services.scaffold = scaffold.NewScaffolder(log.Info)
+	c.funcs.findModule = command.FindModule
+	c.funcs.layers = scaffold.Layers
+
+	return c.run(args)
+}
+
+// run in an auxiliary method, so we can test the business logic with mock dependencies.
+func (c *Command) run(args []string) int {
+	var name string
+
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	fs.StringVar(&name, "name", "", "")
+	fs.Usage = func() {
+		c.ui.Output(c.Help())
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return command.FlagError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), addTimeout)
+	defer cancel()
+
+	// ==============================> RUN PREFLIGHT CHECKS <==============================
+
+	checklist := command.PreflightChecklist{}
+
+	info, err := command.RunPreflightChecks(ctx, checklist)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.PreflightError
+	}
+
+	// ==============================> CHECK THE APPLICATION <==============================
+
+	// The working directory is the root of the application, so the go.mod file is only looked up there
+	module, err := c.funcs.findModule(info.WorkingDirectory, info.WorkingDirectory)
+	if err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to read the Go module: %s", err))
+		return command.OSError
+	}
+
+	if module.Name == "" {
+		c.ui.Error("gelato add should be run in the root directory of an application (no go.mod file found)")
+		return command.UnsupportedError
+	}
+
+	layers := c.funcs.layers(info.WorkingDirectory)
+
+	var missing []string
+	for _, layer := range scaffold.RequiredLayers {
+		if !layers[layer] {
+			missing = append(missing, filepath.Join("internal", layer))
+		}
+	}
+
+	if len(missing) > 0 {
+		c.ui.Error(fmt.Sprintf("gelato add only supports applications with horizontal layout (missing %s)", strings.Join(missing, ", ")))
+		return command.UnsupportedError
+	}
+
+	// ==============================> ASK FOR THE RESOURCE NAME <==============================
+
+	if name == "" {
+		if name, err = c.ui.Ask("Resource name:"); err != nil {
+			c.ui.Error(err.Error())
+			return command.InputError
+		}
+	}
+
+	res, err := scaffold.NewResource(module.Name, name)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.UnsupportedError
+	}
+
+	res.Gateway = layers["gateway"]
+
+	// ==============================> GENERATE THE RESOURCE <==============================
+
+	paths, err := c.services.scaffold.Add(info.WorkingDirectory, res)
+	if err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to add %s: %s", res.Singular, err))
+		return command.GenerationError
+	}
+
+	for _, path := range paths {
+		c.ui.Output("  " + filepath.ToSlash(path))
+	}
+
+	c.ui.Info(fmt.Sprintf("%s is ready at /v1/%s.", res.Name, res.Path))
+
+	// ==============================> DONE <==============================
+
+	return command.Success
+}
@@ -0,0 +1,221 @@
+package add
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/service/scaffold"
+)
+
+var horizontalLayers = map[string]bool{
+	"controller": true,
+	"entity":     true,
+	"gateway":    true,
+	"handler":    true,
+	"idl":        true,
+	"mapper":     true,
+	"repository": true,
+	"server":     true,
+}
+
+func TestNewCommand(t *testing.T) {
+	ui := new(cli.MockUi)
+	c, err := NewCommand(ui)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestCommand_Synopsis(t *testing.T) {
+	c := &Command{}
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestCommand_Help(t *testing.T) {
+	c := &Command{}
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestCommand_Run(t *testing.T) {
+	c := &Command{ui: new(cli.MockUi)}
+	c.Run([]string{"--undefined"})
+
+	assert.NotNil(t, c.services.scaffold)
+	assert.NotNil(t, c.funcs.findModule)
+	assert.NotNil(t, c.funcs.layers)
+}
+
+func TestCommand_run(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		scaffold         *MockScaffoldService
+		findModule       findModuleFunc
+		layers           layersFunc
+		args             []string
+		expectedExitCode int
+		expectedResource scaffold.Resource
+	}{
+		{
+			name:             "UndefinedFlag",
+			args:             []string{"--undefined"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name: "FindModuleFails",
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{}, errors.New("error on reading go.mod")
+			},
+			args:             []string{},
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "NoModule",
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{}, nil
+			},
+			args:             []string{},
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name: "VerticalLayout",
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{Name: "example.com/app"}, nil
+			},
+			layers: func(string) map[string]bool {
+				return map[string]bool{}
+			},
+			args:             []string{},
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name: "NameInputFails",
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{Name: "example.com/app"}, nil
+			},
+			layers: func(string) map[string]bool {
+				return horizontalLayers
+			},
+			args:             []string{},
+			expectedExitCode: command.InputError,
+		},
+		{
+			name: "InvalidName",
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{Name: "example.com/app"}, nil
+			},
+			layers: func(string) map[string]bool {
+				return horizontalLayers
+			},
+			args:             []string{"-name=1st"},
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name: "AddFails",
+			scaffold: &MockScaffoldService{
+				AddMocks: []AddMock{
+					{OutError: errors.New("file already exists: internal/entity/order.go")},
+				},
+			},
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{Name: "example.com/app"}, nil
+			},
+			layers: func(string) map[string]bool {
+				return horizontalLayers
+			},
+			args:             []string{"-name=order"},
+			expectedExitCode: command.GenerationError,
+		},
+		{
+			name:  "Success_NameInput",
+			input: "PurchaseOrder\n",
+			scaffold: &MockScaffoldService{
+				AddMocks: []AddMock{
+					{OutPaths: []string{"internal/entity/purchase_order.go"}},
+				},
+			},
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{Name: "example.com/app"}, nil
+			},
+			layers: func(string) map[string]bool {
+				return horizontalLayers
+			},
+			args:             []string{},
+			expectedExitCode: command.Success,
+			expectedResource: scaffold.Resource{
+				Module:   "example.com/app",
+				Name:     "PurchaseOrder",
+				Var:      "purchaseOrder",
+				Label:    "purchase-order",
+				Singular: "purchase order",
+				Plural:   "purchase orders",
+				Path:     "purchase-orders",
+				File:     "purchase_order",
+				Gateway:  true,
+			},
+		},
+		{
+			name: "Success_NoGateway",
+			scaffold: &MockScaffoldService{
+				AddMocks: []AddMock{
+					{OutPaths: []string{"internal/entity/order.go"}},
+				},
+			},
+			findModule: func(string, string) (command.Module, error) {
+				return command.Module{Name: "example.com/app"}, nil
+			},
+			layers: func(string) map[string]bool {
+				return map[string]bool{
+					"controller": true,
+					"entity":     true,
+					"handler":    true,
+					"idl":        true,
+					"mapper":     true,
+					"repository": true,
+					"server":     true,
+				}
+			},
+			args:             []string{"-name=order"},
+			expectedExitCode: command.Success,
+			expectedResource: scaffold.Resource{
+				Module:   "example.com/app",
+				Name:     "Order",
+				Var:      "order",
+				Label:    "order",
+				Singular: "order",
+				Plural:   "orders",
+				Path:     "orders",
+				File:     "order",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			ui.InputReader = strings.NewReader(tc.input)
+
+			c := &Command{ui: ui}
+			c.services.scaffold = tc.scaffold
+			c.funcs.findModule = tc.findModule
+			c.funcs.layers = tc.layers
+
+			exitCode := c.run(tc.args)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.expectedExitCode == command.Success {
+				assert.Equal(t, tc.expectedResource, tc.scaffold.AddMocks[0].InResource)
+			}
+		})
+	}
+}
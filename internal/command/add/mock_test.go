@@ -0,0 +1,27 @@
+package add
+
+import (
+	"github.com/moorara/gelato/internal/service/scaffold"
+)
+
+type (
+	AddMock struct {
+		InRoot     string
+		InResource scaffold.Resource
+		OutPaths   []string
+		OutError   error
+	}
+
+	MockScaffoldService struct {
+		AddIndex int
+		AddMocks []AddMock
+	}
+)
+
+func (m *MockScaffoldService) Add(root string, res scaffold.Resource) ([]string, error) {
+	i := m.AddIndex
+	m.AddIndex++
+	m.AddMocks[i].InRoot = root
+	m.AddMocks[i].InResource = res
+	return m.AddMocks[i].OutPaths, m.AddMocks[i].OutError
+}
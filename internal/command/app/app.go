@@ -3,12 +3,12 @@ package app
 import (
 	"bytes"
 	"context"
+	"flag"
 	"fmt"
 	"html/template"
 	"io"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"time"
 
@@ -20,6 +20,7 @@ import (
 	"github.com/moorara/gelato/internal/service/archive"
 	"github.com/moorara/gelato/internal/service/edit"
 	"github.com/moorara/gelato/internal/service/git"
+	"github.com/moorara/gelato/internal/service/render"
 	"github.com/moorara/gelato/internal/spec"
 )
 
@@ -34,15 +35,37 @@ const (
 
   Flags:
     -language    the programming language of the new application (values: go{{if .App.Language}}, default: {{.App.Language}}{{end}})
-    -type        the type of the new application (values: cli|http-service|grpc-service{{if .App.Type}}, default: {{.App.Type}}){{end}})
+    -type        the type of the new application (values: cli|library|http-service|grpc-service|worker{{if .App.Type}}, default: {{.App.Type}}){{end}})
     -layout      the layout of the new application (values: vertical|horizontal{{if .App.Layout}}, default: {{.App.Layout}}){{end}})
+    -template    the name of a template in the spec, or a local directory, a local tarball, or a git repository{{if .App.Template}} (default: {{.App.Template}}){{end}}
+    -list-templates  list the available templates
     -module      the Go module name for the new application
-    -docker      the Docker ID for the Docker image of the new application
+    -docker      the Docker ID for the Docker image of the new application (not required for cli and library)
     -owners      a list of GitHub usernames, teams, or emails as code owners separated by space
+    -http-port   the HTTP port of the new application (default: 4000)
+    -grpc-port   the gRPC port of the new application (default: 5000)
+    -answers     a YAML file with the answers to the prompts (the flags take precedence over the answers)
+    -yes         accept the default values and do not prompt for missing inputs (non-interactive mode)
+    -offline     only use the cached templates and do not download them
+    -cache       manage the cached templates (values: list|prune)
+
+  By default, the templates in the gelato repository at the current revision are used.
+  A ref (branch, tag, or commit) can be specified for a git repository as repo#ref.
+  The templates in a source are expected at templates/<language>/<layout>/<type> unless otherwise specified in the spec.
+  The templates from GitHub and git repositories are cached by revision in the user cache directory.
 
   Examples:
     gelato app
+    gelato app -list-templates
+    gelato app -template=https://github.com/octocat/templates.git#v1.0.0
     gelato app -type=http-service -layout=vertical -module=github.com/octocat/service -docker=octocat -owners=@octocat
+    gelato app -type=worker -layout=horizontal -module=github.com/octocat/worker -docker=octocat -owners=@octocat
+    gelato app -type=cli -layout=horizontal -module=github.com/octocat/tool -owners=@octocat
+    gelato app -type=library -module=github.com/octocat/library -owners=@octocat
+    gelato app -answers=answers.yaml -yes
+    gelato app -offline
+    gelato app -cache=list
+    gelato app -cache=prune
   `
 )
 
@@ -65,7 +88,12 @@ type (
 		Remove(...string) error
 		Move(bool, ...edit.MoveSpec) error
 		Append(bool, ...edit.AppendSpec) error
-		ReplaceInDir(string, ...edit.ReplaceSpec) error
+	}
+
+	renderService interface {
+		ReadManifest(string) (render.Manifest, error)
+		Render(string, render.Manifest, interface{}) error
+		RenameModule(string, string, string) error
 	}
 
 	gitService interface {
@@ -81,17 +109,22 @@ type (
 
 // Command is the cli.Command implementation for app command.
 type Command struct {
-	ui       cli.Ui
-	spec     spec.Spec
+	ui   cli.Ui
+	spec spec.Spec
+	data struct {
+		cacheDir string
+	}
 	services struct {
-		repo repoService
-		arch archiveService
-		edit editService
+		arch   archiveService
+		edit   editService
+		render renderService
 	}
 	funcs struct {
+		repo      repoFunc
 		detectGit detectGitFunc
 		gitInit   gitFunc
 		gitOpen   gitFunc
+		gitClone  cloneFunc
 	}
 	outputs struct{}
 }
@@ -123,9 +156,20 @@ func (c *Command) Run(args []string) int {
 	// If no access token is provided, we try without it!
 	token := os.Getenv("GELATO_GITHUB_TOKEN")
 
-	c.services.repo = github.NewClient(token).Repo(templateOwner, templateRepo)
+	client := github.NewClient(token)
+
+	// The remote templates are not cached if there is no cache directory
+	if dir, err := os.UserCacheDir(); err == nil {
+		c.data.cacheDir = filepath.Join(dir, cacheDir, cacheTemplDir)
+	}
+
 	c.services.arch = archive.NewTarArchive(log.Info)
 	c.services.edit = edit.NewEditor(log.Info)
+	c.services.render = render.NewRenderer(log.Info)
+
+	c.funcs.repo = func(owner, name string) repoService {
+		return client.Repo(owner, name)
+	}
 
 	c.funcs.detectGit = git.DetectGit
 
@@ -137,21 +181,43 @@ func (c *Command) Run(args []string) int {
 		return git.Open(path)
 	}
 
+	c.funcs.gitClone = func(ctx context.Context, url, ref, path string) error {
+		_, err := git.Clone(ctx, url, ref, path)
+		return err
+	}
+
 	return c.run(args)
 }
 
 // run in an auxiliary method, so we can test the business logic with mock dependencies.
-func (c *Command) run(args []string) int {
+func (c *Command) run(args []string) (code int) {
 	flags := struct {
-		module string
-		docker string
-		owners string
-	}{}
+		module        string
+		docker        string
+		owners        string
+		httpPort      int
+		grpcPort      int
+		listTemplates bool
+		answers       string
+		yes           bool
+		offline       bool
+		cache         string
+	}{
+		httpPort: defaultHTTPPort,
+		grpcPort: defaultGRPCPort,
+	}
 
 	fs := c.spec.App.FlagSet()
 	fs.StringVar(&flags.module, "module", flags.module, "")
 	fs.StringVar(&flags.docker, "docker", flags.docker, "")
 	fs.StringVar(&flags.owners, "owners", flags.owners, "")
+	fs.IntVar(&flags.httpPort, "http-port", flags.httpPort, "")
+	fs.IntVar(&flags.grpcPort, "grpc-port", flags.grpcPort, "")
+	fs.BoolVar(&flags.listTemplates, "list-templates", flags.listTemplates, "")
+	fs.StringVar(&flags.answers, "answers", flags.answers, "")
+	fs.BoolVar(&flags.yes, "yes", flags.yes, "")
+	fs.BoolVar(&flags.offline, "offline", flags.offline, "")
+	fs.StringVar(&flags.cache, "cache", flags.cache, "")
 	fs.Usage = func() {
 		c.ui.Output(c.Help())
 	}
@@ -160,6 +226,15 @@ func (c *Command) run(args []string) int {
 		return command.FlagError
 	}
 
+	if flags.listTemplates {
+		c.listTemplates()
+		return command.Success
+	}
+
+	if flags.cache != "" {
+		return c.manageCache(flags.cache)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), appTimeout)
 	defer cancel()
 
@@ -175,6 +250,67 @@ func (c *Command) run(args []string) int {
 
 	// ==============================> GET INPUTS <==============================
 
+	// The command-line flags take precedence over the answers file and the answers file takes precedence over the spec file
+	var answered answers
+	if flags.answers != "" {
+		answered, err = readAnswers(flags.answers)
+		if err != nil {
+			c.ui.Error(err.Error())
+			return command.InputError
+		}
+
+		set := map[string]bool{}
+		fs.Visit(func(f *flag.Flag) {
+			set[f.Name] = true
+		})
+
+		setString(&c.spec.App.Language, answered.Language, set["language"])
+		setString(&c.spec.App.Type, answered.Type, set["type"])
+		setString(&c.spec.App.Layout, answered.Layout, set["layout"])
+		setString(&c.spec.App.Template, answered.Template, set["template"])
+		setString(&flags.module, answered.Module, set["module"])
+		setString(&flags.docker, answered.Docker, set["docker"])
+		setString(&flags.owners, answered.Owners, set["owners"])
+		setInt(&flags.httpPort, answered.HTTPPort, set["http-port"])
+		setInt(&flags.grpcPort, answered.GRPCPort, set["grpc-port"])
+	}
+
+	// Libraries are not sliced into layers
+	if c.spec.App.Layout == "" && c.spec.App.Type == spec.AppTypeLibrary {
+		c.spec.App.Layout = spec.AppLayoutVertical
+	}
+
+	if flags.yes {
+		if c.spec.App.Language == "" {
+			c.spec.App.Language = spec.AppLanguageGo
+		}
+
+		if c.spec.App.Layout == "" {
+			c.spec.App.Layout = spec.AppLayoutVertical
+		}
+	}
+
+	// Validate the inputs provided upfront all together before asking for the missing ones
+	// In non-interactive mode (-yes), the missing inputs are reported too
+	errs := inputs{
+		Language: c.spec.App.Language,
+		Type:     c.spec.App.Type,
+		Layout:   c.spec.App.Layout,
+		Template: c.spec.App.Template,
+		Module:   flags.module,
+		Docker:   flags.docker,
+		Owners:   flags.owners,
+		HTTPPort: flags.httpPort,
+		GRPCPort: flags.grpcPort,
+	}.validate(flags.yes)
+
+	if len(errs) > 0 {
+		for _, err := range errs {
+			c.ui.Error(err.Error())
+		}
+		return command.UnsupportedError
+	}
+
 	if c.spec.App.Language == "" {
 		langOptions := strings.Join([]string{spec.AppLanguageGo}, ", ")
 		c.spec.App.Language, err = c.ui.Ask(fmt.Sprintf("Application Language (%s):", langOptions))
@@ -183,26 +319,29 @@ func (c *Command) run(args []string) int {
 			return command.InputError
 		}
 
-		// Only Go applications are supported
-		if c.spec.App.Language != spec.AppLanguageGo {
-			c.ui.Error(fmt.Sprintf("unsupported application language: %s", c.spec.App.Language))
+		if err := validateLanguage(c.spec.App.Language); err != nil {
+			c.ui.Error(err.Error())
 			return command.UnsupportedError
 		}
 	}
 
 	if c.spec.App.Type == "" {
-		typeOptions := strings.Join([]string{spec.AppTypeCLI, spec.AppTypeHTTPService, spec.AppTypeGRPCService}, ", ")
+		typeOptions := strings.Join([]string{spec.AppTypeCLI, spec.AppTypeLibrary, spec.AppTypeHTTPService, spec.AppTypeGRPCService, spec.AppTypeWorker}, ", ")
 		c.spec.App.Type, err = c.ui.Ask(fmt.Sprintf("Application Type (%s):", typeOptions))
 		if err != nil {
 			c.ui.Error(fmt.Sprintf("invalid application type: %s", err))
 			return command.InputError
 		}
 
-		// Only HTTP and gRPC services are supported
-		if c.spec.App.Type != spec.AppTypeHTTPService && c.spec.App.Type != spec.AppTypeGRPCService {
-			c.ui.Error(fmt.Sprintf("unsupported application type: %s", c.spec.App.Type))
+		if err := validateType(c.spec.App.Type); err != nil {
+			c.ui.Error(err.Error())
 			return command.UnsupportedError
 		}
+
+		// Libraries are not sliced into layers
+		if c.spec.App.Layout == "" && c.spec.App.Type == spec.AppTypeLibrary {
+			c.spec.App.Layout = spec.AppLayoutVertical
+		}
 	}
 
 	if c.spec.App.Layout == "" {
@@ -213,9 +352,16 @@ func (c *Command) run(args []string) int {
 			return command.InputError
 		}
 
-		// Only vertical and horizontal layouts are supported
-		if c.spec.App.Layout != spec.AppLayoutVertical && c.spec.App.Layout != spec.AppLayoutHorizontal {
-			c.ui.Error(fmt.Sprintf("unsupported application layout: %s", c.spec.App.Layout))
+		if err := validateLayout(c.spec.App.Layout); err != nil {
+			c.ui.Error(err.Error())
+			return command.UnsupportedError
+		}
+	}
+
+	// The application type and layout may be asked for separately
+	if c.spec.App.Template == "" {
+		if err := validateBuiltinTemplate(c.spec.App.Language, c.spec.App.Type, c.spec.App.Layout); err != nil {
+			c.ui.Error(err.Error())
 			return command.UnsupportedError
 		}
 	}
@@ -227,22 +373,21 @@ func (c *Command) run(args []string) int {
 			return command.InputError
 		}
 
-		// TODO: validate module name using a regular expression
-		if flags.module == "" {
-			c.ui.Error(fmt.Sprintf("unsupported module name: %s", flags.module))
+		if err := validateModule(flags.module); err != nil {
+			c.ui.Error(err.Error())
 			return command.UnsupportedError
 		}
 	}
 
-	if flags.docker == "" {
+	if flags.docker == "" && needsDocker(c.spec.App.Type) {
 		flags.docker, err = c.ui.Ask("Docker ID:")
 		if err != nil {
 			c.ui.Error(fmt.Sprintf("invalid Docker ID: %s", err))
 			return command.InputError
 		}
 
-		if flags.docker == "" {
-			c.ui.Error(fmt.Sprintf("unsupported Docker ID: %s", flags.docker))
+		if err := validateDockerID(flags.docker); err != nil {
+			c.ui.Error(err.Error())
 			return command.UnsupportedError
 		}
 	}
@@ -254,8 +399,8 @@ func (c *Command) run(args []string) int {
 			return command.InputError
 		}
 
-		if flags.owners == "" {
-			c.ui.Error(fmt.Sprintf("unsupported GitHub code owners: %s", flags.owners))
+		if err := validateOwners(flags.owners); err != nil {
+			c.ui.Error(err.Error())
 			return command.UnsupportedError
 		}
 	}
@@ -263,51 +408,43 @@ func (c *Command) run(args []string) int {
 	appName := filepath.Base(flags.module)
 	appPath := filepath.Join(info.WorkingDirectory, appName)
 
-	// ==============================> DOWNLOAD REPO ARCHIVE <==============================
+	// ==============================> FETCH TEMPLATE <==============================
 
-	buf := new(bytes.Buffer)
-
-	ref := c.spec.Gelato.Revision
-	if ref == "" {
-		ref = "main"
+	tmpl, err := c.resolveTemplate(c.spec.App.Template)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.InputError
 	}
 
-	c.ui.Output(fmt.Sprintf("Downloading templates revision %s ...", ref))
-
-	_, err = c.services.repo.DownloadTarArchive(ctx, ref, buf)
+	source, err := c.newSource(tmpl, flags.offline)
 	if err != nil {
-		c.ui.Error(fmt.Sprintf("Failed to download repository archive: %s", err))
-		return command.GitHubError
+		c.ui.Error(err.Error())
+		return command.InputError
 	}
 
-	// ==============================> EXTRACT REPO ARCHIVE <==============================
-
-	c.ui.Output(fmt.Sprintf("Extracting templates revision %s ...", ref))
-
-	targetPath := filepath.Join("templates",
-		c.spec.App.Language,
-		c.spec.App.Layout,
-		c.spec.App.Type,
-	)
+	// ==============================> STAGE APPLICATION <==============================
 
-	dirRegex, err := regexp.Compile(fmt.Sprintf("%s-%s-[0-9a-f]{7,40}/%s", templateOwner, templateRepo, targetPath))
+	// The application is created in a staging directory and moved into place when it is ready
+	// On failure, the staging directory is removed and the shared files in the monorepo are restored
+	stage, err := newStaging(appPath)
 	if err != nil {
-		c.ui.Error(fmt.Sprintf("Cannot create regex for directory: %s", err))
-		return command.MiscError
+		c.ui.Error(fmt.Sprintf("Failed to create staging directory: %s", err))
+		return command.OSError
 	}
 
-	err = c.services.arch.Extract(info.WorkingDirectory, buf, func(path string) (string, bool) {
-		if !strings.Contains(path, targetPath) {
-			return "", false
+	defer func() {
+		if code != command.Success {
+			if err := stage.Rollback(); err != nil {
+				c.ui.Warn(fmt.Sprintf("Failed to roll back %s: %s", appName, err))
+			}
 		}
+	}()
 
-		path = dirRegex.ReplaceAllString(path, appName)
-		return path, true
-	})
+	c.ui.Output(fmt.Sprintf("Fetching template %s from %s ...", tmpl.Path, source))
 
-	if err != nil {
-		c.ui.Error(err.Error())
-		return command.ExtractionError
+	if err := source.Fetch(ctx, tmpl.Path, stage.dir); err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to fetch template: %s", err))
+		return exitCode(err)
 	}
 
 	// ==============================> OPEN GIT REPO <==============================
@@ -320,7 +457,7 @@ func (c *Command) run(args []string) int {
 	}
 
 	if !monorepo {
-		if git, err = c.funcs.gitInit(appPath); err != nil {
+		if git, err = c.funcs.gitInit(stage.dir); err != nil {
 			c.ui.Error(fmt.Sprintf("Failed to init git repo: %s", err))
 			return command.GitError
 		}
@@ -339,6 +476,11 @@ func (c *Command) run(args []string) int {
 		return command.GitError
 	}
 
+	// The new repository of a microrepo is in the staging directory and is moved along with the application
+	if !monorepo {
+		repoPath = appPath
+	}
+
 	submod, err := git.Submodule(makeSubmod)
 	if err != nil {
 		c.ui.Error(fmt.Sprintf("Failed to get make git submodule: %s", err))
@@ -366,155 +508,118 @@ func (c *Command) run(args []string) int {
 		return command.MiscError
 	}
 
-	// ==============================> EDIT FILES <==============================
+	// ==============================> RESOLVE REPO URL <==============================
+
+	var repoURL, workflowName string
+
+	if !monorepo {
+		repoURL = fmt.Sprintf("https://%s", flags.module)
+		workflowName = "Main"
+	} else {
+		repoDomain, repoFullName, err := git.Remote("origin")
+		if err != nil {
+			c.ui.Error(fmt.Sprintf("Failed to get git remote url: %s", err))
+			return command.GitError
+		}
+
+		repoURL = fmt.Sprintf("https://%s/%s", repoDomain, repoFullName)
+		workflowName = appName
+	}
+
+	// ==============================> RENDER TEMPLATE <==============================
 
 	c.ui.Output(fmt.Sprintf("Finishing %s ...", appName))
 
-	specs := []edit.ReplaceSpec{
-		// Edit module name and import paths
-		{
-			PathRE: regexp.MustCompile(`(\.go|\.proto|go.mod|README.md)$`),
-			OldRE: regexp.MustCompile(fmt.Sprintf(`%s/%s`,
-				c.spec.App.Layout,
-				c.spec.App.Type,
-			)),
-			New: flags.module,
-		},
-		// Edit application name
-		{
-			PathRE: regexp.MustCompile(`(main\.go|\.gitignore|\.dockerignore|Makefile|Dockerfile|Dockerfile\.test|docker-compose\.yml|\.md)$`),
-			OldRE:  regexp.MustCompile(c.spec.App.Type),
-			New:    appName,
-		},
-		// Edit Docker image
-		{
-			PathRE: regexp.MustCompile(`Makefile$`),
-			OldRE:  regexp.MustCompile(`dockerid`),
-			New:    flags.docker,
-		},
-		// Edit make submodule path
-		{
-			PathRE: regexp.MustCompile(`Makefile$`),
-			OldRE:  regexp.MustCompile(`\.\./\.\./make`),
-			New:    makeRelPath,
-		},
-		// Edit GitHub code owners
-		{
-			PathRE: regexp.MustCompile(`CODEOWNERS$`),
-			OldRE:  regexp.MustCompile(`@octocat`),
-			New:    flags.owners,
-		},
-	}
-
-	if err := c.services.edit.ReplaceInDir(appPath, specs...); err != nil {
-		c.ui.Error(err.Error())
-		return command.OSError
+	manifest, err := c.services.render.ReadManifest(stage.dir)
+	if err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to read template manifest: %s", err))
+		return command.GenerationError
 	}
 
-	// ==============================> PREPARE GIT REPO <==============================
+	vars, code := c.askVariables(manifest.Variables, answered.Vars, flags.yes)
+	if code != command.Success {
+		return code
+	}
 
-	monorepoWorkflowPath := filepath.Join(appPath, ".github", "workflows", "monorepo.yml")
+	data := templateData{
+		Language:     c.spec.App.Language,
+		Type:         c.spec.App.Type,
+		Layout:       c.spec.App.Layout,
+		Module:       flags.module,
+		Name:         appName,
+		DockerID:     flags.docker,
+		Owners:       flags.owners,
+		Monorepo:     monorepo,
+		RepoURL:      repoURL,
+		WorkflowName: workflowName,
+		AppPath:      filepath.ToSlash(relAppPath),
+		MakePath:     filepath.ToSlash(makeRelPath),
+		HTTPPort:     flags.httpPort,
+		GRPCPort:     flags.grpcPort,
+		Vars:         vars,
+	}
 
-	if !monorepo {
-		specs := []edit.ReplaceSpec{
-			// Edit README
-			{
-				PathRE: regexp.MustCompile(`README.md$`),
-				OldRE:  regexp.MustCompile(`REPO_URL`),
-				New:    fmt.Sprintf("https://%s", flags.module),
-			},
-			// Edit README
-			{
-				PathRE: regexp.MustCompile(`README.md$`),
-				OldRE:  regexp.MustCompile(`WORKFLOW_NAME`),
-				New:    "Main",
-			},
-		}
+	if err := c.services.render.Render(stage.dir, manifest, data); err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to render template: %s", err))
+		return command.GenerationError
+	}
 
-		if err := c.services.edit.ReplaceInDir(appPath, specs...); err != nil {
-			c.ui.Error(err.Error())
-			return command.OSError
-		}
+	if err := c.services.render.RenameModule(stage.dir, manifest.Module, flags.module); err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to rename module: %s", err))
+		return command.GenerationError
+	}
 
-		if err := c.services.edit.Remove(monorepoWorkflowPath); err != nil {
-			c.ui.Error(fmt.Sprintf("Failed to remove: %s", err))
-			return command.OSError
-		}
+	// ==============================> PREPARE GIT REPO <==============================
 
+	if !monorepo {
 		if err := git.UpdateSubmodules(); err != nil {
 			c.ui.Error(fmt.Sprintf("Failed to add update git submodules: %s", err))
 			return command.GitError
 		}
 	} else {
-		repoDomain, repoFullName, err := git.Remote("origin")
-		if err != nil {
-			c.ui.Error(fmt.Sprintf("Failed to get git remote url: %s", err))
-			return command.GitError
+		moveWorkflow := edit.MoveSpec{
+			Src:  filepath.Join(stage.dir, ".github", "workflows", "monorepo.yml"),
+			Dest: filepath.Join(repoPath, ".github", "workflows", fmt.Sprintf("%s.yml", appName)),
 		}
 
-		specs := []edit.ReplaceSpec{
-			// Edit monorepo workflow
-			{
-				PathRE: regexp.MustCompile(`monorepo.yml$`),
-				OldRE:  regexp.MustCompile(`APP_NAME`),
-				New:    appName,
-			},
-			// Edit monorepo workflow
-			{
-				PathRE: regexp.MustCompile(`monorepo.yml$`),
-				OldRE:  regexp.MustCompile(`RELATIVE_PATH`),
-				New:    relAppPath,
-			},
-			// Edit README
-			{
-				PathRE: regexp.MustCompile(`README.md$`),
-				OldRE:  regexp.MustCompile(`REPO_URL`),
-				New:    fmt.Sprintf("https://%s/%s", repoDomain, repoFullName),
-			},
-			// Edit README
-			{
-				PathRE: regexp.MustCompile(`README.md$`),
-				OldRE:  regexp.MustCompile(`WORKFLOW_NAME`),
-				New:    appName,
-			},
+		appendCodeOwner := edit.AppendSpec{
+			Path:    filepath.Join(repoPath, ".github", "CODEOWNERS"),
+			Content: fmt.Sprintf("/%s/  %s", relAppPath, flags.owners),
 		}
 
-		if err := c.services.edit.ReplaceInDir(appPath, specs...); err != nil {
-			c.ui.Error(err.Error())
+		// Keep the original state of the shared files, so they can be restored on failure
+		if err := stage.Track(moveWorkflow.Dest, appendCodeOwner.Path); err != nil {
+			c.ui.Error(fmt.Sprintf("Failed to read shared files: %s", err))
 			return command.OSError
 		}
 
 		// Move workflow file
-		moveWorkflow := edit.MoveSpec{
-			Src:  monorepoWorkflowPath,
-			Dest: filepath.Join(repoPath, ".github", "workflows", fmt.Sprintf("%s.yml", appName)),
-		}
-
 		if err := c.services.edit.Move(true, moveWorkflow); err != nil {
 			c.ui.Error(fmt.Sprintf("Failed to move: %s", err))
 			return command.OSError
 		}
 
 		// Add code owners
-		appendCodeOwner := edit.AppendSpec{
-			Path:    filepath.Join(repoPath, ".github", "CODEOWNERS"),
-			Content: fmt.Sprintf("/%s/  %s", relAppPath, flags.owners),
-		}
-
 		if err := c.services.edit.Append(true, appendCodeOwner); err != nil {
 			c.ui.Error(fmt.Sprintf("Failed to append: %s", err))
 			return command.OSError
 		}
 
-		// Remove irrelevant directories and files
-		githubDir := filepath.Join(appPath, ".github")
-		gitmodFile := filepath.Join(appPath, ".gitmodules")
-		if err := c.services.edit.Remove(githubDir, gitmodFile); err != nil {
+		// Remove the repository-level files
+		githubDir := filepath.Join(stage.dir, ".github")
+		if err := c.services.edit.Remove(githubDir); err != nil {
 			c.ui.Error(fmt.Sprintf("Failed to remove: %s", err))
 			return command.OSError
 		}
 	}
 
+	// ==============================> COMMIT APPLICATION <==============================
+
+	if err := stage.Commit(); err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to move %s into place: %s", appName, err))
+		return command.OSError
+	}
+
 	// ==============================> DONE <==============================
 
 	c.ui.Info(fmt.Sprintf("%s is ready.", appName))
@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"errors"
 	"io"
+	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 
@@ -12,6 +14,7 @@ import (
 
 	"github.com/moorara/gelato/internal/command"
 	"github.com/moorara/gelato/internal/service/git"
+	"github.com/moorara/gelato/internal/service/render"
 	"github.com/moorara/gelato/internal/spec"
 	"github.com/moorara/go-github"
 )
@@ -43,25 +46,54 @@ func TestCommand_Run(t *testing.T) {
 	c := &Command{ui: cli.NewMockUi()}
 	c.Run([]string{"--undefined"})
 
-	assert.NotNil(t, c.services.repo)
 	assert.NotNil(t, c.services.arch)
 	assert.NotNil(t, c.services.edit)
+	assert.NotNil(t, c.funcs.repo)
 	assert.NotNil(t, c.funcs.detectGit)
 	assert.NotNil(t, c.funcs.gitInit)
 	assert.NotNil(t, c.funcs.gitOpen)
+	assert.NotNil(t, c.funcs.gitClone)
+}
+
+// dirEntries returns the names of the entries in the working directory.
+func dirEntries(t *testing.T) map[string]bool {
+	infos, err := ioutil.ReadDir(".")
+	assert.NoError(t, err)
+
+	names := map[string]bool{}
+	for _, info := range infos {
+		names[info.Name()] = true
+	}
+
+	return names
+}
+
+// newEntries returns the names of the entries created in the working directory.
+func newEntries(t *testing.T, before map[string]bool) []string {
+	var names []string
+	for name := range dirEntries(t) {
+		if !before[name] {
+			names = append(names, name)
+		}
+	}
+
+	return names
 }
 
 func TestCommand_run(t *testing.T) {
 	tests := []struct {
 		name             string
+		spec             spec.Spec
 		repo             *MockRepoService
 		arch             *MockArchiveService
 		edit             *MockEditService
 		detectGit        detectGitFunc
 		gitInit          gitFunc
 		gitOpen          gitFunc
+		render           *MockRenderService
 		args             []string
 		inputs           string
+		expectedData     *templateData
 		expectedExitCode int
 	}{
 		{
@@ -72,6 +104,22 @@ func TestCommand_run(t *testing.T) {
 			args:             []string{"--undefined"},
 			expectedExitCode: command.FlagError,
 		},
+		{
+			name:             "ListTemplates",
+			repo:             &MockRepoService{},
+			arch:             &MockArchiveService{},
+			edit:             &MockEditService{},
+			args:             []string{"-list-templates"},
+			expectedExitCode: command.Success,
+		},
+		{
+			name:             "Cache_NoCacheDir",
+			repo:             &MockRepoService{},
+			arch:             &MockArchiveService{},
+			edit:             &MockEditService{},
+			args:             []string{"-cache=list"},
+			expectedExitCode: command.OSError,
+		},
 		{
 			name:             "InvalidAppLang",
 			repo:             &MockRepoService{},
@@ -123,7 +171,7 @@ func TestCommand_run(t *testing.T) {
 			arch:             &MockArchiveService{},
 			edit:             &MockEditService{},
 			args:             []string{},
-			inputs:           "go\ncli\n",
+			inputs:           "go\nweb\n",
 			expectedExitCode: command.UnsupportedError,
 		},
 		{
@@ -207,6 +255,97 @@ func TestCommand_run(t *testing.T) {
 			inputs:           "go\nhttp-service\nvertical\ngithub.com/octocat/service\noctocat\n\n",
 			expectedExitCode: command.UnsupportedError,
 		},
+		{
+			name:             "AnswersFileNotFound",
+			repo:             &MockRepoService{},
+			arch:             &MockArchiveService{},
+			edit:             &MockEditService{},
+			args:             []string{"-answers=test/null.yaml"},
+			expectedExitCode: command.InputError,
+		},
+		{
+			name: "InvalidInputs",
+			repo: &MockRepoService{},
+			arch: &MockArchiveService{},
+			edit: &MockEditService{},
+			args: []string{
+				"-language=javascript",
+				"-type=web",
+				"-module=octocat",
+				"-owners=octocat",
+			},
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name: "UnsupportedLibraryLayout",
+			repo: &MockRepoService{},
+			arch: &MockArchiveService{},
+			edit: &MockEditService{},
+			args: []string{
+				"-language=go",
+				"-type=library",
+				"-layout=horizontal",
+			},
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name:             "UnsupportedLibraryLayout_Asked",
+			repo:             &MockRepoService{},
+			arch:             &MockArchiveService{},
+			edit:             &MockEditService{},
+			args:             []string{"-layout=horizontal"},
+			inputs:           "go\nlibrary\n",
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name:             "NonInteractive_MissingInputs",
+			repo:             &MockRepoService{},
+			arch:             &MockArchiveService{},
+			edit:             &MockEditService{},
+			args:             []string{"-yes"},
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name: "TemplateNotFound",
+			repo: &MockRepoService{},
+			arch: &MockArchiveService{},
+			edit: &MockEditService{},
+			args: []string{
+				"-language=go",
+				"-type=http-service",
+				"-layout=vertical",
+				"-module=github.com/octocat/service",
+				"-docker=octocat",
+				"-owners=@octocat",
+				"-template=/dev/null/templates",
+			},
+			inputs:           "",
+			expectedExitCode: command.InputError,
+		},
+		{
+			name: "InvalidTemplatePath",
+			spec: spec.Spec{
+				App: spec.App{
+					Templates: []spec.Template{
+						{Name: "service", Source: "github.com/octocat/templates", Path: "../service"},
+					},
+				},
+			},
+			repo: &MockRepoService{},
+			arch: &MockArchiveService{},
+			edit: &MockEditService{},
+			args: []string{
+				"-language=go",
+				"-type=http-service",
+				"-layout=vertical",
+				"-module=github.com/octocat/service",
+				"-docker=octocat",
+				"-owners=@octocat",
+				"-template=service",
+			},
+			inputs:           "",
+			expectedExitCode: command.InputError,
+		},
 		{
 			name: "DownloadTarArchiveFails",
 			repo: &MockRepoService{
@@ -222,7 +361,7 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitHubError,
@@ -246,7 +385,7 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.ExtractionError,
@@ -276,7 +415,7 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitError,
@@ -306,7 +445,7 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitError,
@@ -340,7 +479,7 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitError,
@@ -374,7 +513,7 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitError,
@@ -411,7 +550,7 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitError,
@@ -448,13 +587,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitError,
 		},
 		{
-			name: "Microrepo_FirstReplaceFails",
+			name: "Monorepo_GitRemoteFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -465,9 +604,57 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
-					{OutError: errors.New("error on replacing")},
+			edit: &MockEditService{},
+			detectGit: func(string) (string, error) {
+				return "/home/user/code/github.com/octocat/monorepo", nil
+			},
+			gitOpen: func(string) (gitService, error) {
+				return &MockGitService{
+					PathMocks: []PathMock{
+						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+					},
+					SubmoduleMocks: []SubmoduleMock{
+						{
+							OutSubmodule: git.Submodule{
+								Name:   "make",
+								Path:   "services/common/make",
+								URL:    "git@github.com:moorara/make.git",
+								Branch: "main",
+							},
+						},
+					},
+					RemoteMocks: []RemoteMock{
+						{OutError: errors.New("git error")},
+					},
+				}, nil
+			},
+			args: []string{
+				"-language=go",
+				"-type=http-service",
+				"-layout=vertical",
+				"-module=github.com/octocat/monorepo/services/domain/product/name",
+				"-docker=octocat",
+				"-owners=@octocat",
+			},
+			inputs:           "",
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "ReadManifestFails",
+			repo: &MockRepoService{
+				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			arch: &MockArchiveService{
+				ExtractMocks: []ExtractMock{
+					{OutError: nil},
+				},
+			},
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutError: errors.New("invalid manifest")},
 				},
 			},
 			detectGit: func(string) (string, error) {
@@ -476,7 +663,7 @@ func TestCommand_run(t *testing.T) {
 			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
@@ -488,6 +675,9 @@ func TestCommand_run(t *testing.T) {
 							},
 						},
 					},
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
+						{OutError: nil},
+					},
 				}, nil
 			},
 			args: []string{
@@ -496,13 +686,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
-			expectedExitCode: command.OSError,
+			expectedExitCode: command.GenerationError,
 		},
 		{
-			name: "Monorepo_FirstReplaceFails",
+			name: "AskVariableFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -513,44 +703,55 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
-					{OutError: errors.New("error on replacing")},
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{
+						OutManifest: render.Manifest{
+							Module: "vertical/http-service",
+							Variables: []render.Variable{
+								{Name: "team", Prompt: "Team name", Default: "platform", Pattern: "^[a-z]+$"},
+							},
+						},
+					},
 				},
 			},
 			detectGit: func(string) (string, error) {
-				return "/home/user/code/github.com/octocat/monorepo", nil
+				return "", errors.New("git not found")
 			},
-			gitOpen: func(string) (gitService, error) {
+			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
 							OutSubmodule: git.Submodule{
 								Name:   "make",
-								Path:   "services/common/make",
+								Path:   "make",
 								URL:    "git@github.com:moorara/make.git",
 								Branch: "main",
 							},
 						},
 					},
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
+						{OutError: nil},
+					},
 				}, nil
 			},
 			args: []string{
 				"-language=go",
 				"-type=http-service",
 				"-layout=vertical",
-				"-module=github.com/octocat/monorepo/services/domain/product/name",
+				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
-			expectedExitCode: command.OSError,
+			expectedExitCode: command.InputError,
 		},
 		{
-			name: "Microrepo_SecondReplaceFails",
+			name: "InvalidVariable",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -561,10 +762,17 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
-					{OutError: nil},
-					{OutError: errors.New("error on replacing")},
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{
+						OutManifest: render.Manifest{
+							Module: "vertical/http-service",
+							Variables: []render.Variable{
+								{Name: "team", Prompt: "Team name", Default: "platform", Pattern: "^[a-z]+$"},
+							},
+						},
+					},
 				},
 			},
 			detectGit: func(string) (string, error) {
@@ -573,7 +781,7 @@ func TestCommand_run(t *testing.T) {
 			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
@@ -585,6 +793,9 @@ func TestCommand_run(t *testing.T) {
 							},
 						},
 					},
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
+						{OutError: nil},
+					},
 				}, nil
 			},
 			args: []string{
@@ -593,13 +804,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
-			inputs:           "",
-			expectedExitCode: command.OSError,
+			inputs:           "Platform\n",
+			expectedExitCode: command.UnsupportedError,
 		},
 		{
-			name: "Microrepo_RemoveFails",
+			name: "RenderFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -610,13 +821,13 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
-					{OutError: nil},
-					{OutError: nil},
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutManifest: render.Manifest{}},
 				},
-				RemoveMocks: []RemoveMock{
-					{OutError: errors.New("remove error")},
+				RenderMocks: []RenderMock{
+					{OutError: errors.New("template error")},
 				},
 			},
 			detectGit: func(string) (string, error) {
@@ -625,7 +836,7 @@ func TestCommand_run(t *testing.T) {
 			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
@@ -637,6 +848,9 @@ func TestCommand_run(t *testing.T) {
 							},
 						},
 					},
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
+						{OutError: nil},
+					},
 				}, nil
 			},
 			args: []string{
@@ -645,13 +859,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
-			expectedExitCode: command.OSError,
+			expectedExitCode: command.GenerationError,
 		},
 		{
-			name: "Microrepo_GitUpdateSubmodulesFails",
+			name: "RenameModuleFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -662,14 +876,17 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
-					{OutError: nil},
-					{OutError: nil},
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutManifest: render.Manifest{}},
 				},
-				RemoveMocks: []RemoveMock{
+				RenderMocks: []RenderMock{
 					{OutError: nil},
 				},
+				RenameModuleMocks: []RenameModuleMock{
+					{OutError: errors.New("invalid go file")},
+				},
 			},
 			detectGit: func(string) (string, error) {
 				return "", errors.New("git not found")
@@ -677,7 +894,7 @@ func TestCommand_run(t *testing.T) {
 			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
@@ -690,7 +907,7 @@ func TestCommand_run(t *testing.T) {
 						},
 					},
 					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
-						{OutError: errors.New("git error")},
+						{OutError: nil},
 					},
 				}, nil
 			},
@@ -700,13 +917,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
-			expectedExitCode: command.GitError,
+			expectedExitCode: command.GenerationError,
 		},
 		{
-			name: "Monorepo_GitRemoteFails",
+			name: "Microrepo_GitUpdateSubmodulesFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -717,30 +934,37 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutManifest: render.Manifest{}},
+				},
+				RenderMocks: []RenderMock{
+					{OutError: nil},
+				},
+				RenameModuleMocks: []RenameModuleMock{
 					{OutError: nil},
 				},
 			},
 			detectGit: func(string) (string, error) {
-				return "/home/user/code/github.com/octocat/monorepo", nil
+				return "", errors.New("git not found")
 			},
-			gitOpen: func(string) (gitService, error) {
+			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
 							OutSubmodule: git.Submodule{
 								Name:   "make",
-								Path:   "services/common/make",
+								Path:   "make",
 								URL:    "git@github.com:moorara/make.git",
 								Branch: "main",
 							},
 						},
 					},
-					RemoteMocks: []RemoteMock{
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
 						{OutError: errors.New("git error")},
 					},
 				}, nil
@@ -749,15 +973,15 @@ func TestCommand_run(t *testing.T) {
 				"-language=go",
 				"-type=http-service",
 				"-layout=vertical",
-				"-module=github.com/octocat/monorepo/services/domain/product/name",
+				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.GitError,
 		},
 		{
-			name: "Monorepo_SecondReplaceFails",
+			name: "Monorepo_MoveFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -769,9 +993,19 @@ func TestCommand_run(t *testing.T) {
 				},
 			},
 			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
+				MoveMocks: []MoveMock{
+					{OutError: errors.New("error on moving")},
+				},
+			},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutManifest: render.Manifest{}},
+				},
+				RenderMocks: []RenderMock{
+					{OutError: nil},
+				},
+				RenameModuleMocks: []RenameModuleMock{
 					{OutError: nil},
-					{OutError: errors.New("error on replacing")},
 				},
 			},
 			detectGit: func(string) (string, error) {
@@ -806,13 +1040,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.OSError,
 		},
 		{
-			name: "Monorepo_MoveFails",
+			name: "Monorepo_AppendFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -824,12 +1058,22 @@ func TestCommand_run(t *testing.T) {
 				},
 			},
 			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
+				MoveMocks: []MoveMock{
 					{OutError: nil},
+				},
+				AppendMocks: []AppendMock{
+					{OutError: errors.New("error on appending")},
+				},
+			},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutManifest: render.Manifest{}},
+				},
+				RenderMocks: []RenderMock{
 					{OutError: nil},
 				},
-				MoveMocks: []MoveMock{
-					{OutError: errors.New("move error")},
+				RenameModuleMocks: []RenameModuleMock{
+					{OutError: nil},
 				},
 			},
 			detectGit: func(string) (string, error) {
@@ -864,13 +1108,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.OSError,
 		},
 		{
-			name: "Monorepo_AppendFails",
+			name: "Monorepo_RemoveFails",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -882,15 +1126,25 @@ func TestCommand_run(t *testing.T) {
 				},
 			},
 			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
+				MoveMocks: []MoveMock{
 					{OutError: nil},
+				},
+				AppendMocks: []AppendMock{
 					{OutError: nil},
 				},
-				MoveMocks: []MoveMock{
+				RemoveMocks: []RemoveMock{
+					{OutError: errors.New("error on removing")},
+				},
+			},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutManifest: render.Manifest{}},
+				},
+				RenderMocks: []RenderMock{
 					{OutError: nil},
 				},
-				AppendMocks: []AppendMock{
-					{OutError: errors.New("append error")},
+				RenameModuleMocks: []RenameModuleMock{
+					{OutError: nil},
 				},
 			},
 			detectGit: func(string) (string, error) {
@@ -925,13 +1179,13 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
 			},
 			inputs:           "",
 			expectedExitCode: command.OSError,
 		},
 		{
-			name: "Monorepo_RemoveFails",
+			name: "Microrepo_Success",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -942,44 +1196,45 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
-					{OutError: nil},
-					{OutError: nil},
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{
+						OutManifest: render.Manifest{
+							Module: "vertical/http-service",
+							Variables: []render.Variable{
+								{Name: "team", Prompt: "Team name", Default: "platform", Pattern: "^[a-z]+$"},
+							},
+						},
+					},
 				},
-				MoveMocks: []MoveMock{
+				RenderMocks: []RenderMock{
 					{OutError: nil},
 				},
-				AppendMocks: []AppendMock{
+				RenameModuleMocks: []RenameModuleMock{
 					{OutError: nil},
 				},
-				RemoveMocks: []RemoveMock{
-					{OutError: errors.New("remove error")},
-				},
 			},
 			detectGit: func(string) (string, error) {
-				return "/home/user/code/github.com/octocat/monorepo", nil
+				return "", errors.New("git not found")
 			},
-			gitOpen: func(string) (gitService, error) {
+			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
 							OutSubmodule: git.Submodule{
 								Name:   "make",
-								Path:   "services/common/make",
+								Path:   "make",
 								URL:    "git@github.com:moorara/make.git",
 								Branch: "main",
 							},
 						},
 					},
-					RemoteMocks: []RemoteMock{
-						{
-							OutDomain: "github.com",
-							OutPath:   "octocat/monorepo",
-						},
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
+						{OutError: nil},
 					},
 				}, nil
 			},
@@ -987,15 +1242,30 @@ func TestCommand_run(t *testing.T) {
 				"-language=go",
 				"-type=http-service",
 				"-layout=vertical",
-				"-module=github.com/octocat/monorepo/services/domain/product/name",
+				"-module=github.com/octocat/service",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
+			},
+			inputs: "\n",
+			expectedData: &templateData{
+				Language:     "go",
+				Type:         "http-service",
+				Layout:       "vertical",
+				Module:       "github.com/octocat/service",
+				Name:         "service",
+				DockerID:     "octocat",
+				Owners:       "@octocat",
+				Monorepo:     false,
+				RepoURL:      "https://github.com/octocat/service",
+				WorkflowName: "Main",
+				HTTPPort:     4000,
+				GRPCPort:     5000,
+				Vars:         map[string]string{"team": "platform"},
 			},
-			inputs:           "",
-			expectedExitCode: command.OSError,
+			expectedExitCode: command.Success,
 		},
 		{
-			name: "Microrepo_Success",
+			name: "Microrepo_Answers_Success",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -1006,12 +1276,99 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{
+						OutManifest: render.Manifest{
+							Module: "vertical/http-service",
+							Variables: []render.Variable{
+								{Name: "team", Prompt: "Team name", Default: "platform", Pattern: "^[a-z]+$"},
+							},
+						},
+					},
+				},
+				RenderMocks: []RenderMock{
 					{OutError: nil},
+				},
+				RenameModuleMocks: []RenameModuleMock{
 					{OutError: nil},
 				},
-				RemoveMocks: []RemoveMock{
+			},
+			detectGit: func(string) (string, error) {
+				return "", errors.New("git not found")
+			},
+			gitInit: func(string) (gitService, error) {
+				return &MockGitService{
+					PathMocks: []PathMock{
+						{OutPath: "/home/user/code/github.com/octocat/service"},
+					},
+					SubmoduleMocks: []SubmoduleMock{
+						{
+							OutSubmodule: git.Submodule{
+								Name:   "make",
+								Path:   "make",
+								URL:    "git@github.com:moorara/make.git",
+								Branch: "main",
+							},
+						},
+					},
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
+						{OutError: nil},
+					},
+				}, nil
+			},
+			args: []string{
+				"-answers=test/answers.yaml",
+				"-yes",
+				"-layout=vertical",
+			},
+			inputs: "",
+			expectedData: &templateData{
+				Language:     "go",
+				Type:         "http-service",
+				Layout:       "vertical",
+				Module:       "github.com/octocat/service",
+				Name:         "service",
+				DockerID:     "octocat",
+				Owners:       "@octocat @octocat/core",
+				Monorepo:     false,
+				RepoURL:      "https://github.com/octocat/service",
+				WorkflowName: "Main",
+				HTTPPort:     8080,
+				GRPCPort:     5000,
+				Vars:         map[string]string{"team": "core"},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "Microrepo_CLI_Success",
+			repo: &MockRepoService{
+				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			arch: &MockArchiveService{
+				ExtractMocks: []ExtractMock{
+					{OutError: nil},
+				},
+			},
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{
+						OutManifest: render.Manifest{
+							Module: "vertical/http-service",
+							Variables: []render.Variable{
+								{Name: "team", Prompt: "Team name", Default: "platform", Pattern: "^[a-z]+$"},
+							},
+						},
+					},
+				},
+				RenderMocks: []RenderMock{
+					{OutError: nil},
+				},
+				RenameModuleMocks: []RenameModuleMock{
 					{OutError: nil},
 				},
 			},
@@ -1021,7 +1378,7 @@ func TestCommand_run(t *testing.T) {
 			gitInit: func(string) (gitService, error) {
 				return &MockGitService{
 					PathMocks: []PathMock{
-						{OutPath: "/home/user/code/github.com/octocat/monorepo"},
+						{OutPath: "/home/user/code/github.com/octocat/service"},
 					},
 					SubmoduleMocks: []SubmoduleMock{
 						{
@@ -1040,17 +1397,31 @@ func TestCommand_run(t *testing.T) {
 			},
 			args: []string{
 				"-language=go",
-				"-type=http-service",
+				"-type=cli",
 				"-layout=vertical",
-				"-module=github.com/octocat/service",
-				"-docker=octocat",
-				"-owners=octocat",
+				"-module=github.com/octocat/tool",
+				"-owners=@octocat",
+			},
+			inputs: "\n",
+			expectedData: &templateData{
+				Language:     "go",
+				Type:         "cli",
+				Layout:       "vertical",
+				Module:       "github.com/octocat/tool",
+				Name:         "tool",
+				DockerID:     "",
+				Owners:       "@octocat",
+				Monorepo:     false,
+				RepoURL:      "https://github.com/octocat/tool",
+				WorkflowName: "Main",
+				HTTPPort:     4000,
+				GRPCPort:     5000,
+				Vars:         map[string]string{"team": "platform"},
 			},
-			inputs:           "",
 			expectedExitCode: command.Success,
 		},
 		{
-			name: "Monorepo_Success",
+			name: "Microrepo_Library_Success",
 			repo: &MockRepoService{
 				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
 					{OutResponse: &github.Response{}},
@@ -1061,11 +1432,85 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
-			edit: &MockEditService{
-				ReplaceInDirMocks: []ReplaceInDirMock{
+			edit: &MockEditService{},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{
+						OutManifest: render.Manifest{
+							Module: "vertical/http-service",
+							Variables: []render.Variable{
+								{Name: "team", Prompt: "Team name", Default: "platform", Pattern: "^[a-z]+$"},
+							},
+						},
+					},
+				},
+				RenderMocks: []RenderMock{
 					{OutError: nil},
+				},
+				RenameModuleMocks: []RenameModuleMock{
+					{OutError: nil},
+				},
+			},
+			detectGit: func(string) (string, error) {
+				return "", errors.New("git not found")
+			},
+			gitInit: func(string) (gitService, error) {
+				return &MockGitService{
+					PathMocks: []PathMock{
+						{OutPath: "/home/user/code/github.com/octocat/service"},
+					},
+					SubmoduleMocks: []SubmoduleMock{
+						{
+							OutSubmodule: git.Submodule{
+								Name:   "make",
+								Path:   "make",
+								URL:    "git@github.com:moorara/make.git",
+								Branch: "main",
+							},
+						},
+					},
+					UpdateSubmodulesMocks: []UpdateSubmodulesMock{
+						{OutError: nil},
+					},
+				}, nil
+			},
+			args: []string{
+				"-language=go",
+				"-type=library",
+				"-module=github.com/octocat/library",
+				"-owners=@octocat",
+			},
+			inputs: "\n",
+			expectedData: &templateData{
+				Language:     "go",
+				Type:         "library",
+				Layout:       "vertical",
+				Module:       "github.com/octocat/library",
+				Name:         "library",
+				DockerID:     "",
+				Owners:       "@octocat",
+				Monorepo:     false,
+				RepoURL:      "https://github.com/octocat/library",
+				WorkflowName: "Main",
+				HTTPPort:     4000,
+				GRPCPort:     5000,
+				Vars:         map[string]string{"team": "platform"},
+			},
+			expectedExitCode: command.Success,
+		},
+		{
+			name: "Monorepo_Success",
+			repo: &MockRepoService{
+				DownloadTarArchiveMocks: []DownloadTarArchiveMock{
+					{OutResponse: &github.Response{}},
+				},
+			},
+			arch: &MockArchiveService{
+				ExtractMocks: []ExtractMock{
 					{OutError: nil},
 				},
+			},
+			edit: &MockEditService{
 				MoveMocks: []MoveMock{
 					{OutError: nil},
 				},
@@ -1076,6 +1521,17 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
+			render: &MockRenderService{
+				ReadManifestMocks: []ReadManifestMock{
+					{OutManifest: render.Manifest{}},
+				},
+				RenderMocks: []RenderMock{
+					{OutError: nil},
+				},
+				RenameModuleMocks: []RenameModuleMock{
+					{OutError: nil},
+				},
+			},
 			detectGit: func(string) (string, error) {
 				return "/home/user/code/github.com/octocat/monorepo", nil
 			},
@@ -1108,9 +1564,24 @@ func TestCommand_run(t *testing.T) {
 				"-layout=vertical",
 				"-module=github.com/octocat/monorepo/services/domain/product/name",
 				"-docker=octocat",
-				"-owners=octocat",
+				"-owners=@octocat",
+			},
+			inputs: "",
+			expectedData: &templateData{
+				Language:     "go",
+				Type:         "http-service",
+				Layout:       "vertical",
+				Module:       "github.com/octocat/monorepo/services/domain/product/name",
+				Name:         "name",
+				DockerID:     "octocat",
+				Owners:       "@octocat",
+				Monorepo:     true,
+				RepoURL:      "https://github.com/octocat/monorepo",
+				WorkflowName: "name",
+				HTTPPort:     4000,
+				GRPCPort:     5000,
+				Vars:         map[string]string{},
 			},
-			inputs:           "",
 			expectedExitCode: command.Success,
 		},
 	}
@@ -1126,17 +1597,63 @@ func TestCommand_run(t *testing.T) {
 
 			mockUI := cli.NewMockUi()
 			mockUI.InputReader = inputReader
-			c := &Command{ui: mockUI}
-			c.services.repo = tc.repo
+			c := &Command{
+				ui:   mockUI,
+				spec: tc.spec,
+			}
+			c.funcs.repo = func(string, string) repoService {
+				return tc.repo
+			}
 			c.services.arch = tc.arch
 			c.services.edit = tc.edit
+			c.services.render = tc.render
 			c.funcs.detectGit = tc.detectGit
 			c.funcs.gitInit = tc.gitInit
 			c.funcs.gitOpen = tc.gitOpen
 
+			before := dirEntries(t)
 			exitCode := c.run(tc.args)
+			created := newEntries(t, before)
+
+			// Cleanup
+			for _, name := range created {
+				defer os.RemoveAll(name)
+			}
 
 			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			// A failed run should not leave anything behind
+			if tc.expectedExitCode != command.Success {
+				assert.Empty(t, created)
+			}
+
+			if tc.expectedData != nil {
+				data := tc.render.RenderMocks[0].InData.(templateData)
+				// Paths are relative to the working directory of tests
+				data.AppPath, data.MakePath = "", ""
+				assert.Equal(t, *tc.expectedData, data)
+			}
 		})
 	}
 }
+
+func TestCommand_askVariables(t *testing.T) {
+	ui := cli.NewMockUi()
+	c := &Command{ui: ui}
+
+	vars := []render.Variable{
+		{Name: "team", Default: "platform"},
+	}
+
+	answers := map[string]string{
+		"zone":   "us",
+		"alias":  "api",
+		"region": "east",
+	}
+
+	values, code := c.askVariables(vars, answers, true)
+
+	assert.Nil(t, values)
+	assert.Equal(t, command.UnsupportedError, code)
+	assert.Equal(t, "unknown variable: alias\nunknown variable: region\nunknown variable: zone\n", ui.ErrorWriter.String())
+}
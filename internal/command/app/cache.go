@@ -0,0 +1,232 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moorara/gelato/internal/command"
+)
+
+const (
+	cacheDir      = "gelato"
+	cacheTemplDir = "templates"
+	cacheInfoFile = "info.json"
+	cacheTreeDir  = "tree"
+)
+
+// immutableRefRE matches the refs that never change (commit hashes and semantic version tags).
+var immutableRefRE = regexp.MustCompile(`^([0-9a-f]{7,40}|v?[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?)$`)
+
+// cacheInfo is the metadata of a cached template source.
+type cacheInfo struct {
+	Source   string    `json:"source"`
+	CachedAt time.Time `json:"cachedAt"`
+}
+
+// templateCache is a local cache of remote template sources keyed by source and revision.
+// Each entry is a directory named after the hash of the source with the metadata and the full source tree.
+type templateCache struct {
+	root string
+	now  func() time.Time
+}
+
+func newTemplateCache(root string) *templateCache {
+	return &templateCache{
+		root: root,
+		now:  time.Now,
+	}
+}
+
+// entryDir returns the directory of the cache entry for a source.
+func (c *templateCache) entryDir(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(c.root, hex.EncodeToString(sum[:8]))
+}
+
+// Lookup returns the path of the cached source tree for a source if any.
+func (c *templateCache) Lookup(source string) (string, bool) {
+	tree := filepath.Join(c.entryDir(source), cacheTreeDir)
+	if info, err := os.Stat(tree); err != nil || !info.IsDir() {
+		return "", false
+	}
+
+	return tree, true
+}
+
+// Store fetches the full tree of a source and replaces its cache entry.
+// The source is first fetched into a temporary directory, so a failed fetch never leaves a partial entry behind.
+func (c *templateCache) Store(ctx context.Context, source templateSource) (string, error) {
+	if err := os.MkdirAll(c.root, 0755); err != nil {
+		return "", err
+	}
+
+	tmpDir, err := ioutil.TempDir(c.root, ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := source.Fetch(ctx, ".", filepath.Join(tmpDir, cacheTreeDir)); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(cacheInfo{
+		Source:   source.String(),
+		CachedAt: c.now(),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(tmpDir, cacheInfoFile), data, 0644); err != nil {
+		return "", err
+	}
+
+	dir := c.entryDir(source.String())
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, cacheTreeDir), nil
+}
+
+// List returns all cached sources sorted by source.
+func (c *templateCache) List() ([]cacheInfo, error) {
+	infos, err := ioutil.ReadDir(c.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := []cacheInfo{}
+	for _, info := range infos {
+		// Skip the temporary directories and the broken entries
+		if !info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(c.root, info.Name(), cacheInfoFile))
+		if err != nil {
+			continue
+		}
+
+		var entry cacheInfo
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Source < entries[j].Source
+	})
+
+	return entries, nil
+}
+
+// Prune removes all cached sources.
+func (c *templateCache) Prune() error {
+	return os.RemoveAll(c.root)
+}
+
+// cachedSource fetches templates from the local cache of a remote source.
+// A source with an immutable ref is fetched only once, while a source with a mutable ref (a branch) is refreshed on every use.
+// In offline mode, only the cache is used.
+type cachedSource struct {
+	source  templateSource
+	cache   *templateCache
+	ref     string
+	offline bool
+}
+
+func (s *cachedSource) String() string {
+	return s.source.String()
+}
+
+func (s *cachedSource) Fetch(ctx context.Context, dir, dest string) error {
+	tree, ok := s.cache.Lookup(s.source.String())
+
+	switch {
+	case s.offline && !ok:
+		return &sourceError{command.InputError, fmt.Errorf("template source is not cached: %s", s.source)}
+
+	case !s.offline && (!ok || !immutableRefRE.MatchString(s.ref)):
+		var err error
+		if tree, err = s.cache.Store(ctx, s.source); err != nil {
+			if _, ok := err.(*sourceError); ok {
+				return err
+			}
+			return &sourceError{command.OSError, err}
+		}
+	}
+
+	if err := copyDir(filepath.Join(tree, dir), dest); err != nil {
+		return &sourceError{command.OSError, err}
+	}
+
+	return nil
+}
+
+// manageCache lists or prunes the cached templates.
+func (c *Command) manageCache(op string) int {
+	if c.data.cacheDir == "" {
+		c.ui.Error("no cache directory for templates")
+		return command.OSError
+	}
+
+	cache := newTemplateCache(c.data.cacheDir)
+
+	switch op {
+	case "list":
+		entries, err := cache.List()
+		if err != nil {
+			c.ui.Error(fmt.Sprintf("Failed to list cached templates: %s", err))
+			return command.OSError
+		}
+
+		var buf bytes.Buffer
+		w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+
+		fmt.Fprintf(w, "Cached templates (%s):\n", c.data.cacheDir)
+		for _, e := range entries {
+			fmt.Fprintf(w, "  %s\t%s\n", e.Source, e.CachedAt.Format(time.RFC3339))
+		}
+
+		_ = w.Flush()
+		c.ui.Output(strings.TrimSuffix(buf.String(), "\n"))
+
+	case "prune":
+		if err := cache.Prune(); err != nil {
+			c.ui.Error(fmt.Sprintf("Failed to prune cached templates: %s", err))
+			return command.OSError
+		}
+
+		c.ui.Output(fmt.Sprintf("Pruned cached templates in %s", c.data.cacheDir))
+
+	default:
+		c.ui.Error(fmt.Sprintf("unsupported cache operation: %s", op))
+		return command.UnsupportedError
+	}
+
+	return command.Success
+}
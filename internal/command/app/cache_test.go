@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/command"
+)
+
+// newTestSource creates a local source with one template for testing the cache.
+func newTestSource(t *testing.T, content string) (*dirSource, func()) {
+	root, err := ioutil.TempDir("", "gelato-source-")
+	assert.NoError(t, err)
+
+	dir := filepath.Join(root, "templates", "go")
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(content), 0644))
+
+	return &dirSource{root: root}, func() {
+		os.RemoveAll(root)
+	}
+}
+
+func TestTemplateCache(t *testing.T) {
+	root, err := ioutil.TempDir("", "gelato-cache-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	source, cleanup := newTestSource(t, "package main")
+	defer cleanup()
+
+	cache := newTemplateCache(filepath.Join(root, "templates"))
+	cache.now = func() time.Time {
+		return time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := cache.Lookup(source.String())
+		assert.False(t, ok)
+
+		entries, err := cache.List()
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("StoreFails", func(t *testing.T) {
+		_, err := cache.Store(context.Background(), &dirSource{root: "/dev/null/templates"})
+		assert.Error(t, err)
+
+		_, ok := cache.Lookup("/dev/null/templates")
+		assert.False(t, ok)
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		tree, err := cache.Store(context.Background(), source)
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(filepath.Join(tree, "templates", "go", "main.go"))
+		assert.NoError(t, err)
+		assert.Equal(t, "package main", string(data))
+
+		cached, ok := cache.Lookup(source.String())
+		assert.True(t, ok)
+		assert.Equal(t, tree, cached)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		entries, err := cache.List()
+		assert.NoError(t, err)
+		assert.Equal(t, []cacheInfo{
+			{Source: source.String(), CachedAt: time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)},
+		}, entries)
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		assert.NoError(t, cache.Prune())
+
+		_, ok := cache.Lookup(source.String())
+		assert.False(t, ok)
+	})
+}
+
+func TestCachedSource_Fetch(t *testing.T) {
+	tests := []struct {
+		name            string
+		cached          string
+		content         string
+		ref             string
+		offline         bool
+		expectedContent string
+		expectedError   string
+	}{
+		{
+			name:          "Offline_NotCached",
+			content:       "package main",
+			offline:       true,
+			expectedError: "template source is not cached",
+		},
+		{
+			name:            "Offline_Cached",
+			cached:          "package cached",
+			content:         "package main",
+			ref:             "main",
+			offline:         true,
+			expectedContent: "package cached",
+		},
+		{
+			name:            "NotCached",
+			content:         "package main",
+			ref:             "v1.0.0",
+			expectedContent: "package main",
+		},
+		{
+			name:            "Cached_ImmutableRef",
+			cached:          "package cached",
+			content:         "package main",
+			ref:             "c0ffee1",
+			expectedContent: "package cached",
+		},
+		{
+			name:            "Cached_MutableRef",
+			cached:          "package cached",
+			content:         "package main",
+			ref:             "main",
+			expectedContent: "package main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := ioutil.TempDir("", "gelato-cache-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(root)
+
+			source, cleanup := newTestSource(t, tc.cached)
+			defer cleanup()
+
+			cache := newTemplateCache(filepath.Join(root, "templates"))
+
+			if tc.cached != "" {
+				_, err := cache.Store(context.Background(), source)
+				assert.NoError(t, err)
+			}
+
+			// Change the source after it is cached
+			err = ioutil.WriteFile(filepath.Join(source.root, "templates", "go", "main.go"), []byte(tc.content), 0644)
+			assert.NoError(t, err)
+
+			s := &cachedSource{
+				source:  source,
+				cache:   cache,
+				ref:     tc.ref,
+				offline: tc.offline,
+			}
+
+			dest := filepath.Join(root, "app")
+			err = s.Fetch(context.Background(), "templates/go", dest)
+
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Equal(t, command.InputError, exitCode(err))
+			} else {
+				assert.NoError(t, err)
+				data, err := ioutil.ReadFile(filepath.Join(dest, "main.go"))
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedContent, string(data))
+			}
+		})
+	}
+}
+
+func TestCachedSource_Fetch_SourceFails(t *testing.T) {
+	root, err := ioutil.TempDir("", "gelato-cache-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	s := &cachedSource{
+		source: &dirSource{root: "/dev/null/templates"},
+		cache:  newTemplateCache(root),
+		ref:    "main",
+	}
+
+	err = s.Fetch(context.Background(), ".", filepath.Join(root, "app"))
+	assert.Error(t, err)
+	assert.Equal(t, command.OSError, exitCode(err))
+}
+
+func TestCommand_manageCache(t *testing.T) {
+	root, err := ioutil.TempDir("", "gelato-cache-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	source, cleanup := newTestSource(t, "package main")
+	defer cleanup()
+
+	cacheDir := filepath.Join(root, "templates")
+	_, err = newTemplateCache(cacheDir).Store(context.Background(), source)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		cacheDir         string
+		op               string
+		expectedExitCode int
+		expectedOutput   string
+	}{
+		{
+			name:             "NoCacheDir",
+			cacheDir:         "",
+			op:               "list",
+			expectedExitCode: command.OSError,
+		},
+		{
+			name:             "Unsupported",
+			cacheDir:         cacheDir,
+			op:               "clear",
+			expectedExitCode: command.UnsupportedError,
+		},
+		{
+			name:             "List",
+			cacheDir:         cacheDir,
+			op:               "list",
+			expectedExitCode: command.Success,
+			expectedOutput:   source.String(),
+		},
+		{
+			name:             "Prune",
+			cacheDir:         cacheDir,
+			op:               "prune",
+			expectedExitCode: command.Success,
+			expectedOutput:   "Pruned cached templates",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := &Command{ui: ui}
+			c.data.cacheDir = tc.cacheDir
+
+			exitCode := c.manageCache(tc.op)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Contains(t, ui.OutputWriter.String(), tc.expectedOutput)
+		})
+	}
+
+	_, err = os.Stat(cacheDir)
+	assert.True(t, os.IsNotExist(err))
+}
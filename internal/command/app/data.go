@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/service/render"
+)
+
+const (
+	defaultHTTPPort = 4000
+	defaultGRPCPort = 5000
+)
+
+// templateData is the data model for rendering application templates.
+type templateData struct {
+	Language string
+	Type     string
+	Layout   string
+
+	// Module is the Go module name of the application.
+	Module string
+	// Name is the name of the application.
+	Name string
+	// DockerID is the Docker ID for the Docker image of the application.
+	DockerID string
+	// Owners is a list of GitHub code owners separated by space.
+	Owners string
+
+	// Monorepo determines whether or not the application is created in a monorepo.
+	Monorepo bool
+	// RepoURL is the URL of the git repository.
+	RepoURL string
+	// WorkflowName is the name of the GitHub workflow for the application.
+	WorkflowName string
+	// AppPath is the path of the application relative to the git repository.
+	AppPath string
+	// MakePath is the path of the make submodule relative to the application.
+	MakePath string
+
+	HTTPPort int
+	GRPCPort int
+
+	// Vars are the values of the custom variables declared by the template manifest.
+	Vars map[string]string
+}
+
+// askVariables asks for the values of the custom variables declared by a template manifest.
+// The values from the answers file are used without asking and in non-interactive mode the default values are used.
+// All invalid and unknown values are reported together.
+func (c *Command) askVariables(vars []render.Variable, answers map[string]string, yes bool) (map[string]string, int) {
+	values := map[string]string{}
+	var errs []error
+
+	for _, v := range vars {
+		value, ok := answers[v.Name]
+
+		if !ok && yes {
+			value, ok = v.Default, true
+		}
+
+		if !ok {
+			prompt := v.Prompt
+			if prompt == "" {
+				prompt = v.Name
+			}
+
+			if v.Default != "" {
+				prompt = fmt.Sprintf("%s (default: %s)", prompt, v.Default)
+			}
+
+			var err error
+			value, err = c.ui.Ask(prompt + ":")
+			if err != nil {
+				c.ui.Error(fmt.Sprintf("invalid %s: %s", v.Name, err))
+				return nil, command.InputError
+			}
+
+			if value == "" {
+				value = v.Default
+			}
+		}
+
+		if err := v.Validate(value); err != nil {
+			errs = append(errs, err)
+		}
+
+		values[v.Name] = value
+	}
+
+	// The unknown variables are reported in a deterministic order
+	unknown := make([]string, 0)
+	for name := range answers {
+		if _, ok := values[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("unknown variable: %s", name))
+	}
+
+	if len(errs) > 0 {
+		for _, err := range errs {
+			c.ui.Error(err.Error())
+		}
+		return nil, command.UnsupportedError
+	}
+
+	return values, command.Success
+}
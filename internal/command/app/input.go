@@ -0,0 +1,231 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"gopkg.in/yaml.v3"
+
+	"github.com/moorara/gelato/internal/spec"
+)
+
+var (
+	// A Docker ID is an optional registry host followed by a namespace (e.g. octocat or ghcr.io/octocat).
+	dockerIDRE = regexp.MustCompile(`^([a-z0-9.-]+(:[0-9]+)?/)?[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+	// A GitHub username is at most 39 alphanumeric characters or single hyphens and cannot begin or end with a hyphen.
+	githubUserRE = regexp.MustCompile(`^[A-Za-z0-9](-?[A-Za-z0-9])*$`)
+	githubTeamRE = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	emailRE      = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// answers are the inputs for creating an application read from a file instead of being prompted.
+type answers struct {
+	Language string            `yaml:"language"`
+	Type     string            `yaml:"type"`
+	Layout   string            `yaml:"layout"`
+	Template string            `yaml:"template"`
+	Module   string            `yaml:"module"`
+	Docker   string            `yaml:"docker"`
+	Owners   string            `yaml:"owners"`
+	HTTPPort int               `yaml:"http_port"`
+	GRPCPort int               `yaml:"grpc_port"`
+	Vars     map[string]string `yaml:"vars"`
+}
+
+// readAnswers reads an answers file in YAML format.
+// Unknown fields are rejected, so a typo does not silently fall back to a prompt or a default value.
+func readAnswers(path string) (answers, error) {
+	var a answers
+
+	f, err := os.Open(path)
+	if err != nil {
+		return answers{}, err
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(&a); err != nil && err != io.EOF {
+		return answers{}, fmt.Errorf("invalid answers file %s: %s", path, err)
+	}
+
+	return a, nil
+}
+
+// inputs are the inputs for creating an application.
+type inputs struct {
+	Language string
+	Type     string
+	Layout   string
+	Template string
+	Module   string
+	Docker   string
+	Owners   string
+	HTTPPort int
+	GRPCPort int
+}
+
+// validate validates all inputs and returns all errors together.
+// If required is true, missing inputs are also reported as errors; otherwise, they are skipped and will be asked for.
+func (i inputs) validate(required bool) []error {
+	var errs []error
+
+	check := func(name, value string, validate func(string) error) {
+		if value == "" {
+			if required {
+				errs = append(errs, fmt.Errorf("%s is required", name))
+			}
+		} else if err := validate(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	check("application language", i.Language, validateLanguage)
+	check("application type", i.Type, validateType)
+	check("application layout", i.Layout, validateLayout)
+
+	// The built-in templates are not available for all combinations of languages, types, and layouts
+	if i.Template == "" && validateLanguage(i.Language) == nil && validateType(i.Type) == nil && validateLayout(i.Layout) == nil {
+		if err := validateBuiltinTemplate(i.Language, i.Type, i.Layout); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	check("module name", i.Module, validateModule)
+	if needsDocker(i.Type) {
+		check("Docker ID", i.Docker, validateDockerID)
+	}
+	check("GitHub code owners", i.Owners, validateOwners)
+
+	if err := validatePort("HTTP", i.HTTPPort); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := validatePort("gRPC", i.GRPCPort); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// needsDocker determines whether or not an application type is containerized.
+// Command-line applications and libraries are not containerized.
+func needsDocker(appType string) bool {
+	return appType != spec.AppTypeCLI && appType != spec.AppTypeLibrary
+}
+
+func validateLanguage(lang string) error {
+	// Only Go applications are supported
+	if lang != spec.AppLanguageGo {
+		return fmt.Errorf("unsupported application language: %s", lang)
+	}
+	return nil
+}
+
+func validateType(appType string) error {
+	// Only CLI applications, libraries, HTTP and gRPC services, and workers are supported
+	switch appType {
+	case spec.AppTypeCLI, spec.AppTypeLibrary, spec.AppTypeHTTPService, spec.AppTypeGRPCService, spec.AppTypeWorker:
+		return nil
+	default:
+		return fmt.Errorf("unsupported application type: %s", appType)
+	}
+}
+
+func validateLayout(layout string) error {
+	// Only vertical and horizontal layouts are supported
+	if layout != spec.AppLayoutVertical && layout != spec.AppLayoutHorizontal {
+		return fmt.Errorf("unsupported application layout: %s", layout)
+	}
+	return nil
+}
+
+// validateBuiltinTemplate validates that a built-in template exists for an application language, type, and layout.
+func validateBuiltinTemplate(lang, appType, layout string) error {
+	var layouts []string
+	for _, t := range builtinTemplates {
+		if t.Language == lang && t.Type == appType {
+			if t.Layout == layout {
+				return nil
+			}
+			layouts = append(layouts, t.Layout)
+		}
+	}
+
+	return fmt.Errorf("unsupported application layout for %s: %s (supported layouts: %s)", appType, layout, strings.Join(layouts, ", "))
+}
+
+func validateModule(mod string) error {
+	if err := module.CheckPath(mod); err != nil {
+		return fmt.Errorf("unsupported module name: %s", err)
+	}
+	return nil
+}
+
+func validateDockerID(id string) error {
+	if !dockerIDRE.MatchString(id) {
+		return fmt.Errorf("unsupported Docker ID: %s", id)
+	}
+	return nil
+}
+
+// validateOwners validates a list of code owners separated by space.
+// Each code owner is either a GitHub user (@octocat), a GitHub team (@org/team), or an email address.
+func validateOwners(owners string) error {
+	fields := strings.Fields(owners)
+	if len(fields) == 0 {
+		return errors.New("unsupported GitHub code owners: no code owner")
+	}
+
+	for _, owner := range fields {
+		if !isOwner(owner) {
+			return fmt.Errorf("unsupported GitHub code owner: %s", owner)
+		}
+	}
+
+	return nil
+}
+
+func isOwner(owner string) bool {
+	if !strings.HasPrefix(owner, "@") {
+		return emailRE.MatchString(owner)
+	}
+
+	user := strings.TrimPrefix(owner, "@")
+	var team string
+	if i := strings.Index(user, "/"); i != -1 {
+		user, team = user[:i], user[i+1:]
+		if !githubTeamRE.MatchString(team) {
+			return false
+		}
+	}
+
+	return len(user) <= 39 && githubUserRE.MatchString(user)
+}
+
+func validatePort(name string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("unsupported %s port: %d", name, port)
+	}
+	return nil
+}
+
+// setString sets a string input from the answers file unless it is explicitly set by a command-line flag.
+func setString(dst *string, answer string, flagSet bool) {
+	if answer != "" && !flagSet {
+		*dst = answer
+	}
+}
+
+// setInt sets an integer input from the answers file unless it is explicitly set by a command-line flag.
+func setInt(dst *int, answer int, flagSet bool) {
+	if answer != 0 && !flagSet {
+		*dst = answer
+	}
+}
@@ -0,0 +1,253 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadAnswers(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		expectedAnswers answers
+		expectedError   string
+	}{
+		{
+			name:          "NoFile",
+			path:          "test/null.yaml",
+			expectedError: "open test/null.yaml: no such file or directory",
+		},
+		{
+			name:          "UnknownField",
+			path:          "test/invalid.yaml",
+			expectedError: "invalid answers file test/invalid.yaml: yaml: unmarshal errors:\n  line 2: field typo not found in type app.answers",
+		},
+		{
+			name: "Success",
+			path: "test/answers.yaml",
+			expectedAnswers: answers{
+				Language: "go",
+				Type:     "http-service",
+				Layout:   "horizontal",
+				Module:   "github.com/octocat/service",
+				Docker:   "octocat",
+				Owners:   "@octocat @octocat/core",
+				HTTPPort: 8080,
+				Vars:     map[string]string{"team": "core"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			answers, err := readAnswers(tc.path)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAnswers, answers)
+			}
+		})
+	}
+}
+
+func TestInputs_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputs         inputs
+		required       bool
+		expectedErrors []error
+	}{
+		{
+			name:     "Missing_NotRequired",
+			inputs:   inputs{HTTPPort: 4000, GRPCPort: 5000},
+			required: false,
+		},
+		{
+			name:     "Missing_Required",
+			inputs:   inputs{Type: "cli", HTTPPort: 4000, GRPCPort: 5000},
+			required: true,
+			expectedErrors: []error{
+				errors.New("application language is required"),
+				errors.New("application layout is required"),
+				errors.New("module name is required"),
+				errors.New("GitHub code owners is required"),
+			},
+		},
+		{
+			name: "Invalid",
+			inputs: inputs{
+				Language: "javascript",
+				Type:     "web",
+				Layout:   "diagonal",
+				Module:   "octocat",
+				Docker:   "Octocat",
+				Owners:   "octocat",
+				HTTPPort: 0,
+				GRPCPort: 70000,
+			},
+			required: false,
+			expectedErrors: []error{
+				errors.New("unsupported application language: javascript"),
+				errors.New("unsupported application type: web"),
+				errors.New("unsupported application layout: diagonal"),
+				errors.New(`unsupported module name: malformed module path "octocat": missing dot in first path element`),
+				errors.New("unsupported Docker ID: Octocat"),
+				errors.New("unsupported GitHub code owner: octocat"),
+				errors.New("unsupported HTTP port: 0"),
+				errors.New("unsupported gRPC port: 70000"),
+			},
+		},
+		{
+			name: "NoBuiltinTemplate",
+			inputs: inputs{
+				Language: "go",
+				Type:     "library",
+				Layout:   "horizontal",
+				HTTPPort: 4000,
+				GRPCPort: 5000,
+			},
+			required: false,
+			expectedErrors: []error{
+				errors.New("unsupported application layout for library: horizontal (supported layouts: vertical)"),
+			},
+		},
+		{
+			name: "NoBuiltinTemplate_CustomTemplate",
+			inputs: inputs{
+				Language: "go",
+				Type:     "library",
+				Layout:   "horizontal",
+				Template: "github.com/octocat/templates",
+				HTTPPort: 4000,
+				GRPCPort: 5000,
+			},
+			required: false,
+		},
+		{
+			name: "Valid",
+			inputs: inputs{
+				Language: "go",
+				Type:     "worker",
+				Layout:   "horizontal",
+				Module:   "github.com/octocat/worker",
+				Docker:   "ghcr.io/octocat",
+				Owners:   "@octocat @octocat/core octocat@example.com",
+				HTTPPort: 4000,
+				GRPCPort: 5000,
+			},
+			required: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.inputs.validate(tc.required)
+
+			assert.Equal(t, tc.expectedErrors, errs)
+		})
+	}
+}
+
+func TestValidateDockerID(t *testing.T) {
+	tests := []struct {
+		id            string
+		expectedError bool
+	}{
+		{"octocat", false},
+		{"octo-cat", false},
+		{"octocat/team", false},
+		{"ghcr.io/octocat", false},
+		{"localhost:5000/octocat", false},
+		{"", true},
+		{"Octocat", true},
+		{"-octocat", true},
+		{"octocat/", true},
+		{"octo cat", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			err := validateDockerID(tc.id)
+
+			assert.Equal(t, tc.expectedError, err != nil)
+		})
+	}
+}
+
+func TestValidateOwners(t *testing.T) {
+	tests := []struct {
+		owners        string
+		expectedError string
+	}{
+		{"", "unsupported GitHub code owners: no code owner"},
+		{"@octocat", ""},
+		{"@octo-cat @octocat/core-team", ""},
+		{"octocat@example.com", ""},
+		{"octocat", "unsupported GitHub code owner: octocat"},
+		{"@octocat @-octocat", "unsupported GitHub code owner: @-octocat"},
+		{"@octocat-", "unsupported GitHub code owner: @octocat-"},
+		{"@octo--cat", "unsupported GitHub code owner: @octo--cat"},
+		{"@octocat/", "unsupported GitHub code owner: @octocat/"},
+		{"@aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "unsupported GitHub code owner: @aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.owners, func(t *testing.T) {
+			err := validateOwners(tc.owners)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetString(t *testing.T) {
+	tests := []struct {
+		name          string
+		dst           string
+		answer        string
+		flagSet       bool
+		expectedValue string
+	}{
+		{"NoAnswer", "spec", "", false, "spec"},
+		{"FlagSet", "flag", "answer", true, "flag"},
+		{"Answer", "spec", "answer", false, "answer"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setString(&tc.dst, tc.answer, tc.flagSet)
+
+			assert.Equal(t, tc.expectedValue, tc.dst)
+		})
+	}
+}
+
+func TestSetInt(t *testing.T) {
+	tests := []struct {
+		name          string
+		dst           int
+		answer        int
+		flagSet       bool
+		expectedValue int
+	}{
+		{"NoAnswer", 4000, 0, false, 4000},
+		{"FlagSet", 4000, 8080, true, 4000},
+		{"Answer", 4000, 8080, false, 8080},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setInt(&tc.dst, tc.answer, tc.flagSet)
+
+			assert.Equal(t, tc.expectedValue, tc.dst)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/moorara/gelato/internal/service/archive"
 	"github.com/moorara/gelato/internal/service/edit"
 	"github.com/moorara/gelato/internal/service/git"
+	"github.com/moorara/gelato/internal/service/render"
 )
 
 type (
@@ -76,12 +77,6 @@ type (
 		OutError error
 	}
 
-	ReplaceInDirMock struct {
-		InRoot   string
-		InSpecs  []edit.ReplaceSpec
-		OutError error
-	}
-
 	MockEditService struct {
 		RemoveIndex int
 		RemoveMocks []RemoveMock
@@ -91,9 +86,6 @@ type (
 
 		AppendIndex int
 		AppendMocks []AppendMock
-
-		ReplaceInDirIndex int
-		ReplaceInDirMocks []ReplaceInDirMock
 	}
 )
 
@@ -120,12 +112,62 @@ func (m *MockEditService) Append(create bool, specs ...edit.AppendSpec) error {
 	return m.AppendMocks[i].OutError
 }
 
-func (m *MockEditService) ReplaceInDir(root string, specs ...edit.ReplaceSpec) error {
-	i := m.ReplaceInDirIndex
-	m.ReplaceInDirIndex++
-	m.ReplaceInDirMocks[i].InRoot = root
-	m.ReplaceInDirMocks[i].InSpecs = specs
-	return m.ReplaceInDirMocks[i].OutError
+type (
+	ReadManifestMock struct {
+		InRoot      string
+		OutManifest render.Manifest
+		OutError    error
+	}
+
+	RenderMock struct {
+		InRoot     string
+		InManifest render.Manifest
+		InData     interface{}
+		OutError   error
+	}
+
+	RenameModuleMock struct {
+		InRoot    string
+		InOldPath string
+		InNewPath string
+		OutError  error
+	}
+
+	MockRenderService struct {
+		ReadManifestIndex int
+		ReadManifestMocks []ReadManifestMock
+
+		RenderIndex int
+		RenderMocks []RenderMock
+
+		RenameModuleIndex int
+		RenameModuleMocks []RenameModuleMock
+	}
+)
+
+func (m *MockRenderService) ReadManifest(root string) (render.Manifest, error) {
+	i := m.ReadManifestIndex
+	m.ReadManifestIndex++
+	m.ReadManifestMocks[i].InRoot = root
+	return m.ReadManifestMocks[i].OutManifest, m.ReadManifestMocks[i].OutError
+}
+
+func (m *MockRenderService) Render(root string, manifest render.Manifest, data interface{}) error {
+	i := m.RenderIndex
+	m.RenderIndex++
+	m.RenderMocks[i].InRoot = root
+	m.RenderMocks[i].InManifest = manifest
+	m.RenderMocks[i].InData = data
+	return m.RenderMocks[i].OutError
+}
+
+func (m *MockRenderService) RenameModule(root, oldPath, newPath string) error {
+	i := m.RenameModuleIndex
+	m.RenameModuleIndex++
+	m.RenameModuleMocks[i].InRoot = root
+	m.RenameModuleMocks[i].InOldPath = oldPath
+	m.RenameModuleMocks[i].InNewPath = newPath
+	return m.RenameModuleMocks[i].OutError
 }
 
 type (
@@ -0,0 +1,399 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/service/archive"
+	"github.com/moorara/gelato/internal/spec"
+)
+
+const (
+	defaultSource       = "github.com/" + templateOwner + "/" + templateRepo
+	defaultTemplatePath = "templates/{{.Language}}/{{.Layout}}/{{.Type}}"
+)
+
+var (
+	githubSourceRE = regexp.MustCompile(`^(https://)?github\.com/([0-9A-Za-z_.-]+)/([0-9A-Za-z_.-]+?)(\.git)?/?$`)
+	gitSourceRE    = regexp.MustCompile(`^([a-z+]+://|[0-9A-Za-z_.-]+@[0-9A-Za-z_.-]+:)`)
+)
+
+// builtinTemplates are the application templates available in the gelato repository.
+var builtinTemplates = []spec.App{
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutVertical, Type: spec.AppTypeCLI},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutVertical, Type: spec.AppTypeLibrary},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutVertical, Type: spec.AppTypeHTTPService},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutVertical, Type: spec.AppTypeGRPCService},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutVertical, Type: spec.AppTypeWorker},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutHorizontal, Type: spec.AppTypeCLI},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutHorizontal, Type: spec.AppTypeHTTPService},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutHorizontal, Type: spec.AppTypeGRPCService},
+	{Language: spec.AppLanguageGo, Layout: spec.AppLayoutHorizontal, Type: spec.AppTypeWorker},
+}
+
+type (
+	// templateSource is where application templates are fetched from.
+	templateSource interface {
+		fmt.Stringer
+		// Fetch copies a template directory in the source to a new directory.
+		Fetch(ctx context.Context, dir, dest string) error
+	}
+
+	repoFunc  func(string, string) repoService
+	cloneFunc func(context.Context, string, string, string) error
+)
+
+// sourceError is an error returned by a template source along with an exit code.
+type sourceError struct {
+	code int
+	err  error
+}
+
+func (e *sourceError) Error() string {
+	return e.err.Error()
+}
+
+// exitCode returns the exit code for an error returned by a template source.
+func exitCode(err error) int {
+	if e, ok := err.(*sourceError); ok {
+		return e.code
+	}
+	return command.MiscError
+}
+
+// githubSource fetches templates from a GitHub repository tarball.
+type githubSource struct {
+	repo  repoService
+	arch  archiveService
+	owner string
+	name  string
+	ref   string
+}
+
+func (s *githubSource) String() string {
+	return fmt.Sprintf("github.com/%s/%s@%s", s.owner, s.name, s.ref)
+}
+
+func (s *githubSource) Fetch(ctx context.Context, dir, dest string) error {
+	buf := new(bytes.Buffer)
+
+	if _, err := s.repo.DownloadTarArchive(ctx, s.ref, buf); err != nil {
+		return &sourceError{command.GitHubError, err}
+	}
+
+	// GitHub tarballs have a top-level directory named after the owner, the repository, and the commit
+	if err := s.arch.Extract(filepath.Dir(dest), buf, selectDir(dir, filepath.Base(dest), 1)); err != nil {
+		return &sourceError{command.ExtractionError, err}
+	}
+
+	return nil
+}
+
+// tarballSource fetches templates from a local tar.gz file.
+type tarballSource struct {
+	arch archiveService
+	file string
+}
+
+func (s *tarballSource) String() string {
+	return s.file
+}
+
+func (s *tarballSource) Fetch(ctx context.Context, dir, dest string) error {
+	f, err := os.Open(s.file)
+	if err != nil {
+		return &sourceError{command.OSError, err}
+	}
+	defer f.Close()
+
+	if err := s.arch.Extract(filepath.Dir(dest), f, selectDir(dir, filepath.Base(dest), 0)); err != nil {
+		return &sourceError{command.ExtractionError, err}
+	}
+
+	return nil
+}
+
+// dirSource fetches templates from a local directory.
+type dirSource struct {
+	root string
+}
+
+func (s *dirSource) String() string {
+	return s.root
+}
+
+func (s *dirSource) Fetch(ctx context.Context, dir, dest string) error {
+	if err := copyDir(filepath.Join(s.root, dir), dest); err != nil {
+		return &sourceError{command.OSError, err}
+	}
+
+	return nil
+}
+
+// gitSource fetches templates from a git repository.
+type gitSource struct {
+	clone cloneFunc
+	url   string
+	ref   string
+}
+
+func (s *gitSource) String() string {
+	if s.ref == "" {
+		return s.url
+	}
+	return s.url + "#" + s.ref
+}
+
+func (s *gitSource) Fetch(ctx context.Context, dir, dest string) error {
+	tmpDir, err := ioutil.TempDir("", "gelato-template-")
+	if err != nil {
+		return &sourceError{command.OSError, err}
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := s.clone(ctx, s.url, s.ref, tmpDir); err != nil {
+		return &sourceError{command.GitError, err}
+	}
+
+	if err := copyDir(filepath.Join(tmpDir, dir), dest); err != nil {
+		return &sourceError{command.OSError, err}
+	}
+
+	return nil
+}
+
+// selectDir creates a selector for extracting a directory in an archive into a new directory.
+// strip is the number of leading path components to be ignored.
+func selectDir(dir, name string, strip int) archive.Selector {
+	dir = path.Clean(filepath.ToSlash(dir))
+
+	return func(p string) (string, bool) {
+		p = strings.TrimPrefix(p, "./")
+
+		parts := strings.SplitN(p, "/", strip+1)
+		if len(parts) <= strip {
+			return "", false
+		}
+
+		rel := strings.TrimSuffix(parts[strip], "/")
+		if dir != "." {
+			if rel == dir {
+				rel = ""
+			} else if strings.HasPrefix(rel, dir+"/") {
+				rel = strings.TrimPrefix(rel, dir+"/")
+			} else {
+				return "", false
+			}
+		}
+
+		return path.Join(name, rel), true
+	}
+}
+
+// copyDir recursively copies a directory (except git directories) to a new directory.
+func copyDir(src, dest string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", src)
+	}
+
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		in, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		out, err := os.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+
+		_, err = io.Copy(out, in)
+		return err
+	})
+}
+
+// resolveTemplate resolves a template by its name from the spec.
+// If the name is not found in the spec, it is used as the source of the template.
+// If no name is given, the gelato templates at the current revision are used.
+func (c *Command) resolveTemplate(name string) (spec.Template, error) {
+	var tmpl spec.Template
+
+	if name == "" {
+		ref := c.spec.Gelato.Revision
+		if ref == "" {
+			ref = "main"
+		}
+
+		tmpl = spec.Template{Source: defaultSource, Ref: ref}
+	} else {
+		tmpl = spec.Template{Source: name}
+		for _, t := range c.spec.App.Templates {
+			if t.Name == name {
+				tmpl = t
+				break
+			}
+		}
+	}
+
+	if tmpl.Source == "" {
+		return spec.Template{}, fmt.Errorf("no source for template: %s", name)
+	}
+
+	// A ref can also be specified as part of the source (i.e. repo.git#v1.0.0)
+	if i := strings.LastIndex(tmpl.Source, "#"); i > 0 {
+		if tmpl.Ref == "" {
+			tmpl.Ref = tmpl.Source[i+1:]
+		}
+		tmpl.Source = tmpl.Source[:i]
+	}
+
+	if tmpl.Path == "" {
+		tmpl.Path = defaultTemplatePath
+	}
+
+	t, err := template.New("path").Parse(tmpl.Path)
+	if err != nil {
+		return spec.Template{}, fmt.Errorf("invalid template path: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, c.spec.App); err != nil {
+		return spec.Template{}, fmt.Errorf("invalid template path: %s", err)
+	}
+
+	tmpl.Path = path.Clean(filepath.ToSlash(buf.String()))
+	if path.IsAbs(tmpl.Path) || tmpl.Path == ".." || strings.HasPrefix(tmpl.Path, "../") {
+		return spec.Template{}, fmt.Errorf("template path is outside of the source: %s", tmpl.Path)
+	}
+
+	return tmpl, nil
+}
+
+// newSource creates a template source from its address.
+// The remote sources (GitHub and git repositories) are cached if there is a cache directory.
+func (c *Command) newSource(tmpl spec.Template, offline bool) (templateSource, error) {
+	source := tmpl.Source
+
+	switch {
+	case githubSourceRE.MatchString(source):
+		m := githubSourceRE.FindStringSubmatch(source)
+		ref := tmpl.Ref
+		if ref == "" {
+			ref = "main"
+		}
+
+		return c.cacheSource(&githubSource{
+			repo:  c.funcs.repo(m[2], m[3]),
+			arch:  c.services.arch,
+			owner: m[2],
+			name:  m[3],
+			ref:   ref,
+		}, ref, offline)
+
+	case strings.HasSuffix(source, ".tar.gz") || strings.HasSuffix(source, ".tgz"):
+		return &tarballSource{
+			arch: c.services.arch,
+			file: source,
+		}, nil
+
+	case gitSourceRE.MatchString(source):
+		return c.cacheSource(&gitSource{
+			clone: c.funcs.gitClone,
+			url:   source,
+			ref:   tmpl.Ref,
+		}, tmpl.Ref, offline)
+
+	default:
+		if info, err := os.Stat(source); err != nil || !info.IsDir() {
+			return nil, errors.New("template source is not a directory, a tarball, or a git repository: " + source)
+		}
+
+		return &dirSource{
+			root: source,
+		}, nil
+	}
+}
+
+// cacheSource wraps a remote source with the template cache.
+func (c *Command) cacheSource(source templateSource, ref string, offline bool) (templateSource, error) {
+	if c.data.cacheDir == "" {
+		if offline {
+			return nil, errors.New("no cache directory for using templates offline")
+		}
+		return source, nil
+	}
+
+	return &cachedSource{
+		source:  source,
+		cache:   newTemplateCache(c.data.cacheDir),
+		ref:     ref,
+		offline: offline,
+	}, nil
+}
+
+// listTemplates prints the built-in templates as well as the templates defined in the spec.
+func (c *Command) listTemplates() {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Built-in templates (%s):\n", defaultSource)
+	for _, app := range builtinTemplates {
+		fmt.Fprintf(w, "  -language=%s -layout=%s -type=%s\n", app.Language, app.Layout, app.Type)
+	}
+
+	if len(c.spec.App.Templates) > 0 {
+		fmt.Fprintln(w, "\nTemplates:")
+		for _, t := range c.spec.App.Templates {
+			source := t.Source
+			if t.Ref != "" {
+				source += "#" + t.Ref
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", t.Name, source, t.Description)
+		}
+	}
+
+	_ = w.Flush()
+	c.ui.Output(strings.TrimSuffix(buf.String(), "\n"))
+}
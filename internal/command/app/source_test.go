@@ -0,0 +1,508 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/log"
+	"github.com/moorara/gelato/internal/service/archive"
+	"github.com/moorara/gelato/internal/spec"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, command.GitError, exitCode(&sourceError{command.GitError, errors.New("git error")}))
+	assert.Equal(t, command.MiscError, exitCode(errors.New("error")))
+}
+
+func TestSelectDir(t *testing.T) {
+	tests := []struct {
+		name          string
+		dir           string
+		strip         int
+		path          string
+		expectedPath  string
+		expectedMatch bool
+	}{
+		{"TopDirectory", "templates/go", 1, "octocat-templates-c3d4e5f/", "", false},
+		{"ParentDirectory", "templates/go", 1, "octocat-templates-c3d4e5f/templates/", "", false},
+		{"OtherDirectory", "templates/go", 1, "octocat-templates-c3d4e5f/templates/golang/", "", false},
+		{"TemplateDirectory", "templates/go", 1, "octocat-templates-c3d4e5f/templates/go/", "app", true},
+		{"TemplateFile", "templates/go", 1, "octocat-templates-c3d4e5f/templates/go/cmd/main.go", "app/cmd/main.go", true},
+		{"NoStrip", "templates/go", 0, "./templates/go/go.mod", "app/go.mod", true},
+		{"RootDirectory", ".", 0, "go.mod", "app/go.mod", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, ok := selectDir(tc.dir, "app", tc.strip)(tc.path)
+
+			assert.Equal(t, tc.expectedMatch, ok)
+			assert.Equal(t, tc.expectedPath, path)
+		})
+	}
+}
+
+func TestCopyDir(t *testing.T) {
+	src, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(src)
+
+	dest, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dest)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, ".git"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "cmd"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "cmd", "main.go"), []byte("package main"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "build.sh"), []byte("#!/bin/sh"), 0755))
+
+	t.Run("NotExist", func(t *testing.T) {
+		err := copyDir(filepath.Join(src, "foo"), filepath.Join(dest, "app"))
+		assert.Error(t, err)
+	})
+
+	t.Run("NotDirectory", func(t *testing.T) {
+		err := copyDir(filepath.Join(src, "build.sh"), filepath.Join(dest, "app"))
+		assert.EqualError(t, err, "not a directory: "+filepath.Join(src, "build.sh"))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		err := copyDir(src, filepath.Join(dest, "app"))
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(filepath.Join(dest, "app", "cmd", "main.go"))
+		assert.NoError(t, err)
+		assert.Equal(t, "package main", string(data))
+
+		info, err := os.Stat(filepath.Join(dest, "app", "build.sh"))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+		assert.NoDirExists(t, filepath.Join(dest, "app", ".git"))
+	})
+}
+
+func TestSources_Fetch(t *testing.T) {
+	src, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(src)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "templates", "go"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "templates", "go", "go.mod"), []byte("module app"), 0644))
+
+	tests := []struct {
+		name             string
+		source           templateSource
+		dir              string
+		expectedError    string
+		expectedExitCode int
+	}{
+		{
+			name: "GitHub_DownloadTarArchiveFails",
+			source: &githubSource{
+				repo: &MockRepoService{
+					DownloadTarArchiveMocks: []DownloadTarArchiveMock{
+						{OutError: errors.New("github error")},
+					},
+				},
+				owner: "octocat",
+				name:  "templates",
+				ref:   "main",
+			},
+			dir:              "templates/go",
+			expectedError:    "github error",
+			expectedExitCode: command.GitHubError,
+		},
+		{
+			name: "GitHub_ExtractFails",
+			source: &githubSource{
+				repo: &MockRepoService{
+					DownloadTarArchiveMocks: []DownloadTarArchiveMock{
+						{},
+					},
+				},
+				arch: &MockArchiveService{
+					ExtractMocks: []ExtractMock{
+						{OutError: errors.New("archive error")},
+					},
+				},
+				owner: "octocat",
+				name:  "templates",
+				ref:   "main",
+			},
+			dir:              "templates/go",
+			expectedError:    "archive error",
+			expectedExitCode: command.ExtractionError,
+		},
+		{
+			name: "Tarball_OpenFails",
+			source: &tarballSource{
+				file: filepath.Join(src, "templates.tar.gz"),
+			},
+			dir:              "templates/go",
+			expectedError:    "open " + filepath.Join(src, "templates.tar.gz") + ": no such file or directory",
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "Tarball_Success",
+			source: &tarballSource{
+				arch: archive.NewTarArchive(log.None),
+				file: "../../service/archive/test/nodirs.tar.gz",
+			},
+			dir: "templates/go",
+		},
+		{
+			name: "Dir_NotExist",
+			source: &dirSource{
+				root: src,
+			},
+			dir:              "templates/golang",
+			expectedError:    "stat " + filepath.Join(src, "templates", "golang") + ": no such file or directory",
+			expectedExitCode: command.OSError,
+		},
+		{
+			name: "Dir_Success",
+			source: &dirSource{
+				root: src,
+			},
+			dir: "templates/go",
+		},
+		{
+			name: "Git_CloneFails",
+			source: &gitSource{
+				clone: func(context.Context, string, string, string) error {
+					return errors.New("git error")
+				},
+				url: "https://example.com/octocat/templates.git",
+			},
+			dir:              "templates/go",
+			expectedError:    "git error",
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "Git_Success",
+			source: &gitSource{
+				clone: func(_ context.Context, _, _, path string) error {
+					return copyDir(src, path)
+				},
+				url: "https://example.com/octocat/templates.git",
+				ref: "v1.0.0",
+			},
+			dir: "templates/go",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dest, err := ioutil.TempDir("", "gelato-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dest)
+
+			assert.NotEmpty(t, tc.source.String())
+
+			err = tc.source.Fetch(context.Background(), tc.dir, filepath.Join(dest, "app"))
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Equal(t, tc.expectedExitCode, exitCode(err))
+			} else {
+				assert.NoError(t, err)
+				assert.DirExists(t, filepath.Join(dest, "app"))
+			}
+		})
+	}
+}
+
+func TestCommand_resolveTemplate(t *testing.T) {
+	tests := []struct {
+		name             string
+		spec             spec.Spec
+		template         string
+		expectedTemplate spec.Template
+		expectedError    string
+	}{
+		{
+			name: "Default",
+			spec: spec.Spec{
+				App: spec.App{Language: "go", Type: "http-service", Layout: "vertical"},
+			},
+			template: "",
+			expectedTemplate: spec.Template{
+				Source: "github.com/moorara/gelato",
+				Ref:    "main",
+				Path:   "templates/go/vertical/http-service",
+			},
+		},
+		{
+			name: "Default_WithRevision",
+			spec: spec.Spec{
+				Gelato: spec.Gelato{Revision: "c3d4e5f"},
+				App:    spec.App{Language: "go", Type: "grpc-service", Layout: "horizontal"},
+			},
+			template: "",
+			expectedTemplate: spec.Template{
+				Source: "github.com/moorara/gelato",
+				Ref:    "c3d4e5f",
+				Path:   "templates/go/horizontal/grpc-service",
+			},
+		},
+		{
+			name: "Source_WithRef",
+			spec: spec.Spec{
+				App: spec.App{Language: "go", Type: "http-service", Layout: "vertical"},
+			},
+			template: "git@github.com:octocat/templates.git#v1.0.0",
+			expectedTemplate: spec.Template{
+				Source: "git@github.com:octocat/templates.git",
+				Ref:    "v1.0.0",
+				Path:   "templates/go/vertical/http-service",
+			},
+		},
+		{
+			name: "Named_NoSource",
+			spec: spec.Spec{
+				App: spec.App{
+					Templates: []spec.Template{
+						{Name: "service"},
+					},
+				},
+			},
+			template:      "service",
+			expectedError: "no source for template: service",
+		},
+		{
+			name: "Named_InvalidPath",
+			spec: spec.Spec{
+				App: spec.App{
+					Templates: []spec.Template{
+						{Name: "service", Source: "./templates", Path: "{{.Foo"},
+					},
+				},
+			},
+			template:      "service",
+			expectedError: `invalid template path: template: path:1: unclosed action`,
+		},
+		{
+			name: "Named_PathOutsideSource",
+			spec: spec.Spec{
+				App: spec.App{
+					Templates: []spec.Template{
+						{Name: "service", Source: "./templates", Path: "go/../../service"},
+					},
+				},
+			},
+			template:      "service",
+			expectedError: "template path is outside of the source: ../service",
+		},
+		{
+			name: "Named_Success",
+			spec: spec.Spec{
+				App: spec.App{
+					Language: "go",
+					Type:     "http-service",
+					Layout:   "vertical",
+					Templates: []spec.Template{
+						{Name: "api", Source: "./templates", Path: "{{.Language}}/api"},
+						{Name: "service", Source: "https://example.com/octocat/templates.git", Ref: "v1.0.0", Path: "{{.Language}}/{{.Type}}/"},
+					},
+				},
+			},
+			template: "service",
+			expectedTemplate: spec.Template{
+				Name:   "service",
+				Source: "https://example.com/octocat/templates.git",
+				Ref:    "v1.0.0",
+				Path:   "go/http-service",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{spec: tc.spec}
+			tmpl, err := c.resolveTemplate(tc.template)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedTemplate, tmpl)
+			}
+		})
+	}
+}
+
+func TestCommand_newSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		cacheDir       string
+		template       spec.Template
+		offline        bool
+		expectedSource templateSource
+		expectedError  string
+	}{
+		{
+			name:     "GitHub",
+			template: spec.Template{Source: "github.com/octocat/templates"},
+			expectedSource: &githubSource{
+				repo:  &MockRepoService{},
+				arch:  &MockArchiveService{},
+				owner: "octocat",
+				name:  "templates",
+				ref:   "main",
+			},
+		},
+		{
+			name:     "GitHub_URL",
+			template: spec.Template{Source: "https://github.com/octocat/templates.git", Ref: "v1.0.0"},
+			expectedSource: &githubSource{
+				repo:  &MockRepoService{},
+				arch:  &MockArchiveService{},
+				owner: "octocat",
+				name:  "templates",
+				ref:   "v1.0.0",
+			},
+		},
+		{
+			name:     "Tarball",
+			template: spec.Template{Source: "templates.tar.gz"},
+			expectedSource: &tarballSource{
+				arch: &MockArchiveService{},
+				file: "templates.tar.gz",
+			},
+		},
+		{
+			name:     "Git_SSH",
+			template: spec.Template{Source: "git@gitlab.com:octocat/templates.git", Ref: "v1.0.0"},
+			expectedSource: &gitSource{
+				url: "git@gitlab.com:octocat/templates.git",
+				ref: "v1.0.0",
+			},
+		},
+		{
+			name:     "Git_HTTPS",
+			template: spec.Template{Source: "https://gitlab.com/octocat/templates.git"},
+			expectedSource: &gitSource{
+				url: "https://gitlab.com/octocat/templates.git",
+			},
+		},
+		{
+			name:     "Directory",
+			template: spec.Template{Source: "."},
+			expectedSource: &dirSource{
+				root: ".",
+			},
+		},
+		{
+			name:          "Directory_NotExist",
+			template:      spec.Template{Source: "./templates"},
+			expectedError: "template source is not a directory, a tarball, or a git repository: ./templates",
+		},
+		{
+			name:     "GitHub_Cached",
+			cacheDir: "/cache",
+			template: spec.Template{Source: "github.com/octocat/templates", Ref: "v1.0.0"},
+			offline:  true,
+			expectedSource: &cachedSource{
+				source: &githubSource{
+					repo:  &MockRepoService{},
+					arch:  &MockArchiveService{},
+					owner: "octocat",
+					name:  "templates",
+					ref:   "v1.0.0",
+				},
+				cache:   &templateCache{root: "/cache"},
+				ref:     "v1.0.0",
+				offline: true,
+			},
+		},
+		{
+			name:     "Git_Cached",
+			cacheDir: "/cache",
+			template: spec.Template{Source: "https://gitlab.com/octocat/templates.git"},
+			expectedSource: &cachedSource{
+				source: &gitSource{
+					url: "https://gitlab.com/octocat/templates.git",
+				},
+				cache: &templateCache{root: "/cache"},
+			},
+		},
+		{
+			name:          "Offline_NoCacheDir",
+			template:      spec.Template{Source: "github.com/octocat/templates"},
+			offline:       true,
+			expectedError: "no cache directory for using templates offline",
+		},
+		{
+			name:     "Offline_Directory",
+			template: spec.Template{Source: "."},
+			offline:  true,
+			expectedSource: &dirSource{
+				root: ".",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Command{}
+			c.data.cacheDir = tc.cacheDir
+			c.services.arch = &MockArchiveService{}
+			c.funcs.repo = func(string, string) repoService {
+				return &MockRepoService{}
+			}
+
+			source, err := c.newSource(tc.template, tc.offline)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, source)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+
+				// Functions are not comparable
+				src := source
+				if s, ok := src.(*cachedSource); ok {
+					s.cache.now = nil
+					src = s.source
+				}
+				if s, ok := src.(*gitSource); ok {
+					s.clone = nil
+				}
+
+				assert.Equal(t, tc.expectedSource, source)
+			}
+		})
+	}
+}
+
+func TestCommand_listTemplates(t *testing.T) {
+	ui := cli.NewMockUi()
+	c := &Command{
+		ui: ui,
+		spec: spec.Spec{
+			App: spec.App{
+				Templates: []spec.Template{
+					{Name: "service", Description: "HTTP service", Source: "git@github.com:octocat/templates.git", Ref: "v1.0.0"},
+				},
+			},
+		},
+	}
+
+	c.listTemplates()
+
+	out := ui.OutputWriter.String()
+	assert.Contains(t, out, "-language=go -layout=vertical -type=http-service")
+	assert.Contains(t, out, "-language=go -layout=horizontal -type=grpc-service")
+	assert.Contains(t, out, "-language=go -layout=horizontal -type=worker")
+	assert.Contains(t, out, "service  git@github.com:octocat/templates.git#v1.0.0  HTTP service")
+}
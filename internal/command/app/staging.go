@@ -0,0 +1,136 @@
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// sharedFile is the original state of a file outside of the application that is changed while creating the application.
+type sharedFile struct {
+	path   string
+	exists bool
+	data   []byte
+	mode   os.FileMode
+	// dirs are the parent directories that did not exist (from the innermost to the outermost).
+	dirs []string
+}
+
+// staging is a staging area for creating an application.
+// The application is created in a temporary directory next to its final path and moved into place at once when it is ready,
+// so a failure never leaves a partially created application behind.
+// The changes to shared files outside of the application (e.g. CODEOWNERS in a monorepo) are tracked, so they can be reverted too.
+type staging struct {
+	dir       string
+	dest      string
+	committed bool
+	shared    []sharedFile
+}
+
+// newStaging creates a new staging directory for an application path.
+// The staging directory is created in the same parent directory, so the application can be moved into place atomically.
+func newStaging(dest string) (*staging, error) {
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%s already exists", dest)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	parent := filepath.Dir(dest)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir(parent, fmt.Sprintf(".%s-", filepath.Base(dest)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &staging{
+		dir:  dir,
+		dest: dest,
+	}, nil
+}
+
+// Track records the current state of shared files before they are changed.
+func (s *staging) Track(paths ...string) error {
+	for _, path := range paths {
+		f := sharedFile{path: path}
+
+		info, err := os.Stat(path)
+		switch {
+		case err == nil:
+			if f.data, err = ioutil.ReadFile(path); err != nil {
+				return err
+			}
+			f.exists, f.mode = true, info.Mode().Perm()
+
+		case os.IsNotExist(err):
+			for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+				if _, err := os.Stat(dir); err == nil || dir == filepath.Dir(dir) {
+					break
+				}
+				f.dirs = append(f.dirs, dir)
+			}
+
+		default:
+			return err
+		}
+
+		s.shared = append(s.shared, f)
+	}
+
+	return nil
+}
+
+// Commit moves the application from the staging directory into its final path.
+func (s *staging) Commit() error {
+	if err := os.Rename(s.dir, s.dest); err != nil {
+		return err
+	}
+
+	s.committed = true
+
+	return nil
+}
+
+// Rollback removes the staging directory and restores the shared files to their original state.
+// It is a no-op after the application is committed.
+func (s *staging) Rollback() error {
+	if s.committed {
+		return nil
+	}
+
+	var firstErr error
+	record := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	record(os.RemoveAll(s.dir))
+
+	// Restore the shared files in reverse order, so a file tracked more than once ends up in its original state
+	for i := len(s.shared) - 1; i >= 0; i-- {
+		f := s.shared[i]
+
+		if f.exists {
+			record(ioutil.WriteFile(f.path, f.data, f.mode))
+			continue
+		}
+
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			record(err)
+		}
+
+		// Only the directories created for the file are removed and only if they are empty
+		for _, dir := range f.dirs {
+			if err := os.Remove(dir); err != nil {
+				break
+			}
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStaging(t *testing.T) {
+	root, err := ioutil.TempDir("", "gelato-staging-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "existing"), 0755))
+
+	tests := []struct {
+		name          string
+		dest          string
+		expectedError string
+	}{
+		{
+			name:          "DestinationExists",
+			dest:          filepath.Join(root, "existing"),
+			expectedError: filepath.Join(root, "existing") + " already exists",
+		},
+		{
+			name:          "Success",
+			dest:          filepath.Join(root, "services", "app"),
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := newStaging(tc.dest)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, s)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.dest, s.dest)
+				assert.Equal(t, filepath.Dir(tc.dest), filepath.Dir(s.dir))
+				assert.DirExists(t, s.dir)
+			}
+		})
+	}
+}
+
+func TestStaging_Commit(t *testing.T) {
+	root, err := ioutil.TempDir("", "gelato-staging-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	dest := filepath.Join(root, "app")
+	s, err := newStaging(dest)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(s.dir, "main.go"), []byte("package main\n"), 0644))
+	assert.NoError(t, s.Commit())
+	assert.FileExists(t, filepath.Join(dest, "main.go"))
+	assert.NoDirExists(t, s.dir)
+
+	// Rollback is a no-op after commit
+	assert.NoError(t, s.Rollback())
+	assert.FileExists(t, filepath.Join(dest, "main.go"))
+}
+
+func TestStaging_Rollback(t *testing.T) {
+	root, err := ioutil.TempDir("", "gelato-staging-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	codeOwners := filepath.Join(root, ".github", "CODEOWNERS")
+	workflow := filepath.Join(root, ".github", "workflows", "app.yml")
+
+	assert.NoError(t, os.Mkdir(filepath.Join(root, ".github"), 0755))
+	assert.NoError(t, ioutil.WriteFile(codeOwners, []byte("*  @octocat\n"), 0644))
+
+	s, err := newStaging(filepath.Join(root, "app"))
+	assert.NoError(t, err)
+	assert.NoError(t, s.Track(workflow, codeOwners))
+
+	// Change the shared files
+	assert.NoError(t, os.MkdirAll(filepath.Dir(workflow), 0755))
+	assert.NoError(t, ioutil.WriteFile(workflow, []byte("name: app\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(codeOwners, []byte("*  @octocat\n/app/  @octocat\n"), 0644))
+
+	assert.NoError(t, s.Rollback())
+
+	assert.NoDirExists(t, s.dir)
+	assert.NoDirExists(t, filepath.Join(root, "app"))
+	assert.NoDirExists(t, filepath.Dir(workflow))
+	assert.DirExists(t, filepath.Join(root, ".github"))
+
+	data, err := ioutil.ReadFile(codeOwners)
+	assert.NoError(t, err)
+	assert.Equal(t, "*  @octocat\n", string(data))
+}
@@ -37,6 +37,8 @@ const (
   By convention, It assumes the current directory is a main package if it contains a main.go file.
   It also assumes every directory inside cmd is a main package for a binary with the same name as the directory name.
 
+  For libraries (modules with no main package), the build can be a check that vets and tests all packages.
+
   Decoration is an experimental feature to decorate the applications with horizontal layout.
   It wraps the controller, gateway, handler, and repository packages with a set of decorators.
   Decorators can be used for augmenting an application with observability, error reccovery, etc.
@@ -44,11 +46,13 @@ const (
   Usage:  gelato build [flags]
 
   Flags:
+    -check            run go vet and go test instead of building binaries (default: {{.Build.Check}})
     -cross-compile    build the binary for all platforms (default: {{.Build.CrossCompile}})
     -decorate         [EXPERIMENTAL] decorate the application before building
 
   Examples:
     gelato build
+    gelato build -check
     gelato build -cross-compile
     gelato build -decorate
     gelato build -cross-compile -decorate
@@ -57,6 +61,7 @@ const (
 
 const (
 	decoratedDir = ".build"
+	manifestFile = ".manifest.json"
 	cmdDir       = "cmd"
 	binPath      = "./bin/"
 	versionPath  = "./version"
@@ -99,6 +104,8 @@ type Command struct {
 	}
 	funcs struct {
 		goList  shell.RunnerFunc
+		goVet   shell.RunnerFunc
+		goTest  shell.RunnerFunc
 		goBuild shell.RunnerWithFunc
 	}
 	commands struct {
@@ -144,6 +151,8 @@ func (c *Command) Run(args []string) int {
 	c.services.git = git
 	c.services.decorator = decorator.New(log.Info)
 	c.funcs.goList = shell.Runner("go", "list", versionPath)
+	c.funcs.goVet = shell.Runner("go", "vet", "./...")
+	c.funcs.goTest = shell.Runner("go", "test", "./...")
 	c.funcs.goBuild = shell.RunnerWith("go", "build")
 	c.commands.semver = semver
 
@@ -177,6 +186,29 @@ func (c *Command) run(args []string) int {
 		return command.PreflightError
 	}
 
+	// ==============================> CHECK <==============================
+
+	// Libraries do not have any binary, so they are only vetted and tested
+	if c.spec.Build.Check {
+		if _, _, err := c.funcs.goVet(ctx); err != nil {
+			c.ui.Error(err.Error())
+			return command.GoError
+		}
+
+		c.ui.Output("🍨 go vet ./...")
+
+		_, out, err := c.funcs.goTest(ctx)
+		if err != nil {
+			c.ui.Error(err.Error())
+			return command.GoError
+		}
+
+		c.ui.Output(out)
+		c.ui.Output("🍨 go test ./...")
+
+		return command.Success
+	}
+
 	// ==============================> GET GIT & GO INFORMATION <==============================
 
 	gitSHA, gitBranch, err := c.services.git.HEAD()
@@ -228,6 +260,7 @@ func (c *Command) run(args []string) int {
 
 	opts := compiler.ParseOptions{
 		SkipTestFiles: true,
+		Manifest:      filepath.Join(decoratedDir, manifestFile),
 	}
 
 	if c.spec.Build.Decorate {
@@ -44,6 +44,8 @@ func TestCommand_Run(t *testing.T) {
 	assert.NotNil(t, c.services.git)
 	assert.NotNil(t, c.services.decorator)
 	assert.NotNil(t, c.funcs.goList)
+	assert.NotNil(t, c.funcs.goVet)
+	assert.NotNil(t, c.funcs.goTest)
 	assert.NotNil(t, c.funcs.goBuild)
 	assert.NotNil(t, c.commands.semver)
 }
@@ -55,6 +57,8 @@ func TestCommand_run(t *testing.T) {
 		git              *MockGitService
 		decorator        *MockCompilerService
 		goList           shell.RunnerFunc
+		goVet            shell.RunnerFunc
+		goTest           shell.RunnerFunc
 		goBuild          shell.RunnerWithFunc
 		semver           *MockSemverCommand
 		args             []string
@@ -72,6 +76,51 @@ func TestCommand_run(t *testing.T) {
 			args:             []string{"--undefined"},
 			expectedExitCode: command.FlagError,
 		},
+		{
+			name: "Check_GoVetFails",
+			spec: spec.Spec{
+				Build: spec.Build{
+					Check: true,
+				},
+			},
+			goVet: func(ctx context.Context, args ...string) (int, string, error) {
+				return 1, "", errors.New("go vet error")
+			},
+			args:             []string{},
+			expectedExitCode: command.GoError,
+		},
+		{
+			name: "Check_GoTestFails",
+			spec: spec.Spec{
+				Build: spec.Build{
+					Check: true,
+				},
+			},
+			goVet: func(ctx context.Context, args ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			goTest: func(ctx context.Context, args ...string) (int, string, error) {
+				return 1, "", errors.New("go test error")
+			},
+			args:             []string{},
+			expectedExitCode: command.GoError,
+		},
+		{
+			name: "Check_Success",
+			spec: spec.Spec{
+				Build: spec.Build{
+					Check: true,
+				},
+			},
+			goVet: func(ctx context.Context, args ...string) (int, string, error) {
+				return 0, "", nil
+			},
+			goTest: func(ctx context.Context, args ...string) (int, string, error) {
+				return 0, "ok  	github.com/octocat/library	0.010s", nil
+			},
+			args:             []string{"-check"},
+			expectedExitCode: command.Success,
+		},
 		{
 			name: "GitHEADFails",
 			spec: spec.Spec{
@@ -230,6 +279,8 @@ func TestCommand_run(t *testing.T) {
 			c.services.git = tc.git
 			c.services.decorator = tc.decorator
 			c.funcs.goList = tc.goList
+			c.funcs.goVet = tc.goVet
+			c.funcs.goTest = tc.goTest
 			c.funcs.goBuild = tc.goBuild
 			c.commands.semver = tc.semver
 
@@ -42,6 +42,8 @@ const (
 	ExtractionError
 	// MiscError is the exit code when a miscellaneous operation fails.
 	MiscError
+	// VerificationError is the exit code when verifying the integrity of a downloaded file fails.
+	VerificationError
 )
 
 var (
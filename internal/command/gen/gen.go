@@ -1,8 +1,12 @@
 package gen
 
 import (
+	"bytes"
 	"context"
-	"flag"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"text/template"
 	"time"
 
 	"github.com/mitchellh/cli"
@@ -11,7 +15,10 @@ import (
 	"github.com/moorara/gelato/internal/log"
 	"github.com/moorara/gelato/internal/service/compiler"
 	"github.com/moorara/gelato/internal/service/compiler/builder"
+	"github.com/moorara/gelato/internal/service/compiler/faker"
 	"github.com/moorara/gelato/internal/service/compiler/mocker"
+	"github.com/moorara/gelato/internal/service/openapi"
+	"github.com/moorara/gelato/internal/spec"
 )
 
 const (
@@ -20,31 +27,68 @@ const (
 	genHelp     = `
   Use this command for generating test helpers (mocks, factories, builders, etc.).
 
-  Usage:  gelato gen
+  The builder generator creates builders for structs and the mocker generator creates mocks for interfaces.
+  The faker generator creates in-memory fakes for repository interfaces (interfaces with Create, Get, List, Update, and Delete methods).
+  The openapi generator creates the models, handler interfaces, routes, and clients in the internal/idl package from the OpenAPI specifications in the idl directory.
+  Packages are specified by their directories and they can be glob patterns (internal/*) or recursive (internal/...).
+  Types with a //gelato:build, //gelato:mock, or //gelato:fake directive comment are always included.
+
+  Usage:  gelato gen [flags]
+
+  Flags:
+    -generators          comma-separated list of generators (default: {{range $i, $g := .Gen.Generators}}{{if $i}},{{end}}{{$g}}{{end}})
+    -packages            comma-separated list of packages to include
+    -exclude-packages    comma-separated list of packages to exclude
+    -types               comma-separated list of types to include
+    -exclude-types       comma-separated list of types to exclude
+    -type-pattern        regular expression for types to include
+    -output              output directory for generated files (default: {{.Gen.Output}})
+    -in-place            write generated files next to source files as test files (default: {{.Gen.InPlace}})
+    -package-suffix      suffix for naming generated packages (default: {{.Gen.PackageSuffix}})
 
   Examples:
     gelato gen
+    gelato gen -generators mocker
+    gelato gen -generators faker -packages internal/repository
+    gelato gen -generators openapi
+    gelato gen -packages internal/... -exclude-packages internal/test
+    gelato gen -types Store,Cache -type-pattern 'Service$'
+    gelato gen -in-place
   `
+
+	manifestFile = ".manifest.json"
 )
 
-type compilerService interface {
-	Compile(string, compiler.ParseOptions) error
-}
+type (
+	compilerService interface {
+		Compile(string, compiler.ParseOptions) error
+	}
+
+	compilerFunc func(compiler.Layout) compilerService
+
+	openapiService interface {
+		Generate(string) ([]string, error)
+	}
+)
 
 // Command is the cli.Command implementation for gen command.
 type Command struct {
-	ui       cli.Ui
-	services struct {
-		builder compilerService
-		mocker  compilerService
+	ui    cli.Ui
+	spec  spec.Spec
+	funcs struct {
+		builder compilerFunc
+		mocker  compilerFunc
+		faker   compilerFunc
+		openapi func() openapiService
 	}
 	outputs struct{}
 }
 
 // NewCommand creates a gen command.
-func NewCommand(ui cli.Ui) (*Command, error) {
+func NewCommand(ui cli.Ui, spec spec.Spec) (*Command, error) {
 	return &Command{
-		ui: ui,
+		ui:   ui,
+		spec: spec,
 	}, nil
 }
 
@@ -55,21 +99,37 @@ func (c *Command) Synopsis() string {
 
 // Help returns a long help text including usage, description, and list of flags for the command.
 func (c *Command) Help() string {
-	return genHelp
+	var buf bytes.Buffer
+	t := template.Must(template.New("help").Parse(genHelp))
+	_ = t.Execute(&buf, c.spec)
+	return buf.String()
 }
 
 // Run runs the actual command with the given command-line arguments.
 // This method is used as a proxy for creating dependencies and the actual command execution is delegated to the run method for testing purposes.
 func (c *Command) Run(args []string) int {
-	c.services.builder = builder.New(log.Trace)
-	c.services.mocker = mocker.New(log.Trace)
+	c.funcs.builder = func(layout compiler.Layout) compilerService {
+		return builder.New(log.Trace, layout)
+	}
+
+	c.funcs.mocker = func(layout compiler.Layout) compilerService {
+		return mocker.New(log.Trace, layout)
+	}
+
+	c.funcs.faker = func(layout compiler.Layout) compilerService {
+		return faker.New(log.Trace, layout)
+	}
+
+	c.funcs.openapi = func() openapiService {
+		return openapi.NewGenerator(log.Trace)
+	}
 
 	return c.run(args)
 }
 
 // run in an auxiliary method, so we can test the business logic with mock dependencies.
 func (c *Command) run(args []string) int {
-	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs := c.spec.Gen.FlagSet()
 	fs.Usage = func() {
 		c.ui.Output(c.Help())
 	}
@@ -78,6 +138,33 @@ func (c *Command) run(args []string) int {
 		return command.FlagError
 	}
 
+	var genOpenAPI bool
+	generators := make([]compilerFunc, 0, len(c.spec.Gen.Generators))
+	for _, name := range c.spec.Gen.Generators {
+		switch name {
+		case spec.GenGeneratorOpenAPI:
+			genOpenAPI = true
+		case spec.GenGeneratorBuilder:
+			generators = append(generators, c.funcs.builder)
+		case spec.GenGeneratorMocker:
+			generators = append(generators, c.funcs.mocker)
+		case spec.GenGeneratorFaker:
+			generators = append(generators, c.funcs.faker)
+		default:
+			c.ui.Error(fmt.Sprintf("Unknown generator: %s", name))
+			return command.FlagError
+		}
+	}
+
+	var typeRegexp *regexp.Regexp
+	if c.spec.Gen.TypePattern != "" {
+		var err error
+		if typeRegexp, err = regexp.Compile(c.spec.Gen.TypePattern); err != nil {
+			c.ui.Error(fmt.Sprintf("Invalid type pattern: %s", err))
+			return command.FlagError
+		}
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), genTimeout)
 	defer cancel()
 
@@ -93,18 +180,38 @@ func (c *Command) run(args []string) int {
 
 	// ==============================> GENERATE CODES <==============================
 
+	// The idl package is generated first, so the other generators can use it
+	if genOpenAPI {
+		if _, err := c.funcs.openapi().Generate(info.WorkingDirectory); err != nil {
+			c.ui.Error(err.Error())
+			return command.GenerationError
+		}
+	}
+
 	opts := compiler.ParseOptions{
-		SkipTestFiles: true,
+		SkipTestFiles:    true,
+		Packages:         c.spec.Gen.Packages,
+		ExcludePackages:  c.spec.Gen.ExcludePackages,
+		TypeNames:        c.spec.Gen.Types,
+		ExcludeTypeNames: c.spec.Gen.ExcludeTypes,
+		TypeRegexp:       typeRegexp,
+		Manifest:         filepath.Join(c.spec.Gen.Output, manifestFile),
 	}
 
-	if err := c.services.builder.Compile(info.WorkingDirectory, opts); err != nil {
-		c.ui.Error(err.Error())
-		return command.GenerationError
+	layout := compiler.Layout{
+		OutputDir:     c.spec.Gen.Output,
+		PackageSuffix: c.spec.Gen.PackageSuffix,
 	}
 
-	if err := c.services.mocker.Compile(info.WorkingDirectory, opts); err != nil {
-		c.ui.Error(err.Error())
-		return command.GenerationError
+	if c.spec.Gen.InPlace {
+		layout.OutputDir = ""
+	}
+
+	for _, generator := range generators {
+		if err := generator(layout).Compile(info.WorkingDirectory, opts); err != nil {
+			c.ui.Error(err.Error())
+			return command.GenerationError
+		}
 	}
 
 	// ==============================> DONE <==============================
@@ -2,17 +2,20 @@ package gen
 
 import (
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/service/compiler"
+	"github.com/moorara/gelato/internal/spec"
 )
 
 func TestNewCommand(t *testing.T) {
 	ui := new(cli.MockUi)
-	c, err := NewCommand(ui)
+	c, err := NewCommand(ui, spec.Spec{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
@@ -36,25 +39,66 @@ func TestCommand_Run(t *testing.T) {
 	c := &Command{ui: new(cli.MockUi)}
 	c.Run([]string{"--undefined"})
 
-	assert.NotNil(t, c.services.builder)
-	assert.NotNil(t, c.services.mocker)
+	assert.NotNil(t, c.funcs.builder)
+	assert.NotNil(t, c.funcs.mocker)
+	assert.NotNil(t, c.funcs.faker)
+	assert.NotNil(t, c.funcs.openapi)
+	assert.NotNil(t, c.funcs.builder(compiler.DefaultLayout()))
+	assert.NotNil(t, c.funcs.mocker(compiler.DefaultLayout()))
+	assert.NotNil(t, c.funcs.faker(compiler.DefaultLayout()))
+	assert.NotNil(t, c.funcs.openapi())
 }
 
 func TestCommand_run(t *testing.T) {
 	tests := []struct {
 		name             string
+		spec             spec.Spec
 		builder          *MockCompilerService
 		mocker           *MockCompilerService
+		faker            *MockCompilerService
+		openapi          *MockOpenAPIService
 		args             []string
 		expectedExitCode int
+		expectedLayout   compiler.Layout
+		expectedOptions  compiler.ParseOptions
 	}{
 		{
 			name:             "UndefinedFlag",
+			spec:             spec.Spec{}.WithDefaults(),
 			args:             []string{"--undefined"},
 			expectedExitCode: command.FlagError,
 		},
+		{
+			name:             "UnknownGenerator",
+			spec:             spec.Spec{}.WithDefaults(),
+			args:             []string{"-generators", "stubber"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "InvalidTypePattern",
+			spec:             spec.Spec{}.WithDefaults(),
+			args:             []string{"-type-pattern", "["},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name: "OpenAPIGenerateFails",
+			spec: spec.Spec{}.WithDefaults(),
+			openapi: &MockOpenAPIService{
+				GenerateMocks: []GenerateMock{
+					{OutError: errors.New("error on generating")},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.GenerationError,
+		},
 		{
 			name: "BuilderCompileFails",
+			spec: spec.Spec{}.WithDefaults(),
+			openapi: &MockOpenAPIService{
+				GenerateMocks: []GenerateMock{
+					{OutPaths: []string{"internal/idl/greeting.go"}},
+				},
+			},
 			builder: &MockCompilerService{
 				CompileMocks: []CompileMock{
 					{OutError: errors.New("error on compiling")},
@@ -65,12 +109,44 @@ func TestCommand_run(t *testing.T) {
 		},
 		{
 			name: "MockerCompileFails",
+			spec: spec.Spec{}.WithDefaults(),
+			openapi: &MockOpenAPIService{
+				GenerateMocks: []GenerateMock{
+					{OutPaths: []string{"internal/idl/greeting.go"}},
+				},
+			},
+			builder: &MockCompilerService{
+				CompileMocks: []CompileMock{
+					{OutError: nil},
+				},
+			},
+			mocker: &MockCompilerService{
+				CompileMocks: []CompileMock{
+					{OutError: errors.New("error on compiling")},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.GenerationError,
+		},
+		{
+			name: "FakerCompileFails",
+			spec: spec.Spec{}.WithDefaults(),
+			openapi: &MockOpenAPIService{
+				GenerateMocks: []GenerateMock{
+					{OutPaths: []string{"internal/idl/greeting.go"}},
+				},
+			},
 			builder: &MockCompilerService{
 				CompileMocks: []CompileMock{
 					{OutError: nil},
 				},
 			},
 			mocker: &MockCompilerService{
+				CompileMocks: []CompileMock{
+					{OutError: nil},
+				},
+			},
+			faker: &MockCompilerService{
 				CompileMocks: []CompileMock{
 					{OutError: errors.New("error on compiling")},
 				},
@@ -80,6 +156,12 @@ func TestCommand_run(t *testing.T) {
 		},
 		{
 			name: "Success",
+			spec: spec.Spec{}.WithDefaults(),
+			openapi: &MockOpenAPIService{
+				GenerateMocks: []GenerateMock{
+					{OutPaths: []string{"internal/idl/greeting.go"}},
+				},
+			},
 			builder: &MockCompilerService{
 				CompileMocks: []CompileMock{
 					{OutError: nil},
@@ -90,20 +172,123 @@ func TestCommand_run(t *testing.T) {
 					{OutError: nil},
 				},
 			},
+			faker: &MockCompilerService{
+				CompileMocks: []CompileMock{
+					{OutError: nil},
+				},
+			},
 			args:             []string{},
 			expectedExitCode: command.Success,
+			expectedLayout:   compiler.DefaultLayout(),
+			expectedOptions: compiler.ParseOptions{
+				SkipTestFiles: true,
+				Manifest:      ".gen/.manifest.json",
+			},
+		},
+		{
+			name: "Success_WithSpec",
+			spec: spec.Spec{
+				Gen: spec.Gen{
+					Generators:      []string{spec.GenGeneratorMocker},
+					Packages:        []string{"internal/..."},
+					ExcludePackages: []string{"internal/test"},
+					Types:           []string{"Store"},
+					ExcludeTypes:    []string{"Helper"},
+					Output:          "test/gen",
+					PackageSuffix:   "mock",
+				},
+			}.WithDefaults(),
+			mocker: &MockCompilerService{
+				CompileMocks: []CompileMock{
+					{OutError: nil},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.Success,
+			expectedLayout: compiler.Layout{
+				OutputDir:     "test/gen",
+				PackageSuffix: "mock",
+			},
+			expectedOptions: compiler.ParseOptions{
+				SkipTestFiles:    true,
+				Packages:         []string{"internal/..."},
+				ExcludePackages:  []string{"internal/test"},
+				TypeNames:        []string{"Store"},
+				ExcludeTypeNames: []string{"Helper"},
+				Manifest:         "test/gen/.manifest.json",
+			},
+		},
+		{
+			name: "Success_WithFlags",
+			spec: spec.Spec{}.WithDefaults(),
+			mocker: &MockCompilerService{
+				CompileMocks: []CompileMock{
+					{OutError: nil},
+				},
+			},
+			faker: &MockCompilerService{
+				CompileMocks: []CompileMock{
+					{OutError: nil},
+				},
+			},
+			args:             []string{"-generators", "mocker,faker", "-types", "Store,Cache", "-type-pattern", "Service$", "-in-place"},
+			expectedExitCode: command.Success,
+			expectedLayout: compiler.Layout{
+				OutputDir:     "",
+				PackageSuffix: "test",
+			},
+			expectedOptions: compiler.ParseOptions{
+				SkipTestFiles: true,
+				TypeNames:     []string{"Store", "Cache"},
+				TypeRegexp:    regexp.MustCompile("Service$"),
+				Manifest:      ".gen/.manifest.json",
+			},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			c := &Command{ui: cli.NewMockUi()}
-			c.services.builder = tc.builder
-			c.services.mocker = tc.mocker
+			var layouts []compiler.Layout
+
+			c := &Command{
+				ui:   cli.NewMockUi(),
+				spec: tc.spec,
+			}
+
+			c.funcs.builder = func(layout compiler.Layout) compilerService {
+				layouts = append(layouts, layout)
+				return tc.builder
+			}
+
+			c.funcs.mocker = func(layout compiler.Layout) compilerService {
+				layouts = append(layouts, layout)
+				return tc.mocker
+			}
+
+			c.funcs.faker = func(layout compiler.Layout) compilerService {
+				layouts = append(layouts, layout)
+				return tc.faker
+			}
+
+			c.funcs.openapi = func() openapiService {
+				return tc.openapi
+			}
 
 			exitCode := c.run(tc.args)
 
 			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.expectedExitCode == command.Success {
+				for _, layout := range layouts {
+					assert.Equal(t, tc.expectedLayout, layout)
+				}
+
+				for _, m := range []*MockCompilerService{tc.builder, tc.mocker, tc.faker} {
+					if m != nil {
+						assert.Equal(t, tc.expectedOptions, m.CompileMocks[0].InOptions)
+					}
+				}
+			}
 		})
 	}
 }
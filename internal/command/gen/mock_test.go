@@ -15,6 +15,17 @@ type (
 		CompileIndex int
 		CompileMocks []CompileMock
 	}
+
+	GenerateMock struct {
+		InRoot   string
+		OutPaths []string
+		OutError error
+	}
+
+	MockOpenAPIService struct {
+		GenerateIndex int
+		GenerateMocks []GenerateMock
+	}
 )
 
 func (m *MockCompilerService) Compile(path string, opts compiler.ParseOptions) error {
@@ -24,3 +35,10 @@ func (m *MockCompilerService) Compile(path string, opts compiler.ParseOptions) e
 	m.CompileMocks[i].InOptions = opts
 	return m.CompileMocks[i].OutError
 }
+
+func (m *MockOpenAPIService) Generate(root string) ([]string, error) {
+	i := m.GenerateIndex
+	m.GenerateIndex++
+	m.GenerateMocks[i].InRoot = root
+	return m.GenerateMocks[i].OutPaths, m.GenerateMocks[i].OutError
+}
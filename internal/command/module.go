@@ -0,0 +1,115 @@
+package command
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	majorVersionRE = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// Module represents a Go module in a git repository.
+type Module struct {
+	// Name is the module path declared in the go.mod file.
+	Name string
+	// Path is the absolute path of the module directory.
+	Path string
+	// Dir is the module directory relative to the root of the git repository using forward slashes.
+	// The root module has "." as its directory.
+	Dir string
+}
+
+// IsNested determines whether or not the module is located in a sub-directory of the git repository.
+func (m Module) IsNested() bool {
+	return m.Dir != "" && m.Dir != "."
+}
+
+// TagPrefix returns the prefix that git tags of the module should have.
+// As described in https://golang.org/ref/mod#vcs-version, the tags for a module in a sub-directory are prefixed by the sub-directory.
+// A major version sub-directory (e.g. v2) is not considered a part of the prefix.
+func (m Module) TagPrefix() string {
+	if !m.IsNested() {
+		return ""
+	}
+
+	dir := m.Dir
+	if base := filepath.Base(dir); majorVersionRE.MatchString(base) && strings.HasSuffix(m.Name, "/"+base) {
+		dir = filepath.Dir(dir)
+	}
+
+	if dir == "." {
+		return ""
+	}
+
+	return filepath.ToSlash(dir) + "/"
+}
+
+// FindModule finds the nearest Go module containing a given path by looking for a go.mod file in the path and its parents.
+// The search stops at the root of the git repository.
+// If no go.mod file is found, the module is assumed to be the whole repository.
+func FindModule(root, path string) (Module, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return Module{}, err
+	}
+
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return Module{}, err
+	}
+
+	for dir := path; ; dir = filepath.Dir(dir) {
+		name, err := readModuleName(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return Module{}, err
+		}
+
+		if name != "" {
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return Module{}, err
+			}
+
+			return Module{
+				Name: name,
+				Path: dir,
+				Dir:  filepath.ToSlash(rel),
+			}, nil
+		}
+
+		if dir == root || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	return Module{
+		Path: root,
+		Dir:  ".",
+	}, nil
+}
+
+// readModuleName reads the module name from a go.mod file.
+// If the file does not exist, an empty string is returned.
+func readModuleName(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+
+	return "", scanner.Err()
+}
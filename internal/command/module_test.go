@@ -0,0 +1,177 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModule_IsNested(t *testing.T) {
+	tests := []struct {
+		name           string
+		module         Module
+		expectedNested bool
+	}{
+		{
+			name:           "Zero",
+			module:         Module{},
+			expectedNested: false,
+		},
+		{
+			name:           "Root",
+			module:         Module{Name: "github.com/octocat/Hello-World", Dir: "."},
+			expectedNested: false,
+		},
+		{
+			name:           "Nested",
+			module:         Module{Name: "github.com/octocat/Hello-World/api", Dir: "api"},
+			expectedNested: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedNested, tc.module.IsNested())
+		})
+	}
+}
+
+func TestModule_TagPrefix(t *testing.T) {
+	tests := []struct {
+		name           string
+		module         Module
+		expectedPrefix string
+	}{
+		{
+			name:           "Root",
+			module:         Module{Name: "github.com/octocat/Hello-World", Dir: "."},
+			expectedPrefix: "",
+		},
+		{
+			name:           "RootMajorVersion",
+			module:         Module{Name: "github.com/octocat/Hello-World/v2", Dir: "v2"},
+			expectedPrefix: "",
+		},
+		{
+			name:           "Nested",
+			module:         Module{Name: "github.com/octocat/Hello-World/api", Dir: "api"},
+			expectedPrefix: "api/",
+		},
+		{
+			name:           "DeeplyNested",
+			module:         Module{Name: "github.com/octocat/Hello-World/services/api", Dir: "services/api"},
+			expectedPrefix: "services/api/",
+		},
+		{
+			name:           "NestedMajorVersion",
+			module:         Module{Name: "github.com/octocat/Hello-World/api/v2", Dir: "api/v2"},
+			expectedPrefix: "api/",
+		},
+		{
+			name:           "NestedVersionLikeDirectory",
+			module:         Module{Name: "github.com/octocat/Hello-World/api", Dir: "api/v2"},
+			expectedPrefix: "api/v2/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPrefix, tc.module.TagPrefix())
+		})
+	}
+}
+
+func TestFindModule(t *testing.T) {
+	root, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	root, err = filepath.EvalSymlinks(root)
+	assert.NoError(t, err)
+
+	files := map[string]string{
+		"go.mod":                  "module github.com/octocat/Hello-World\n\ngo 1.15\n",
+		"api/go.mod":              "// API module\nmodule \"github.com/octocat/Hello-World/api\"\n",
+		"api/internal/handler.go": "package internal\n",
+		"docs/README.md":          "",
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	noModRoot, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(noModRoot)
+
+	tests := []struct {
+		name           string
+		root           string
+		path           string
+		expectedModule Module
+	}{
+		{
+			name: "RootModule",
+			root: root,
+			path: root,
+			expectedModule: Module{
+				Name: "github.com/octocat/Hello-World",
+				Path: root,
+				Dir:  ".",
+			},
+		},
+		{
+			name: "RootModule_SubDirectory",
+			root: root,
+			path: filepath.Join(root, "docs"),
+			expectedModule: Module{
+				Name: "github.com/octocat/Hello-World",
+				Path: root,
+				Dir:  ".",
+			},
+		},
+		{
+			name: "NestedModule",
+			root: root,
+			path: filepath.Join(root, "api"),
+			expectedModule: Module{
+				Name: "github.com/octocat/Hello-World/api",
+				Path: filepath.Join(root, "api"),
+				Dir:  "api",
+			},
+		},
+		{
+			name: "NestedModule_SubDirectory",
+			root: root,
+			path: filepath.Join(root, "api", "internal"),
+			expectedModule: Module{
+				Name: "github.com/octocat/Hello-World/api",
+				Path: filepath.Join(root, "api"),
+				Dir:  "api",
+			},
+		},
+		{
+			name: "NoModule",
+			root: noModRoot,
+			path: noModRoot,
+			expectedModule: Module{
+				Path: noModRoot,
+				Dir:  ".",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			module, err := FindModule(tc.root, tc.path)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedModule, module)
+		})
+	}
+}
@@ -2,11 +2,13 @@ package release
 
 import (
 	"context"
+	"sync"
 
 	"github.com/moorara/go-github"
 
 	changelogSpec "github.com/moorara/changelog/spec"
 	buildcmd "github.com/moorara/gelato/internal/command/build"
+	"github.com/moorara/gelato/internal/service/git"
 	"github.com/moorara/gelato/pkg/semver"
 )
 
@@ -29,6 +31,18 @@ type (
 		OutError error
 	}
 
+	TagsMock struct {
+		OutTags  git.Tags
+		OutError error
+	}
+
+	CommitsInPathMock struct {
+		InRev      string
+		InPath     string
+		OutCommits git.Commits
+		OutError   error
+	}
+
 	CreateCommitMock struct {
 		InMessage string
 		InPaths   []string
@@ -72,6 +86,12 @@ type (
 		IsCleanIndex int
 		IsCleanMocks []IsCleanMock
 
+		TagsIndex int
+		TagsMocks []TagsMock
+
+		CommitsInPathIndex int
+		CommitsInPathMocks []CommitsInPathMock
+
 		CreateCommitIndex int
 		CreateCommitMocks []CreateCommitMock
 
@@ -108,6 +128,20 @@ func (m *MockGitService) IsClean() (bool, error) {
 	return m.IsCleanMocks[i].OutBool, m.IsCleanMocks[i].OutError
 }
 
+func (m *MockGitService) Tags() (git.Tags, error) {
+	i := m.TagsIndex
+	m.TagsIndex++
+	return m.TagsMocks[i].OutTags, m.TagsMocks[i].OutError
+}
+
+func (m *MockGitService) CommitsInPath(rev, path string) (git.Commits, error) {
+	i := m.CommitsInPathIndex
+	m.CommitsInPathIndex++
+	m.CommitsInPathMocks[i].InRev = rev
+	m.CommitsInPathMocks[i].InPath = path
+	return m.CommitsInPathMocks[i].OutCommits, m.CommitsInPathMocks[i].OutError
+}
+
 func (m *MockGitService) CreateCommit(message string, paths ...string) (string, error) {
 	i := m.CreateCommitIndex
 	m.CreateCommitIndex++
@@ -235,6 +269,7 @@ type (
 		UpdateReleaseIndex int
 		UpdateReleaseMocks []UpdateReleaseMock
 
+		UploadReleaseAssetMutex sync.Mutex
 		UploadReleaseAssetIndex int
 		UploadReleaseAssetMocks []UploadReleaseAssetMock
 	}
@@ -282,6 +317,10 @@ func (m *MockRepoService) UpdateRelease(ctx context.Context, releaseID int, para
 }
 
 func (m *MockRepoService) UploadReleaseAsset(ctx context.Context, releaseID int, assetFile, assetLabel string) (*github.ReleaseAsset, *github.Response, error) {
+	// Artifacts are uploaded concurrently
+	m.UploadReleaseAssetMutex.Lock()
+	defer m.UploadReleaseAssetMutex.Unlock()
+
 	i := m.UploadReleaseAssetIndex
 	m.UploadReleaseAssetIndex++
 	m.UploadReleaseAssetMocks[i].InContext = ctx
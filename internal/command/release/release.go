@@ -3,8 +3,12 @@ package release
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"text/template"
@@ -36,6 +40,12 @@ const (
   Currently, the release command only supports GitHub repositories.
   It also assumes the remote repository is named origin.
 
+  For a Go module in a sub-directory of the repository (i.e. the nearest go.mod file is not at the root),
+  the release tag is prefixed by the module directory (e.g. api/v0.1.0) and the changelog only includes the module tags
+  and the issues and pull requests referenced by the commits changing the module directory.
+  In this case, the command should be run from the module directory.
+  The changelog of the root module excludes the tags of the nested modules.
+
   Usage:  gelato release [flags]
 
   Flags:
@@ -58,11 +68,15 @@ const (
 )
 
 const (
-	remoteName = "origin"
+	remoteName    = "origin"
+	checksumsFile = "checksums.txt"
 )
 
 var (
-	h2Regex = regexp.MustCompile(`##[^\n]*\n`)
+	h2Regex          = regexp.MustCompile(`##[^\n]*\n`)
+	numberRegex      = regexp.MustCompile(`#(\d+)`)
+	changeRegex      = regexp.MustCompile(`^  - .* \[#(\d+)\]\(`)
+	changeGroupRegex = regexp.MustCompile(`^\*\*.*:\*\*$`)
 )
 
 type (
@@ -70,6 +84,8 @@ type (
 		Remote(string) (string, string, error)
 		HEAD() (string, string, error)
 		IsClean() (bool, error)
+		Tags() (git.Tags, error)
+		CommitsInPath(string, string) (git.Commits, error)
 		CreateCommit(string, ...string) (string, error)
 		CreateTag(string, string, string) (string, error)
 		Pull(context.Context) error
@@ -113,6 +129,7 @@ type Command struct {
 		owner         string
 		repo          string
 		changelogSpec changelogSpec.Spec
+		module        command.Module
 	}
 	services struct {
 		git       gitService
@@ -157,6 +174,18 @@ func (c *Command) Run(args []string) int {
 		return command.GitError
 	}
 
+	gitPath, err := git.Path()
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.GitError
+	}
+
+	module, err := command.FindModule(gitPath, ".")
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.OSError
+	}
+
 	// TODO: should we check for remote names other than origin?
 	domain, path, err := git.Remote(remoteName)
 	if err != nil {
@@ -207,6 +236,7 @@ func (c *Command) Run(args []string) int {
 	c.data.owner = ownerName
 	c.data.repo = repoName
 	c.data.changelogSpec = cs
+	c.data.module = module
 
 	c.services.git = git
 	c.services.users = client.Users
@@ -339,14 +369,18 @@ func (c *Command) run(args []string) int {
 		version = c.commands.semver.SemVer().ReleasePatch()
 	}
 
-	tagName := "v" + version.String()
+	tagPrefix := c.data.module.TagPrefix()
+	tagName := tagPrefix + "v" + version.String()
+
+	// The release name for a nested module includes the module prefix (e.g. api/0.1.0)
+	releaseName := tagPrefix + version.String()
 
 	// ==============================> CREATE A DRAFT RELEASE <==============================
 
-	c.ui.Info(fmt.Sprintf("Creating the draft release %s ...", version))
+	c.ui.Info(fmt.Sprintf("Creating the draft release %s ...", releaseName))
 
 	params := github.ReleaseParams{
-		Name:       version.String(),
+		Name:       releaseName,
 		TagName:    tagName,
 		Target:     gitBranch,
 		Draft:      true,
@@ -365,23 +399,61 @@ func (c *Command) run(args []string) int {
 
 	c.data.changelogSpec.Tags.Future = tagName
 
+	tags, err := c.services.git.Tags()
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.GitError
+	}
+
+	// For a nested module, the tags that do not belong to the module are excluded from the changelog.
+	// For the root module, the tags of the nested modules (e.g. api/v0.1.0) are excluded from the changelog.
+	for _, t := range tags {
+		if tagPrefix == "" {
+			if isNestedModuleTag(t.Name) {
+				c.data.changelogSpec.Tags.Exclude = append(c.data.changelogSpec.Tags.Exclude, t.Name)
+			}
+		} else if _, ok := semver.Parse(strings.TrimPrefix(t.Name, tagPrefix)); !ok || !strings.HasPrefix(t.Name, tagPrefix) {
+			c.data.changelogSpec.Tags.Exclude = append(c.data.changelogSpec.Tags.Exclude, t.Name)
+		}
+	}
+
 	changelog, err := c.services.changelog.Generate(ctx, c.data.changelogSpec)
 	if err != nil {
 		c.ui.Error(err.Error())
 		return command.ChangelogError
 	}
 
+	// For a nested module, only the issues and pull requests referenced by the commits changing the module directory are kept
+	if tagPrefix != "" {
+		commits, err := c.services.git.CommitsInPath("HEAD", c.data.module.Dir)
+		if err != nil {
+			c.ui.Error(err.Error())
+			return command.GitError
+		}
+
+		filtered := filterChangelog(changelog, referencedNumbers(commits))
+		if err := replaceInFile(c.data.changelogSpec.General.File, changelog, filtered); err != nil {
+			c.ui.Error(err.Error())
+			return command.ChangelogError
+		}
+
+		changelog = filtered
+	}
+
 	// Remove the H2 title
 	changelog = h2Regex.ReplaceAllString(changelog, "")
 	changelog = strings.TrimLeft(changelog, "\n")
 
 	// ==============================> CREATE RELEASE COMMIT & TAG <==============================
 
-	c.ui.Info(fmt.Sprintf("Creating the release commit and tag %s ...", version))
+	c.ui.Info(fmt.Sprintf("Creating the release commit and tag %s ...", releaseName))
+
+	message := fmt.Sprintf("Release %s", releaseName)
 
-	message := fmt.Sprintf("Release %s", version)
+	// The changelog file is created in the module directory, but it should be staged relative to the root of the repository
+	changelogPath := filepath.ToSlash(filepath.Join(c.data.module.Dir, c.data.changelogSpec.General.File))
 
-	commit, err := c.services.git.CreateCommit(message, c.data.changelogSpec.General.File)
+	commit, err := c.services.git.CreateCommit(message, changelogPath)
 	if err != nil {
 		c.ui.Error(err.Error())
 		return command.GitError
@@ -404,11 +476,22 @@ func (c *Command) run(args []string) int {
 			return code
 		}
 
+		artifacts := c.commands.build.Artifacts()
+
+		// The checksums of artifacts are included in the release, so they can be verified after download
+		checksums, err := writeChecksums(artifacts)
+		if err != nil {
+			c.ui.Error(err.Error())
+			return command.OSError
+		}
+
+		artifacts = append(artifacts, checksums)
+
 		c.ui.Info(fmt.Sprintf("Uploading artifacts to release %s ...", release.Name))
 
 		group, groupCtx := errgroup.WithContext(ctx)
 
-		for _, artifact := range c.commands.build.Artifacts() {
+		for _, artifact := range artifacts {
 			artifact := artifact // https://golang.org/doc/faq#closures_and_goroutines
 			group.Go(func() error {
 				_, _, err := c.services.repo.UploadReleaseAsset(groupCtx, release.ID, artifact.Path, artifact.Label)
@@ -487,3 +570,119 @@ func (c *Command) run(args []string) int {
 
 	return command.Success
 }
+
+// writeChecksums creates a checksums file for a list of artifacts in the format produced by sha256sum.
+// The checksums file is created next to the first artifact.
+func writeChecksums(artifacts []buildcmd.Artifact) (buildcmd.Artifact, error) {
+	var buf bytes.Buffer
+
+	for _, artifact := range artifacts {
+		f, err := os.Open(artifact.Path)
+		if err != nil {
+			return buildcmd.Artifact{}, err
+		}
+
+		hash := sha256.New()
+		_, err = io.Copy(hash, f)
+		f.Close()
+
+		if err != nil {
+			return buildcmd.Artifact{}, err
+		}
+
+		fmt.Fprintf(&buf, "%x  %s\n", hash.Sum(nil), filepath.Base(artifact.Path))
+	}
+
+	var dir string
+	if len(artifacts) > 0 {
+		dir = filepath.Dir(artifacts[0].Path)
+	}
+
+	path := filepath.Join(dir, checksumsFile)
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return buildcmd.Artifact{}, err
+	}
+
+	return buildcmd.Artifact{
+		Path:  path,
+		Label: checksumsFile,
+	}, nil
+}
+
+// isNestedModuleTag determines whether or not a git tag belongs to a module in a sub-directory of the repository (e.g. api/v0.1.0).
+func isNestedModuleTag(name string) bool {
+	i := strings.LastIndex(name, "/")
+	if i <= 0 {
+		return false
+	}
+
+	_, ok := semver.Parse(name[i+1:])
+	return ok
+}
+
+// referencedNumbers returns the issue and pull request numbers referenced in the messages of commits (e.g. "Merge pull request #1").
+func referencedNumbers(commits git.Commits) map[string]bool {
+	numbers := map[string]bool{}
+	for _, commit := range commits {
+		for _, m := range numberRegex.FindAllStringSubmatch(commit.Message, -1) {
+			numbers[m[1]] = true
+		}
+	}
+
+	return numbers
+}
+
+// filterChangelog removes the issues and pull requests that are not in a set of numbers from a generated changelog.
+// The groups of issues and pull requests that become empty are removed too.
+func filterChangelog(changelog string, numbers map[string]bool) string {
+	lines := strings.Split(changelog, "\n")
+	out := make([]string, 0, len(lines))
+
+	for i := 0; i < len(lines); i++ {
+		if !changeGroupRegex.MatchString(lines[i]) {
+			out = append(out, lines[i])
+			continue
+		}
+
+		// A group is a title followed by a blank line, a list of changes, and another blank line
+		j := i + 1
+		if j < len(lines) && lines[j] == "" {
+			j++
+		}
+
+		var changes []string
+		for ; j < len(lines) && changeRegex.MatchString(lines[j]); j++ {
+			if m := changeRegex.FindStringSubmatch(lines[j]); numbers[m[1]] {
+				changes = append(changes, lines[j])
+			}
+		}
+
+		if len(changes) > 0 {
+			out = append(out, lines[i], "")
+			out = append(out, changes...)
+		} else if j < len(lines) && lines[j] == "" {
+			// Skip the blank line after the removed group
+			j++
+		}
+
+		i = j - 1
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// replaceInFile replaces the first occurrence of a string in a file.
+func replaceInFile(path, old, new string) error {
+	if old == new {
+		return nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	content := strings.Replace(string(b), old, new, 1)
+
+	return ioutil.WriteFile(path, []byte(content), 0644)
+}
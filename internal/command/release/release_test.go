@@ -2,7 +2,9 @@ package release
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/mitchellh/cli"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/moorara/gelato/internal/command"
 	buildcmd "github.com/moorara/gelato/internal/command/build"
+	"github.com/moorara/gelato/internal/service/git"
 	"github.com/moorara/gelato/internal/spec"
 	"github.com/moorara/gelato/pkg/semver"
 	"github.com/moorara/go-github"
@@ -115,9 +118,17 @@ func TestCommand_run(t *testing.T) {
 		Prerelease: false,
 	}
 
+	binDir, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(binDir)
+
+	binPath := filepath.Join(binDir, "app")
+	err = ioutil.WriteFile(binPath, []byte("binary"), 0755)
+	assert.NoError(t, err)
+
 	artifacts := []buildcmd.Artifact{
 		{
-			Path:  "bin/app",
+			Path:  binPath,
 			Label: "linux",
 		},
 	}
@@ -135,17 +146,33 @@ func TestCommand_run(t *testing.T) {
 		Prerelease: false,
 	}
 
+	nestedChangelog := "## [api/v0.1.0](https://github.com/octocat/Hello-World/tree/api/v0.1.0) (2020-10-10)\n\n" +
+		"**Closed Issues:**\n\n" +
+		"  - Web bug [#1](https://github.com/octocat/Hello-World/issues/1) ([octocat](https://github.com/octocat))\n\n" +
+		"**Merged Changes:**\n\n" +
+		"  - API feature [#2](https://github.com/octocat/Hello-World/pull/2) ([octocat](https://github.com/octocat))\n" +
+		"  - Web feature [#4](https://github.com/octocat/Hello-World/pull/4) ([octocat](https://github.com/octocat))\n\n"
+
+	filteredChangelog := "## [api/v0.1.0](https://github.com/octocat/Hello-World/tree/api/v0.1.0) (2020-10-10)\n\n" +
+		"**Merged Changes:**\n\n" +
+		"  - API feature [#2](https://github.com/octocat/Hello-World/pull/2) ([octocat](https://github.com/octocat))\n\n"
+
 	tests := []struct {
-		name             string
-		spec             spec.Spec
-		git              *MockGitService
-		users            *MockUsersService
-		repo             *MockRepoService
-		changelog        *MockChangelogService
-		semver           *MockSemverCommand
-		build            *MockBuildCommand
-		args             []string
-		expectedExitCode int
+		name                  string
+		spec                  spec.Spec
+		git                   *MockGitService
+		users                 *MockUsersService
+		repo                  *MockRepoService
+		changelog             *MockChangelogService
+		semver                *MockSemverCommand
+		build                 *MockBuildCommand
+		module                command.Module
+		args                  []string
+		expectedExitCode      int
+		expectedTagName       string
+		expectedChangelogPath string
+		expectedExcludedTags  []string
+		expectedChangelog     string
 	}{
 		{
 			name:             "UndefinedFlag",
@@ -430,6 +457,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 			},
 			users: &MockUsersService{
 				UserMocks: []UserMock{
@@ -476,6 +506,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutError: errors.New("git error")},
 				},
@@ -525,6 +558,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -581,6 +617,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -642,6 +681,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -666,6 +708,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutError: errors.New("github error")},
+					{OutError: errors.New("github error")},
 				},
 			},
 			changelog: &MockChangelogService{
@@ -709,6 +752,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -733,6 +779,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
 					{OutError: errors.New("github error")},
@@ -779,6 +826,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -806,6 +856,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
 					{OutResponse: &github.Response{}},
@@ -853,6 +904,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -883,6 +937,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
 					{OutResponse: &github.Response{}},
@@ -930,6 +985,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -960,6 +1018,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
 					{OutResponse: &github.Response{}},
@@ -1010,6 +1069,102 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{Name: "v0.1.0"},
+							{Name: "api/v0.1.0"},
+							{Name: "web/v0.1.0-rc.1"},
+						},
+					},
+				},
+				CreateCommitMocks: []CreateCommitMock{
+					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
+				},
+				CreateTagMocks: []CreateTagMock{
+					{OutHash: "a3580a0f64b08ba6085d530c828c40b8aa082c1e"},
+				},
+				PushMocks: []PushMock{
+					{OutError: nil},
+				},
+				PushTagMocks: []PushTagMock{
+					{OutError: nil},
+				},
+			},
+			users: &MockUsersService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				CreateReleaseMocks: []CreateReleaseMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+					{OutResponse: &github.Response{}},
+				},
+				UpdateReleaseMocks: []UpdateReleaseMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ArtifactsMocks: []ArtifactsMock{
+					{OutArtifacts: artifacts},
+				},
+			},
+			args:                  []string{"-comment", "Release description"},
+			expectedExitCode:      command.Success,
+			expectedTagName:       "v0.1.0",
+			expectedChangelogPath: "CHANGELOG.md",
+			expectedExcludedTags:  []string{"api/v0.1.0", "web/v0.1.0-rc.1"},
+		},
+		{
+			name: "NestedModule_TagsFails",
+			spec: spec.Spec{
+				Release: spec.Release{
+					Artifacts: true,
+				},
+			},
+			git: &MockGitService{
+				HEADMocks: []HEADMock{
+					{OutBranch: "main"},
+				},
+				IsCleanMocks: []IsCleanMock{
+					{OutBool: true},
+				},
+				PullMocks: []PullMock{
+					{OutError: nil},
+				},
+				TagsMocks: []TagsMock{
+					{OutError: errors.New("git error")},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -1040,6 +1195,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
 					{OutResponse: &github.Response{}},
@@ -1070,8 +1226,206 @@ func TestCommand_run(t *testing.T) {
 					{OutArtifacts: artifacts},
 				},
 			},
+			module:           command.Module{Name: "github.com/octocat/Hello-World/api", Dir: "api"},
 			args:             []string{"-comment", "Release description"},
-			expectedExitCode: command.Success,
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "NestedModule_CommitsInPathFails",
+			spec: spec.Spec{
+				Release: spec.Release{
+					Artifacts: true,
+				},
+			},
+			git: &MockGitService{
+				HEADMocks: []HEADMock{
+					{OutBranch: "main"},
+				},
+				IsCleanMocks: []IsCleanMock{
+					{OutBool: true},
+				},
+				PullMocks: []PullMock{
+					{OutError: nil},
+				},
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{Name: "api/v0.1.0"},
+							{Name: "v0.2.0"},
+							{Name: "web/v0.1.0"},
+						},
+					},
+				},
+				CommitsInPathMocks: []CommitsInPathMock{
+					{OutError: errors.New("git error")},
+				},
+				CreateCommitMocks: []CreateCommitMock{
+					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
+				},
+				CreateTagMocks: []CreateTagMock{
+					{OutHash: "a3580a0f64b08ba6085d530c828c40b8aa082c1e"},
+				},
+				PushMocks: []PushMock{
+					{OutError: nil},
+				},
+				PushTagMocks: []PushTagMock{
+					{OutError: nil},
+				},
+			},
+			users: &MockUsersService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				CreateReleaseMocks: []CreateReleaseMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+					{OutResponse: &github.Response{}},
+				},
+				UpdateReleaseMocks: []UpdateReleaseMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: "changelog content"},
+				},
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ArtifactsMocks: []ArtifactsMock{
+					{OutArtifacts: artifacts},
+				},
+			},
+			module:           command.Module{Name: "github.com/octocat/Hello-World/api", Dir: "api"},
+			args:             []string{"-comment", "Release description"},
+			expectedExitCode: command.GitError,
+		},
+		{
+			name: "Success_NestedModule",
+			spec: spec.Spec{
+				Release: spec.Release{
+					Artifacts: true,
+				},
+			},
+			git: &MockGitService{
+				HEADMocks: []HEADMock{
+					{OutBranch: "main"},
+				},
+				IsCleanMocks: []IsCleanMock{
+					{OutBool: true},
+				},
+				PullMocks: []PullMock{
+					{OutError: nil},
+				},
+				TagsMocks: []TagsMock{
+					{
+						OutTags: git.Tags{
+							{Name: "api/v0.1.0"},
+							{Name: "v0.2.0"},
+							{Name: "web/v0.1.0"},
+						},
+					},
+				},
+				CommitsInPathMocks: []CommitsInPathMock{
+					{
+						OutCommits: git.Commits{
+							{Message: "Merge pull request #2 from octocat/api"},
+							{Message: "Fix the api handler (#3)"},
+						},
+					},
+				},
+				CreateCommitMocks: []CreateCommitMock{
+					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
+				},
+				CreateTagMocks: []CreateTagMock{
+					{OutHash: "a3580a0f64b08ba6085d530c828c40b8aa082c1e"},
+				},
+				PushMocks: []PushMock{
+					{OutError: nil},
+				},
+				PushTagMocks: []PushTagMock{
+					{OutError: nil},
+				},
+			},
+			users: &MockUsersService{
+				UserMocks: []UserMock{
+					{OutUser: &user, OutResponse: &github.Response{}},
+				},
+			},
+			repo: &MockRepoService{
+				GetMocks: []GetMock{
+					{OutRepository: &repo, OutResponse: &github.Response{}},
+				},
+				PermissionMocks: []PermissionMock{
+					{OutPermission: github.PermissionAdmin, OutResponse: &github.Response{}},
+				},
+				CreateReleaseMocks: []CreateReleaseMock{
+					{OutRelease: &draftRelease, OutResponse: &github.Response{}},
+				},
+				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+				},
+				BranchProtectionMocks: []BranchProtectionMock{
+					{OutResponse: &github.Response{}},
+					{OutResponse: &github.Response{}},
+				},
+				UpdateReleaseMocks: []UpdateReleaseMock{
+					{OutRelease: &release, OutResponse: &github.Response{}},
+				},
+			},
+			changelog: &MockChangelogService{
+				GenerateMocks: []GenerateMock{
+					{OutContent: nestedChangelog},
+				},
+			},
+			semver: &MockSemverCommand{
+				RunMocks: []SemverRunMock{
+					{OutCode: command.Success},
+				},
+				SemVerMocks: []SemVerMock{
+					{OutSemVer: version},
+				},
+			},
+			build: &MockBuildCommand{
+				RunMocks: []BuildRunMock{
+					{OutCode: command.Success},
+				},
+				ArtifactsMocks: []ArtifactsMock{
+					{OutArtifacts: artifacts},
+				},
+			},
+			module:                command.Module{Name: "github.com/octocat/Hello-World/api", Dir: "api"},
+			args:                  []string{"-comment", "Release description"},
+			expectedExitCode:      command.Success,
+			expectedTagName:       "api/v0.1.0",
+			expectedChangelogPath: "api/CHANGELOG.md",
+			expectedExcludedTags:  []string{"v0.2.0", "web/v0.1.0"},
+			expectedChangelog:     filteredChangelog,
 		},
 		{
 			name: "Success_MinorRelease",
@@ -1090,6 +1444,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -1120,6 +1477,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
 					{OutResponse: &github.Response{}},
@@ -1170,6 +1528,9 @@ func TestCommand_run(t *testing.T) {
 				PullMocks: []PullMock{
 					{OutError: nil},
 				},
+				TagsMocks: []TagsMock{
+					{OutTags: git.Tags{}},
+				},
 				CreateCommitMocks: []CreateCommitMock{
 					{OutHash: "6e8c7d217faab1d88905d4c75b4e7995a42c81d5"},
 				},
@@ -1200,6 +1561,7 @@ func TestCommand_run(t *testing.T) {
 				},
 				UploadReleaseAssetMocks: []UploadReleaseAssetMock{
 					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
+					{OutReleaseAsset: &asset, OutResponse: &github.Response{}},
 				},
 				BranchProtectionMocks: []BranchProtectionMock{
 					{OutResponse: &github.Response{}},
@@ -1256,10 +1618,126 @@ func TestCommand_run(t *testing.T) {
 			c.services.changelog = tc.changelog
 			c.commands.semver = tc.semver
 			c.commands.build = tc.build
+			c.data.module = tc.module
+
+			// The changelog of a nested module is filtered in the changelog file
+			if tc.module.IsNested() {
+				wd, err := os.Getwd()
+				assert.NoError(t, err)
+				assert.NoError(t, os.Chdir(t.TempDir()))
+				defer os.Chdir(wd)
+
+				if len(tc.changelog.GenerateMocks) > 0 {
+					assert.NoError(t, ioutil.WriteFile("CHANGELOG.md", []byte(tc.changelog.GenerateMocks[0].OutContent), 0644))
+				}
+			}
 
 			exitCode := c.run(tc.args)
 
 			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			if tc.expectedTagName != "" {
+				assert.Equal(t, tc.expectedTagName, tc.git.CreateTagMocks[0].InName)
+				assert.Equal(t, []string{tc.expectedChangelogPath}, tc.git.CreateCommitMocks[0].InPaths)
+				assert.Equal(t, tc.expectedExcludedTags, c.data.changelogSpec.Tags.Exclude)
+			}
+
+			if tc.expectedChangelog != "" {
+				b, err := ioutil.ReadFile("CHANGELOG.md")
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedChangelog, string(b))
+				assert.Equal(t, "api", tc.git.CommitsInPathMocks[0].InPath)
+			}
+		})
+	}
+}
+
+func TestWriteChecksums(t *testing.T) {
+	binDir, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(binDir)
+
+	linuxPath := filepath.Join(binDir, "app-linux-amd64")
+	assert.NoError(t, ioutil.WriteFile(linuxPath, []byte("linux"), 0755))
+
+	darwinPath := filepath.Join(binDir, "app-darwin-amd64")
+	assert.NoError(t, ioutil.WriteFile(darwinPath, []byte("darwin"), 0755))
+
+	t.Run("MissingArtifact", func(t *testing.T) {
+		artifacts := []buildcmd.Artifact{
+			{Path: filepath.Join(binDir, "missing")},
+		}
+
+		_, err := writeChecksums(artifacts)
+		assert.Error(t, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		artifacts := []buildcmd.Artifact{
+			{Path: linuxPath},
+			{Path: darwinPath},
+		}
+
+		artifact, err := writeChecksums(artifacts)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(binDir, "checksums.txt"), artifact.Path)
+		assert.Equal(t, "checksums.txt", artifact.Label)
+
+		data, err := ioutil.ReadFile(artifact.Path)
+		assert.NoError(t, err)
+		assert.Equal(t, "caf90169eefa5f807d577486b9f795ab86ae2983c5c20806cff959117e90af18  app-linux-amd64\n"+
+			"26ce1a1580f693873b6268fef54c5f0d0607f2896cad02ce2894c0c899a11575  app-darwin-amd64\n", string(data))
+	})
+}
+
+func TestReferencedNumbers(t *testing.T) {
+	commits := git.Commits{
+		{Message: "Merge pull request #12 from octocat/feature"},
+		{Message: "Fix the handler (#7)\n\nCloses #3 and #7"},
+		{Message: "Update docs"},
+	}
+
+	assert.Equal(t, map[string]bool{"12": true, "7": true, "3": true}, referencedNumbers(commits))
+}
+
+func TestFilterChangelog(t *testing.T) {
+	changelog := "## [v0.1.0](https://github.com/octocat/Hello-World/tree/v0.1.0) (2020-10-10)\n\n" +
+		"[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.0.1...v0.1.0)\n\n" +
+		"**Fixed Bugs:**\n\n" +
+		"  - Bug [#1](https://github.com/octocat/Hello-World/issues/1) ([octocat](https://github.com/octocat))\n\n" +
+		"**Merged Changes:**\n\n" +
+		"  - Feature [#2](https://github.com/octocat/Hello-World/pull/2) ([octocat](https://github.com/octocat))\n" +
+		"  - Fix [#3](https://github.com/octocat/Hello-World/pull/3) ([octocat](https://github.com/octocat))\n\n"
+
+	tests := []struct {
+		name              string
+		numbers           map[string]bool
+		expectedChangelog string
+	}{
+		{
+			name:              "All",
+			numbers:           map[string]bool{"1": true, "2": true, "3": true},
+			expectedChangelog: changelog,
+		},
+		{
+			name:    "Some",
+			numbers: map[string]bool{"3": true},
+			expectedChangelog: "## [v0.1.0](https://github.com/octocat/Hello-World/tree/v0.1.0) (2020-10-10)\n\n" +
+				"[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.0.1...v0.1.0)\n\n" +
+				"**Merged Changes:**\n\n" +
+				"  - Fix [#3](https://github.com/octocat/Hello-World/pull/3) ([octocat](https://github.com/octocat))\n\n",
+		},
+		{
+			name:    "None",
+			numbers: map[string]bool{},
+			expectedChangelog: "## [v0.1.0](https://github.com/octocat/Hello-World/tree/v0.1.0) (2020-10-10)\n\n" +
+				"[Compare Changes](https://github.com/octocat/Hello-World/compare/v0.0.1...v0.1.0)\n\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedChangelog, filterChangelog(changelog, tc.numbers))
 		})
 	}
 }
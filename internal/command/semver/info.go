@@ -0,0 +1,175 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/moorara/gelato/pkg/semver"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatEnv  = "env"
+
+	pseudoTimeFormat = "20060102150405"
+)
+
+// Info is the machine-readable information about the current semantic version.
+type Info struct {
+	// Version is the full semantic version.
+	Version string `json:"version"`
+	// Major is the major part of the semantic version.
+	Major uint `json:"major"`
+	// Minor is the minor part of the semantic version.
+	Minor uint `json:"minor"`
+	// Patch is the patch part of the semantic version.
+	Patch uint `json:"patch"`
+	// Prerelease is the dot-separated pre-release identifiers.
+	Prerelease string `json:"prerelease"`
+	// Metadata is the dot-separated build metadata identifiers.
+	Metadata string `json:"metadata"`
+	// DockerTag is the semantic version in a form that can be used as a Docker image tag.
+	DockerTag string `json:"dockerTag"`
+	// PseudoVersion is the semantic version in the Go module pseudo-version format.
+	PseudoVersion string `json:"pseudoVersion"`
+	// Tag is the name of the most recent semantic version tag (if any).
+	Tag string `json:"tag"`
+	// CommitCount is the number of commits since the most recent semantic version tag.
+	CommitCount int `json:"commitCount"`
+	// Commit is the full hash of the HEAD commit.
+	Commit string `json:"commit"`
+	// Dirty determines whether or not the working tree has uncommitted changes.
+	Dirty bool `json:"dirty"`
+}
+
+func newInfo(sv, base semver.SemVer, tag string, count int, commit string, commitTime time.Time, dirty bool) Info {
+	return Info{
+		Version:       sv.String(),
+		Major:         sv.Major,
+		Minor:         sv.Minor,
+		Patch:         sv.Patch,
+		Prerelease:    strings.Join(sv.Prerelease, "."),
+		Metadata:      strings.Join(sv.Metadata, "."),
+		DockerTag:     dockerTag(sv),
+		PseudoVersion: pseudoVersion(base, tag != "", count, commit, commitTime, dirty),
+		Tag:           tag,
+		CommitCount:   count,
+		Commit:        commit,
+		Dirty:         dirty,
+	}
+}
+
+// dockerTag converts a semantic version to a valid Docker image tag.
+// Docker tags cannot contain +, so the build metadata are separated by a hyphen instead.
+func dockerTag(sv semver.SemVer) string {
+	return strings.ReplaceAll(sv.String(), "+", "-")
+}
+
+// pseudoVersion creates a Go module pseudo-version as described in https://golang.org/ref/mod#pseudo-versions.
+// If the HEAD commit is tagged but the working tree is dirty, the tag is marked as dirty.
+func pseudoVersion(base semver.SemVer, hasTag bool, count int, commit string, commitTime time.Time, dirty bool) string {
+	// Build metadata are not allowed in Go module versions
+	base.Metadata = nil
+
+	// The HEAD commit is tagged, so the tag itself is the module version
+	if hasTag && count == 0 {
+		if dirty {
+			return "v" + base.String() + "-dirty"
+		}
+		return "v" + base.String()
+	}
+
+	rev := commit
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+
+	timestamp := commitTime.UTC().Format(pseudoTimeFormat)
+
+	switch {
+	case !hasTag:
+		return fmt.Sprintf("v0.0.0-%s-%s", timestamp, rev)
+	case len(base.Prerelease) > 0:
+		return fmt.Sprintf("v%s.0.%s-%s", base, timestamp, rev)
+	default:
+		return fmt.Sprintf("v%s-0.%s-%s", base.Next(), timestamp, rev)
+	}
+}
+
+// formatter creates the command output from the version information.
+type formatter func(Info) (string, error)
+
+// newFormatter returns a formatter for a given format.
+// Any format other than the known ones is considered a Go template.
+func newFormatter(format string) (formatter, error) {
+	switch format {
+	case "", formatText:
+		return formatTextInfo, nil
+	case formatJSON:
+		return formatJSONInfo, nil
+	case formatEnv:
+		return formatEnvInfo, nil
+	}
+
+	tmpl, err := template.New("format").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format: %s", err)
+	}
+
+	return func(info Info) (string, error) {
+		buf := new(bytes.Buffer)
+		if err := tmpl.Execute(buf, info); err != nil {
+			return "", fmt.Errorf("invalid format: %s", err)
+		}
+		return buf.String(), nil
+	}, nil
+}
+
+func formatTextInfo(info Info) (string, error) {
+	return info.Version, nil
+}
+
+func formatJSONInfo(info Info) (string, error) {
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func formatEnvInfo(info Info) (string, error) {
+	vars := []struct {
+		name  string
+		value interface{}
+	}{
+		{"VERSION", info.Version},
+		{"VERSION_MAJOR", info.Major},
+		{"VERSION_MINOR", info.Minor},
+		{"VERSION_PATCH", info.Patch},
+		{"VERSION_PRERELEASE", info.Prerelease},
+		{"VERSION_METADATA", info.Metadata},
+		{"VERSION_DOCKER_TAG", info.DockerTag},
+		{"VERSION_PSEUDO", info.PseudoVersion},
+		{"VERSION_TAG", info.Tag},
+		{"VERSION_COMMIT_COUNT", info.CommitCount},
+		{"VERSION_COMMIT", info.Commit},
+		{"VERSION_DIRTY", info.Dirty},
+	}
+
+	lines := make([]string, len(vars))
+	for i, v := range vars {
+		lines[i] = fmt.Sprintf("export %s=%s", v.name, shellQuote(fmt.Sprint(v.value)))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// shellQuote quotes a string, so it is a single word for POSIX shells and no character has a special meaning in it.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,267 @@
+package semver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/pkg/semver"
+)
+
+func TestNewInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		sv, base     semver.SemVer
+		tag          string
+		count        int
+		commit       string
+		commitTime   time.Time
+		dirty        bool
+		expectedInfo Info
+	}{
+		{
+			name:       "WithoutTag",
+			sv:         semver.SemVer{Major: 0, Minor: 1, Patch: 0, Prerelease: []string{"2", "8d2f152"}},
+			base:       semver.SemVer{},
+			tag:        "",
+			count:      2,
+			commit:     "8d2f15295f28f28355178250ede5cf43a40f0d14",
+			commitTime: time.Date(2020, time.November, 20, 12, 30, 0, 0, time.UTC),
+			dirty:      false,
+			expectedInfo: Info{
+				Version:       "0.1.0-2.8d2f152",
+				Major:         0,
+				Minor:         1,
+				Patch:         0,
+				Prerelease:    "2.8d2f152",
+				Metadata:      "",
+				DockerTag:     "0.1.0-2.8d2f152",
+				PseudoVersion: "v0.0.0-20201120123000-8d2f15295f28",
+				Tag:           "",
+				CommitCount:   2,
+				Commit:        "8d2f15295f28f28355178250ede5cf43a40f0d14",
+				Dirty:         false,
+			},
+		},
+		{
+			name:       "OnTag",
+			sv:         semver.SemVer{Major: 1, Minor: 2, Patch: 3, Metadata: []string{"20201120"}},
+			base:       semver.SemVer{Major: 1, Minor: 2, Patch: 3, Metadata: []string{"20201120"}},
+			tag:        "v1.2.3+20201120",
+			count:      0,
+			commit:     "8d2f15295f28f28355178250ede5cf43a40f0d14",
+			commitTime: time.Date(2020, time.November, 20, 12, 30, 0, 0, time.UTC),
+			dirty:      false,
+			expectedInfo: Info{
+				Version:       "1.2.3+20201120",
+				Major:         1,
+				Minor:         2,
+				Patch:         3,
+				Prerelease:    "",
+				Metadata:      "20201120",
+				DockerTag:     "1.2.3-20201120",
+				PseudoVersion: "v1.2.3",
+				Tag:           "v1.2.3+20201120",
+				CommitCount:   0,
+				Commit:        "8d2f15295f28f28355178250ede5cf43a40f0d14",
+				Dirty:         false,
+			},
+		},
+		{
+			name:       "OnTag_Dirty",
+			sv:         semver.SemVer{Major: 1, Minor: 2, Patch: 4, Prerelease: []string{"0", "dev"}},
+			base:       semver.SemVer{Major: 1, Minor: 2, Patch: 3},
+			tag:        "v1.2.3",
+			count:      0,
+			commit:     "8d2f15295f28f28355178250ede5cf43a40f0d14",
+			commitTime: time.Date(2020, time.November, 20, 12, 30, 0, 0, time.UTC),
+			dirty:      true,
+			expectedInfo: Info{
+				Version:       "1.2.4-0.dev",
+				Major:         1,
+				Minor:         2,
+				Patch:         4,
+				Prerelease:    "0.dev",
+				Metadata:      "",
+				DockerTag:     "1.2.4-0.dev",
+				PseudoVersion: "v1.2.3-dirty",
+				Tag:           "v1.2.3",
+				CommitCount:   0,
+				Commit:        "8d2f15295f28f28355178250ede5cf43a40f0d14",
+				Dirty:         true,
+			},
+		},
+		{
+			name:       "AfterReleaseTag",
+			sv:         semver.SemVer{Major: 1, Minor: 2, Patch: 4, Prerelease: []string{"3", "dev"}},
+			base:       semver.SemVer{Major: 1, Minor: 2, Patch: 3},
+			tag:        "v1.2.3",
+			count:      3,
+			commit:     "605a46c79d2500fef8d34145e4831624a7244bd1",
+			commitTime: time.Date(2020, time.November, 20, 12, 30, 0, 0, time.FixedZone("EST", -5*3600)),
+			dirty:      true,
+			expectedInfo: Info{
+				Version:       "1.2.4-3.dev",
+				Major:         1,
+				Minor:         2,
+				Patch:         4,
+				Prerelease:    "3.dev",
+				Metadata:      "",
+				DockerTag:     "1.2.4-3.dev",
+				PseudoVersion: "v1.2.4-0.20201120173000-605a46c79d25",
+				Tag:           "v1.2.3",
+				CommitCount:   3,
+				Commit:        "605a46c79d2500fef8d34145e4831624a7244bd1",
+				Dirty:         true,
+			},
+		},
+		{
+			name:       "AfterPrereleaseTag",
+			sv:         semver.SemVer{Major: 1, Minor: 2, Patch: 4, Prerelease: []string{"1", "605a46c"}},
+			base:       semver.SemVer{Major: 1, Minor: 2, Patch: 3, Prerelease: []string{"rc", "1"}},
+			tag:        "api/v1.2.3-rc.1",
+			count:      1,
+			commit:     "605a46c79d2500fef8d34145e4831624a7244bd1",
+			commitTime: time.Date(2020, time.November, 20, 12, 30, 0, 0, time.UTC),
+			dirty:      false,
+			expectedInfo: Info{
+				Version:       "1.2.4-1.605a46c",
+				Major:         1,
+				Minor:         2,
+				Patch:         4,
+				Prerelease:    "1.605a46c",
+				Metadata:      "",
+				DockerTag:     "1.2.4-1.605a46c",
+				PseudoVersion: "v1.2.3-rc.1.0.20201120123000-605a46c79d25",
+				Tag:           "api/v1.2.3-rc.1",
+				CommitCount:   1,
+				Commit:        "605a46c79d2500fef8d34145e4831624a7244bd1",
+				Dirty:         false,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			info := newInfo(tc.sv, tc.base, tc.tag, tc.count, tc.commit, tc.commitTime, tc.dirty)
+
+			assert.Equal(t, tc.expectedInfo, info)
+		})
+	}
+}
+
+func TestNewFormatter(t *testing.T) {
+	info := Info{
+		Version:       "1.2.4-3.605a46c+20201120",
+		Major:         1,
+		Minor:         2,
+		Patch:         4,
+		Prerelease:    "3.605a46c",
+		Metadata:      "20201120",
+		DockerTag:     "1.2.4-3.605a46c-20201120",
+		PseudoVersion: "v1.2.4-0.20201120123000-605a46c79d25",
+		Tag:           "v1.2.3",
+		CommitCount:   3,
+		Commit:        "605a46c79d2500fef8d34145e4831624a7244bd1",
+		Dirty:         false,
+	}
+
+	tests := []struct {
+		name           string
+		format         string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "InvalidTemplate",
+			format:        "{{.Major",
+			expectedError: `invalid format: template: format:1: unclosed action`,
+		},
+		{
+			name:           "Default",
+			format:         "",
+			expectedOutput: "1.2.4-3.605a46c+20201120",
+		},
+		{
+			name:           "Text",
+			format:         "text",
+			expectedOutput: "1.2.4-3.605a46c+20201120",
+		},
+		{
+			name:   "JSON",
+			format: "json",
+			expectedOutput: `{
+  "version": "1.2.4-3.605a46c+20201120",
+  "major": 1,
+  "minor": 2,
+  "patch": 4,
+  "prerelease": "3.605a46c",
+  "metadata": "20201120",
+  "dockerTag": "1.2.4-3.605a46c-20201120",
+  "pseudoVersion": "v1.2.4-0.20201120123000-605a46c79d25",
+  "tag": "v1.2.3",
+  "commitCount": 3,
+  "commit": "605a46c79d2500fef8d34145e4831624a7244bd1",
+  "dirty": false
+}`,
+		},
+		{
+			name:   "Env",
+			format: "env",
+			expectedOutput: `export VERSION='1.2.4-3.605a46c+20201120'
+export VERSION_MAJOR='1'
+export VERSION_MINOR='2'
+export VERSION_PATCH='4'
+export VERSION_PRERELEASE='3.605a46c'
+export VERSION_METADATA='20201120'
+export VERSION_DOCKER_TAG='1.2.4-3.605a46c-20201120'
+export VERSION_PSEUDO='v1.2.4-0.20201120123000-605a46c79d25'
+export VERSION_TAG='v1.2.3'
+export VERSION_COMMIT_COUNT='3'
+export VERSION_COMMIT='605a46c79d2500fef8d34145e4831624a7244bd1'
+export VERSION_DIRTY='false'`,
+		},
+		{
+			name:           "Template",
+			format:         "{{.Major}}.{{.Minor}} {{.DockerTag}}",
+			expectedOutput: "1.2 1.2.4-3.605a46c-20201120",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := newFormatter(tc.format)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, f)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				out, err := f(info)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedOutput, out)
+			}
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		name           string
+		s              string
+		expectedQuoted string
+	}{
+		{"Empty", "", `''`},
+		{"Plain", "v1.2.3", `'v1.2.3'`},
+		{"Space", "api v1.2.3", `'api v1.2.3'`},
+		{"SpecialCharacters", "$(echo) `echo` \\ \"", "'$(echo) `echo` \\ \"'"},
+		{"SingleQuote", "it's", `'it'\''s'`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedQuoted, shellQuote(tc.s))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/cli"
@@ -19,18 +20,28 @@ const (
 	semverHelp     = `
   Use this command for getting the current semantic version.
 
-  Usage:  gelato semver
+  Usage:  gelato semver [flags]
+
+  Flags:
+    -format        the output format (values: text|json|env|<go template>, default: text)
+    -tag-prefix    only consider tags with this prefix (e.g. api/ for tags such as api/v1.2.3)
+
+  For a Go module in a sub-directory of the repository (i.e. the nearest go.mod file is not at the root),
+  the tag prefix defaults to the module directory and only the commits touching the module directory are counted.
 
   Examples:
     gelato semver
+    gelato semver -format=json
+    gelato semver -format=env
+    gelato semver -format='{{.Major}}.{{.Minor}}'
+    gelato semver -format='{{.DockerTag}}'
+    gelato semver -tag-prefix=api/
   `
 )
 
 type gitService interface {
 	IsClean() (bool, error)
-	HEAD() (string, string, error)
-	Tags() (git.Tags, error)
-	CommitsIn(string) (git.Commits, error)
+	Describe(string, git.DescribeOptions) (git.Description, error)
 }
 
 // Command is the cli.Command implementation for semver command.
@@ -39,8 +50,12 @@ type Command struct {
 	services struct {
 		git gitService
 	}
+	data struct {
+		module command.Module
+	}
 	outputs struct {
 		semver semver.SemVer
+		info   Info
 	}
 }
 
@@ -70,14 +85,34 @@ func (c *Command) Run(args []string) int {
 		return command.GitError
 	}
 
+	gitPath, err := git.Path()
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.GitError
+	}
+
+	module, err := command.FindModule(gitPath, ".")
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.OSError
+	}
+
 	c.services.git = git
+	c.data.module = module
 
 	return c.run(args)
 }
 
 // run in an auxiliary method, so we can test the business logic with mock dependencies.
 func (c *Command) run(args []string) int {
+	flags := struct {
+		format    string
+		tagPrefix string
+	}{}
+
 	fs := flag.NewFlagSet("semver", flag.ContinueOnError)
+	fs.StringVar(&flags.format, "format", formatText, "")
+	fs.StringVar(&flags.tagPrefix, "tag-prefix", c.data.module.TagPrefix(), "")
 	fs.Usage = func() {
 		c.ui.Output(c.Help())
 	}
@@ -86,6 +121,12 @@ func (c *Command) run(args []string) int {
 		return command.FlagError
 	}
 
+	formatter, err := newFormatter(flags.format)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.FlagError
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), semverTimeout)
 	defer cancel()
 
@@ -93,7 +134,7 @@ func (c *Command) run(args []string) int {
 
 	checklist := command.PreflightChecklist{}
 
-	_, err := command.RunPreflightChecks(ctx, checklist)
+	_, err = command.RunPreflightChecks(ctx, checklist)
 	if err != nil {
 		c.ui.Error(err.Error())
 		return command.PreflightError
@@ -107,44 +148,49 @@ func (c *Command) run(args []string) int {
 		return command.GitError
 	}
 
-	gitSHA, _, err := c.services.git.HEAD()
-	if err != nil {
-		c.ui.Error(err.Error())
-		return command.GitError
+	// parseTag returns the semantic version of a tag if the tag has the expected prefix.
+	parseTag := func(name string) (semver.SemVer, bool) {
+		if !strings.HasPrefix(name, flags.tagPrefix) {
+			return semver.SemVer{}, false
+		}
+		return semver.Parse(strings.TrimPrefix(name, flags.tagPrefix))
 	}
 
-	// Get all git tags
-	tags, err := c.services.git.Tags()
-	if err != nil {
-		c.ui.Error(err.Error())
-		return command.GitError
+	opts := git.DescribeOptions{
+		Match: func(name string) bool {
+			_, ok := parseTag(name)
+			return ok
+		},
 	}
 
-	// Get all git commits in the current branch
-	commits, err := c.services.git.CommitsIn("HEAD")
+	// For a nested module, only the commits that modify the module directory are counted
+	if c.data.module.IsNested() {
+		opts.Path = c.data.module.Dir
+	}
+
+	// Find the most recent semantic version tags reachable from the HEAD commit
+	desc, err := c.services.git.Describe("HEAD", opts)
 	if err != nil {
 		c.ui.Error(err.Error())
 		return command.GitError
 	}
 
-	// Get the most recent tag that is a semantic version
-	tag, _ := tags.First(func(t git.Tag) bool {
-		// Make sure the tag falls in the commits range
-		if t.Commit.After(commits[0]) {
-			return false
-		}
+	head := desc.Commit
+	gitSHA := head.Hash
 
-		// Make sure the tag is a semantic version
-		if _, ok := semver.Parse(t.Name); !ok {
-			return false
+	// If there are more than one semantic version tags pointing to the same commit, the highest one is picked
+	var tag git.Tag
+	var tagSemVer semver.SemVer
+	for _, t := range desc.Tags {
+		if u, ok := parseTag(t.Name); ok && (tag.IsZero() || u.GreaterThan(tagSemVer)) {
+			tag, tagSemVer = t, u
 		}
-
-		return true
-	})
+	}
 
 	// ==============================> RESOLVE THE CURRENT SEMANTIC VERSION <==============================
 
-	var sv semver.SemVer
+	var sv, base semver.SemVer
+	var count int
 
 	var signature string
 	if isClean {
@@ -153,24 +199,17 @@ func (c *Command) run(args []string) int {
 		signature = "dev"
 	}
 
+	// The number of commits HEAD is ahead of the most recent tag (or all commits if there is no tag)
+	count = desc.Distance
+
 	if tag.IsZero() {
 		// No git tag and no previous semantic version -> using the default initial semantic version
 		sv = semver.SemVer{Major: 0, Minor: 1, Patch: 0}
-		count := strconv.Itoa(len(commits))
-		sv.AddPrerelease(count, signature)
+		sv.AddPrerelease(strconv.Itoa(count), signature)
 	} else {
 		// The most recent tag either points to the HEAD commit or is reachable from the HEAD commit
-		// The tag is guaranteed to be a valid semantic version thanks to the predicte for selecting it
-		sv, _ = semver.Parse(tag.Name)
-
-		// Count how many commits HEAD is ahead of the most recent tag
-		var count int
-		for i, c := range commits {
-			if c.Equal(tag.Commit) {
-				count = i
-				break
-			}
-		}
+		sv = tagSemVer
+		base = sv
 
 		// If there are any changes since the most recent tag, we are on next semantic version
 		// If the the most recent tag points to the HEAD commit and the working tree is clean, we are just at current semantic version
@@ -180,9 +219,20 @@ func (c *Command) run(args []string) int {
 		}
 	}
 
+	// ==============================> PRINT THE SEMANTIC VERSION <==============================
+
+	info := newInfo(sv, base, tag.Name, count, gitSHA, head.Committer.Time, !isClean)
+
+	out, err := formatter(info)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.MiscError
+	}
+
 	c.outputs.semver = sv
+	c.outputs.info = info
 
-	c.ui.Output(sv.String())
+	c.ui.Output(out)
 
 	// ==============================> DONE <==============================
 
@@ -193,3 +243,8 @@ func (c *Command) run(args []string) int {
 func (c *Command) SemVer() semver.SemVer {
 	return c.outputs.semver
 }
+
+// Info returns the machine-readable version information after the command is run.
+func (c *Command) Info() Info {
+	return c.outputs.info
+}
@@ -19,35 +19,19 @@ type (
 		OutError error
 	}
 
-	HEADMock struct {
-		OutHash   string
-		OutBranch string
-		OutError  error
-	}
-
-	TagsMock struct {
-		OutTags  git.Tags
-		OutError error
-	}
-
-	CommitsInMock struct {
-		InRev      string
-		OutCommits git.Commits
-		OutError   error
+	DescribeMock struct {
+		InRev          string
+		InOpts         git.DescribeOptions
+		OutDescription git.Description
+		OutError       error
 	}
 
 	MockGitService struct {
 		IsCleanIndex int
 		IsCleanMocks []IsCleanMock
 
-		HEADIndex int
-		HEADMocks []HEADMock
-
-		TagsIndex int
-		TagsMocks []TagsMock
-
-		CommitsInIndex int
-		CommitsInMocks []CommitsInMock
+		DescribeIndex int
+		DescribeMocks []DescribeMock
 	}
 )
 
@@ -57,23 +41,12 @@ func (m *MockGitService) IsClean() (bool, error) {
 	return m.IsCleanMocks[i].OutBool, m.IsCleanMocks[i].OutError
 }
 
-func (m *MockGitService) HEAD() (string, string, error) {
-	i := m.HEADIndex
-	m.HEADIndex++
-	return m.HEADMocks[i].OutHash, m.HEADMocks[i].OutBranch, m.HEADMocks[i].OutError
-}
-
-func (m *MockGitService) Tags() (git.Tags, error) {
-	i := m.TagsIndex
-	m.TagsIndex++
-	return m.TagsMocks[i].OutTags, m.TagsMocks[i].OutError
-}
-
-func (m *MockGitService) CommitsIn(rev string) (git.Commits, error) {
-	i := m.CommitsInIndex
-	m.CommitsInIndex++
-	m.CommitsInMocks[i].InRev = rev
-	return m.CommitsInMocks[i].OutCommits, m.CommitsInMocks[i].OutError
+func (m *MockGitService) Describe(rev string, opts git.DescribeOptions) (git.Description, error) {
+	i := m.DescribeIndex
+	m.DescribeIndex++
+	m.DescribeMocks[i].InRev = rev
+	m.DescribeMocks[i].InOpts = opts
+	return m.DescribeMocks[i].OutDescription, m.DescribeMocks[i].OutError
 }
 
 func TestNewCommand(t *testing.T) {
@@ -109,9 +82,12 @@ func TestCommand_run(t *testing.T) {
 	tests := []struct {
 		name             string
 		git              *MockGitService
+		module           command.Module
 		args             []string
 		expectedExitCode int
 		expectedSemver   string
+		expectedPath     string
+		expectedMatches  map[string]bool
 	}{
 		{
 			name:             "UndefinedFlag",
@@ -119,22 +95,14 @@ func TestCommand_run(t *testing.T) {
 			expectedExitCode: command.FlagError,
 		},
 		{
-			name: "IsCleanFails",
-			git: &MockGitService{
-				IsCleanMocks: []IsCleanMock{
-					{OutError: errors.New("git error")},
-				},
-			},
-			args:             []string{},
-			expectedExitCode: command.GitError,
+			name:             "InvalidFormat",
+			args:             []string{"-format", "{{.Version"},
+			expectedExitCode: command.FlagError,
 		},
 		{
-			name: "HEADFails",
+			name: "IsCleanFails",
 			git: &MockGitService{
 				IsCleanMocks: []IsCleanMock{
-					{OutBool: false},
-				},
-				HEADMocks: []HEADMock{
 					{OutError: errors.New("git error")},
 				},
 			},
@@ -142,15 +110,12 @@ func TestCommand_run(t *testing.T) {
 			expectedExitCode: command.GitError,
 		},
 		{
-			name: "TagsFails",
+			name: "DescribeFails",
 			git: &MockGitService{
 				IsCleanMocks: []IsCleanMock{
-					{OutBool: false},
-				},
-				HEADMocks: []HEADMock{
-					{OutHash: "8d2f15295f28f28355178250ede5cf43a40f0d14", OutBranch: "main"},
+					{OutBool: true},
 				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{OutError: errors.New("git error")},
 				},
 			},
@@ -158,25 +123,23 @@ func TestCommand_run(t *testing.T) {
 			expectedExitCode: command.GitError,
 		},
 		{
-			name: "CommitsInFails",
+			name: "FormatFails",
 			git: &MockGitService{
 				IsCleanMocks: []IsCleanMock{
-					{OutBool: false},
-				},
-				HEADMocks: []HEADMock{
-					{OutHash: "8d2f15295f28f28355178250ede5cf43a40f0d14", OutBranch: "main"},
+					{OutBool: true},
 				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutTags: git.Tags{},
+						OutDescription: git.Description{
+							Commit:   git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							Tags:     git.Tags{},
+							Distance: 1,
+						},
 					},
 				},
-				CommitsInMocks: []CommitsInMock{
-					{OutError: errors.New("git error")},
-				},
 			},
-			args:             []string{},
-			expectedExitCode: command.GitError,
+			args:             []string{"-format", "{{.Unknown}}"},
+			expectedExitCode: command.MiscError,
 		},
 		{
 			name: "WithoutTags_WithoutCommits_WorkingTreeNotClean",
@@ -184,17 +147,18 @@ func TestCommand_run(t *testing.T) {
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: false},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "8d2f15295f28f28355178250ede5cf43a40f0d14", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
-					{
-						OutTags: git.Tags{},
-					},
-				},
-				CommitsInMocks: []CommitsInMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutCommits: git.Commits{},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							Tags:     git.Tags{},
+							Distance: 0,
+						},
 					},
 				},
 			},
@@ -208,19 +172,17 @@ func TestCommand_run(t *testing.T) {
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: true},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "8d2f15295f28f28355178250ede5cf43a40f0d14", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
-					{
-						OutTags: git.Tags{},
-					},
-				},
-				CommitsInMocks: []CommitsInMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutCommits: git.Commits{
-							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
-							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							Tags:     git.Tags{},
+							Distance: 2,
 						},
 					},
 				},
@@ -235,19 +197,17 @@ func TestCommand_run(t *testing.T) {
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: false},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "8d2f15295f28f28355178250ede5cf43a40f0d14", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
-					{
-						OutTags: git.Tags{},
-					},
-				},
-				CommitsInMocks: []CommitsInMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutCommits: git.Commits{
-							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
-							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							Tags:     git.Tags{},
+							Distance: 2,
 						},
 					},
 				},
@@ -262,24 +222,22 @@ func TestCommand_run(t *testing.T) {
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: true},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "8d2f15295f28f28355178250ede5cf43a40f0d14", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutTags: git.Tags{
-							{
-								Name:   "v0.1.0",
-								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
 							},
-						},
-					},
-				},
-				CommitsInMocks: []CommitsInMock{
-					{
-						OutCommits: git.Commits{
-							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
-							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+							Tags: git.Tags{
+								{
+									Name:   "v0.1.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
+							},
+							Distance: 0,
 						},
 					},
 				},
@@ -294,24 +252,22 @@ func TestCommand_run(t *testing.T) {
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: false},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "8d2f15295f28f28355178250ede5cf43a40f0d14", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutTags: git.Tags{
-							{
-								Name:   "v0.1.0",
-								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
 							},
-						},
-					},
-				},
-				CommitsInMocks: []CommitsInMock{
-					{
-						OutCommits: git.Commits{
-							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
-							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+							Tags: git.Tags{
+								{
+									Name:   "v0.1.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
+							},
+							Distance: 0,
 						},
 					},
 				},
@@ -326,26 +282,22 @@ func TestCommand_run(t *testing.T) {
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: true},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "605a46c79d2500fef8d34145e4831624a7244bd1", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutTags: git.Tags{
-							{
-								Name:   "v0.1.0",
-								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
 							},
-						},
-					},
-				},
-				CommitsInMocks: []CommitsInMock{
-					{
-						OutCommits: git.Commits{
-							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
-							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b"},
-							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
-							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+							Tags: git.Tags{
+								{
+									Name:   "v0.1.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
+							},
+							Distance: 2,
 						},
 					},
 				},
@@ -360,138 +312,201 @@ func TestCommand_run(t *testing.T) {
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: false},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "605a46c79d2500fef8d34145e4831624a7244bd1", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutTags: git.Tags{
-							{
-								Name:   "v0.1.0",
-								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							Tags: git.Tags{
+								{
+									Name:   "v0.1.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
 							},
+							Distance: 2,
 						},
 					},
 				},
-				CommitsInMocks: []CommitsInMock{
+			},
+			args:             []string{},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.1.1-2.dev",
+		},
+		{
+			name: "WithTags_WithNewCommits_WorkingTreeClean_WithMultipleTagsOnCommit",
+			git: &MockGitService{
+				IsCleanMocks: []IsCleanMock{
+					{OutBool: true},
+				},
+				DescribeMocks: []DescribeMock{
 					{
-						OutCommits: git.Commits{
-							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
-							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b"},
-							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
-							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							Tags: git.Tags{
+								{
+									Name:   "v0.2.0-rc.1",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
+								{
+									Name:   "v0.2.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
+								{
+									Name:   "v0.1.9",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
+							},
+							Distance: 2,
 						},
 					},
 				},
 			},
 			args:             []string{},
 			expectedExitCode: command.Success,
-			expectedSemver:   "0.1.1-2.dev",
+			expectedSemver:   "0.2.1-2.605a46c",
 		},
 		{
-			name: "WithTags_WithNewCommits_WorkingTreeClean_WithMiscTags",
+			name: "WithTagPrefix_WithNewCommits_WorkingTreeClean",
 			git: &MockGitService{
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: true},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "605a46c79d2500fef8d34145e4831624a7244bd1", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutTags: git.Tags{
-							{
-								Name: "non-semver",
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
 							},
-							{
-								Name:   "v0.1.0",
-								Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+							Tags: git.Tags{
+								{
+									Name:   "api/v0.2.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
+								},
 							},
+							Distance: 2,
 						},
 					},
 				},
-				CommitsInMocks: []CommitsInMock{
+			},
+			args:             []string{"-tag-prefix", "api/", "-format", "json"},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.1-2.605a46c",
+			expectedMatches: map[string]bool{
+				"api/v0.2.0": true,
+				"api/next":   false,
+				"v0.3.0":     false,
+			},
+		},
+		{
+			name: "NestedModule_WithoutTags_WorkingTreeClean",
+			git: &MockGitService{
+				IsCleanMocks: []IsCleanMock{
+					{OutBool: true},
+				},
+				DescribeMocks: []DescribeMock{
 					{
-						OutCommits: git.Commits{
-							{Hash: "605a46c79d2500fef8d34145e4831624a7244bd1"},
-							{Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b"},
-							{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
-							{Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6"},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
+								},
+							},
+							Tags:     git.Tags{},
+							Distance: 2,
 						},
 					},
 				},
 			},
+			module:           command.Module{Name: "github.com/octocat/Hello-World/api", Dir: "api"},
 			args:             []string{},
 			expectedExitCode: command.Success,
-			expectedSemver:   "0.1.1-2.605a46c",
+			expectedSemver:   "0.1.0-2.605a46c",
+			expectedPath:     "api",
 		},
 		{
-			name: "WithTags_WithNewCommits_WorkingTreeClean_WithTagsAfterHEAD",
+			name: "NestedModule_WithTags_WorkingTreeClean",
 			git: &MockGitService{
 				IsCleanMocks: []IsCleanMock{
 					{OutBool: true},
 				},
-				HEADMocks: []HEADMock{
-					{OutHash: "605a46c79d2500fef8d34145e4831624a7244bd1", OutBranch: "main"},
-				},
-				TagsMocks: []TagsMock{
+				DescribeMocks: []DescribeMock{
 					{
-						OutTags: git.Tags{
-							{
-								Name: "v0.2.0",
-								Commit: git.Commit{
-									Hash: "9df3723fd334bbff67db8149e6e0893769d5a9d3",
-									Committer: git.Signature{
-										Time: time.Date(2020, time.November, 25, 12, 0, 0, 0, time.UTC),
-									},
+						OutDescription: git.Description{
+							Commit: git.Commit{
+								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
+								Committer: git.Signature{
+									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
 								},
 							},
-							{
-								Name: "v0.1.0",
-								Commit: git.Commit{
-									Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
-									Committer: git.Signature{
-										Time: time.Date(2020, time.November, 10, 12, 0, 0, 0, time.UTC),
-									},
+							Tags: git.Tags{
+								{
+									Name:   "api/v0.2.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
 								},
 							},
+							Distance: 1,
 						},
 					},
 				},
-				CommitsInMocks: []CommitsInMock{
+			},
+			module:           command.Module{Name: "github.com/octocat/Hello-World/api", Dir: "api"},
+			args:             []string{},
+			expectedExitCode: command.Success,
+			expectedSemver:   "0.2.1-1.605a46c",
+			expectedPath:     "api",
+			expectedMatches: map[string]bool{
+				"api/v0.2.0": true,
+				"v0.3.0":     false,
+			},
+		},
+		{
+			name: "NestedMajorVersionModule_WithTags_WorkingTreeClean",
+			git: &MockGitService{
+				IsCleanMocks: []IsCleanMock{
+					{OutBool: true},
+				},
+				DescribeMocks: []DescribeMock{
 					{
-						OutCommits: git.Commits{
-							{
+						OutDescription: git.Description{
+							Commit: git.Commit{
 								Hash: "605a46c79d2500fef8d34145e4831624a7244bd1",
 								Committer: git.Signature{
 									Time: time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC),
 								},
 							},
-							{
-								Hash: "7fa23333fbc158af08d5b8073fa4828addde9c6b",
-								Committer: git.Signature{
-									Time: time.Date(2020, time.November, 15, 12, 0, 0, 0, time.UTC),
-								},
-							},
-							{
-								Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14",
-								Committer: git.Signature{
-									Time: time.Date(2020, time.November, 10, 12, 0, 0, 0, time.UTC),
-								},
-							},
-							{
-								Hash: "3a1960ec0cec18d2dca14d270d11c5bc4138abf6",
-								Committer: git.Signature{
-									Time: time.Date(2020, time.November, 5, 12, 0, 0, 0, time.UTC),
+							Tags: git.Tags{
+								{
+									Name:   "api/v2.0.0",
+									Commit: git.Commit{Hash: "8d2f15295f28f28355178250ede5cf43a40f0d14"},
 								},
 							},
+							Distance: 1,
 						},
 					},
 				},
 			},
+			module:           command.Module{Name: "github.com/octocat/Hello-World/api/v2", Dir: "api/v2"},
 			args:             []string{},
 			expectedExitCode: command.Success,
-			expectedSemver:   "0.1.1-2.605a46c",
+			expectedSemver:   "2.0.1-1.605a46c",
+			expectedPath:     "api/v2",
+			expectedMatches: map[string]bool{
+				"api/v2.0.0":    true,
+				"api/v2/v2.0.0": false,
+			},
 		},
 	}
 
@@ -499,6 +514,7 @@ func TestCommand_run(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			c := &Command{ui: cli.NewMockUi()}
 			c.services.git = tc.git
+			c.data.module = tc.module
 
 			exitCode := c.run(tc.args)
 
@@ -506,6 +522,13 @@ func TestCommand_run(t *testing.T) {
 
 			if tc.expectedExitCode == command.Success {
 				assert.Equal(t, tc.expectedSemver, c.outputs.semver.String())
+
+				opts := tc.git.DescribeMocks[0].InOpts
+				assert.Equal(t, "HEAD", tc.git.DescribeMocks[0].InRev)
+				assert.Equal(t, tc.expectedPath, opts.Path)
+				for name, expected := range tc.expectedMatches {
+					assert.Equal(t, expected, opts.Match(name), name)
+				}
 			} else {
 				assert.Empty(t, c.outputs.semver)
 			}
@@ -513,6 +536,19 @@ func TestCommand_run(t *testing.T) {
 	}
 }
 
+func TestCommand_Info(t *testing.T) {
+	info := Info{
+		Version: "0.1.0-2.605a46c",
+		Major:   0, Minor: 1, Patch: 0,
+		Prerelease: "2.605a46c",
+	}
+
+	c := &Command{}
+	c.outputs.info = info
+
+	assert.Equal(t, info, c.Info())
+}
+
 func TestCommand_SemVer(t *testing.T) {
 	sv := semver.SemVer{
 		Major: 0, Minor: 1, Patch: 0,
@@ -0,0 +1,169 @@
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+const (
+	checksumsAsset = "checksums.txt"
+	signatureAsset = "checksums.txt.sig"
+	backupSuffix   = ".bak"
+)
+
+// signingKey is the base64-encoded Ed25519 public key for verifying the signature of release checksums.
+// It can be set at build time using -ldflags "-X github.com/moorara/gelato/internal/command/update.signingKey=...".
+// Signed releases cannot be installed by a binary built without a signing key.
+var signingKey = ""
+
+// parseChecksums parses a checksums file in the format produced by sha256sum.
+// It returns a map of file names to their checksums in hex.
+func parseChecksums(data []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid checksum line: %s", line)
+		}
+
+		sum := strings.ToLower(fields[0])
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid sha256 checksum: %s", fields[0])
+		}
+
+		// The file name may be prefixed by * when the file is read in binary mode
+		name := strings.TrimPrefix(fields[1], "*")
+		checksums[name] = sum
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return checksums, nil
+}
+
+// verifySignature verifies an Ed25519 signature of a message using a base64-encoded public key.
+// The signature can be either raw or base64-encoded.
+func verifySignature(key string, message, signature []byte) error {
+	pub, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return errors.New("invalid signing key")
+	}
+
+	sig := signature
+	if len(sig) != ed25519.SignatureSize {
+		if sig, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err != nil {
+			return errors.New("invalid signature")
+		}
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(pub), message, sig) {
+		return errors.New("signature verification failed")
+	}
+
+	return nil
+}
+
+// replaceBinary atomically replaces a binary file with a new one and keeps a backup of the old binary.
+func replaceBinary(newPath, binPath, backupPath string) error {
+	info, err := os.Stat(binPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(newPath, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// A running executable cannot be replaced on Windows, but it can be renamed
+	if runtime.GOOS == "windows" {
+		if err := os.Rename(binPath, backupPath); err != nil {
+			return err
+		}
+
+		if err := os.Rename(newPath, binPath); err != nil {
+			_ = os.Rename(backupPath, binPath)
+			return err
+		}
+
+		return nil
+	}
+
+	// Keep the old binary in place until the new one is renamed over it
+	if err := os.Link(binPath, backupPath); err != nil {
+		if err := copyFile(binPath, backupPath, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(newPath, binPath)
+}
+
+// restoreBinary atomically restores a binary file from its backup.
+func restoreBinary(binPath, backupPath string) error {
+	if _, err := os.Stat(backupPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no backup found at %s", backupPath)
+		}
+		return err
+	}
+
+	// A running executable cannot be replaced on Windows, but it can be removed after being renamed
+	if runtime.GOOS == "windows" {
+		oldPath := binPath + ".old"
+		if err := os.Rename(binPath, oldPath); err != nil {
+			return err
+		}
+
+		if err := os.Rename(backupPath, binPath); err != nil {
+			_ = os.Rename(oldPath, binPath)
+			return err
+		}
+
+		_ = os.Remove(oldPath)
+		return nil
+	}
+
+	return os.Rename(backupPath, binPath)
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,159 @@
+package update
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChecksums(t *testing.T) {
+	tests := []struct {
+		name              string
+		data              string
+		expectedChecksums map[string]string
+		expectedError     string
+	}{
+		{
+			name:              "Empty",
+			data:              "",
+			expectedChecksums: map[string]string{},
+		},
+		{
+			name:          "InvalidLine",
+			data:          "gelato-linux-amd64\n",
+			expectedError: "invalid checksum line: gelato-linux-amd64",
+		},
+		{
+			name:          "InvalidChecksum",
+			data:          "abcd  gelato-linux-amd64\n",
+			expectedError: "invalid sha256 checksum: abcd",
+		},
+		{
+			name: "Success",
+			data: "11507A0E2F5E69D5DFA40A62A1BD7B6EE57E6BCD85C67C9B8431B36FFF21C437  gelato-linux-amd64\n\n" +
+				"0b6cba1a6d0c5b35c4b5a8bd6fd5bdf4ffc1dae6b4b9cf43c4c0fe6b1dbfa4a5 *gelato-darwin-amd64\n",
+			expectedChecksums: map[string]string{
+				"gelato-linux-amd64":  "11507a0e2f5e69d5dfa40a62a1bd7b6ee57e6bcd85c67c9b8431b36fff21c437",
+				"gelato-darwin-amd64": "0b6cba1a6d0c5b35c4b5a8bd6fd5bdf4ffc1dae6b4b9cf43c4c0fe6b1dbfa4a5",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checksums, err := parseChecksums([]byte(tc.data))
+
+			if tc.expectedError != "" {
+				assert.Nil(t, checksums)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedChecksums, checksums)
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	message := []byte("checksums")
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	publicKey := base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	signature := ed25519.Sign(key, message)
+
+	tests := []struct {
+		name          string
+		key           string
+		message       []byte
+		signature     []byte
+		expectedError string
+	}{
+		{
+			name:          "InvalidKey",
+			key:           "invalid",
+			message:       message,
+			signature:     signature,
+			expectedError: "invalid signing key",
+		},
+		{
+			name:          "InvalidSignature",
+			key:           publicKey,
+			message:       message,
+			signature:     []byte("invalid"),
+			expectedError: "invalid signature",
+		},
+		{
+			name:          "VerificationFails",
+			key:           publicKey,
+			message:       []byte("tampered"),
+			signature:     signature,
+			expectedError: "signature verification failed",
+		},
+		{
+			name:      "RawSignature",
+			key:       publicKey,
+			message:   message,
+			signature: signature,
+		},
+		{
+			name:      "EncodedSignature",
+			key:       publicKey,
+			message:   message,
+			signature: []byte(base64.StdEncoding.EncodeToString(signature) + "\n"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifySignature(tc.key, tc.message, tc.signature)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReplaceBinary_RestoreBinary(t *testing.T) {
+	binDir, err := ioutil.TempDir("", "gelato-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(binDir)
+
+	binPath := filepath.Join(binDir, "gelato")
+	newPath := filepath.Join(binDir, "gelato.new")
+	backupPath := binPath + backupSuffix
+
+	assert.NoError(t, ioutil.WriteFile(binPath, []byte("old"), 0755))
+	assert.NoError(t, ioutil.WriteFile(newPath, []byte("new"), 0600))
+
+	err = replaceBinary(newPath, binPath, backupPath)
+	assert.NoError(t, err)
+
+	data, err := ioutil.ReadFile(binPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	info, err := os.Stat(binPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	data, err = ioutil.ReadFile(backupPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+
+	err = restoreBinary(binPath, backupPath)
+	assert.NoError(t, err)
+
+	data, err = ioutil.ReadFile(binPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+
+	err = restoreBinary(binPath, backupPath)
+	assert.EqualError(t, err, "no backup found at "+backupPath)
+}
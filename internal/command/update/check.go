@@ -0,0 +1,161 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/moorara/go-github"
+
+	"github.com/moorara/gelato/internal/spec"
+	"github.com/moorara/gelato/pkg/semver"
+)
+
+const (
+	checkInterval = 24 * time.Hour
+	checkTimeout  = 5 * time.Second
+	checkCacheDir = "gelato"
+	checkFile     = "update-check.json"
+
+	// checkEnvVar is the environment variable for disabling the check for new versions.
+	checkEnvVar = "GELATO_NO_UPDATE_CHECK"
+)
+
+// checkCache is the cached result of the last check for a new version.
+type checkCache struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Channel   string    `json:"channel"`
+	Latest    string    `json:"latest"`
+}
+
+func readCheckCache(path string) (checkCache, error) {
+	var cache checkCache
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return checkCache{}, err
+	}
+
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return checkCache{}, err
+	}
+
+	return cache, nil
+}
+
+func writeCheckCache(path string, cache checkCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Checker checks for a new version of Gelato in the background.
+// The result of the check is cached, so GitHub is not called more than once in a day.
+type Checker struct {
+	ui   cli.Ui
+	spec spec.Spec
+	data struct {
+		cachePath string
+	}
+	services struct {
+		repo releasesService
+	}
+	funcs struct {
+		now func() time.Time
+	}
+	outputs struct {
+		latest chan string
+	}
+}
+
+// NewChecker creates a new checker for new versions of Gelato.
+func NewChecker(ui cli.Ui, spec spec.Spec) *Checker {
+	// If no access token is provided, we try without it!
+	token := os.Getenv("GELATO_GITHUB_TOKEN")
+
+	c := &Checker{
+		ui:   ui,
+		spec: spec,
+	}
+
+	if dir, err := os.UserCacheDir(); err == nil {
+		c.data.cachePath = filepath.Join(dir, checkCacheDir, checkFile)
+	}
+
+	c.services.repo = newReleasesClient(github.NewClient(token), updateOwner, updateRepo)
+	c.funcs.now = time.Now
+
+	return c
+}
+
+// Start starts checking for a new version in the background.
+// The check is skipped if it is disabled by the spec or the GELATO_NO_UPDATE_CHECK environment variable,
+// or the current binary is not a released version.
+func (c *Checker) Start() {
+	if c.spec.Update.DisableCheck || os.Getenv(checkEnvVar) != "" || c.data.cachePath == "" {
+		return
+	}
+
+	// A development build is never notified
+	if _, ok := semver.Parse(c.spec.Gelato.Version); !ok {
+		return
+	}
+
+	channel := c.spec.Update.WithDefaults().Channel
+	c.outputs.latest = make(chan string, 1)
+
+	// Use the cached result if it is recent enough
+	cache, err := readCheckCache(c.data.cachePath)
+	if err == nil && cache.Channel == channel && c.funcs.now().Sub(cache.CheckedAt) < checkInterval {
+		c.outputs.latest <- cache.Latest
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		defer cancel()
+
+		var latest string
+		if release, err := findRelease(ctx, c.services.repo, channel, ""); err == nil {
+			latest = release.TagName
+		}
+
+		// The check is not retried until the next interval even if it fails
+		_ = writeCheckCache(c.data.cachePath, checkCache{
+			CheckedAt: c.funcs.now(),
+			Channel:   channel,
+			Latest:    latest,
+		})
+
+		c.outputs.latest <- latest
+	}()
+}
+
+// Notify prints a message if a new version is available.
+// It never blocks, so if the check is not finished yet, nothing is printed.
+func (c *Checker) Notify() {
+	if c.outputs.latest == nil {
+		return
+	}
+
+	select {
+	case latest := <-c.outputs.latest:
+		if isNewer(latest, c.spec.Gelato.Version) {
+			c.ui.Warn(fmt.Sprintf("A new version of Gelato is available: %s (current: %s)", latest, c.spec.Gelato.Version))
+			c.ui.Warn("Run gelato update to update.")
+		}
+	default:
+	}
+}
@@ -0,0 +1,208 @@
+package update
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/moorara/go-github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/spec"
+)
+
+func TestNewChecker(t *testing.T) {
+	c := NewChecker(cli.NewMockUi(), spec.Spec{})
+
+	assert.NotNil(t, c)
+	assert.NotNil(t, c.services.repo)
+	assert.NotNil(t, c.funcs.now)
+}
+
+func TestChecker(t *testing.T) {
+	now := time.Date(2020, time.November, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		environment    map[string]string
+		spec           spec.Spec
+		cache          *checkCache
+		repo           *MockRepoService
+		expectedNotice bool
+		expectedCache  *checkCache
+	}{
+		{
+			name: "DisabledBySpec",
+			spec: spec.Spec{
+				Gelato: spec.Gelato{Version: "1.0.0"},
+				Update: spec.Update{DisableCheck: true},
+			},
+			repo: &MockRepoService{},
+		},
+		{
+			name:        "DisabledByEnvironment",
+			environment: map[string]string{"GELATO_NO_UPDATE_CHECK": "true"},
+			spec: spec.Spec{
+				Gelato: spec.Gelato{Version: "1.0.0"},
+			},
+			repo: &MockRepoService{},
+		},
+		{
+			name: "DevelopmentBuild",
+			spec: spec.Spec{},
+			repo: &MockRepoService{},
+		},
+		{
+			name: "RecentCache",
+			spec: spec.Spec{
+				Gelato: spec.Gelato{Version: "1.0.0"},
+			},
+			cache: &checkCache{
+				CheckedAt: now.Add(-time.Hour),
+				Channel:   "stable",
+				Latest:    "v1.1.0",
+			},
+			repo:           &MockRepoService{},
+			expectedNotice: true,
+		},
+		{
+			name: "StaleCache_CheckFails",
+			spec: spec.Spec{
+				Gelato: spec.Gelato{Version: "1.0.0"},
+			},
+			cache: &checkCache{
+				CheckedAt: now.Add(-48 * time.Hour),
+				Channel:   "stable",
+				Latest:    "v1.1.0",
+			},
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutError: os.ErrDeadlineExceeded},
+				},
+			},
+			expectedNotice: false,
+			expectedCache: &checkCache{
+				CheckedAt: now,
+				Channel:   "stable",
+				Latest:    "",
+			},
+		},
+		{
+			name: "NoCache_NoNewVersion",
+			spec: spec.Spec{
+				Gelato: spec.Gelato{Version: "1.0.0"},
+			},
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: &github.Release{TagName: "v1.0.0"}},
+				},
+			},
+			expectedNotice: false,
+			expectedCache: &checkCache{
+				CheckedAt: now,
+				Channel:   "stable",
+				Latest:    "v1.0.0",
+			},
+		},
+		{
+			name: "CacheForOtherChannel_NewVersion",
+			spec: spec.Spec{
+				Gelato: spec.Gelato{Version: "1.0.0"},
+				Update: spec.Update{Channel: "prerelease"},
+			},
+			cache: &checkCache{
+				CheckedAt: now.Add(-time.Hour),
+				Channel:   "stable",
+				Latest:    "v1.0.0",
+			},
+			repo: &MockRepoService{
+				ReleasesMocks: []ReleasesMock{
+					{
+						OutReleases: []github.Release{
+							{TagName: "v1.1.0-rc.1"},
+						},
+					},
+				},
+			},
+			expectedNotice: true,
+			expectedCache: &checkCache{
+				CheckedAt: now,
+				Channel:   "prerelease",
+				Latest:    "v1.1.0-rc.1",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, val := range tc.environment {
+				err := os.Setenv(key, val)
+				assert.NoError(t, err)
+				defer os.Unsetenv(key)
+			}
+
+			cacheDir, err := ioutil.TempDir("", "gelato-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(cacheDir)
+
+			cachePath := filepath.Join(cacheDir, "gelato", "update-check.json")
+			if tc.cache != nil {
+				assert.NoError(t, writeCheckCache(cachePath, *tc.cache))
+			}
+
+			ui := cli.NewMockUi()
+			c := &Checker{
+				ui:   ui,
+				spec: tc.spec,
+			}
+			c.data.cachePath = cachePath
+			c.services.repo = tc.repo
+			c.funcs.now = func() time.Time { return now }
+
+			c.Start()
+
+			// Wait for the background check to finish
+			if c.outputs.latest != nil {
+				assert.Eventually(t, func() bool {
+					return len(c.outputs.latest) == 1
+				}, time.Second, 10*time.Millisecond)
+			}
+
+			c.Notify()
+
+			if tc.expectedNotice {
+				assert.Contains(t, ui.ErrorWriter.String(), "A new version of Gelato is available")
+			} else {
+				assert.Empty(t, ui.ErrorWriter.String())
+			}
+
+			if tc.expectedCache != nil {
+				cache, err := readCheckCache(cachePath)
+				assert.NoError(t, err)
+				assert.True(t, tc.expectedCache.CheckedAt.Equal(cache.CheckedAt))
+				assert.Equal(t, tc.expectedCache.Channel, cache.Channel)
+				assert.Equal(t, tc.expectedCache.Latest, cache.Latest)
+			}
+		})
+	}
+}
+
+func TestChecker_Notify_NotBlocking(t *testing.T) {
+	c := &Checker{ui: cli.NewMockUi()}
+	c.outputs.latest = make(chan string, 1)
+
+	done := make(chan struct{})
+	go func() {
+		c.Notify()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Notify blocked waiting for the check")
+	}
+}
@@ -0,0 +1,93 @@
+package update
+
+import (
+	"context"
+	"io"
+
+	"github.com/moorara/go-github"
+)
+
+type (
+	LatestReleaseMock struct {
+		InContext   context.Context
+		OutRelease  *github.Release
+		OutResponse *github.Response
+		OutError    error
+	}
+
+	ReleaseByTagMock struct {
+		InContext   context.Context
+		InTag       string
+		OutRelease  *github.Release
+		OutResponse *github.Response
+		OutError    error
+	}
+
+	ReleasesMock struct {
+		InContext   context.Context
+		InPageSize  int
+		InPageNo    int
+		OutReleases []github.Release
+		OutResponse *github.Response
+		OutError    error
+	}
+
+	DownloadReleaseAssetMock struct {
+		InContext    context.Context
+		InReleaseTag string
+		InAssetName  string
+		InWriter     io.Writer
+		OutContent   string
+		OutResponse  *github.Response
+		OutError     error
+	}
+
+	MockRepoService struct {
+		LatestReleaseIndex int
+		LatestReleaseMocks []LatestReleaseMock
+
+		ReleaseByTagIndex int
+		ReleaseByTagMocks []ReleaseByTagMock
+
+		ReleasesIndex int
+		ReleasesMocks []ReleasesMock
+
+		DownloadReleaseAssetIndex int
+		DownloadReleaseAssetMocks []DownloadReleaseAssetMock
+	}
+)
+
+func (m *MockRepoService) LatestRelease(ctx context.Context) (*github.Release, *github.Response, error) {
+	i := m.LatestReleaseIndex
+	m.LatestReleaseIndex++
+	m.LatestReleaseMocks[i].InContext = ctx
+	return m.LatestReleaseMocks[i].OutRelease, m.LatestReleaseMocks[i].OutResponse, m.LatestReleaseMocks[i].OutError
+}
+
+func (m *MockRepoService) ReleaseByTag(ctx context.Context, tag string) (*github.Release, *github.Response, error) {
+	i := m.ReleaseByTagIndex
+	m.ReleaseByTagIndex++
+	m.ReleaseByTagMocks[i].InContext = ctx
+	m.ReleaseByTagMocks[i].InTag = tag
+	return m.ReleaseByTagMocks[i].OutRelease, m.ReleaseByTagMocks[i].OutResponse, m.ReleaseByTagMocks[i].OutError
+}
+
+func (m *MockRepoService) Releases(ctx context.Context, pageSize, pageNo int) ([]github.Release, *github.Response, error) {
+	i := m.ReleasesIndex
+	m.ReleasesIndex++
+	m.ReleasesMocks[i].InContext = ctx
+	m.ReleasesMocks[i].InPageSize = pageSize
+	m.ReleasesMocks[i].InPageNo = pageNo
+	return m.ReleasesMocks[i].OutReleases, m.ReleasesMocks[i].OutResponse, m.ReleasesMocks[i].OutError
+}
+
+func (m *MockRepoService) DownloadReleaseAsset(ctx context.Context, releaseTag, assetName string, writer io.Writer) (*github.Response, error) {
+	i := m.DownloadReleaseAssetIndex
+	m.DownloadReleaseAssetIndex++
+	m.DownloadReleaseAssetMocks[i].InContext = ctx
+	m.DownloadReleaseAssetMocks[i].InReleaseTag = releaseTag
+	m.DownloadReleaseAssetMocks[i].InAssetName = assetName
+	m.DownloadReleaseAssetMocks[i].InWriter = writer
+	_, _ = io.WriteString(writer, m.DownloadReleaseAssetMocks[i].OutContent)
+	return m.DownloadReleaseAssetMocks[i].OutResponse, m.DownloadReleaseAssetMocks[i].OutError
+}
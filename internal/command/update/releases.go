@@ -0,0 +1,153 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moorara/go-github"
+
+	"github.com/moorara/gelato/internal/spec"
+	"github.com/moorara/gelato/pkg/semver"
+)
+
+const releasesPageSize = 50
+
+type releasesService interface {
+	LatestRelease(context.Context) (*github.Release, *github.Response, error)
+	ReleaseByTag(context.Context, string) (*github.Release, *github.Response, error)
+	Releases(context.Context, int, int) ([]github.Release, *github.Response, error)
+}
+
+// releasesClient extends the GitHub repository service with the release endpoints it does not provide.
+type releasesClient struct {
+	*github.RepoService
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+func newReleasesClient(client *github.Client, owner, repo string) *releasesClient {
+	return &releasesClient{
+		RepoService: client.Repo(owner, repo),
+		client:      client,
+		owner:       owner,
+		repo:        repo,
+	}
+}
+
+// ReleaseByTag retrieves a release by its tag name.
+// See https://docs.github.com/rest/reference/repos#get-a-release-by-tag-name
+func (c *releasesClient) ReleaseByTag(ctx context.Context, tag string) (*github.Release, *github.Response, error) {
+	url := fmt.Sprintf("/repos/%s/%s/releases/tags/%s", c.owner, c.repo, tag)
+	req, err := c.client.NewRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := new(github.Release)
+
+	resp, err := c.client.Do(req, release)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return release, resp, nil
+}
+
+// Releases retrieves a page of the published releases (including pre-releases) from the most recent one.
+// See https://docs.github.com/rest/reference/repos#list-releases
+func (c *releasesClient) Releases(ctx context.Context, pageSize, pageNo int) ([]github.Release, *github.Response, error) {
+	url := fmt.Sprintf("/repos/%s/%s/releases", c.owner, c.repo)
+	req, err := c.client.NewPageRequest(ctx, "GET", url, pageSize, pageNo, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	releases := []github.Release{}
+
+	resp, err := c.client.Do(req, &releases)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return releases, resp, nil
+}
+
+// findRelease finds the release to update to.
+// If a version is specified, the release for that version is returned.
+// Otherwise, the most recent release on the given channel is returned.
+func findRelease(ctx context.Context, service releasesService, channel, version string) (*github.Release, error) {
+	if version != "" {
+		tag := "v" + strings.TrimPrefix(version, "v")
+		release, _, err := service.ReleaseByTag(ctx, tag)
+		return release, err
+	}
+
+	switch channel {
+	case "", spec.UpdateChannelStable:
+		release, _, err := service.LatestRelease(ctx)
+		return release, err
+
+	case spec.UpdateChannelPrerelease:
+		releases, _, err := service.Releases(ctx, releasesPageSize, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		// The most recent releases are not necessarily the highest versions
+		var latest *github.Release
+		var latestSemVer semver.SemVer
+		for i, r := range releases {
+			sv, ok := semver.Parse(r.TagName)
+			if r.Draft || !ok {
+				continue
+			}
+
+			if latest == nil || sv.GreaterThan(latestSemVer) {
+				latest, latestSemVer = &releases[i], sv
+			}
+		}
+
+		if latest == nil {
+			return nil, errors.New("no release found")
+		}
+
+		return latest, nil
+
+	default:
+		return nil, fmt.Errorf("invalid channel: %s", channel)
+	}
+}
+
+// isNewer determines whether or not a version is newer than a current version.
+// If the current version is not a valid semantic version (i.e. a development build), no version is considered newer.
+func isNewer(version, current string) bool {
+	cur, ok := semver.Parse(current)
+	if !ok {
+		return false
+	}
+
+	sv, ok := semver.Parse(version)
+	if !ok {
+		return false
+	}
+
+	return sv.GreaterThan(cur)
+}
+
+// isCurrent determines whether or not a version is the same as a current version.
+func isCurrent(version, current string) bool {
+	cur, ok := semver.Parse(current)
+	if !ok {
+		return false
+	}
+
+	sv, ok := semver.Parse(version)
+	if !ok {
+		return false
+	}
+
+	return sv.Equal(cur)
+}
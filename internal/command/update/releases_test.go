@@ -0,0 +1,167 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moorara/go-github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReleasesClient(t *testing.T) {
+	c := newReleasesClient(github.NewClient(""), "octocat", "Hello-World")
+
+	assert.NotNil(t, c)
+	assert.NotNil(t, c.RepoService)
+	assert.Equal(t, "octocat", c.owner)
+	assert.Equal(t, "Hello-World", c.repo)
+}
+
+func TestFindRelease(t *testing.T) {
+	tests := []struct {
+		name            string
+		repo            *MockRepoService
+		channel         string
+		version         string
+		expectedRelease *github.Release
+		expectedError   string
+	}{
+		{
+			name:          "InvalidChannel",
+			repo:          &MockRepoService{},
+			channel:       "nightly",
+			expectedError: "invalid channel: nightly",
+		},
+		{
+			name: "Version_ReleaseByTagFails",
+			repo: &MockRepoService{
+				ReleaseByTagMocks: []ReleaseByTagMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			version:       "0.9.2",
+			expectedError: "github error",
+		},
+		{
+			name: "Version_Success",
+			repo: &MockRepoService{
+				ReleaseByTagMocks: []ReleaseByTagMock{
+					{OutRelease: &github.Release{TagName: "v0.9.2"}},
+				},
+			},
+			channel:         "prerelease",
+			version:         "0.9.2",
+			expectedRelease: &github.Release{TagName: "v0.9.2"},
+		},
+		{
+			name: "Stable_Success",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: &github.Release{TagName: "v1.0.0"}},
+				},
+			},
+			channel:         "stable",
+			expectedRelease: &github.Release{TagName: "v1.0.0"},
+		},
+		{
+			name: "Prerelease_ReleasesFails",
+			repo: &MockRepoService{
+				ReleasesMocks: []ReleasesMock{
+					{OutError: errors.New("github error")},
+				},
+			},
+			channel:       "prerelease",
+			expectedError: "github error",
+		},
+		{
+			name: "Prerelease_NoRelease",
+			repo: &MockRepoService{
+				ReleasesMocks: []ReleasesMock{
+					{
+						OutReleases: []github.Release{
+							{TagName: "v2.0.0", Draft: true},
+							{TagName: "nightly"},
+						},
+					},
+				},
+			},
+			channel:       "prerelease",
+			expectedError: "no release found",
+		},
+		{
+			name: "Prerelease_Success",
+			repo: &MockRepoService{
+				ReleasesMocks: []ReleasesMock{
+					{
+						OutReleases: []github.Release{
+							{TagName: "v1.0.1"},
+							{TagName: "v2.0.0", Draft: true},
+							{TagName: "v1.1.0-rc.2", Prerelease: true},
+							{TagName: "v1.1.0-rc.10", Prerelease: true},
+							{TagName: "v1.0.0"},
+						},
+					},
+				},
+			},
+			channel:         "prerelease",
+			expectedRelease: &github.Release{TagName: "v1.1.0-rc.10", Prerelease: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			release, err := findRelease(context.Background(), tc.repo, tc.channel, tc.version)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, release)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRelease, release)
+			}
+		})
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       string
+		current       string
+		expectedNewer bool
+	}{
+		{"DevelopmentBuild", "v1.0.0", "", false},
+		{"InvalidVersion", "nightly", "1.0.0", false},
+		{"Older", "v0.9.2", "1.0.0", false},
+		{"Same", "v1.0.0", "1.0.0", false},
+		{"Newer", "v1.0.1", "1.0.0", true},
+		{"NewerPrerelease", "v1.1.0-rc.1", "1.0.0", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedNewer, isNewer(tc.version, tc.current))
+		})
+	}
+}
+
+func TestIsCurrent(t *testing.T) {
+	tests := []struct {
+		name            string
+		version         string
+		current         string
+		expectedCurrent bool
+	}{
+		{"DevelopmentBuild", "v1.0.0", "", false},
+		{"InvalidVersion", "nightly", "1.0.0", false},
+		{"Different", "v1.0.1", "1.0.0", false},
+		{"Same", "v1.0.0", "1.0.0", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedCurrent, isCurrent(tc.version, tc.current))
+		})
+	}
+}
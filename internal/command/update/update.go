@@ -1,19 +1,25 @@
 package update
 
 import (
+	"bytes"
 	"context"
-	"flag"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
-	"os/exec"
+	"path/filepath"
 	"runtime"
+	"text/template"
 	"time"
 
 	"github.com/mitchellh/cli"
 	"github.com/moorara/go-github"
 
 	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/spec"
+	"github.com/moorara/gelato/pkg/semver"
 )
 
 const (
@@ -21,11 +27,27 @@ const (
 	updateSynopsis = `Update Gelato`
 	updateHelp     = `
   Use this command for updating gelato to the latest release.
+  If gelato is already at the latest release, this command does nothing.
 
-  Usage:  gelato update
+  The new binary is verified against the release checksums (and their signature if available),
+  and then it atomically replaces the current binary. A backup of the current binary is kept next to it.
+
+  Usage:  gelato update [flags]
+
+  Flags:
+    -version     update to a specific version instead of the latest release
+    -channel     the release channel to update from (values: stable|prerelease, default: {{.Update.Channel}})
+    -rollback    restore the binary from before the last update
+
+  Each command checks for a new version at most once a day and prints a notice if one is available.
+  You can disable this check by setting disable_check to true under update in the spec file
+  or by setting the GELATO_NO_UPDATE_CHECK environment variable.
 
   Examples:
     gelato update
+    gelato update -version v0.9.2
+    gelato update -channel prerelease
+    gelato update -rollback
   `
 )
 
@@ -35,23 +57,28 @@ const (
 )
 
 type repoService interface {
-	LatestRelease(context.Context) (*github.Release, *github.Response, error)
+	releasesService
 	DownloadReleaseAsset(context.Context, string, string, io.Writer) (*github.Response, error)
 }
 
 // Command is the cli.Command implementation for update command.
 type Command struct {
 	ui       cli.Ui
+	spec     spec.Spec
 	services struct {
 		repo repoService
 	}
+	data struct {
+		binPath string
+	}
 	outputs struct{}
 }
 
 // NewCommand creates an update command.
-func NewCommand(ui cli.Ui) (*Command, error) {
+func NewCommand(ui cli.Ui, spec spec.Spec) (*Command, error) {
 	return &Command{
-		ui: ui,
+		ui:   ui,
+		spec: spec,
 	}, nil
 }
 
@@ -62,7 +89,10 @@ func (c *Command) Synopsis() string {
 
 // Help returns a long help text including usage, description, and list of flags for the command.
 func (c *Command) Help() string {
-	return updateHelp
+	var buf bytes.Buffer
+	t := template.Must(template.New("help").Parse(updateHelp))
+	_ = t.Execute(&buf, c.spec)
+	return buf.String()
 }
 
 // Run runs the actual command with the given command-line arguments.
@@ -71,14 +101,28 @@ func (c *Command) Run(args []string) int {
 	// If no access token is provided, we try without it!
 	token := os.Getenv("GELATO_GITHUB_TOKEN")
 
-	c.services.repo = github.NewClient(token).Repo(updateOwner, updateRepo)
+	binPath, err := binaryPath()
+	if err != nil {
+		c.ui.Error(fmt.Sprintf("Cannot find the path for Gelato binary: %s", err))
+		return command.OSError
+	}
+
+	c.services.repo = newReleasesClient(github.NewClient(token), updateOwner, updateRepo)
+	c.data.binPath = binPath
 
 	return c.run(args)
 }
 
 // run in an auxiliary method, so we can test the business logic with mock dependencies.
 func (c *Command) run(args []string) int {
-	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	flags := struct {
+		version  string
+		rollback bool
+	}{}
+
+	fs := c.spec.Update.FlagSet()
+	fs.StringVar(&flags.version, "version", "", "")
+	fs.BoolVar(&flags.rollback, "rollback", false, "")
 	fs.Usage = func() {
 		c.ui.Output(c.Help())
 	}
@@ -100,44 +144,157 @@ func (c *Command) run(args []string) int {
 		return command.PreflightError
 	}
 
-	// ==============================> GET THE LATEST RELEASE <==============================
+	backupPath := c.data.binPath + backupSuffix
+
+	// ==============================> ROLLBACK TO THE PREVIOUS BINARY <==============================
+
+	if flags.rollback {
+		c.ui.Output("Restoring the previous Gelato binary ...")
+
+		if err := restoreBinary(c.data.binPath, backupPath); err != nil {
+			c.ui.Error(fmt.Sprintf("Failed to restore Gelato binary: %s", err))
+			return command.OSError
+		}
+
+		c.ui.Info(fmt.Sprintf("🍨 Gelato restored to %s", c.data.binPath))
+
+		return command.Success
+	}
+
+	// ==============================> FIND THE RELEASE <==============================
 
-	c.ui.Output("Finding the latest release of Gelato ...")
+	switch c.spec.Update.Channel {
+	case spec.UpdateChannelStable, spec.UpdateChannelPrerelease:
+	default:
+		c.ui.Error(fmt.Sprintf("Invalid channel: %s", c.spec.Update.Channel))
+		return command.FlagError
+	}
+
+	if flags.version != "" {
+		if _, ok := semver.Parse(flags.version); !ok {
+			c.ui.Error(fmt.Sprintf("Invalid version: %s", flags.version))
+			return command.FlagError
+		}
+
+		c.ui.Output(fmt.Sprintf("Finding the release %s of Gelato ...", flags.version))
+	} else {
+		c.ui.Output(fmt.Sprintf("Finding the latest %s release of Gelato ...", c.spec.Update.Channel))
+	}
 
-	release, _, err := c.services.repo.LatestRelease(ctx)
+	release, err := findRelease(ctx, c.services.repo, c.spec.Update.Channel, flags.version)
 	if err != nil {
 		c.ui.Error(err.Error())
 		return command.GitHubError
 	}
 
-	// ==============================> DOWNLOAD THE LATEST BINARY <==============================
+	if isCurrent(release.TagName, c.spec.Gelato.Version) {
+		c.ui.Info(fmt.Sprintf("🍨 Gelato is already at %s", release.TagName))
+		return command.Success
+	}
 
-	c.ui.Output(fmt.Sprintf("Downloading Gelato %s ...", release.TagName))
+	// ==============================> DOWNLOAD AND VERIFY THE CHECKSUMS <==============================
+
+	c.ui.Output("Downloading the release checksums ...")
 
 	assetName := fmt.Sprintf("gelato-%s-%s", runtime.GOOS, runtime.GOARCH)
 
-	binPath, err := exec.LookPath(os.Args[0])
+	checksumsData := new(bytes.Buffer)
+	if _, err = c.services.repo.DownloadReleaseAsset(ctx, release.TagName, checksumsAsset, checksumsData); err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to download the release checksums: %s", err))
+		return command.GitHubError
+	}
+
+	// A signed release is never installed without verifying its signature
+	if hasAsset(release, signatureAsset) {
+		if signingKey == "" {
+			c.ui.Error("The release checksums are signed, but no signing key is available for verifying them.")
+			return command.VerificationError
+		}
+
+		signature := new(bytes.Buffer)
+		if _, err = c.services.repo.DownloadReleaseAsset(ctx, release.TagName, signatureAsset, signature); err != nil {
+			c.ui.Error(fmt.Sprintf("Failed to download the release checksums signature: %s", err))
+			return command.GitHubError
+		}
+
+		if err := verifySignature(signingKey, checksumsData.Bytes(), signature.Bytes()); err != nil {
+			c.ui.Error(fmt.Sprintf("Failed to verify the release checksums: %s", err))
+			return command.VerificationError
+		}
+	}
+
+	checksums, err := parseChecksums(checksumsData.Bytes())
 	if err != nil {
-		c.ui.Error(fmt.Sprintf("Cannot find the path for Gelato binary: %s", err))
-		return command.OSError
+		c.ui.Error(fmt.Sprintf("Failed to parse the release checksums: %s", err))
+		return command.VerificationError
 	}
 
-	f, err := os.OpenFile(binPath, os.O_WRONLY, 0755)
+	expectedChecksum, ok := checksums[assetName]
+	if !ok {
+		c.ui.Error(fmt.Sprintf("No checksum found for %s", assetName))
+		return command.VerificationError
+	}
+
+	// ==============================> DOWNLOAD THE LATEST BINARY <==============================
+
+	c.ui.Output(fmt.Sprintf("Downloading Gelato %s ...", release.TagName))
+
+	// The temporary file is created next to the binary, so it can be renamed atomically
+	tmp, err := ioutil.TempFile(filepath.Dir(c.data.binPath), ".gelato-")
 	if err != nil {
-		c.ui.Error(fmt.Sprintf("Cannot open file for writing: %s", err))
+		c.ui.Error(fmt.Sprintf("Cannot create a temporary file: %s", err))
 		return command.OSError
 	}
-	defer f.Close()
 
-	_, err = c.services.repo.DownloadReleaseAsset(ctx, release.TagName, assetName, f)
+	// Make sure the temporary file is cleaned up if anything goes wrong
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	_, err = c.services.repo.DownloadReleaseAsset(ctx, release.TagName, assetName, io.MultiWriter(tmp, hash))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
 	if err != nil {
-		c.ui.Error(fmt.Sprintf("Failed to download and update Gelato binary: %s", err))
+		c.ui.Error(fmt.Sprintf("Failed to download Gelato binary: %s", err))
 		return command.GitHubError
 	}
 
-	c.ui.Info(fmt.Sprintf("🍨 Gelato %s written to %s", release.Name, binPath))
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != expectedChecksum {
+		c.ui.Error(fmt.Sprintf("Checksum mismatch for %s: expected %s, got %s", assetName, expectedChecksum, checksum))
+		return command.VerificationError
+	}
+
+	// ==============================> REPLACE THE BINARY <==============================
+
+	if err := replaceBinary(tmp.Name(), c.data.binPath, backupPath); err != nil {
+		c.ui.Error(fmt.Sprintf("Failed to update Gelato binary: %s", err))
+		return command.OSError
+	}
+
+	c.ui.Info(fmt.Sprintf("🍨 Gelato %s written to %s", release.Name, c.data.binPath))
+	c.ui.Output(fmt.Sprintf("The previous binary is kept at %s (use gelato update -rollback to restore it)", backupPath))
 
 	// ==============================> DONE <==============================
 
 	return command.Success
 }
+
+// binaryPath returns the path to the binary of the running process.
+func binaryPath() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(path)
+}
+
+func hasAsset(release *github.Release, name string) bool {
+	for _, asset := range release.Assets {
+		if asset.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,15 @@
 package update
 
 import (
-	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
-	"io"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/mitchellh/cli"
@@ -11,54 +17,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/moorara/gelato/internal/command"
+	"github.com/moorara/gelato/internal/spec"
 )
 
-type (
-	LatestReleaseMock struct {
-		InContext   context.Context
-		OutRelease  *github.Release
-		OutResponse *github.Response
-		OutError    error
-	}
-
-	DownloadReleaseAssetMock struct {
-		InContext    context.Context
-		InReleaseTag string
-		InAssetName  string
-		InWriter     io.Writer
-		OutResponse  *github.Response
-		OutError     error
-	}
-
-	MockRepoService struct {
-		LatestReleaseIndex int
-		LatestReleaseMocks []LatestReleaseMock
-
-		DownloadReleaseAssetIndex int
-		DownloadReleaseAssetMocks []DownloadReleaseAssetMock
-	}
-)
-
-func (m *MockRepoService) LatestRelease(ctx context.Context) (*github.Release, *github.Response, error) {
-	i := m.LatestReleaseIndex
-	m.LatestReleaseIndex++
-	m.LatestReleaseMocks[i].InContext = ctx
-	return m.LatestReleaseMocks[i].OutRelease, m.LatestReleaseMocks[i].OutResponse, m.LatestReleaseMocks[i].OutError
-}
-
-func (m *MockRepoService) DownloadReleaseAsset(ctx context.Context, releaseTag, assetName string, writer io.Writer) (*github.Response, error) {
-	i := m.DownloadReleaseAssetIndex
-	m.DownloadReleaseAssetIndex++
-	m.DownloadReleaseAssetMocks[i].InContext = ctx
-	m.DownloadReleaseAssetMocks[i].InReleaseTag = releaseTag
-	m.DownloadReleaseAssetMocks[i].InAssetName = assetName
-	m.DownloadReleaseAssetMocks[i].InWriter = writer
-	return m.DownloadReleaseAssetMocks[i].OutResponse, m.DownloadReleaseAssetMocks[i].OutError
-}
-
 func TestNewCommand(t *testing.T) {
 	ui := new(cli.MockUi)
-	c, err := NewCommand(ui)
+	c, err := NewCommand(ui, spec.Spec{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
@@ -86,17 +50,59 @@ func TestCommand_Run(t *testing.T) {
 }
 
 func TestCommand_run(t *testing.T) {
+	assetName := fmt.Sprintf("gelato-%s-%s", runtime.GOOS, runtime.GOARCH)
+	checksums := fmt.Sprintf("%x  %s\n", sha256.Sum256([]byte("new")), assetName)
+
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	publicKey := base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(checksums)))
+
+	release := &github.Release{
+		Name:    "1.0.0",
+		TagName: "v1.0.0",
+	}
+
+	signedRelease := &github.Release{
+		Name:    "1.0.0",
+		TagName: "v1.0.0",
+		Assets: []github.ReleaseAsset{
+			{Name: "checksums.txt"},
+			{Name: "checksums.txt.sig"},
+		},
+	}
+
 	tests := []struct {
 		name             string
 		repo             *MockRepoService
+		spec             spec.Spec
+		signingKey       string
+		backup           string
 		args             []string
 		expectedExitCode int
+		expectedBinary   string
+		expectedBackup   string
 	}{
 		{
 			name:             "UndefinedFlag",
 			repo:             &MockRepoService{},
 			args:             []string{"--undefined"},
 			expectedExitCode: command.FlagError,
+			expectedBinary:   "old",
+		},
+		{
+			name:             "Rollback_NoBackup",
+			repo:             &MockRepoService{},
+			args:             []string{"-rollback"},
+			expectedExitCode: command.OSError,
+			expectedBinary:   "old",
+		},
+		{
+			name:             "Rollback_Success",
+			repo:             &MockRepoService{},
+			backup:           "older",
+			args:             []string{"-rollback"},
+			expectedExitCode: command.Success,
+			expectedBinary:   "older",
 		},
 		{
 			name: "LatestReleaseFails",
@@ -107,57 +113,297 @@ func TestCommand_run(t *testing.T) {
 			},
 			args:             []string{},
 			expectedExitCode: command.GitHubError,
+			expectedBinary:   "old",
+		},
+		{
+			name:             "InvalidChannel",
+			repo:             &MockRepoService{},
+			spec:             spec.Spec{Update: spec.Update{Channel: "nightly"}},
+			args:             []string{},
+			expectedExitCode: command.FlagError,
+			expectedBinary:   "old",
+		},
+		{
+			name:             "InvalidVersion",
+			repo:             &MockRepoService{},
+			args:             []string{"-version", "latest"},
+			expectedExitCode: command.FlagError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "ReleaseByTagFails",
+			repo: &MockRepoService{
+				ReleaseByTagMocks: []ReleaseByTagMock{
+					{OutError: errors.New("error on getting the GitHub release")},
+				},
+			},
+			args:             []string{"-version", "0.9.2"},
+			expectedExitCode: command.GitHubError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "ReleasesFails",
+			repo: &MockRepoService{
+				ReleasesMocks: []ReleasesMock{
+					{OutError: errors.New("error on getting the GitHub releases")},
+				},
+			},
+			args:             []string{"-channel", "prerelease"},
+			expectedExitCode: command.GitHubError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "AlreadyCurrent",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: release, OutResponse: &github.Response{}},
+				},
+			},
+			spec:             spec.Spec{Gelato: spec.Gelato{Version: "1.0.0"}},
+			args:             []string{},
+			expectedExitCode: command.Success,
+			expectedBinary:   "old",
+		},
+		{
+			name: "DownloadChecksumsFails",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: release, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutError: errors.New("error on downloading the release asset")},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.GitHubError,
+			expectedBinary:   "old",
 		},
-		/* {
-			name: "DownloadReleaseAssetFails",
+		{
+			name: "DownloadSignatureFails",
 			repo: &MockRepoService{
 				LatestReleaseMocks: []LatestReleaseMock{
-					{
-						OutRelease: &github.Release{
-							Name:    "1.0.0",
-							TagName: "v1.0.0",
-						},
-						OutResponse: &github.Response{},
-					},
+					{OutRelease: signedRelease, OutResponse: &github.Response{}},
 				},
 				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
 					{OutError: errors.New("error on downloading the release asset")},
 				},
 			},
+			signingKey:       publicKey,
 			args:             []string{},
 			expectedExitCode: command.GitHubError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "InvalidSignature",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: signedRelease, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte("tampered"))), OutResponse: &github.Response{}},
+				},
+			},
+			signingKey:       publicKey,
+			args:             []string{},
+			expectedExitCode: command.VerificationError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "InvalidChecksums",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: release, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: "invalid checksum " + assetName, OutResponse: &github.Response{}},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.VerificationError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "NoChecksumForAsset",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: release, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: fmt.Sprintf("%x  gelato-plan9-mips\n", sha256.Sum256([]byte("new"))), OutResponse: &github.Response{}},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.VerificationError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "DownloadBinaryFails",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: release, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: "ne", OutError: errors.New("error on downloading the release asset")},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.GitHubError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "ChecksumMismatch",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: release, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: "corrupted", OutResponse: &github.Response{}},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.VerificationError,
+			expectedBinary:   "old",
 		},
 		{
 			name: "Success",
 			repo: &MockRepoService{
 				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: release, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: "new", OutResponse: &github.Response{}},
+				},
+			},
+			backup:           "older",
+			args:             []string{},
+			expectedExitCode: command.Success,
+			expectedBinary:   "new",
+			expectedBackup:   "old",
+		},
+		{
+			name: "Success_WithVersion",
+			repo: &MockRepoService{
+				ReleaseByTagMocks: []ReleaseByTagMock{
+					{OutRelease: &github.Release{Name: "0.9.2", TagName: "v0.9.2"}, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: "new", OutResponse: &github.Response{}},
+				},
+			},
+			spec:             spec.Spec{Gelato: spec.Gelato{Version: "1.0.0"}},
+			args:             []string{"-version", "0.9.2"},
+			expectedExitCode: command.Success,
+			expectedBinary:   "new",
+			expectedBackup:   "old",
+		},
+		{
+			name: "Success_PrereleaseChannel",
+			repo: &MockRepoService{
+				ReleasesMocks: []ReleasesMock{
 					{
-						OutRelease: &github.Release{
-							Name:    "1.0.0",
-							TagName: "v1.0.0",
+						OutReleases: []github.Release{
+							{Name: "1.1.0-rc.1", TagName: "v1.1.0-rc.1"},
+							{Name: "1.0.0", TagName: "v1.0.0"},
 						},
 						OutResponse: &github.Response{},
 					},
 				},
 				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
-					{
-						OutResponse: &github.Response{},
-					},
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: "new", OutResponse: &github.Response{}},
+				},
+			},
+			spec:             spec.Spec{Gelato: spec.Gelato{Version: "1.0.0"}},
+			args:             []string{"-channel", "prerelease"},
+			expectedExitCode: command.Success,
+			expectedBinary:   "new",
+			expectedBackup:   "old",
+		},
+		{
+			name: "SignatureWithoutKey",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: signedRelease, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+				},
+			},
+			args:             []string{},
+			expectedExitCode: command.VerificationError,
+			expectedBinary:   "old",
+		},
+		{
+			name: "Success_WithSignature",
+			repo: &MockRepoService{
+				LatestReleaseMocks: []LatestReleaseMock{
+					{OutRelease: signedRelease, OutResponse: &github.Response{}},
+				},
+				DownloadReleaseAssetMocks: []DownloadReleaseAssetMock{
+					{OutContent: checksums, OutResponse: &github.Response{}},
+					{OutContent: signature, OutResponse: &github.Response{}},
+					{OutContent: "new", OutResponse: &github.Response{}},
 				},
 			},
+			signingKey:       publicKey,
 			args:             []string{},
 			expectedExitCode: command.Success,
-		}, */
+			expectedBinary:   "new",
+			expectedBackup:   "old",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			c := &Command{ui: cli.NewMockUi()}
+			binDir, err := ioutil.TempDir("", "gelato-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(binDir)
+
+			binPath := filepath.Join(binDir, "gelato")
+			assert.NoError(t, ioutil.WriteFile(binPath, []byte("old"), 0755))
+
+			if tc.backup != "" {
+				assert.NoError(t, ioutil.WriteFile(binPath+".bak", []byte(tc.backup), 0755))
+			}
+
+			defer func(key string) {
+				signingKey = key
+			}(signingKey)
+			signingKey = tc.signingKey
+
+			c := &Command{
+				ui:   cli.NewMockUi(),
+				spec: tc.spec.WithDefaults(),
+			}
 			c.services.repo = tc.repo
+			c.data.binPath = binPath
 
 			exitCode := c.run(tc.args)
 
 			assert.Equal(t, tc.expectedExitCode, exitCode)
+
+			binary, err := ioutil.ReadFile(binPath)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBinary, string(binary))
+
+			if tc.expectedBackup != "" {
+				backup, err := ioutil.ReadFile(binPath + ".bak")
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBackup, string(backup))
+			}
+
+			// No temporary file should be left behind
+			files, err := ioutil.ReadDir(binDir)
+			assert.NoError(t, err)
+			for _, f := range files {
+				assert.NotRegexp(t, `^\.gelato-`, f.Name())
+			}
 		})
 	}
 }
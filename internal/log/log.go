@@ -152,32 +152,32 @@ func (l *coloredLogger) SetLevel(level Level) {
 
 func (l *coloredLogger) Tracef(format string, v ...interface{}) {
 	msg := l.color.Sprintf(format, v...)
-	l.logger.Tracef(msg)
+	l.logger.Tracef("%s", msg)
 }
 
 func (l *coloredLogger) Debugf(format string, v ...interface{}) {
 	msg := l.color.Sprintf(format, v...)
-	l.logger.Debugf(msg)
+	l.logger.Debugf("%s", msg)
 }
 
 func (l *coloredLogger) Infof(format string, v ...interface{}) {
 	msg := l.color.Sprintf(format, v...)
-	l.logger.Infof(msg)
+	l.logger.Infof("%s", msg)
 }
 
 func (l *coloredLogger) Warnf(format string, v ...interface{}) {
 	msg := l.color.Sprintf(format, v...)
-	l.logger.Warnf(msg)
+	l.logger.Warnf("%s", msg)
 }
 
 func (l *coloredLogger) Errorf(format string, v ...interface{}) {
 	msg := l.color.Sprintf(format, v...)
-	l.logger.Errorf(msg)
+	l.logger.Errorf("%s", msg)
 }
 
 func (l *coloredLogger) Fatalf(format string, v ...interface{}) {
 	msg := l.color.Sprintf(format, v...)
-	l.logger.Fatalf(msg)
+	l.logger.Fatalf("%s", msg)
 }
 
 // ColorfulLogger is a collection of colored loggers.
@@ -52,7 +52,7 @@ func (a *TarArchive) Extract(dest string, r io.Reader, f Selector) error {
 		case tar.TypeDir:
 			if path, ok := f(header.Name); ok {
 				path = filepath.Join(dest, path)
-				if err := os.Mkdir(path, 0755); err != nil {
+				if err := os.MkdirAll(path, 0755); err != nil {
 					return fmt.Errorf("error on creating directory: %s", err)
 				}
 				a.logger.Cyan.Debugf("Directory created: %s", path)
@@ -61,12 +61,21 @@ func (a *TarArchive) Extract(dest string, r io.Reader, f Selector) error {
 		case tar.TypeReg:
 			if path, ok := f(header.Name); ok {
 				path = filepath.Join(dest, path)
-				file, err := os.Create(path)
+
+				// Not all archives have entries for directories (i.e. local tarballs)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return fmt.Errorf("error on creating directory: %s", err)
+				}
+
+				file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode).Perm()|0600)
 				if err != nil {
 					return fmt.Errorf("error on creating file: %s", err)
 				}
 
-				if _, err := io.Copy(file, tarReader); err != nil {
+				_, err = io.Copy(file, tarReader)
+				file.Close()
+
+				if err != nil {
 					return fmt.Errorf("error on copying from tar reader: %s", err)
 				}
 
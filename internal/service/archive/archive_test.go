@@ -62,6 +62,14 @@ func TestTarArchive_Extract(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "Success_NoDirectories",
+			archFile: "test/nodirs.tar.gz",
+			f: func(path string) (string, bool) {
+				return path, true
+			},
+			expectedError: "",
+		},
 	}
 
 	for _, tc := range tests {
@@ -4,36 +4,50 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"path/filepath"
-	"strings"
+	"go/types"
 
 	"github.com/moorara/gelato/internal/service/compiler"
 	"github.com/moorara/gelato/internal/log"
 )
 
 const (
-	mainPkg = "main"
+	mainPkg   = "main"
+	valuePath = "github.com/moorara/gelato/pkg/value"
 )
 
 // New creates a new compiler for generating builders for structs.
-func New(level log.Level) *compiler.Compiler {
+// Generated files are written and their packages are named according to the layout.
+func New(level log.Level, layout compiler.Layout) *compiler.Compiler {
 	logger := log.NewColorful(level)
-	b := &builder{}
-	consumer := &compiler.Consumer{
-		Name:     "builder",
-		Package:  b.Package,
-		FilePre:  b.FilePre,
-		FilePost: b.FilePost,
-		Import:   b.Import,
-		Struct:   b.Struct,
-	}
 
-	return compiler.New(logger, consumer)
+	return compiler.NewConcurrent(logger, func() []*compiler.Consumer {
+		b := &builder{
+			layout: layout,
+		}
+
+		return []*compiler.Consumer{
+			{
+				Name:      "builder",
+				Key:       fmt.Sprintf("%+v", layout),
+				Directive: "build",
+				Package:   b.Package,
+				FilePre:   b.FilePre,
+				FilePost:  b.FilePost,
+				Import:    b.Import,
+				Struct:    b.Struct,
+			},
+		}
+	})
 }
 
 type builder struct {
+	layout  compiler.Layout
 	imports []ast.Spec
 	decls   []ast.Decl
+	// pkgNames maps import paths to package names in the generated file.
+	pkgNames map[string]string
+	// usedNames keeps track of the package names taken in the generated file.
+	usedNames map[string]bool
 }
 
 func (b *builder) Package(info *compiler.PackageInfo, pkg *ast.Package) bool {
@@ -42,6 +56,13 @@ func (b *builder) Package(info *compiler.PackageInfo, pkg *ast.Package) bool {
 
 func (b *builder) FilePre(info *compiler.FileInfo, file *ast.File) bool {
 	b.imports, b.decls = nil, nil
+	b.pkgNames, b.usedNames = nil, nil
+
+	// The package names that the generated file uses regardless of the source file
+	b.reserve(valuePath, "value")
+	b.reserve(info.ImportPath, info.PackageName)
+	b.usedNames[b.layout.PackageName(info)] = true
+
 	return true
 }
 
@@ -64,7 +85,7 @@ func (b *builder) FilePost(info *compiler.FileInfo, file *ast.File) error {
 		},
 		&ast.ImportSpec{
 			Path: &ast.BasicLit{
-				Value: `"github.com/moorara/acai/value"`,
+				Value: fmt.Sprintf("%q", valuePath),
 			},
 		},
 	)
@@ -79,13 +100,12 @@ func (b *builder) FilePost(info *compiler.FileInfo, file *ast.File) error {
 		Package: 45,
 		Name: &ast.Ident{
 			NamePos: 53,
-			Name:    info.PackageName + "test",
+			Name:    b.layout.PackageName(info),
 		},
 		Decls: append([]ast.Decl{importDecl}, b.decls...),
 	}
 
-	fileName := strings.Replace(info.FileName, ".go", "_factory.go", 1)
-	filePath := filepath.Join(info.BaseDir, ".gen", info.RelativeDir+"test", fileName)
+	filePath := b.layout.FilePath(info, "_factory")
 	if err := compiler.WriteFile(filePath, info.FileSet, newFile); err != nil {
 		return err
 	}
@@ -95,20 +115,35 @@ func (b *builder) FilePost(info *compiler.FileInfo, file *ast.File) error {
 
 func (b *builder) Import(info *compiler.FileInfo, spec *ast.ImportSpec) {
 	b.imports = append(b.imports, spec)
+
+	// Use the same package names as the source file where possible
+	if pkgName := importedPkgName(info, spec); pkgName != nil {
+		if name := pkgName.Name(); name != "_" && name != "." {
+			b.reserve(pkgName.Imported().Path(), name)
+		}
+	}
 }
 
 func (b *builder) Struct(info *compiler.TypeInfo, node *ast.StructType) {
+	// Field types are qualified for the generated file if the type information is known
+	typeExpr := func(typ ast.Expr) ast.Expr {
+		if t := info.TypeOf(typ); t != nil && t != types.Typ[types.Invalid] {
+			return compiler.TypeExpr(t, b.qualify)
+		}
+		return typ
+	}
+
 	decls := []ast.Decl{}
 	decls = append(decls, createFuncDecl(info.PackageName, info.TypeName))
 	decls = append(decls, createBuilderStructDecl(info.PackageName, info.TypeName))
-	decls = append(decls, createBuildFuncDecl(info.PackageName, info.TypeName, node.Fields))
+	decls = append(decls, createBuildFuncDecl(info.PackageName, info.TypeName, node.Fields, info.TypeOf, b.qualify))
 
 	for _, field := range node.Fields.List {
 		if len(field.Names) > 0 {
 			for _, id := range field.Names {
 				// Only consider exported fields
 				if compiler.IsExported(id.Name) {
-					decls = append(decls, createBuilderMethodDecl(info.TypeName, id, field.Type))
+					decls = append(decls, createBuilderMethodDecl(info.TypeName, id, typeExpr(field.Type)))
 				}
 			}
 		} else {
@@ -117,7 +152,7 @@ func (b *builder) Struct(info *compiler.TypeInfo, node *ast.StructType) {
 
 			// Only consider exported fields
 			if compiler.IsExported(id.Name) {
-				decls = append(decls, createBuilderMethodDecl(info.TypeName, id, field.Type))
+				decls = append(decls, createBuilderMethodDecl(info.TypeName, id, typeExpr(field.Type)))
 			}
 		}
 	}
@@ -125,9 +160,53 @@ func (b *builder) Struct(info *compiler.TypeInfo, node *ast.StructType) {
 	decls = append(decls, createBuilderValueDecl(info.PackageName, info.TypeName))
 	decls = append(decls, createBuilderPointerDecl(info.PackageName, info.TypeName))
 
+	// Builders for generic structs are generic too
+	compiler.MakeGeneric(decls, info.TypeParams, info.PackageName, info.TypeName)
+
 	b.decls = append(b.decls, decls...)
 }
 
+// reserve assigns a package name to an import path in the generated file.
+func (b *builder) reserve(path, name string) {
+	if b.pkgNames == nil {
+		b.pkgNames, b.usedNames = map[string]string{}, map[string]bool{}
+	}
+
+	if _, ok := b.pkgNames[path]; !ok && !b.usedNames[name] {
+		b.pkgNames[path] = name
+		b.usedNames[name] = true
+	}
+}
+
+// qualify returns the package name for qualifying the types from a package in the generated file.
+// The package is imported if it is not already imported.
+func (b *builder) qualify(pkg *types.Package) string {
+	if name, ok := b.pkgNames[pkg.Path()]; ok {
+		return name
+	}
+
+	name := pkg.Name()
+	for i := 2; b.usedNames[name]; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+
+	b.reserve(pkg.Path(), name)
+
+	spec := &ast.ImportSpec{
+		Path: &ast.BasicLit{
+			Value: fmt.Sprintf("%q", pkg.Path()),
+		},
+	}
+
+	if name != pkg.Name() {
+		spec.Name = &ast.Ident{Name: name}
+	}
+
+	b.imports = append(b.imports, spec)
+
+	return name
+}
+
 func createFuncDecl(pkgName, typeName string) ast.Decl {
 	return &ast.FuncDecl{
 		Name: &ast.Ident{Name: typeName},
@@ -191,15 +270,17 @@ func createBuilderStructDecl(pkgName, typeName string) ast.Decl {
 	}
 }
 
-func createBuildFuncDecl(pkgName, typeName string, fields *ast.FieldList) ast.Decl {
+func createBuildFuncDecl(pkgName, typeName string, fields *ast.FieldList, typeOf func(ast.Expr) types.Type, qualifier types.Qualifier) ast.Decl {
 	elts := []ast.Expr{}
 
 	for _, field := range fields.List {
+		tag := fieldTag(field)
+
 		if len(field.Names) > 0 {
 			for _, id := range field.Names {
 				// Only consider exported fields
 				if compiler.IsExported(id.Name) {
-					elts = append(elts, createFieldInitExpr(id, field.Type))
+					elts = append(elts, createFieldInitExpr(id, field.Type, typeOf(field.Type), tag, qualifier))
 				}
 			}
 		} else {
@@ -208,7 +289,7 @@ func createBuildFuncDecl(pkgName, typeName string, fields *ast.FieldList) ast.De
 
 			// Only consider exported fields
 			if compiler.IsExported(id.Name) {
-				elts = append(elts, createFieldInitExpr(id, field.Type))
+				elts = append(elts, createFieldInitExpr(id, field.Type, typeOf(field.Type), tag, qualifier))
 			}
 		}
 	}
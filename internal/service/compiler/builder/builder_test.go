@@ -13,7 +13,7 @@ import (
 )
 
 func TestNew(t *testing.T) {
-	c := New(log.Info)
+	c := New(log.Info, compiler.DefaultLayout())
 
 	assert.NotNil(t, c)
 	assert.IsType(t, &compiler.Compiler{}, c)
@@ -84,6 +84,7 @@ func TestBuilder_FilePre(t *testing.T) {
 func TestBuilder_FilePost(t *testing.T) {
 	tests := []struct {
 		name          string
+		layout        compiler.Layout
 		imports       []ast.Spec
 		decls         []ast.Decl
 		info          *compiler.FileInfo
@@ -92,6 +93,7 @@ func TestBuilder_FilePost(t *testing.T) {
 	}{
 		{
 			name:          "NoDeclaration",
+			layout:        compiler.DefaultLayout(),
 			imports:       nil,
 			decls:         nil,
 			info:          &compiler.FileInfo{},
@@ -99,7 +101,8 @@ func TestBuilder_FilePost(t *testing.T) {
 			expectedError: "",
 		},
 		{
-			name: "WriteFileFails",
+			name:   "WriteFileFails",
+			layout: compiler.DefaultLayout(),
 			imports: []ast.Spec{
 				&ast.ImportSpec{
 					Path: &ast.BasicLit{Value: `"fmt"`},
@@ -133,7 +136,43 @@ func TestBuilder_FilePost(t *testing.T) {
 			expectedError: "mkdir /dev/null: not a directory",
 		},
 		{
-			name: "Success",
+			name:   "Success",
+			layout: compiler.DefaultLayout(),
+			imports: []ast.Spec{
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{Value: `"fmt"`},
+				},
+			},
+			decls: []ast.Decl{
+				&ast.GenDecl{
+					Tok: token.VAR,
+					Specs: []ast.Spec{
+						&ast.ValueSpec{
+							Names: []*ast.Ident{
+								&ast.Ident{Name: "dummy"},
+							},
+							Type: &ast.Ident{Name: "string"},
+						},
+					},
+				},
+			},
+			info: &compiler.FileInfo{
+				PackageInfo: compiler.PackageInfo{
+					ModuleName:  "github.com/octocat/service",
+					PackageName: "lookup",
+					ImportPath:  "github.com/octocat/service/internal/lookup",
+					BaseDir:     "./service",
+					RelativeDir: "internal/lookup",
+				},
+				FileName: "lookup.go",
+				FileSet:  token.NewFileSet(),
+			},
+			file:          &ast.File{},
+			expectedError: "",
+		},
+		{
+			name:   "Success_NextToSource",
+			layout: compiler.Layout{},
 			imports: []ast.Spec{
 				&ast.ImportSpec{
 					Path: &ast.BasicLit{Value: `"fmt"`},
@@ -171,6 +210,7 @@ func TestBuilder_FilePost(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			b := &builder{
+				layout:  tc.layout,
 				imports: tc.imports,
 				decls:   tc.decls,
 			}
@@ -497,6 +537,350 @@ func TestBuilder_Struct(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Generic",
+			info: &compiler.TypeInfo{
+				FileInfo: compiler.FileInfo{
+					PackageInfo: compiler.PackageInfo{
+						PackageName: "lookup",
+					},
+				},
+				TypeName: "Page",
+				TypeParams: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								{Name: "T"},
+							},
+							Type: &ast.Ident{Name: "any"},
+						},
+					},
+				},
+			},
+			node: &ast.StructType{
+				Fields: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								&ast.Ident{Name: "Items"},
+							},
+							Type: &ast.ArrayType{
+								Elt: &ast.Ident{Name: "T"},
+							},
+						},
+					},
+				},
+			},
+			expectedDecls: []ast.Decl{
+				// Type func
+				&ast.FuncDecl{
+					Name: &ast.Ident{Name: "Page"},
+					Type: &ast.FuncType{
+						TypeParams: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										{Name: "T"},
+									},
+									Type: &ast.Ident{Name: "any"},
+								},
+							},
+						},
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.IndexExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "lookup"},
+											Sel: &ast.Ident{Name: "Page"},
+										},
+										Index: &ast.Ident{Name: "T"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X: &ast.CallExpr{
+												Fun: &ast.IndexExpr{
+													X:     &ast.Ident{Name: "BuildPage"},
+													Index: &ast.Ident{Name: "T"},
+												},
+											},
+											Sel: &ast.Ident{Name: "Value"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				// Builder struct
+				&ast.GenDecl{
+					Tok: token.TYPE,
+					Specs: []ast.Spec{
+						&ast.TypeSpec{
+							Name: &ast.Ident{Name: "PageBuilder"},
+							TypeParams: &ast.FieldList{
+								List: []*ast.Field{
+									{
+										Names: []*ast.Ident{
+											{Name: "T"},
+										},
+										Type: &ast.Ident{Name: "any"},
+									},
+								},
+							},
+							Type: &ast.StructType{
+								Fields: &ast.FieldList{
+									List: []*ast.Field{
+										{
+											Names: []*ast.Ident{
+												{Name: "v"},
+											},
+											Type: &ast.IndexExpr{
+												X: &ast.SelectorExpr{
+													X:   &ast.Ident{Name: "lookup"},
+													Sel: &ast.Ident{Name: "Page"},
+												},
+												Index: &ast.Ident{Name: "T"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				// Build func
+				&ast.FuncDecl{
+					Name: &ast.Ident{Name: "BuildPage"},
+					Type: &ast.FuncType{
+						TypeParams: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										{Name: "T"},
+									},
+									Type: &ast.Ident{Name: "any"},
+								},
+							},
+						},
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.IndexExpr{
+										X:     &ast.Ident{Name: "PageBuilder"},
+										Index: &ast.Ident{Name: "T"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.CompositeLit{
+										Type: &ast.IndexExpr{
+											X:     &ast.Ident{Name: "PageBuilder"},
+											Index: &ast.Ident{Name: "T"},
+										},
+										Elts: []ast.Expr{
+											&ast.KeyValueExpr{
+												Key: &ast.Ident{Name: "v"},
+												Value: &ast.CompositeLit{
+													Type: &ast.IndexExpr{
+														X: &ast.SelectorExpr{
+															X:   &ast.Ident{Name: "lookup"},
+															Sel: &ast.Ident{Name: "Page"},
+														},
+														Index: &ast.Ident{Name: "T"},
+													},
+													Elts: []ast.Expr{
+														&ast.KeyValueExpr{
+															Key:   &ast.Ident{Name: "Items"},
+															Value: &ast.Ident{Name: "nil"},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				// Builder method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "b"},
+								},
+								Type: &ast.IndexExpr{
+									X:     &ast.Ident{Name: "PageBuilder"},
+									Index: &ast.Ident{Name: "T"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "WithItems"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										{Name: "items"},
+									},
+									Type: &ast.ArrayType{
+										Elt: &ast.Ident{Name: "T"},
+									},
+								},
+							},
+						},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.IndexExpr{
+										X:     &ast.Ident{Name: "PageBuilder"},
+										Index: &ast.Ident{Name: "T"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									&ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "b"},
+											Sel: &ast.Ident{Name: "v"},
+										},
+										Sel: &ast.Ident{Name: "Items"},
+									},
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.Ident{Name: "items"},
+								},
+							},
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.Ident{Name: "b"},
+								},
+							},
+						},
+					},
+				},
+				// Value method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "b"},
+								},
+								Type: &ast.IndexExpr{
+									X:     &ast.Ident{Name: "PageBuilder"},
+									Index: &ast.Ident{Name: "T"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "Value"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.IndexExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "lookup"},
+											Sel: &ast.Ident{Name: "Page"},
+										},
+										Index: &ast.Ident{Name: "T"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.SelectorExpr{
+										X:   &ast.Ident{Name: "b"},
+										Sel: &ast.Ident{Name: "v"},
+									},
+								},
+							},
+						},
+					},
+				},
+				// Pointer method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "b"},
+								},
+								Type: &ast.IndexExpr{
+									X:     &ast.Ident{Name: "PageBuilder"},
+									Index: &ast.Ident{Name: "T"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "Pointer"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.IndexExpr{
+											X: &ast.SelectorExpr{
+												X:   &ast.Ident{Name: "lookup"},
+												Sel: &ast.Ident{Name: "Page"},
+											},
+											Index: &ast.Ident{Name: "T"},
+										},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.UnaryExpr{
+										Op: token.AND,
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "b"},
+											Sel: &ast.Ident{Name: "v"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Response",
 			info: &compiler.TypeInfo{
@@ -875,8 +1259,13 @@ func TestBuilder_Struct(t *testing.T) {
 													},
 													Elts: []ast.Expr{
 														&ast.KeyValueExpr{
-															Key:   &ast.Ident{Name: "Address"},
-															Value: &ast.Ident{Name: "nil"},
+															Key: &ast.Ident{Name: "Address"},
+															Value: &ast.CompositeLit{
+																Type: &ast.SelectorExpr{
+																	X:   &ast.Ident{Name: "common"},
+																	Sel: &ast.Ident{Name: "Address"},
+																},
+															},
 														},
 													},
 												},
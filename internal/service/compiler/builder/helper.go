@@ -1,202 +1,262 @@
 package builder
 
-import "go/ast"
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+
+	"github.com/moorara/gelato/internal/service/compiler"
+)
+
+// tagKey is the key of struct tags for choosing the values of fields (e.g. `gelato:"email"`).
+// The "-" tag means a field is initialized with its zero value.
+const tagKey = "gelato"
+
+// basicFuncs maps the basic types to the functions in the value package generating random values of them.
+var basicFuncs = map[string]string{
+	"bool":       "Bool",
+	"string":     "String",
+	"byte":       "Byte",
+	"rune":       "Rune",
+	"int":        "Int",
+	"int8":       "Int8",
+	"int16":      "Int16",
+	"int32":      "Int32",
+	"int64":      "Int64",
+	"uint":       "Uint",
+	"uint8":      "Uint8",
+	"uint16":     "Uint16",
+	"uint32":     "Uint32",
+	"uint64":     "Uint64",
+	"uintptr":    "Uintptr",
+	"float32":    "Float32",
+	"float64":    "Float64",
+	"complex64":  "Complex64",
+	"complex128": "Complex128",
+}
+
+// tagFuncs maps the struct tags to the functions in the value package generating string values for them.
+var tagFuncs = map[string]string{
+	"email": "Email",
+	"uuid":  "UUID",
+	"url":   "URL",
+	"name":  "Name",
+	"phone": "Phone",
+	"ip":    "IP",
+}
+
+// importedPkgName returns the package name declared by an import spec or nil if it is not known.
+func importedPkgName(info *compiler.FileInfo, spec *ast.ImportSpec) *types.PkgName {
+	if info.TypesInfo == nil {
+		return nil
+	}
+
+	var obj types.Object
+	if spec.Name != nil {
+		obj = info.TypesInfo.Defs[spec.Name]
+	} else {
+		obj = info.TypesInfo.Implicits[spec]
+	}
+
+	pkgName, _ := obj.(*types.PkgName)
+	return pkgName
+}
+
+// fieldTag returns the value of the gelato key in the tag of a field.
+func fieldTag(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+
+	return reflect.StructTag(tag).Get(tagKey)
+}
+
+// createFieldInitExpr creates a key-value expression for initializing a field.
+// Fields are initialized with random values if possible and with zero values otherwise.
+// If the type information is known, the values of nested structs, pointers, slices, and maps are created recursively,
+// and the types are qualified using the qualifier.
+func createFieldInitExpr(id *ast.Ident, typ ast.Expr, t types.Type, tag string, qualifier types.Qualifier) *ast.KeyValueExpr {
+	if t == types.Typ[types.Invalid] {
+		t = nil
+	}
 
-func createFieldInitExpr(id *ast.Ident, typ ast.Expr) *ast.KeyValueExpr {
 	var value ast.Expr
 
-	switch e := typ.(type) {
-	case *ast.Ident:
-		switch e.Name {
-		case "error":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Error"},
-				},
+	if tag != "-" {
+		if t != nil {
+			value = createValueExpr(t, tag, qualifier, map[*types.Named]bool{})
+		} else if e, ok := typ.(*ast.Ident); ok {
+			if e.Name == "error" {
+				value = createValueCallExpr("Error")
+			} else if name, ok := basicFuncs[e.Name]; ok {
+				value = createValueCallExpr(name)
 			}
+		}
+	}
 
-		case "bool":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Bool"},
-				},
-			}
+	if value == nil {
+		if t != nil {
+			typ = compiler.TypeExpr(t, qualifier)
+		}
+		value = compiler.ZeroValueExpr(typ, t)
+	}
 
-		case "string":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "String"},
-				},
-			}
+	return &ast.KeyValueExpr{
+		Key:   &ast.Ident{Name: id.Name},
+		Value: value,
+	}
+}
 
-		case "byte":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Byte"},
-				},
-			}
+// createValueExpr creates an expression for a random value of a type.
+// It returns nil if no random value can be created for the type.
+// The named structs being created are kept track of, so recursive types are not created infinitely.
+func createValueExpr(t types.Type, tag string, qualifier types.Qualifier, creating map[*types.Named]bool) ast.Expr {
+	if _, ok := t.(*types.TypeParam); ok {
+		return nil
+	}
 
-		case "rune":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Rune"},
-				},
-			}
+	if types.Identical(t, types.Universe.Lookup("error").Type()) {
+		return createValueCallExpr("Error")
+	}
 
-		case "int":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Int"},
-				},
-			}
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		if obj := named.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			return createValueCallExpr("Time")
+		}
+	}
 
-		case "int8":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Int8"},
-				},
-			}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		var call ast.Expr
+		if name, ok := tagFuncs[tag]; ok && u.Info()&types.IsString != 0 {
+			call = createValueCallExpr(name)
+		} else if name, ok := basicFuncs[u.Name()]; ok {
+			call = createValueCallExpr(name)
+		} else {
+			return nil
+		}
 
-		case "int16":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Int16"},
-				},
-			}
+		// Named types (e.g. type Status string) are converted
+		if _, ok := t.(*types.Basic); ok {
+			return call
+		}
 
-		case "int32":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Int32"},
-				},
-			}
+		return &ast.CallExpr{
+			Fun:  compiler.TypeExpr(t, qualifier),
+			Args: []ast.Expr{call},
+		}
 
-		case "int64":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Int64"},
-				},
+	case *types.Struct:
+		named, _ := types.Unalias(t).(*types.Named)
+		if named != nil {
+			if creating[named] {
+				return nil
 			}
 
-		case "uint":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Uint"},
-				},
-			}
+			creating[named] = true
+			defer delete(creating, named)
+		}
 
-		case "uint8":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Uint8"},
-				},
+		elts := []ast.Expr{}
+		for i := 0; i < u.NumFields(); i++ {
+			// Only consider exported fields
+			f := u.Field(i)
+			if !f.Exported() {
+				continue
 			}
 
-		case "uint16":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Uint16"},
-				},
+			fieldTag := reflect.StructTag(u.Tag(i)).Get(tagKey)
+			if fieldTag == "-" {
+				continue
 			}
 
-		case "uint32":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Uint32"},
-				},
+			if value := createValueExpr(f.Type(), fieldTag, qualifier, creating); value != nil {
+				elts = append(elts, &ast.KeyValueExpr{
+					Key:   &ast.Ident{Name: f.Name()},
+					Value: value,
+				})
 			}
+		}
 
-		case "uint64":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Uint64"},
-				},
-			}
+		if len(elts) == 0 {
+			return nil
+		}
 
-		case "uintptr":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Uintptr"},
-				},
-			}
+		return &ast.CompositeLit{
+			Type: compiler.TypeExpr(t, qualifier),
+			Elts: elts,
+		}
 
-		case "float32":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Float32"},
-				},
-			}
+	case *types.Pointer:
+		if _, ok := t.(*types.Pointer); !ok {
+			return nil
+		}
 
-		case "float64":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Float64"},
-				},
-			}
+		value := createValueExpr(u.Elem(), tag, qualifier, creating)
+		if value == nil {
+			return nil
+		}
 
-		case "complex64":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Complex64"},
-				},
+		if lit, ok := value.(*ast.CompositeLit); ok {
+			return &ast.UnaryExpr{
+				Op: token.AND,
+				X:  lit,
 			}
+		}
 
-		case "complex128":
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "value"},
-					Sel: &ast.Ident{Name: "Complex128"},
-				},
-			}
+		return &ast.CallExpr{
+			Fun:  createValueSelectorExpr("Ptr"),
+			Args: []ast.Expr{value},
+		}
 
-		// struct
-		default:
-			// TODO:
-			value = &ast.Ident{Name: "nil"}
+	case *types.Slice:
+		value := createValueExpr(u.Elem(), tag, qualifier, creating)
+		if value == nil {
+			return nil
 		}
 
-	case *ast.SelectorExpr:
-		// TODO:
-		value = &ast.Ident{Name: "nil"}
+		return &ast.CompositeLit{
+			Type: compiler.TypeExpr(t, qualifier),
+			Elts: []ast.Expr{value},
+		}
 
-	case *ast.StarExpr:
-		// TODO:
-		value = &ast.Ident{Name: "nil"}
+	case *types.Map:
+		key := createValueExpr(u.Key(), "", qualifier, creating)
+		value := createValueExpr(u.Elem(), tag, qualifier, creating)
+		if key == nil || value == nil {
+			return nil
+		}
 
-	case *ast.ArrayType:
-		// TODO:
-		value = &ast.Ident{Name: "nil"}
+		return &ast.CompositeLit{
+			Type: compiler.TypeExpr(t, qualifier),
+			Elts: []ast.Expr{
+				&ast.KeyValueExpr{
+					Key:   key,
+					Value: value,
+				},
+			},
+		}
+	}
 
-	case *ast.MapType:
-		// TODO:
-		value = &ast.Ident{Name: "nil"}
+	return nil
+}
 
-	case *ast.ChanType:
-		// TODO:
-		value = &ast.Ident{Name: "nil"}
+func createValueSelectorExpr(name string) ast.Expr {
+	return &ast.SelectorExpr{
+		X:   &ast.Ident{Name: "value"},
+		Sel: &ast.Ident{Name: name},
 	}
+}
 
-	return &ast.KeyValueExpr{
-		Key:   &ast.Ident{Name: id.Name},
-		Value: value,
+func createValueCallExpr(name string) ast.Expr {
+	return &ast.CallExpr{
+		Fun: createValueSelectorExpr(name),
 	}
 }
@@ -2,6 +2,13 @@ package builder
 
 import (
 	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +19,8 @@ func TestCreateFieldInitExpr(t *testing.T) {
 		name         string
 		id           *ast.Ident
 		typ          ast.Expr
+		t            types.Type
+		tag          string
 		expectedExpr *ast.KeyValueExpr
 	}{
 		{
@@ -294,13 +303,41 @@ func TestCreateFieldInitExpr(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Tag_Zero",
+			id:   &ast.Ident{Name: "s"},
+			typ:  &ast.Ident{Name: "string"},
+			tag:  "-",
+			expectedExpr: &ast.KeyValueExpr{
+				Key:   &ast.Ident{Name: "s"},
+				Value: &ast.BasicLit{Kind: token.STRING, Value: `""`},
+			},
+		},
+		{
+			name: "Tag_Typed",
+			id:   &ast.Ident{Name: "e"},
+			typ:  &ast.Ident{Name: "string"},
+			t:    types.Typ[types.String],
+			tag:  "email",
+			expectedExpr: &ast.KeyValueExpr{
+				Key: &ast.Ident{Name: "e"},
+				Value: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   &ast.Ident{Name: "value"},
+						Sel: &ast.Ident{Name: "Email"},
+					},
+				},
+			},
+		},
 		{
 			name: "Struct_SamePackage",
 			id:   &ast.Ident{Name: "a"},
 			typ:  &ast.Ident{Name: "Address"},
 			expectedExpr: &ast.KeyValueExpr{
-				Key:   &ast.Ident{Name: "a"},
-				Value: &ast.Ident{Name: "nil"},
+				Key: &ast.Ident{Name: "a"},
+				Value: &ast.CompositeLit{
+					Type: &ast.Ident{Name: "Address"},
+				},
 			},
 		},
 		{
@@ -311,8 +348,13 @@ func TestCreateFieldInitExpr(t *testing.T) {
 				Sel: &ast.Ident{Name: "Transport"},
 			},
 			expectedExpr: &ast.KeyValueExpr{
-				Key:   &ast.Ident{Name: "t"},
-				Value: &ast.Ident{Name: "nil"},
+				Key: &ast.Ident{Name: "t"},
+				Value: &ast.CompositeLit{
+					Type: &ast.SelectorExpr{
+						X:   &ast.Ident{Name: "http"},
+						Sel: &ast.Ident{Name: "Transport"},
+					},
+				},
 			},
 		},
 		{
@@ -360,13 +402,202 @@ func TestCreateFieldInitExpr(t *testing.T) {
 				Value: &ast.Ident{Name: "nil"},
 			},
 		},
+		{
+			name: "Function",
+			id:   &ast.Ident{Name: "f"},
+			typ:  &ast.FuncType{},
+			expectedExpr: &ast.KeyValueExpr{
+				Key:   &ast.Ident{Name: "f"},
+				Value: &ast.Ident{Name: "nil"},
+			},
+		},
+		{
+			name: "Interface",
+			id:   &ast.Ident{Name: "i"},
+			typ:  &ast.InterfaceType{},
+			expectedExpr: &ast.KeyValueExpr{
+				Key:   &ast.Ident{Name: "i"},
+				Value: &ast.Ident{Name: "nil"},
+			},
+		},
+		{
+			name: "TypeParam",
+			id:   &ast.Ident{Name: "v"},
+			typ:  &ast.Ident{Name: "T"},
+			t:    types.NewTypeParam(types.NewTypeName(0, nil, "T", nil), types.NewInterfaceType(nil, nil)),
+			expectedExpr: &ast.KeyValueExpr{
+				Key: &ast.Ident{Name: "v"},
+				Value: &ast.StarExpr{
+					X: &ast.CallExpr{
+						Fun:  &ast.Ident{Name: "new"},
+						Args: []ast.Expr{&ast.Ident{Name: "T"}},
+					},
+				},
+			},
+		},
+		{
+			name: "Generic",
+			id:   &ast.Ident{Name: "p"},
+			typ: &ast.IndexExpr{
+				X:     &ast.Ident{Name: "Page"},
+				Index: &ast.Ident{Name: "T"},
+			},
+			expectedExpr: &ast.KeyValueExpr{
+				Key: &ast.Ident{Name: "p"},
+				Value: &ast.StarExpr{
+					X: &ast.CallExpr{
+						Fun: &ast.Ident{Name: "new"},
+						Args: []ast.Expr{
+							&ast.IndexExpr{
+								X:     &ast.Ident{Name: "Page"},
+								Index: &ast.Ident{Name: "T"},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			expr := createFieldInitExpr(tc.id, tc.typ)
+			expr := createFieldInitExpr(tc.id, tc.typ, tc.t, tc.tag, nil)
 
 			assert.Equal(t, tc.expectedExpr, expr)
 		})
 	}
 }
+
+const shopSrc = `package shop
+
+import "time"
+
+type Status string
+
+type Address struct {
+	Street string
+	City   string
+	zip    string
+}
+
+type Customer struct {
+	ID        string ` + "`gelato:\"uuid\"`" + `
+	Email     string ` + "`gelato:\"email\"`" + `
+	Internal  string ` + "`gelato:\"-\"`" + `
+	Status    Status
+	Address   Address
+	Billing   *Address
+	Addresses []Address
+	Tags      map[string]int
+	Phones    []string ` + "`gelato:\"phone\"`" + `
+	Nickname  *string
+	Joined    time.Time
+	Referrer  *Customer
+	Handler   func()
+}
+`
+
+func TestCreateFieldInitExpr_Typed(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "shop.go", shopSrc, 0)
+	assert.NoError(t, err)
+
+	config := &types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+	}
+
+	pkg, err := config.Check("example.com/shop", fset, []*ast.File{file}, nil)
+	assert.NoError(t, err)
+
+	qualifier := func(p *types.Package) string {
+		return p.Name()
+	}
+
+	s := pkg.Scope().Lookup("Customer").Type().Underlying().(*types.Struct)
+
+	tests := []struct {
+		name         string
+		expectedExpr string
+	}{
+		{"ID", `ID: value.UUID()`},
+		{"Email", `Email: value.Email()`},
+		{"Internal", `Internal: ""`},
+		{"Status", `Status: shop.Status(value.String())`},
+		{"Address", `Address: shop.Address{Street: value.String(), City: value.String()}`},
+		{"Billing", `Billing: &shop.Address{Street: value.String(), City: value.String()}`},
+		{"Addresses", `Addresses: []shop.Address{shop.Address{Street: value.String(), City: value.String()}}`},
+		{"Tags", `Tags: map[string]int{value.String(): value.Int()}`},
+		{"Phones", `Phones: []string{value.Phone()}`},
+		{"Nickname", `Nickname: value.Ptr(value.String())`},
+		{"Joined", `Joined: value.Time()`},
+		{"Handler", `Handler: nil`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var field *types.Var
+			var tag string
+			for i := 0; i < s.NumFields(); i++ {
+				if s.Field(i).Name() == tc.name {
+					field, tag = s.Field(i), reflect.StructTag(s.Tag(i)).Get(tagKey)
+				}
+			}
+
+			expr := createFieldInitExpr(&ast.Ident{Name: tc.name}, nil, field.Type(), tag, qualifier)
+
+			var b strings.Builder
+			assert.NoError(t, printer.Fprint(&b, token.NewFileSet(), expr))
+			assert.Equal(t, tc.expectedExpr, b.String())
+		})
+	}
+
+	t.Run("Recursive", func(t *testing.T) {
+		var field *types.Var
+		for i := 0; i < s.NumFields(); i++ {
+			if s.Field(i).Name() == "Referrer" {
+				field = s.Field(i)
+			}
+		}
+
+		expr := createFieldInitExpr(&ast.Ident{Name: "Referrer"}, nil, field.Type(), "", qualifier)
+
+		var b strings.Builder
+		assert.NoError(t, printer.Fprint(&b, token.NewFileSet(), expr))
+		assert.True(t, strings.HasPrefix(b.String(), "Referrer: &shop.Customer{ID: value.UUID(), "))
+		assert.Equal(t, 1, strings.Count(b.String(), "Referrer:"))
+	})
+}
+
+func TestFieldTag(t *testing.T) {
+	tests := []struct {
+		name        string
+		field       *ast.Field
+		expectedTag string
+	}{
+		{
+			name:        "NoTag",
+			field:       &ast.Field{},
+			expectedTag: "",
+		},
+		{
+			name: "Tag",
+			field: &ast.Field{
+				Tag: &ast.BasicLit{Kind: token.STRING, Value: "`json:\"email\" gelato:\"email\"`"},
+			},
+			expectedTag: "email",
+		},
+		{
+			name: "OtherTag",
+			field: &ast.Field{
+				Tag: &ast.BasicLit{Kind: token.STRING, Value: "`json:\"email\"`"},
+			},
+			expectedTag: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedTag, fieldTag(tc.field))
+		})
+	}
+}
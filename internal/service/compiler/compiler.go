@@ -20,6 +20,17 @@ func New(logger *log.ColorfulLogger, consumers ...*Consumer) *Compiler {
 	}
 }
 
+// NewConcurrent creates a new compiler that processes packages concurrently.
+// newConsumers is called once per worker, so every worker has its own consumers and consumers do not need to be safe for concurrent use.
+func NewConcurrent(logger *log.ColorfulLogger, newConsumers func() []*Consumer) *Compiler {
+	return &Compiler{
+		parser: &parser{
+			logger:       logger,
+			newConsumers: newConsumers,
+		},
+	}
+}
+
 // Compile parses all Go source code files recursively from a given path and generates new artifacts (source codes, etc.).
 func (c *Compiler) Compile(path string, opts ParseOptions) error {
 	return c.parser.Parse(path, opts)
@@ -46,6 +46,45 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewConcurrent(t *testing.T) {
+	logger := log.New(log.None)
+	clogger := &log.ColorfulLogger{
+		Red:     logger,
+		Green:   logger,
+		Yellow:  logger,
+		Blue:    logger,
+		Magenta: logger,
+		Cyan:    logger,
+		White:   logger,
+	}
+
+	tests := []struct {
+		name      string
+		logger    *log.ColorfulLogger
+		consumers []*Consumer
+	}{
+		{
+			name:      "OK",
+			logger:    clogger,
+			consumers: []*Consumer{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewConcurrent(tc.logger, func() []*Consumer {
+				return tc.consumers
+			})
+
+			assert.NotNil(t, c)
+			assert.NotNil(t, c.parser)
+			assert.Equal(t, tc.logger, c.parser.logger)
+			assert.Nil(t, c.parser.consumers)
+			assert.Equal(t, tc.consumers, c.parser.newConsumers())
+		})
+	}
+}
+
 func TestCompiler_Compile(t *testing.T) {
 	logger := log.New(log.None)
 	clogger := &log.ColorfulLogger{
@@ -0,0 +1,69 @@
+// Package compilertest provides helpers for testing the source code generated by compilers.
+package compilertest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/service/compiler"
+)
+
+const goMod = `module %s
+
+go 1.25
+
+require github.com/moorara/gelato v0.0.0
+
+replace github.com/moorara/gelato => %s
+`
+
+// GoTest creates a Go module with a set of files, compiles it using a compiler, and runs its tests with the race detector.
+// The module requires Gelato from the local repository, so the generated source code can use its packages (mock, fake, etc.).
+func GoTest(t *testing.T, c *compiler.Compiler, module string, files map[string]string) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping the go test of generated source code in short mode")
+	}
+
+	_, file, _, _ := runtime.Caller(0)
+	root := filepath.Join(filepath.Dir(file), "../../../..")
+
+	goSum, err := ioutil.ReadFile(filepath.Join(root, "go.sum"))
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+
+	all := map[string]string{
+		"go.mod": fmt.Sprintf(goMod, module, root),
+		"go.sum": string(goSum),
+	}
+
+	for name, content := range files {
+		all[name] = content
+	}
+
+	for name, content := range all {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	err = c.Compile(dir, compiler.ParseOptions{
+		SkipTestFiles: true,
+	})
+	assert.NoError(t, err)
+
+	cmd := exec.Command("go", "test", "-race", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
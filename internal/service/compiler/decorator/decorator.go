@@ -11,27 +11,29 @@ const decoratedDir = ".build"
 func New(level log.Level) *compiler.Compiler {
 	logger := log.NewColorful(level)
 
-	md := &mainDecorator{}
-	mainConsumer := &compiler.Consumer{
-		Name:     "mainDecorator",
-		Package:  md.Package,
-		FilePre:  md.FilePre,
-		FilePost: md.FilePost,
-		Import:   md.Import,
-		FuncDecl: md.FuncDecl,
-	}
+	return compiler.NewConcurrent(logger, func() []*compiler.Consumer {
+		md := &mainDecorator{}
+		pd := &pkgDecorator{}
 
-	pd := &pkgDecorator{}
-	pkgConsumer := &compiler.Consumer{
-		Name:      "packageDecorator",
-		Package:   pd.Package,
-		FilePre:   pd.FilePre,
-		FilePost:  pd.FilePost,
-		Import:    pd.Import,
-		Struct:    pd.Struct,
-		Interface: pd.Interface,
-		FuncDecl:  pd.FuncDecl,
-	}
-
-	return compiler.New(logger, mainConsumer, pkgConsumer)
+		return []*compiler.Consumer{
+			{
+				Name:     "mainDecorator",
+				Package:  md.Package,
+				FilePre:  md.FilePre,
+				FilePost: md.FilePost,
+				Import:   md.Import,
+				FuncDecl: md.FuncDecl,
+			},
+			{
+				Name:      "packageDecorator",
+				Package:   pd.Package,
+				FilePre:   pd.FilePre,
+				FilePost:  pd.FilePost,
+				Import:    pd.Import,
+				Struct:    pd.Struct,
+				Interface: pd.Interface,
+				FuncDecl:  pd.FuncDecl,
+			},
+		}
+	})
 }
@@ -0,0 +1,87 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	goparser "go/parser"
+	"go/token"
+	"io/ioutil"
+	"sort"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Edit is a change to a Go source code file that inserts a text at a position.
+type Edit struct {
+	Pos  token.Pos
+	Text string
+}
+
+// LocateFunc locates the edits for a Go source code file using its AST.
+// It also returns the import paths required by the edits.
+type LocateFunc func(*token.FileSet, *ast.File) ([]Edit, []string, error)
+
+// EditFile edits an existing Go source code file safely.
+// The edits are located on the AST of the file and applied to its source code, so the comments and formatting are preserved.
+// The edited source code is parsed and formatted again before the file is overwritten, so an invalid edit never corrupts the file.
+func EditFile(path string, locate LocateFunc) error {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, path, src, goparser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	edits, imports, err := locate(fset, file)
+	if err != nil {
+		return err
+	}
+
+	// Apply the edits from the end of the file, so the offsets of the remaining edits stay valid
+	// The edits at the same position are inserted in the given order
+	order := make([]int, len(edits))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		if a, b := edits[order[i]], edits[order[j]]; a.Pos != b.Pos {
+			return a.Pos > b.Pos
+		}
+		return order[i] > order[j]
+	})
+
+	out := src
+	for _, i := range order {
+		e := edits[i]
+		offset := fset.Position(e.Pos).Offset
+		if offset < 0 || offset > len(out) {
+			return fmt.Errorf("invalid edit position: %d", offset)
+		}
+
+		out = append(out[:offset:offset], append([]byte(e.Text), out[offset:]...)...)
+	}
+
+	fset = token.NewFileSet()
+	file, err = goparser.ParseFile(fset, path, out, goparser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("invalid edit: %s", err)
+	}
+
+	for _, path := range imports {
+		astutil.AddImport(fset, file, path)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := format.Node(buf, fset, file); err != nil {
+		return fmt.Errorf("gofmt error: %s", err)
+	}
+
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
@@ -0,0 +1,113 @@
+package compiler
+
+import (
+	"errors"
+	"go/ast"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const editorSrc = `package main
+
+import "fmt"
+
+func main() {
+	// Print a greeting
+	fmt.Println("Hello, World!")
+}
+`
+
+func TestEditFile(t *testing.T) {
+	// lastStmt returns the end position of the last statement in the main function.
+	lastStmt := func(file *ast.File) token.Pos {
+		fn := file.Decls[1].(*ast.FuncDecl)
+		return fn.Body.List[len(fn.Body.List)-1].End()
+	}
+
+	tests := []struct {
+		name          string
+		src           string
+		locate        LocateFunc
+		expectedError string
+		expectedSrc   string
+	}{
+		{
+			name:          "InvalidFile",
+			src:           "package",
+			locate:        nil,
+			expectedError: "./main.go:1:8: expected 'IDENT', found 'EOF'",
+			expectedSrc:   "package",
+		},
+		{
+			name: "LocateFails",
+			src:  editorSrc,
+			locate: func(*token.FileSet, *ast.File) ([]Edit, []string, error) {
+				return nil, nil, errors.New("cannot find main")
+			},
+			expectedError: "cannot find main",
+			expectedSrc:   editorSrc,
+		},
+		{
+			name: "InvalidEdit",
+			src:  editorSrc,
+			locate: func(fset *token.FileSet, file *ast.File) ([]Edit, []string, error) {
+				return []Edit{
+					{Pos: lastStmt(file), Text: "\n}}"},
+				}, nil, nil
+			},
+			expectedError: "invalid edit: ./main.go:8:2: expected declaration, found '}'",
+			expectedSrc:   editorSrc,
+		},
+		{
+			name: "Success",
+			src:  editorSrc,
+			locate: func(fset *token.FileSet, file *ast.File) ([]Edit, []string, error) {
+				return []Edit{
+					{Pos: lastStmt(file), Text: "\nfmt.Println(\"Bye!\")"},
+					{Pos: lastStmt(file), Text: "\nos.Exit(0)"},
+					{Pos: file.Decls[1].Pos(), Text: "// main is the entrypoint.\n"},
+				}, []string{"os"}, nil
+			},
+			expectedError: "",
+			expectedSrc: `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// main is the entrypoint.
+func main() {
+	// Print a greeting
+	fmt.Println("Hello, World!")
+	fmt.Println("Bye!")
+	os.Exit(0)
+}
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := "./main.go"
+			assert.NoError(t, ioutil.WriteFile(path, []byte(tc.src), 0644))
+			defer os.Remove(path)
+
+			err := EditFile(path, tc.locate)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+
+			b, err := ioutil.ReadFile(path)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSrc, string(b))
+		})
+	}
+}
@@ -0,0 +1,621 @@
+package faker
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/moorara/gelato/internal/log"
+	"github.com/moorara/gelato/internal/service/compiler"
+)
+
+const (
+	mainPkg  = "main"
+	fakePath = "github.com/moorara/gelato/pkg/fake"
+)
+
+// New creates a new compiler for generating in-memory fakes for repository interfaces.
+// Repository interfaces are the interfaces with CRUD methods (Create, Get, List, Update, and Delete),
+// and at least a Create and a Get method. Other interfaces are skipped.
+// Generated files are written and their packages are named according to the layout.
+func New(level log.Level, layout compiler.Layout) *compiler.Compiler {
+	logger := log.NewColorful(level)
+
+	return compiler.NewConcurrent(logger, func() []*compiler.Consumer {
+		f := &faker{
+			logger: logger,
+			layout: layout,
+		}
+
+		return []*compiler.Consumer{
+			{
+				Name:      "faker",
+				Key:       fmt.Sprintf("%+v", layout),
+				Directive: "fake",
+				Package:   f.Package,
+				FilePre:   f.FilePre,
+				FilePost:  f.FilePost,
+				Import:    f.Import,
+				Interface: f.Interface,
+			},
+		}
+	})
+}
+
+type faker struct {
+	logger  *log.ColorfulLogger
+	layout  compiler.Layout
+	imports []ast.Spec
+	decls   []ast.Decl
+	// pkgNames maps import paths to package names in the generated file.
+	pkgNames map[string]string
+	// usedNames keeps track of the package names taken in the generated file.
+	usedNames map[string]bool
+	// imported keeps track of the import paths imported by the generated file.
+	imported map[string]bool
+}
+
+func (f *faker) Package(info *compiler.PackageInfo, pkg *ast.Package) bool {
+	return pkg.Name != mainPkg
+}
+
+func (f *faker) FilePre(info *compiler.FileInfo, file *ast.File) bool {
+	f.imports, f.decls = nil, nil
+	f.pkgNames, f.usedNames = nil, nil
+	f.imported = map[string]bool{}
+
+	// The package names that the generated file uses regardless of the source file
+	f.reserve(fakePath, "fake")
+	f.reserve(info.ImportPath, info.PackageName)
+	f.usedNames[f.layout.PackageName(info)] = true
+
+	return true
+}
+
+func (f *faker) FilePost(info *compiler.FileInfo, file *ast.File) error {
+	if len(f.decls) == 0 {
+		return nil
+	}
+
+	// Imports
+	importDecl := &ast.GenDecl{
+		Tok:   token.IMPORT,
+		Specs: f.imports,
+	}
+
+	importDecl.Specs = append(importDecl.Specs,
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Value: fmt.Sprintf("%q", fakePath),
+			},
+		},
+	)
+
+	newFile := &ast.File{
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{Slash: 1, Text: "// DO NOT EDIT"},
+				{Slash: 16, Text: "// Code generated by Gelato"},
+			},
+		},
+		Package: 45,
+		Name: &ast.Ident{
+			NamePos: 53,
+			Name:    f.layout.PackageName(info),
+		},
+		Decls: append([]ast.Decl{importDecl}, f.decls...),
+	}
+
+	filePath := f.layout.FilePath(info, "_fake")
+	if err := compiler.WriteFile(filePath, info.FileSet, newFile); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *faker) Import(info *compiler.FileInfo, spec *ast.ImportSpec) {
+	// Use the same package names as the source file where possible.
+	// The packages are only imported if the generated file uses them.
+	if pkgName := importedPkgName(info, spec); pkgName != nil {
+		if name := pkgName.Name(); name != "_" && name != "." {
+			f.reserve(pkgName.Imported().Path(), name)
+		}
+	}
+}
+
+func (f *faker) Interface(info *compiler.TypeInfo, node *ast.InterfaceType) {
+	// Repository interfaces can only be recognized using the type information
+	iface, ok := info.TypeOf(node).(*types.Interface)
+	if !ok || !iface.IsMethodSet() {
+		return
+	}
+
+	key, value := inferEntity(iface)
+	if key == nil || value == nil {
+		f.logger.Yellow.Debugf("          Skipped %s: not a repository interface (at least Create and Get methods are needed)", info.TypeName)
+		return
+	}
+
+	keyType := compiler.TypeExpr(key, f.qualify)
+	valueType := compiler.TypeExpr(value, f.qualify)
+
+	decls := []ast.Decl{}
+	decls = append(decls, createFakeStructDecl(info.TypeName, keyType, valueType))
+	decls = append(decls, createFakeFuncDecl(info.TypeName, keyType, valueType, keyField(key, value)))
+
+	for i := 0; i < iface.NumMethods(); i++ {
+		decls = append(decls, f.createFakeMethodDecl(info.TypeName, iface.Method(i), key, value))
+	}
+
+	// Fakes for generic interfaces are generic too
+	compiler.MakeGeneric(decls, f.typeParams(info), info.PackageName, info.TypeName)
+
+	f.decls = append(f.decls, decls...)
+}
+
+// typeParams returns the type parameters of a generic type with their constraints qualified for the generated file.
+// The constraints are used as they are if the type information is not known.
+func (f *faker) typeParams(info *compiler.TypeInfo) *ast.FieldList {
+	if info.TypeParams == nil {
+		return nil
+	}
+
+	typeParams := &ast.FieldList{}
+	for _, field := range info.TypeParams.List {
+		constraint := field.Type
+		if t := info.TypeOf(field.Type); t != nil {
+			constraint = compiler.TypeExpr(t, f.qualify)
+		}
+
+		typeParams.List = append(typeParams.List, &ast.Field{
+			Names: field.Names,
+			Type:  constraint,
+		})
+	}
+
+	return typeParams
+}
+
+// reserve assigns a package name to an import path in the generated file.
+func (f *faker) reserve(path, name string) {
+	if f.pkgNames == nil {
+		f.pkgNames, f.usedNames = map[string]string{}, map[string]bool{}
+	}
+
+	if _, ok := f.pkgNames[path]; !ok && !f.usedNames[name] {
+		f.pkgNames[path] = name
+		f.usedNames[name] = true
+	}
+}
+
+// qualify returns the package name for qualifying the types from a package in the generated file.
+// The package is imported if it is not already imported.
+func (f *faker) qualify(pkg *types.Package) string {
+	name, ok := f.pkgNames[pkg.Path()]
+	if !ok {
+		name = pkg.Name()
+		for i := 2; f.usedNames[name]; i++ {
+			name = fmt.Sprintf("%s%d", pkg.Name(), i)
+		}
+
+		f.reserve(pkg.Path(), name)
+	}
+
+	if !f.imported[pkg.Path()] {
+		f.imported[pkg.Path()] = true
+
+		spec := &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Value: fmt.Sprintf("%q", pkg.Path()),
+			},
+		}
+
+		if name != pkg.Name() {
+			spec.Name = &ast.Ident{Name: name}
+		}
+
+		f.imports = append(f.imports, spec)
+	}
+
+	return name
+}
+
+// createFakeMethodDecl creates the implementation of an interface method.
+// CRUD methods are implemented using the store and other methods return zero values.
+func (f *faker) createFakeMethodDecl(typeName string, method *types.Func, key, value types.Type) ast.Decl {
+	sig := method.Type().(*types.Signature)
+	funcType := compiler.TypeExpr(sig, f.qualify).(*ast.FuncType)
+
+	// The parameters are named, so they can be used in the body.
+	// A parameter can be named key or value only if it is assignable from the variable with the same name in the body.
+	used := map[string]bool{"f": true, "values": true, "err": true}
+	for name := range f.usedNames {
+		used[name] = true
+	}
+
+	args := make([]ast.Expr, len(funcType.Params.List))
+	for i, field := range funcType.Params.List {
+		v := sig.Params().At(i)
+		name := v.Name()
+		switch {
+		case name == "" || name == "_" || used[name],
+			name == "key" && !types.Identical(v.Type(), key),
+			name == "value" && !types.Identical(v.Type(), value):
+			name = fmt.Sprintf("arg%d", i)
+		}
+		for used[name] {
+			name += "_"
+		}
+
+		used[name] = true
+		field.Names = []*ast.Ident{{Name: name}}
+		args[i] = &ast.Ident{Name: name}
+	}
+
+	// The results are not named, so they do not conflict with the variables in the body
+	for _, field := range funcType.Results.List {
+		field.Names = nil
+	}
+
+	body := &ast.BlockStmt{
+		List: createFakeMethodStmts(method.Name(), sig, funcType, args, key, value),
+	}
+
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						{Name: "f"},
+					},
+					Type: &ast.StarExpr{
+						X: &ast.Ident{Name: typeName + "Fake"},
+					},
+				},
+			},
+		},
+		Name: &ast.Ident{Name: method.Name()},
+		Type: funcType,
+		Body: body,
+	}
+}
+
+func createFakeStructDecl(typeName string, keyType, valueType ast.Expr) ast.Decl {
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: typeName + "Fake",
+				},
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "store"},
+								},
+								Type: &ast.StarExpr{
+									X: createStoreTypeExpr(keyType, valueType),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createFakeFuncDecl(typeName string, keyType, valueType ast.Expr, keyField string) ast.Decl {
+	var keyOf, setKey ast.Expr = &ast.Ident{Name: "nil"}, &ast.Ident{Name: "nil"}
+
+	if keyField != "" {
+		keyOf = &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{{Name: "v"}},
+							Type:  valueType,
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Type: keyType},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   &ast.Ident{Name: "v"},
+								Sel: &ast.Ident{Name: keyField},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		setKey = &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{{Name: "v"}},
+							Type:  &ast.StarExpr{X: valueType},
+						},
+						{
+							Names: []*ast.Ident{{Name: "k"}},
+							Type:  keyType,
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							&ast.SelectorExpr{
+								X: &ast.ParenExpr{
+									X: &ast.StarExpr{
+										X: &ast.Ident{Name: "v"},
+									},
+								},
+								Sel: &ast.Ident{Name: keyField},
+							},
+						},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{
+							&ast.Ident{Name: "k"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return &ast.FuncDecl{
+		Name: &ast.Ident{
+			Name: "Fake" + typeName,
+		},
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.StarExpr{
+							X: &ast.Ident{Name: typeName + "Fake"},
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.UnaryExpr{
+							Op: token.AND,
+							X: &ast.CompositeLit{
+								Type: &ast.Ident{Name: typeName + "Fake"},
+								Elts: []ast.Expr{
+									&ast.KeyValueExpr{
+										Key: &ast.Ident{Name: "store"},
+										Value: &ast.CallExpr{
+											Fun: &ast.IndexListExpr{
+												X: &ast.SelectorExpr{
+													X:   &ast.Ident{Name: "fake"},
+													Sel: &ast.Ident{Name: "NewStore"},
+												},
+												Indices: []ast.Expr{keyType, valueType},
+											},
+											Args: []ast.Expr{keyOf, setKey},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createStoreTypeExpr(keyType, valueType ast.Expr) ast.Expr {
+	return &ast.IndexListExpr{
+		X: &ast.SelectorExpr{
+			X:   &ast.Ident{Name: "fake"},
+			Sel: &ast.Ident{Name: "Store"},
+		},
+		Indices: []ast.Expr{keyType, valueType},
+	}
+}
+
+// createFakeMethodStmts creates the statements implementing a method.
+// A CRUD method calls the store if its parameters are the key and/or the value of an entity.
+// The results are returned by their types and any other result is a zero value.
+// Entities are passed to and returned from the store by value or by pointer as the store keeps them.
+func createFakeMethodStmts(name string, sig *types.Signature, funcType *ast.FuncType, args []ast.Expr, key, value types.Type) []ast.Stmt {
+	isKey := func(t types.Type) bool { return types.Identical(t, key) }
+	isValue := func(t types.Type) bool { return isEntity(t, value) }
+
+	var ps []types.Type
+	var pargs []ast.Expr
+	for _, i := range params(sig) {
+		ps = append(ps, sig.Params().At(i).Type())
+		pargs = append(pargs, args[i])
+	}
+
+	// valueArg dereferences a parameter if it is a pointer to an entity stored by value
+	valueArg := func(i int) ast.Expr {
+		if isPointerTo(ps[i], value) {
+			return &ast.StarExpr{X: pargs[i]}
+		}
+		return pargs[i]
+	}
+
+	var storeMethod string
+	var storeArgs, lhs []ast.Expr
+	var keyExpr, valueExpr ast.Expr
+
+	keyVar, valueVar := &ast.Ident{Name: "key"}, &ast.Ident{Name: "value"}
+	valuesVar, errVar := &ast.Ident{Name: "values"}, &ast.Ident{Name: "err"}
+
+	switch op := operationOf(name); {
+	case (op == opCreate || op == opUpdate) && len(ps) == 2 && isKey(ps[0]) && isValue(ps[1]):
+		storeMethod = "CreateWithKey"
+		if op == opUpdate {
+			storeMethod = "UpdateWithKey"
+		}
+		storeArgs, lhs = []ast.Expr{pargs[0], valueArg(1)}, []ast.Expr{errVar}
+		keyExpr, valueExpr = pargs[0], valueArg(1)
+
+	case op == opCreate && len(ps) == 1 && isValue(ps[0]):
+		storeMethod, storeArgs, lhs = "Create", []ast.Expr{valueArg(0)}, []ast.Expr{keyVar, valueVar, errVar}
+		keyExpr, valueExpr = keyVar, valueVar
+
+	case op == opUpdate && len(ps) == 1 && isValue(ps[0]):
+		storeMethod, storeArgs, lhs = "Update", []ast.Expr{valueArg(0)}, []ast.Expr{errVar}
+		valueExpr = valueArg(0)
+
+	case op == opGet && len(ps) == 1 && isKey(ps[0]):
+		storeMethod, storeArgs, lhs = "Get", pargs, []ast.Expr{valueVar, errVar}
+		keyExpr, valueExpr = pargs[0], valueVar
+
+	case op == opDelete && len(ps) == 1 && isKey(ps[0]):
+		storeMethod, storeArgs, lhs = "Delete", pargs, []ast.Expr{errVar}
+		keyExpr = pargs[0]
+
+	case op == opList:
+		storeMethod, lhs = "List", []ast.Expr{valuesVar}
+	}
+
+	// Results
+	used := map[ast.Expr]bool{}
+	use := func(expr ast.Expr) ast.Expr {
+		used[expr] = true
+		return expr
+	}
+
+	// A pointer to an entity is only returned if the store returns no error
+	var checkErr bool
+
+	returnStmt := &ast.ReturnStmt{}
+	for i, field := range funcType.Results.List {
+		t := sig.Results().At(i).Type()
+		s, isSlice := t.(*types.Slice)
+
+		var expr ast.Expr
+		switch {
+		case storeMethod != "" && isError(t):
+			if storeMethod == "List" {
+				expr = &ast.Ident{Name: "nil"}
+			} else {
+				expr = use(errVar)
+			}
+		case keyExpr != nil && isKey(t) && (valueExpr == nil || !isValue(t) || storeMethod == "Create"):
+			expr = use(keyExpr)
+		case valueExpr != nil && isValue(t):
+			expr = use(valueExpr)
+			switch star, ok := expr.(*ast.StarExpr); {
+			case types.Identical(t, value):
+			case ok:
+				expr = star.X
+			default:
+				expr = &ast.UnaryExpr{Op: token.AND, X: expr}
+				checkErr = true
+				use(errVar)
+			}
+		case storeMethod == "List" && isSlice && isValue(s.Elem()):
+			expr = use(valuesVar)
+			if !types.Identical(s.Elem(), value) {
+				expr = &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   &ast.Ident{Name: "fake"},
+						Sel: &ast.Ident{Name: "Pointers"},
+					},
+					Args: []ast.Expr{expr},
+				}
+			}
+		default:
+			expr = compiler.ZeroValueExpr(field.Type, t)
+		}
+
+		returnStmt.Results = append(returnStmt.Results, expr)
+	}
+
+	stmts := []ast.Stmt{}
+
+	// The store is called even if its results are not used.
+	// The variables are defined unless they are all blank or parameters.
+	if storeMethod != "" {
+		isParam := map[string]bool{}
+		for _, arg := range args {
+			isParam[arg.(*ast.Ident).Name] = true
+		}
+
+		tok := token.ASSIGN
+		for i, expr := range lhs {
+			if !used[expr] {
+				lhs[i] = &ast.Ident{Name: "_"}
+			} else if !isParam[expr.(*ast.Ident).Name] {
+				tok = token.DEFINE
+			}
+		}
+
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: lhs,
+			Tok: tok,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "f"},
+							Sel: &ast.Ident{Name: "store"},
+						},
+						Sel: &ast.Ident{Name: storeMethod},
+					},
+					Args: storeArgs,
+				},
+			},
+		})
+	}
+
+	if checkErr {
+		errResults := []ast.Expr{}
+		for i, field := range funcType.Results.List {
+			if t := sig.Results().At(i).Type(); isError(t) {
+				errResults = append(errResults, errVar)
+			} else {
+				errResults = append(errResults, compiler.ZeroValueExpr(field.Type, t))
+			}
+		}
+
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  errVar,
+				Op: token.NEQ,
+				Y:  &ast.Ident{Name: "nil"},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{Results: errResults},
+				},
+			},
+		})
+	}
+
+	if len(returnStmt.Results) > 0 {
+		stmts = append(stmts, returnStmt)
+	}
+
+	return stmts
+}
@@ -0,0 +1,461 @@
+package faker
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/log"
+	"github.com/moorara/gelato/internal/service/compiler"
+	"github.com/moorara/gelato/internal/service/compiler/compilertest"
+)
+
+func TestNew(t *testing.T) {
+	c := New(log.Info, compiler.DefaultLayout())
+
+	assert.NotNil(t, c)
+	assert.IsType(t, &compiler.Compiler{}, c)
+}
+
+func TestFaker_Package(t *testing.T) {
+	tests := []struct {
+		name             string
+		info             *compiler.PackageInfo
+		pkg              *ast.Package
+		expectedContinue bool
+	}{
+		{
+			name: "OK",
+			info: &compiler.PackageInfo{},
+			pkg: &ast.Package{
+				Name: "repository",
+			},
+			expectedContinue: true,
+		},
+		{
+			name: "FilterMainPackage",
+			info: &compiler.PackageInfo{},
+			pkg: &ast.Package{
+				Name: "main",
+			},
+			expectedContinue: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &faker{}
+
+			cont := f.Package(tc.info, tc.pkg)
+
+			assert.Equal(t, tc.expectedContinue, cont)
+		})
+	}
+}
+
+func TestFaker_FilePre(t *testing.T) {
+	tests := []struct {
+		name             string
+		info             *compiler.FileInfo
+		file             *ast.File
+		expectedContinue bool
+	}{
+		{
+			name:             "OK",
+			info:             &compiler.FileInfo{},
+			file:             &ast.File{},
+			expectedContinue: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &faker{}
+
+			cont := f.FilePre(tc.info, tc.file)
+
+			assert.Equal(t, tc.expectedContinue, cont)
+			assert.Equal(t, "fake", f.pkgNames[fakePath])
+		})
+	}
+}
+
+func TestFaker_FilePost(t *testing.T) {
+	decls := []ast.Decl{
+		&ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{
+						{Name: "dummy"},
+					},
+					Type: &ast.Ident{Name: "string"},
+				},
+			},
+		},
+	}
+
+	info := &compiler.FileInfo{
+		PackageInfo: compiler.PackageInfo{
+			ModuleName:  "github.com/octocat/service",
+			PackageName: "repository",
+			ImportPath:  "github.com/octocat/service/internal/repository",
+			BaseDir:     "./service",
+			RelativeDir: "internal/repository",
+		},
+		FileName: "repository.go",
+		FileSet:  token.NewFileSet(),
+	}
+
+	tests := []struct {
+		name          string
+		layout        compiler.Layout
+		decls         []ast.Decl
+		info          *compiler.FileInfo
+		file          *ast.File
+		expectedFile  string
+		expectedError string
+	}{
+		{
+			name:          "NoDeclaration",
+			layout:        compiler.DefaultLayout(),
+			decls:         nil,
+			info:          &compiler.FileInfo{},
+			file:          &ast.File{},
+			expectedError: "",
+		},
+		{
+			name:   "WriteFileFails",
+			layout: compiler.DefaultLayout(),
+			decls:  decls,
+			info: &compiler.FileInfo{
+				PackageInfo: compiler.PackageInfo{
+					PackageName: "repository",
+					BaseDir:     "/dev/null",
+					RelativeDir: "internal/repository",
+				},
+				FileName: "repository.go",
+				FileSet:  token.NewFileSet(),
+			},
+			file:          &ast.File{},
+			expectedError: "mkdir /dev/null: not a directory",
+		},
+		{
+			name:          "Success",
+			layout:        compiler.DefaultLayout(),
+			decls:         decls,
+			info:          info,
+			file:          &ast.File{},
+			expectedFile:  "service/.gen/internal/repositorytest/repository_fake.go",
+			expectedError: "",
+		},
+		{
+			name:          "Success_NextToSource",
+			layout:        compiler.Layout{},
+			decls:         decls,
+			info:          info,
+			file:          &ast.File{},
+			expectedFile:  "service/internal/repository/repository_fake_test.go",
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &faker{
+				layout: tc.layout,
+				decls:  tc.decls,
+			}
+
+			err := f.FilePost(tc.info, tc.file)
+
+			// Cleanup
+			defer os.RemoveAll("./service")
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				if tc.expectedFile != "" {
+					assert.FileExists(t, tc.expectedFile)
+				}
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestFaker_Import(t *testing.T) {
+	tests := []struct {
+		name            string
+		info            *compiler.FileInfo
+		spec            *ast.ImportSpec
+		expectedImports []ast.Spec
+	}{
+		{
+			name: "OK",
+			info: &compiler.FileInfo{},
+			spec: &ast.ImportSpec{
+				Path: &ast.BasicLit{Value: `"fmt"`},
+			},
+			expectedImports: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &faker{}
+
+			f.Import(tc.info, tc.spec)
+
+			assert.Equal(t, tc.expectedImports, f.imports)
+		})
+	}
+}
+
+func TestFaker_Interface(t *testing.T) {
+	tests := []struct {
+		name          string
+		info          *compiler.TypeInfo
+		node          *ast.InterfaceType
+		expectedDecls []ast.Decl
+	}{
+		{
+			name: "NoTypeInfo",
+			info: &compiler.TypeInfo{
+				FileInfo: compiler.FileInfo{
+					PackageInfo: compiler.PackageInfo{
+						PackageName: "repository",
+					},
+				},
+				TypeName: "UserRepository",
+			},
+			node: &ast.InterfaceType{
+				Methods: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								{Name: "Get"},
+							},
+							Type: &ast.FuncType{
+								Params: &ast.FieldList{
+									List: []*ast.Field{
+										{
+											Type: &ast.Ident{Name: "string"},
+										},
+									},
+								},
+								Results: &ast.FieldList{
+									List: []*ast.Field{
+										{
+											Type: &ast.StarExpr{
+												X: &ast.Ident{Name: "User"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedDecls: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &faker{}
+
+			f.Interface(tc.info, tc.node)
+
+			assert.Equal(t, tc.expectedDecls, f.decls)
+		})
+	}
+}
+
+const fakeRepoSrc = `package repository
+
+import (
+	"context"
+	"time"
+)
+
+// User is a user entity.
+type User struct {
+	ID   string
+	Name string
+}
+
+// UserRepository is a repository for users.
+type UserRepository interface {
+	Create(ctx context.Context, user *User) (*User, error)
+	Get(ctx context.Context, id string) (*User, error)
+	List(ctx context.Context) ([]*User, error)
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id string) error
+	Count(ctx context.Context) (int, error)
+}
+
+// GreetingRepository is a repository for greetings.
+type GreetingRepository interface {
+	Create(ctx context.Context, greeting string) (time.Time, error)
+	Get(ctx context.Context, t time.Time) (string, error)
+}
+
+// Greeting is a greeting entity.
+type Greeting struct {
+	ID   int
+	Text string
+}
+
+// GreetingStore is a repository for greetings stored by value and returned by pointer.
+type GreetingStore interface {
+	Create(ctx context.Context, greeting Greeting) (*Greeting, error)
+	Get(ctx context.Context, id int) (*Greeting, error)
+	List(ctx context.Context) ([]*Greeting, error)
+	Update(ctx context.Context, greeting *Greeting) error
+}
+
+// Cache is not a repository interface.
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+	Each(fn func(K, V) bool)
+}
+
+// Item is a generic item.
+type Item[T any] struct {
+	Key   int
+	Value T
+}
+
+// ItemRepository is a generic repository for items.
+type ItemRepository[T any] interface {
+	CreateItem(key int, item Item[T]) error
+	GetItem(key int) (Item[T], error)
+	ListItems() []Item[T]
+	DeleteItem(key int) error
+}
+`
+
+const fakeTestSrc = `package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/moorara/gelato/pkg/fake"
+
+	"example.com/fake/repository"
+)
+
+func TestUserRepository(t *testing.T) {
+	var r repository.UserRepository = FakeUserRepository()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if u, err := r.Create(ctx, &repository.User{Name: "octocat"}); err != nil || u.ID == "" {
+				t.Errorf("unexpected user %v and error %v", u, err)
+			}
+			_, _ = r.List(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	users, err := r.List(ctx)
+	if err != nil || len(users) != 50 {
+		t.Fatalf("expected 50 users, got %d", len(users))
+	}
+
+	id := users[0].ID
+	if err := r.Update(ctx, &repository.User{ID: id, Name: "hubot"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if u, err := r.Get(ctx, id); err != nil || u.Name != "hubot" {
+		t.Fatalf("unexpected user %v and error %v", u, err)
+	}
+
+	if err := r.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Get(ctx, id); !errors.Is(err, fake.ErrNotFound) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestGreetingRepository(t *testing.T) {
+	var r repository.GreetingRepository = FakeGreetingRepository()
+	ctx := context.Background()
+
+	ts, err := r.Create(ctx, "Hello, World!")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g, err := r.Get(ctx, ts); err != nil || g != "Hello, World!" {
+		t.Fatalf("unexpected greeting %q and error %v", g, err)
+	}
+}
+
+func TestGreetingStore(t *testing.T) {
+	var r repository.GreetingStore = FakeGreetingStore()
+	ctx := context.Background()
+
+	g, err := r.Create(ctx, repository.Greeting{Text: "Hello, World!"})
+	if err != nil || g == nil || g.ID == 0 {
+		t.Fatalf("unexpected greeting %v and error %v", g, err)
+	}
+
+	if err := r.Update(ctx, &repository.Greeting{ID: g.ID, Text: "Hi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if g, err := r.Get(ctx, g.ID); err != nil || g == nil || g.Text != "Hi" {
+		t.Fatalf("unexpected greeting %v and error %v", g, err)
+	}
+
+	if g, err := r.Get(ctx, 100); g != nil || !errors.Is(err, fake.ErrNotFound) {
+		t.Fatalf("unexpected greeting %v and error %v", g, err)
+	}
+
+	if gs, err := r.List(ctx); err != nil || len(gs) != 1 || gs[0].Text != "Hi" {
+		t.Fatalf("unexpected greetings %v and error %v", gs, err)
+	}
+}
+
+func TestItemRepository(t *testing.T) {
+	var r repository.ItemRepository[string] = FakeItemRepository[string]()
+
+	if err := r.CreateItem(1, repository.Item[string]{Key: 1, Value: "foo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.CreateItem(1, repository.Item[string]{Key: 1, Value: "bar"}); !errors.Is(err, fake.ErrExists) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if items := r.ListItems(); len(items) != 1 || items[0].Value != "foo" {
+		t.Fatalf("unexpected items %v", items)
+	}
+}
+`
+
+// TestFaker_Generate generates fakes for a module and runs a concurrent test using the fakes with the race detector.
+func TestFaker_Generate(t *testing.T) {
+	compilertest.GoTest(t, New(log.None, compiler.Layout{}), "example.com/fake", map[string]string{
+		"repository/repository.go":      fakeRepoSrc,
+		"repository/repository_test.go": fakeTestSrc,
+	})
+}
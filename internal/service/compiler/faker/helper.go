@@ -0,0 +1,234 @@
+package faker
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moorara/gelato/internal/service/compiler"
+)
+
+type operation int
+
+const (
+	opNone operation = iota
+	opCreate
+	opGet
+	opList
+	opUpdate
+	opDelete
+)
+
+var operationPrefixes = []struct {
+	prefix string
+	op     operation
+}{
+	{"Create", opCreate},
+	{"Get", opGet},
+	{"List", opList},
+	{"Update", opUpdate},
+	{"Delete", opDelete},
+}
+
+// importedPkgName returns the package name declared by an import spec or nil if it is not known.
+func importedPkgName(info *compiler.FileInfo, spec *ast.ImportSpec) *types.PkgName {
+	if info.TypesInfo == nil {
+		return nil
+	}
+
+	var obj types.Object
+	if spec.Name != nil {
+		obj = info.TypesInfo.Defs[spec.Name]
+	} else {
+		obj = info.TypesInfo.Implicits[spec]
+	}
+
+	pkgName, _ := obj.(*types.PkgName)
+	return pkgName
+}
+
+// operationOf returns the CRUD operation of a method by its name (e.g. Get, GetUser, but not Getter).
+func operationOf(name string) operation {
+	for _, p := range operationPrefixes {
+		if rest := strings.TrimPrefix(name, p.prefix); rest != name {
+			if r, _ := utf8.DecodeRuneInString(rest); rest == "" || !unicode.IsLower(r) {
+				return p.op
+			}
+		}
+	}
+
+	return opNone
+}
+
+func isContext(t types.Type) bool {
+	if n, ok := t.(*types.Named); ok {
+		obj := n.Obj()
+		return obj.Pkg() != nil && obj.Pkg().Path() == "context" && obj.Name() == "Context"
+	}
+
+	return false
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// isPointerTo determines whether or not a type is a pointer to another type.
+func isPointerTo(t, elem types.Type) bool {
+	p, ok := t.(*types.Pointer)
+	return ok && types.Identical(p.Elem(), elem)
+}
+
+// isEntity determines whether or not a type is an entity type or a pointer to it.
+func isEntity(t, value types.Type) bool {
+	return types.Identical(t, value) || isPointerTo(t, value)
+}
+
+// hasOperation determines whether or not an interface has a method for a CRUD operation.
+func hasOperation(iface *types.Interface, op operation) bool {
+	for i := 0; i < iface.NumMethods(); i++ {
+		if operationOf(iface.Method(i).Name()) == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// params returns the indices of the parameters of a method that are not contexts.
+func params(sig *types.Signature) []int {
+	indices := []int{}
+	for i := 0; i < sig.Params().Len(); i++ {
+		if !isContext(sig.Params().At(i).Type()) {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// results returns the indices of the results of a method that are not errors.
+func results(sig *types.Signature) []int {
+	indices := []int{}
+	for i := 0; i < sig.Results().Len(); i++ {
+		if !isError(sig.Results().At(i).Type()) {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// inferEntity infers the key and value types of the entities of a repository interface from its CRUD methods.
+// The value type is inferred from the entities created, updated, or returned.
+// The key type is inferred from the keys that entities are created, retrieved, or deleted by.
+// Entities are stored by value if any method passes or returns them by value, and by pointer otherwise.
+// It returns nil types if the interface is not a repository interface (with at least a Create and a Get method).
+func inferEntity(iface *types.Interface) (types.Type, types.Type) {
+	if !hasOperation(iface, opCreate) || !hasOperation(iface, opGet) {
+		return nil, nil
+	}
+
+	var key, value types.Type
+
+	find := func(ops []operation, f func(operation, *types.Signature, []int, []int) types.Type) types.Type {
+		for _, op := range ops {
+			for i := 0; i < iface.NumMethods(); i++ {
+				method := iface.Method(i)
+				if operationOf(method.Name()) == op {
+					sig := method.Type().(*types.Signature)
+					if t := f(op, sig, params(sig), results(sig)); t != nil {
+						return t
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+
+	value = find([]operation{opCreate, opUpdate, opGet, opList}, func(op operation, sig *types.Signature, ps, rs []int) types.Type {
+		switch {
+		case (op == opCreate || op == opUpdate) && len(ps) > 0:
+			return sig.Params().At(ps[len(ps)-1]).Type()
+		case op == opGet && len(rs) > 0:
+			return sig.Results().At(rs[0]).Type()
+		case op == opList && len(rs) > 0:
+			if s, ok := sig.Results().At(rs[0]).Type().(*types.Slice); ok {
+				return s.Elem()
+			}
+		}
+		return nil
+	})
+
+	if value == nil {
+		return nil, nil
+	}
+
+	if p, ok := value.(*types.Pointer); ok {
+		byValue := find([]operation{opCreate, opUpdate, opGet, opList}, func(op operation, sig *types.Signature, ps, rs []int) types.Type {
+			for _, i := range ps {
+				if types.Identical(sig.Params().At(i).Type(), p.Elem()) {
+					return p.Elem()
+				}
+			}
+			for _, i := range rs {
+				t := sig.Results().At(i).Type()
+				if s, ok := t.(*types.Slice); ok {
+					t = s.Elem()
+				}
+				if types.Identical(t, p.Elem()) {
+					return p.Elem()
+				}
+			}
+			return nil
+		})
+
+		if byValue != nil {
+			value = byValue
+		}
+	}
+
+	key = find([]operation{opGet, opDelete, opCreate, opUpdate}, func(op operation, sig *types.Signature, ps, rs []int) types.Type {
+		switch {
+		case (op == opGet || op == opDelete) && len(ps) == 1:
+			return sig.Params().At(ps[0]).Type()
+		case (op == opCreate || op == opUpdate) && len(ps) == 2:
+			return sig.Params().At(ps[0]).Type()
+		case op == opCreate && len(rs) > 0 && !isEntity(sig.Results().At(rs[0]).Type(), value):
+			return sig.Results().At(rs[0]).Type()
+		}
+		return nil
+	})
+
+	if key == nil || !types.Comparable(key) {
+		return nil, nil
+	}
+
+	return key, value
+}
+
+// keyField returns the name of the field holding the key of an entity or an empty string if there is no such field.
+// The key field is an exported ID, Id, or Key field of the key type in a struct or pointer to struct.
+func keyField(key, value types.Type) string {
+	if p, ok := value.(*types.Pointer); ok {
+		value = p.Elem()
+	}
+
+	s, ok := value.Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+
+	for _, name := range []string{"ID", "Id", "Key"} {
+		for i := 0; i < s.NumFields(); i++ {
+			if f := s.Field(i); f.Name() == name && types.Identical(f.Type(), key) {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,244 @@
+package faker
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const repoSrc = `package repo
+
+import (
+	"context"
+	"time"
+)
+
+type User struct {
+	ID   string
+	Name string
+}
+
+type Item struct {
+	Key  int
+	Name string
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, user *User) (*User, error)
+	Get(ctx context.Context, id string) (*User, error)
+	List(ctx context.Context) ([]*User, error)
+	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id string) error
+}
+
+type GreetingRepository interface {
+	Create(ctx context.Context, greeting string) (time.Time, error)
+	Get(ctx context.Context, t time.Time) (string, error)
+}
+
+type ItemStore interface {
+	CreateItem(int, Item) error
+	GetItem(int) (Item, error)
+	ListItems() []Item
+}
+
+type GreetingStore interface {
+	Create(ctx context.Context, greeting Greeting) (*Greeting, error)
+	Get(ctx context.Context, id int) (*Greeting, error)
+	List(ctx context.Context) ([]*Greeting, error)
+}
+
+type Greeting struct {
+	ID   int
+	Text string
+}
+
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+	Each(fn func(K, V) bool)
+}
+
+type ListStore interface {
+	Create(value string) (int, error)
+	List() []string
+}
+
+type ValueStore interface {
+	Create(value []byte) error
+	Get() ([]byte, error)
+}
+
+type SliceStore interface {
+	Create(key []byte, value string) error
+	Get(key []byte) (string, error)
+}
+
+type Getter interface {
+	Getter() string
+}
+`
+
+func checkRepoSrc(t *testing.T) *types.Package {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "repo.go", repoSrc, 0)
+	assert.NoError(t, err)
+
+	config := &types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+	}
+
+	pkg, err := config.Check("example.com/repo", fset, []*ast.File{file}, nil)
+	assert.NoError(t, err)
+
+	return pkg
+}
+
+func lookupType(pkg *types.Package, name string) types.Type {
+	return pkg.Scope().Lookup(name).Type()
+}
+
+func TestOperationOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		expectedOp operation
+	}{
+		{"Create", opCreate},
+		{"CreateUser", opCreate},
+		{"Get", opGet},
+		{"GetByID", opGet},
+		{"Getter", opNone},
+		{"List", opList},
+		{"ListUsers", opList},
+		{"Update", opUpdate},
+		{"Delete", opDelete},
+		{"Count", opNone},
+		{"get", opNone},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedOp, operationOf(tc.name))
+		})
+	}
+}
+
+func TestInferEntity(t *testing.T) {
+	pkg := checkRepoSrc(t)
+
+	tests := []struct {
+		name          string
+		typeName      string
+		expectedKey   string
+		expectedValue string
+	}{
+		{
+			name:          "UserRepository",
+			typeName:      "UserRepository",
+			expectedKey:   "string",
+			expectedValue: "*example.com/repo.User",
+		},
+		{
+			name:          "GreetingRepository",
+			typeName:      "GreetingRepository",
+			expectedKey:   "time.Time",
+			expectedValue: "string",
+		},
+		{
+			name:          "ItemStore",
+			typeName:      "ItemStore",
+			expectedKey:   "int",
+			expectedValue: "example.com/repo.Item",
+		},
+		{
+			name:          "EntityByValue",
+			typeName:      "GreetingStore",
+			expectedKey:   "int",
+			expectedValue: "example.com/repo.Greeting",
+		},
+		{
+			name:     "NoGet",
+			typeName: "ListStore",
+		},
+		{
+			name:     "NoCreate",
+			typeName: "Cache",
+		},
+		{
+			name:     "NoKey",
+			typeName: "ValueStore",
+		},
+		{
+			name:     "KeyNotComparable",
+			typeName: "SliceStore",
+		},
+		{
+			name:     "NotRepository",
+			typeName: "Getter",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			iface := lookupType(pkg, tc.typeName).Underlying().(*types.Interface)
+			key, value := inferEntity(iface)
+
+			if tc.expectedKey == "" {
+				assert.Nil(t, key)
+				assert.Nil(t, value)
+			} else {
+				assert.Equal(t, tc.expectedKey, key.String())
+				assert.Equal(t, tc.expectedValue, value.String())
+			}
+		})
+	}
+}
+
+func TestKeyField(t *testing.T) {
+	pkg := checkRepoSrc(t)
+	user := lookupType(pkg, "User")
+	item := lookupType(pkg, "Item")
+
+	tests := []struct {
+		name          string
+		key           types.Type
+		value         types.Type
+		expectedField string
+	}{
+		{
+			name:          "Struct",
+			key:           types.Typ[types.String],
+			value:         user,
+			expectedField: "ID",
+		},
+		{
+			name:          "Pointer",
+			key:           types.Typ[types.Int],
+			value:         types.NewPointer(item),
+			expectedField: "Key",
+		},
+		{
+			name:          "KeyTypeMismatch",
+			key:           types.Typ[types.Int],
+			value:         user,
+			expectedField: "",
+		},
+		{
+			name:          "NotStruct",
+			key:           types.Typ[types.Int],
+			value:         types.Typ[types.String],
+			expectedField: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedField, keyField(tc.key, tc.value))
+		})
+	}
+}
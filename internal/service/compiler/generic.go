@@ -0,0 +1,96 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// TypeArgs returns the type arguments for instantiating a generic type with its own type parameters.
+func TypeArgs(typeParams *ast.FieldList) []ast.Expr {
+	args := []ast.Expr{}
+
+	if typeParams == nil {
+		return args
+	}
+
+	for _, field := range typeParams.List {
+		for _, id := range field.Names {
+			args = append(args, &ast.Ident{Name: id.Name})
+		}
+	}
+
+	return args
+}
+
+// Instantiate creates the instantiation of a generic type or function with a list of type arguments.
+// The type or function itself is returned if there is no type argument.
+func Instantiate(expr ast.Expr, args []ast.Expr) ast.Expr {
+	switch len(args) {
+	case 0:
+		return expr
+	case 1:
+		return &ast.IndexExpr{X: expr, Index: args[0]}
+	default:
+		return &ast.IndexListExpr{X: expr, Indices: args}
+	}
+}
+
+// MakeGeneric makes the declarations generated for a generic type generic with the same type parameters.
+// The type parameters are added to all types and functions (not methods) declared,
+// and every reference to them or to the generic type (pkgName.typeName) is instantiated with the type parameters.
+func MakeGeneric(decls []ast.Decl, typeParams *ast.FieldList, pkgName, typeName string) {
+	args := TypeArgs(typeParams)
+	if len(args) == 0 {
+		return
+	}
+
+	generics := map[string]bool{}
+
+	for _, decl := range decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok == token.TYPE {
+				for _, spec := range d.Specs {
+					if s, ok := spec.(*ast.TypeSpec); ok {
+						s.TypeParams = typeParams
+						generics[s.Name.Name] = true
+					}
+				}
+			}
+
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				d.Type.TypeParams = typeParams
+				generics[d.Name.Name] = true
+			}
+		}
+	}
+
+	for _, decl := range decls {
+		astutil.Apply(decl, func(c *astutil.Cursor) bool {
+			switch n := c.Node().(type) {
+			case *ast.Ident:
+				// Skip the identifiers declaring or selecting names
+				switch c.Name() {
+				case "Name", "Names", "Sel", "Key":
+					return true
+				}
+
+				if generics[n.Name] {
+					c.Replace(Instantiate(n, args))
+					return false
+				}
+
+			case *ast.SelectorExpr:
+				if x, ok := n.X.(*ast.Ident); ok && x.Name == pkgName && n.Sel.Name == typeName {
+					c.Replace(Instantiate(n, args))
+					return false
+				}
+			}
+
+			return true
+		}, nil)
+	}
+}
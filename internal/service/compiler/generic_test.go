@@ -0,0 +1,258 @@
+package compiler
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	goparser "go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		typeParams   *ast.FieldList
+		expectedArgs []ast.Expr
+	}{
+		{
+			name:         "Nil",
+			typeParams:   nil,
+			expectedArgs: []ast.Expr{},
+		},
+		{
+			name: "OK",
+			typeParams: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{{Name: "K"}},
+						Type:  &ast.Ident{Name: "comparable"},
+					},
+					{
+						Names: []*ast.Ident{{Name: "V"}, {Name: "W"}},
+						Type:  &ast.Ident{Name: "any"},
+					},
+				},
+			},
+			expectedArgs: []ast.Expr{
+				&ast.Ident{Name: "K"},
+				&ast.Ident{Name: "V"},
+				&ast.Ident{Name: "W"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := TypeArgs(tc.typeParams)
+
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         ast.Expr
+		args         []ast.Expr
+		expectedExpr ast.Expr
+	}{
+		{
+			name:         "NoArg",
+			expr:         &ast.Ident{Name: "Page"},
+			args:         []ast.Expr{},
+			expectedExpr: &ast.Ident{Name: "Page"},
+		},
+		{
+			name: "OneArg",
+			expr: &ast.Ident{Name: "Page"},
+			args: []ast.Expr{
+				&ast.Ident{Name: "T"},
+			},
+			expectedExpr: &ast.IndexExpr{
+				X:     &ast.Ident{Name: "Page"},
+				Index: &ast.Ident{Name: "T"},
+			},
+		},
+		{
+			name: "MultipleArgs",
+			expr: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "cache"},
+				Sel: &ast.Ident{Name: "Map"},
+			},
+			args: []ast.Expr{
+				&ast.Ident{Name: "K"},
+				&ast.Ident{Name: "V"},
+			},
+			expectedExpr: &ast.IndexListExpr{
+				X: &ast.SelectorExpr{
+					X:   &ast.Ident{Name: "cache"},
+					Sel: &ast.Ident{Name: "Map"},
+				},
+				Indices: []ast.Expr{
+					&ast.Ident{Name: "K"},
+					&ast.Ident{Name: "V"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr := Instantiate(tc.expr, tc.args)
+
+			assert.Equal(t, tc.expectedExpr, expr)
+		})
+	}
+}
+
+func TestMakeGeneric(t *testing.T) {
+	tests := []struct {
+		name           string
+		src            string
+		typeParams     string
+		expectedOutput string
+	}{
+		{
+			name: "NoTypeParam",
+			src: `package cachetest
+
+type CacheBuilder struct {
+	v cache.Cache
+}
+
+func BuildCache() CacheBuilder {
+	return CacheBuilder{}
+}
+`,
+			typeParams: "",
+			expectedOutput: `package cachetest
+
+type CacheBuilder struct {
+	v cache.Cache
+}
+
+func BuildCache() CacheBuilder {
+	return CacheBuilder{}
+}
+`,
+		},
+		{
+			name: "OneTypeParam",
+			src: `package cachetest
+
+type CacheMocker struct {
+	expectations *CacheExpectations
+}
+
+type CacheExpectations struct {
+	Get []*CacheGetExpectation
+}
+
+func MockCache(t *testing.T) *CacheMocker {
+	return &CacheMocker{expectations: new(CacheExpectations)}
+}
+
+func (m *CacheMocker) Impl() cache.Cache {
+	return &CacheImpl{expectations: m.expectations}
+}
+
+func (e *CacheExpectations) Get() *CacheGetExpectation {
+	return nil
+}
+`,
+			typeParams: "[T any]",
+			expectedOutput: `package cachetest
+
+type CacheMocker[T any] struct {
+	expectations *CacheExpectations[T]
+}
+
+type CacheExpectations[T any] struct {
+	Get []*CacheGetExpectation
+}
+
+func MockCache[T any](t *testing.T) *CacheMocker[T] {
+	return &CacheMocker[T]{expectations: new(CacheExpectations[T])}
+}
+
+func (m *CacheMocker[T]) Impl() cache.Cache[T] {
+	return &CacheImpl{expectations: m.expectations}
+}
+
+func (e *CacheExpectations[T]) Get() *CacheGetExpectation {
+	return nil
+}
+`,
+		},
+		{
+			name: "MultipleTypeParams",
+			src: `package cachetest
+
+func Cache() cache.Cache {
+	return BuildCache().Value()
+}
+
+type CacheBuilder struct {
+	v cache.Cache
+}
+
+func BuildCache() CacheBuilder {
+	return CacheBuilder{v: cache.Cache{}}
+}
+
+func (b CacheBuilder) Value() cache.Cache {
+	return b.v
+}
+`,
+			typeParams: "[K comparable, V any]",
+			expectedOutput: `package cachetest
+
+func Cache[K comparable, V any]() cache.Cache[K, V] {
+	return BuildCache[K, V]().Value()
+}
+
+type CacheBuilder[K comparable, V any] struct {
+	v cache.Cache[K, V]
+}
+
+func BuildCache[K comparable, V any]() CacheBuilder[K, V] {
+	return CacheBuilder[K, V]{v: cache.Cache[K, V]{}}
+}
+
+func (b CacheBuilder[K, V]) Value() cache.Cache[K, V] {
+	return b.v
+}
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := goparser.ParseFile(fset, "cache.go", tc.src, 0)
+			assert.NoError(t, err)
+
+			var typeParams *ast.FieldList
+			if tc.typeParams != "" {
+				src := "package cache\ntype Cache" + tc.typeParams + " struct{}"
+				f, err := goparser.ParseFile(token.NewFileSet(), "", src, 0)
+				assert.NoError(t, err)
+				typeParams = f.Decls[0].(*ast.GenDecl).Specs[0].(*ast.TypeSpec).TypeParams
+			}
+
+			MakeGeneric(file.Decls, typeParams, "cache", "Cache")
+
+			buf := new(bytes.Buffer)
+			assert.NoError(t, format.Node(buf, fset, file))
+
+			// The new nodes have no position, so the output is formatted again
+			output, err := format.Source(buf.Bytes())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, string(output))
+		})
+	}
+}
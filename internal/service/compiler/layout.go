@@ -0,0 +1,59 @@
+package compiler
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultOutputDir is the default directory for generated files relative to the base directory.
+	DefaultOutputDir = ".gen"
+	// DefaultPackageSuffix is the default suffix for naming generated packages after source packages.
+	DefaultPackageSuffix = "test"
+)
+
+// Layout determines where generated files are written and how their packages are named.
+type Layout struct {
+	// OutputDir is the directory for generated files relative to the base directory.
+	// If empty, generated files are written next to their source files as test files.
+	OutputDir string
+	// PackageSuffix is appended to the name of a source package for naming its generated package.
+	// It is ignored when generated files are written next to their source files.
+	PackageSuffix string
+}
+
+// DefaultLayout returns the default layout which writes generated files to .gen/<package>test directories.
+func DefaultLayout() Layout {
+	return Layout{
+		OutputDir:     DefaultOutputDir,
+		PackageSuffix: DefaultPackageSuffix,
+	}
+}
+
+// PackageName returns the name of the generated package for a source file.
+func (l Layout) PackageName(info *FileInfo) string {
+	// Test files next to source files can only belong to the external test package
+	if l.OutputDir == "" {
+		return info.PackageName + "_test"
+	}
+
+	return info.PackageName + l.PackageSuffix
+}
+
+// FilePath returns the path of a generated file for a source file.
+// The suffix is appended to the name of the source file (e.g. _mock).
+func (l Layout) FilePath(info *FileInfo, suffix string) string {
+	name := strings.TrimSuffix(info.FileName, ".go") + suffix
+
+	if l.OutputDir == "" {
+		return filepath.Join(info.BaseDir, info.RelativeDir, name+"_test.go")
+	}
+
+	// The root package is named after its package name
+	relDir := info.RelativeDir
+	if relDir == "." || relDir == "" {
+		relDir = info.PackageName
+	}
+
+	return filepath.Join(info.BaseDir, l.OutputDir, relDir+l.PackageSuffix, name+".go")
+}
@@ -0,0 +1,96 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultLayout(t *testing.T) {
+	layout := DefaultLayout()
+
+	assert.Equal(t, ".gen", layout.OutputDir)
+	assert.Equal(t, "test", layout.PackageSuffix)
+}
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name                string
+		layout              Layout
+		info                *FileInfo
+		suffix              string
+		expectedPackageName string
+		expectedFilePath    string
+	}{
+		{
+			name:   "Default",
+			layout: DefaultLayout(),
+			info: &FileInfo{
+				PackageInfo: PackageInfo{
+					PackageName: "lookup",
+					BaseDir:     "/home/octocat/service",
+					RelativeDir: "internal/lookup",
+				},
+				FileName: "lookup.go",
+			},
+			suffix:              "_mock",
+			expectedPackageName: "lookuptest",
+			expectedFilePath:    "/home/octocat/service/.gen/internal/lookuptest/lookup_mock.go",
+		},
+		{
+			name: "Custom",
+			layout: Layout{
+				OutputDir:     "test/gen",
+				PackageSuffix: "fake",
+			},
+			info: &FileInfo{
+				PackageInfo: PackageInfo{
+					PackageName: "lookup",
+					BaseDir:     "/home/octocat/service",
+					RelativeDir: "internal/lookup",
+				},
+				FileName: "lookup.go",
+			},
+			suffix:              "_factory",
+			expectedPackageName: "lookupfake",
+			expectedFilePath:    "/home/octocat/service/test/gen/internal/lookupfake/lookup_factory.go",
+		},
+		{
+			name:   "RootPackage",
+			layout: DefaultLayout(),
+			info: &FileInfo{
+				PackageInfo: PackageInfo{
+					PackageName: "service",
+					BaseDir:     "/home/octocat/service",
+					RelativeDir: ".",
+				},
+				FileName: "service.go",
+			},
+			suffix:              "_mock",
+			expectedPackageName: "servicetest",
+			expectedFilePath:    "/home/octocat/service/.gen/servicetest/service_mock.go",
+		},
+		{
+			name:   "NextToSource",
+			layout: Layout{},
+			info: &FileInfo{
+				PackageInfo: PackageInfo{
+					PackageName: "lookup",
+					BaseDir:     "/home/octocat/service",
+					RelativeDir: "internal/lookup",
+				},
+				FileName: "lookup.go",
+			},
+			suffix:              "_mock",
+			expectedPackageName: "lookup_test",
+			expectedFilePath:    "/home/octocat/service/internal/lookup/lookup_mock_test.go",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPackageName, tc.layout.PackageName(tc.info))
+			assert.Equal(t, tc.expectedFilePath, tc.layout.FilePath(tc.info, tc.suffix))
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package compiler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	goparser "go/parser"
+	gotoken "go/token"
+)
+
+// manifest keeps the source hashes of the packages compiled by compilers, so the unchanged packages can be skipped.
+// The hashes are kept for each compiler (by the names of its consumers) and each package (by its directory relative to the parsing path).
+type manifest map[string]map[string]string
+
+// readManifest reads a manifest file.
+// A missing or invalid manifest file is treated as an empty manifest, so all packages are compiled.
+func readManifest(path string) manifest {
+	m := manifest{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return m
+	}
+
+	if err := json.Unmarshal(data, &m); err != nil {
+		return manifest{}
+	}
+
+	return m
+}
+
+// write writes a manifest to a file.
+func (m manifest) write(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileIfChanged(path, append(data, '\n'))
+}
+
+// moduleKey returns a key identifying the dependencies of a module.
+// The key covers the go.mod and go.sum files and the version of Go used for loading packages,
+// so the packages are compiled again when a dependency (and the types it provides) changes.
+func moduleKey(path string) (string, error) {
+	h := sha256.New()
+
+	for _, name := range []string{"go.mod", "go.sum"} {
+		data, err := ioutil.ReadFile(filepath.Join(path, name))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+
+		h.Write([]byte(name + "\x00"))
+		h.Write(data)
+		h.Write([]byte("\x00"))
+	}
+
+	// The go command used for loading packages may be different from the one that built this binary
+	version := runtime.Version()
+	cmd := exec.Command("go", "env", "GOVERSION")
+	cmd.Dir = path
+	if out, err := cmd.Output(); err == nil {
+		version = strings.TrimSpace(string(out))
+	}
+
+	h.Write([]byte(version))
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashPackages computes the source hashes of all Go packages recursively from a given path.
+// The hash of a package covers its Go source files, the hashes of the packages it imports from the same module, and a key.
+// The key should identify everything else that the compiled artifacts depend on (consumers, options, etc.).
+func hashPackages(path, module, key string, skipTestFiles bool) (map[string]string, error) {
+	type pkgDir struct {
+		hash string
+		deps []string
+	}
+
+	dirs := map[string]*pkgDir{}
+	fset := gotoken.NewFileSet()
+
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if p == path {
+				return nil
+			}
+
+			// The same directories are ignored as the ./... pattern (nested modules, testdata, etc.)
+			name := info.Name()
+			if !isPackageDir(name) || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(p, ".go") || (skipTestFiles && strings.HasSuffix(p, "_test.go")) {
+			return nil
+		}
+
+		relDir, err := filepath.Rel(path, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		relDir = filepath.ToSlash(relDir)
+
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		d, ok := dirs[relDir]
+		if !ok {
+			d = &pkgDir{}
+			dirs[relDir] = d
+		}
+
+		h := sha256.New()
+		h.Write([]byte(d.hash + "\x00" + info.Name() + "\x00"))
+		h.Write(data)
+		d.hash = hex.EncodeToString(h.Sum(nil))
+
+		// Syntax errors are reported when the package is loaded
+		if file, err := goparser.ParseFile(fset, p, data, goparser.ImportsOnly); err == nil {
+			for _, spec := range file.Imports {
+				if importPath, err := strconv.Unquote(spec.Path.Value); err == nil {
+					if importPath == module {
+						d.deps = append(d.deps, ".")
+					} else if strings.HasPrefix(importPath, module+"/") {
+						d.deps = append(d.deps, strings.TrimPrefix(importPath, module+"/"))
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := map[string]string{}
+	visiting := map[string]bool{}
+
+	var hash func(string) string
+	hash = func(relDir string) string {
+		if h, ok := hashes[relDir]; ok {
+			return h
+		}
+
+		d, ok := dirs[relDir]
+		if !ok || visiting[relDir] {
+			return ""
+		}
+
+		visiting[relDir] = true
+		defer delete(visiting, relDir)
+
+		deps := make([]string, 0, len(d.deps))
+		for _, dep := range d.deps {
+			deps = append(deps, dep+"="+hash(dep))
+		}
+		sort.Strings(deps)
+
+		sum := sha256.Sum256([]byte(key + "\x00" + d.hash + "\x00" + strings.Join(deps, "\x00")))
+		hashes[relDir] = hex.EncodeToString(sum[:])
+
+		return hashes[relDir]
+	}
+
+	for relDir := range dirs {
+		hash(relDir)
+	}
+
+	return hashes, nil
+}
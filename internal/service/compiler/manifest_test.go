@@ -0,0 +1,161 @@
+package compiler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"invalid.json": "{",
+		"valid.json":   `{"builder": {"lookup": "abcd"}}`,
+	})
+
+	tests := []struct {
+		name             string
+		path             string
+		expectedManifest manifest
+	}{
+		{
+			name:             "NoFile",
+			path:             filepath.Join(dir, "missing.json"),
+			expectedManifest: manifest{},
+		},
+		{
+			name:             "InvalidFile",
+			path:             filepath.Join(dir, "invalid.json"),
+			expectedManifest: manifest{},
+		},
+		{
+			name: "Success",
+			path: filepath.Join(dir, "valid.json"),
+			expectedManifest: manifest{
+				"builder": {"lookup": "abcd"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedManifest, readManifest(tc.path))
+		})
+	}
+}
+
+func TestManifest_Write(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gen", ".manifest.json")
+
+	m := manifest{
+		"builder": {".": "1234", "lookup": "abcd"},
+	}
+
+	assert.NoError(t, m.write(path))
+	assert.Equal(t, m, readManifest(path))
+}
+
+func TestModuleKey(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module github.com/octocat/test\n",
+	})
+
+	key, err := moduleKey(dir)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, key)
+
+	t.Run("Deterministic", func(t *testing.T) {
+		again, err := moduleKey(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, key, again)
+	})
+
+	t.Run("GoSumChanged", func(t *testing.T) {
+		writeFiles(t, dir, map[string]string{
+			"go.sum": "github.com/octocat/dep v0.1.0 h1:abcd=\n",
+		})
+
+		changed, err := moduleKey(dir)
+		assert.NoError(t, err)
+		assert.NotEqual(t, key, changed)
+		key = changed
+	})
+
+	t.Run("GoModChanged", func(t *testing.T) {
+		writeFiles(t, dir, map[string]string{
+			"go.mod": "module github.com/octocat/test\n\nrequire github.com/octocat/dep v0.1.0\n",
+		})
+
+		changed, err := moduleKey(dir)
+		assert.NoError(t, err)
+		assert.NotEqual(t, key, changed)
+	})
+}
+
+func TestHashPackages(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"go.mod":                  "module github.com/octocat/test\n",
+		"main.go":                 "package main\n\nimport _ \"github.com/octocat/test/lookup\"\n",
+		"main_test.go":            "package main\n",
+		"lookup/lookup.go":        "package lookup\n",
+		"util/util.go":            "package util\n",
+		"nested/go.mod":           "module github.com/octocat/nested\n",
+		"nested/nested.go":        "package nested\n",
+		"testdata/data.go":        "package data\n",
+		".gen/lookuptest/mock.go": "package lookuptest\n",
+	})
+
+	hashes, err := hashPackages(dir, "github.com/octocat/test", "key", true)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 3)
+	assert.Contains(t, hashes, ".")
+	assert.Contains(t, hashes, "lookup")
+	assert.Contains(t, hashes, "util")
+
+	t.Run("Deterministic", func(t *testing.T) {
+		again, err := hashPackages(dir, "github.com/octocat/test", "key", true)
+		assert.NoError(t, err)
+		assert.Equal(t, hashes, again)
+	})
+
+	t.Run("KeyChanged", func(t *testing.T) {
+		changed, err := hashPackages(dir, "github.com/octocat/test", "another key", true)
+		assert.NoError(t, err)
+		assert.NotEqual(t, hashes["lookup"], changed["lookup"])
+	})
+
+	t.Run("TestFileIncluded", func(t *testing.T) {
+		changed, err := hashPackages(dir, "github.com/octocat/test", "key", false)
+		assert.NoError(t, err)
+		assert.NotEqual(t, hashes["."], changed["."])
+		assert.Equal(t, hashes["lookup"], changed["lookup"])
+	})
+
+	t.Run("DependencyChanged", func(t *testing.T) {
+		writeFiles(t, dir, map[string]string{
+			"lookup/lookup.go": "package lookup\n\ntype Request struct{}\n",
+		})
+
+		changed, err := hashPackages(dir, "github.com/octocat/test", "key", true)
+		assert.NoError(t, err)
+		assert.NotEqual(t, hashes["."], changed["."])
+		assert.NotEqual(t, hashes["lookup"], changed["lookup"])
+		assert.Equal(t, hashes["util"], changed["util"])
+	})
+}
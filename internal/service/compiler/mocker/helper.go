@@ -1,13 +1,29 @@
 package mocker
 
 import (
-	"fmt"
 	"go/ast"
-	"go/token"
+	"go/types"
 
 	"github.com/moorara/gelato/internal/service/compiler"
 )
 
+// importedPkgName returns the package name declared by an import spec or nil if it is not known.
+func importedPkgName(info *compiler.FileInfo, spec *ast.ImportSpec) *types.PkgName {
+	if info.TypesInfo == nil {
+		return nil
+	}
+
+	var obj types.Object
+	if spec.Name != nil {
+		obj = info.TypesInfo.Defs[spec.Name]
+	} else {
+		obj = info.TypesInfo.Implicits[spec]
+	}
+
+	pkgName, _ := obj.(*types.PkgName)
+	return pkgName
+}
+
 func isEmbeddedInterface(method *ast.Field) bool {
 	return len(method.Names) == 0
 }
@@ -23,24 +39,40 @@ func isMethod(method *ast.Field) bool {
 }
 
 // normalizeFieldList clones a field list and converts embedded fields to non-embedded ones.
-func normalizeFieldList(fieldList *ast.FieldList) *ast.FieldList {
+// Unnamed and blank fields are named after their types and all names are made unique.
+// The names do not shadow keywords, predeclared identifiers, or reserved names (package names, receivers, etc.).
+func normalizeFieldList(fieldList *ast.FieldList, reserved map[string]bool) *ast.FieldList {
 	new := &ast.FieldList{}
 
 	if fieldList == nil {
 		return new
 	}
 
+	used := map[string]bool{}
+	for name := range reserved {
+		used[name] = true
+	}
+
 	for _, field := range fieldList.List {
 		f := &ast.Field{
-			Names: field.Names,
-			Type:  field.Type,
+			Type: field.Type,
 		}
 
+		names := field.Names
 		// Unnamed field
-		if len(f.Names) == 0 {
-			f.Names = []*ast.Ident{
-				{Name: compiler.ConvertToUnexported(compiler.InferName(f.Type))},
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+
+		for _, id := range names {
+			name := id.Name
+			if name == "_" {
+				name = compiler.ConvertToUnexported(compiler.InferName(f.Type))
 			}
+			name = compiler.SafeName(name, used)
+			used[name] = true
+
+			f.Names = append(f.Names, &ast.Ident{Name: name})
 		}
 
 		// Trailing arguments (for variadic functions)
@@ -57,27 +89,14 @@ func normalizeFieldList(fieldList *ast.FieldList) *ast.FieldList {
 }
 
 // createKeyValueExprList creates a list of key-value assignments for creating structs from a field list.
-func createKeyValueExprList(fieldList *ast.FieldList) []ast.Expr {
+func createKeyValueExprList(fieldList *ast.FieldList, reserved map[string]bool) []ast.Expr {
 	list := []ast.Expr{}
 
-	if fieldList == nil {
-		return list
-	}
-
-	for _, f := range fieldList.List {
-		if len(f.Names) > 0 {
-			for _, n := range f.Names {
-				list = append(list, &ast.KeyValueExpr{
-					Key:   &ast.Ident{Name: n.Name},
-					Value: &ast.Ident{Name: n.Name},
-				})
-			}
-		} else {
-			// Unnamed field
-			name := compiler.ConvertToUnexported(compiler.InferName(f.Type))
+	for _, f := range normalizeFieldList(fieldList, reserved).List {
+		for _, n := range f.Names {
 			list = append(list, &ast.KeyValueExpr{
-				Key:   &ast.Ident{Name: name},
-				Value: &ast.Ident{Name: name},
+				Key:   &ast.Ident{Name: n.Name},
+				Value: &ast.Ident{Name: n.Name},
 			})
 		}
 	}
@@ -85,36 +104,15 @@ func createKeyValueExprList(fieldList *ast.FieldList) []ast.Expr {
 	return list
 }
 
-func createZeroValueExpr(typ ast.Expr) ast.Expr {
-	switch e := typ.(type) {
-	case *ast.Ident:
-		switch e.Name {
-		case "error":
-			return &ast.Ident{Name: "nil"}
-		case "bool":
-			return &ast.Ident{Name: "false"}
-		case "string":
-			return &ast.BasicLit{Kind: token.STRING, Value: `""`}
-		case "byte", "rune":
-			fallthrough
-		case "int", "int8", "int16", "int32", "int64":
-			fallthrough
-		case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
-			return &ast.BasicLit{Kind: token.INT, Value: "0"}
-		case "float32", "float64":
-			return &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"}
-		case "complex64", "complex128":
-			return &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"}
-		default: // struct
-			return &ast.CompositeLit{Type: e}
-		}
-
-	case *ast.SelectorExpr:
-		return &ast.CompositeLit{Type: e}
+// createIdentList creates a list of identifiers for passing the fields of a field list as arguments.
+func createIdentList(fieldList *ast.FieldList, reserved map[string]bool) []ast.Expr {
+	list := []ast.Expr{}
 
-	case *ast.StarExpr, *ast.ArrayType, *ast.MapType, *ast.ChanType:
-		return &ast.Ident{Name: "nil"}
+	for _, f := range normalizeFieldList(fieldList, reserved).List {
+		for _, n := range f.Names {
+			list = append(list, &ast.Ident{Name: n.Name})
+		}
 	}
 
-	panic(fmt.Sprintf("Unknown type %T", typ))
+	return list
 }
@@ -2,7 +2,6 @@ package mocker
 
 import (
 	"go/ast"
-	"go/token"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,6 +81,7 @@ func TestNormalizeFieldList(t *testing.T) {
 	tests := []struct {
 		name              string
 		fieldList         *ast.FieldList
+		reserved          map[string]bool
 		expectedFieldList *ast.FieldList
 	}{
 		{
@@ -125,7 +125,7 @@ func TestNormalizeFieldList(t *testing.T) {
 				List: []*ast.Field{
 					{
 						Names: []*ast.Ident{
-							{Name: "string"},
+							{Name: "string2"},
 						},
 						Type: &ast.Ident{Name: "string"},
 					},
@@ -174,7 +174,7 @@ func TestNormalizeFieldList(t *testing.T) {
 				List: []*ast.Field{
 					{
 						Names: []*ast.Ident{
-							{Name: "string"},
+							{Name: "string2"},
 						},
 						Type: &ast.ArrayType{
 							Elt: &ast.Ident{Name: "string"},
@@ -183,11 +183,119 @@ func TestNormalizeFieldList(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "DuplicateUnnamedFields",
+			fieldList: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.Ident{Name: "string"},
+					},
+					{
+						Type: &ast.Ident{Name: "string"},
+					},
+				},
+			},
+			expectedFieldList: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							{Name: "string2"},
+						},
+						Type: &ast.Ident{Name: "string"},
+					},
+					{
+						Names: []*ast.Ident{
+							{Name: "string3"},
+						},
+						Type: &ast.Ident{Name: "string"},
+					},
+				},
+			},
+		},
+		{
+			name: "BlankFields",
+			fieldList: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							{Name: "_"},
+							{Name: "_"},
+						},
+						Type: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "reflect"},
+							Sel: &ast.Ident{Name: "Type"},
+						},
+					},
+				},
+			},
+			expectedFieldList: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							{Name: "type_"},
+							{Name: "type_2"},
+						},
+						Type: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "reflect"},
+							Sel: &ast.Ident{Name: "Type"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ReservedNames",
+			fieldList: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "context"},
+							Sel: &ast.Ident{Name: "Context"},
+						},
+					},
+					{
+						Names: []*ast.Ident{
+							{Name: "i"},
+						},
+						Type: &ast.Ident{Name: "int"},
+					},
+					{
+						Type: &ast.Ident{Name: "bool"},
+					},
+				},
+			},
+			reserved: map[string]bool{"context": true, "i": true},
+			expectedFieldList: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							{Name: "ctx"},
+						},
+						Type: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "context"},
+							Sel: &ast.Ident{Name: "Context"},
+						},
+					},
+					{
+						Names: []*ast.Ident{
+							{Name: "i2"},
+						},
+						Type: &ast.Ident{Name: "int"},
+					},
+					{
+						Names: []*ast.Ident{
+							{Name: "ok"},
+						},
+						Type: &ast.Ident{Name: "bool"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			fieldList := normalizeFieldList(tc.fieldList)
+			fieldList := normalizeFieldList(tc.fieldList, tc.reserved)
 
 			assert.Equal(t, tc.expectedFieldList, fieldList)
 		})
@@ -243,8 +351,8 @@ func TestCreateKeyValueExprList(t *testing.T) {
 					Value: &ast.Ident{Name: "response"},
 				},
 				&ast.KeyValueExpr{
-					Key:   &ast.Ident{Name: "error"},
-					Value: &ast.Ident{Name: "error"},
+					Key:   &ast.Ident{Name: "err"},
+					Value: &ast.Ident{Name: "err"},
 				},
 			},
 		},
@@ -252,175 +360,56 @@ func TestCreateKeyValueExprList(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			exprs := createKeyValueExprList(tc.fieldList)
+			exprs := createKeyValueExprList(tc.fieldList, nil)
 
 			assert.Equal(t, tc.expectedExprs, exprs)
 		})
 	}
 }
 
-func TestCreateZeroValueExpr(t *testing.T) {
+func TestCreateIdentList(t *testing.T) {
 	tests := []struct {
-		name         string
-		typ          ast.Expr
-		expectedExpr ast.Expr
+		name          string
+		fieldList     *ast.FieldList
+		expectedExprs []ast.Expr
 	}{
 		{
-			name:         "error",
-			typ:          &ast.Ident{Name: "error"},
-			expectedExpr: &ast.Ident{Name: "nil"},
-		},
-		{
-			name:         "bool",
-			typ:          &ast.Ident{Name: "bool"},
-			expectedExpr: &ast.Ident{Name: "false"},
-		},
-		{
-			name:         "string",
-			typ:          &ast.Ident{Name: "string"},
-			expectedExpr: &ast.BasicLit{Kind: token.STRING, Value: `""`},
-		},
-		{
-			name:         "byte",
-			typ:          &ast.Ident{Name: "byte"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "rune",
-			typ:          &ast.Ident{Name: "rune"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "int",
-			typ:          &ast.Ident{Name: "int"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "int8",
-			typ:          &ast.Ident{Name: "int8"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "int16",
-			typ:          &ast.Ident{Name: "int16"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "int32",
-			typ:          &ast.Ident{Name: "int32"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "int64",
-			typ:          &ast.Ident{Name: "int64"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "uint",
-			typ:          &ast.Ident{Name: "int"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "uint8",
-			typ:          &ast.Ident{Name: "int8"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "uint16",
-			typ:          &ast.Ident{Name: "int16"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "uint32",
-			typ:          &ast.Ident{Name: "int32"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "uint64",
-			typ:          &ast.Ident{Name: "int64"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "uintptr",
-			typ:          &ast.Ident{Name: "uintptr"},
-			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
-		},
-		{
-			name:         "float32",
-			typ:          &ast.Ident{Name: "float32"},
-			expectedExpr: &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"},
-		},
-		{
-			name:         "float64",
-			typ:          &ast.Ident{Name: "float64"},
-			expectedExpr: &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"},
-		},
-		{
-			name:         "complex64",
-			typ:          &ast.Ident{Name: "complex64"},
-			expectedExpr: &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"},
-		},
-		{
-			name:         "complex128",
-			typ:          &ast.Ident{Name: "complex128"},
-			expectedExpr: &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"},
-		},
-		{
-			name: "Struct_SamePackage",
-			typ:  &ast.Ident{Name: "Address"},
-			expectedExpr: &ast.CompositeLit{
-				Type: &ast.Ident{Name: "Address"},
-			},
+			name:          "NilFieldList",
+			fieldList:     nil,
+			expectedExprs: []ast.Expr{},
 		},
 		{
-			name: "Struct_OtherPackage",
-			typ: &ast.SelectorExpr{
-				X:   &ast.Ident{Name: "http"},
-				Sel: &ast.Ident{Name: "Transport"},
-			},
-			expectedExpr: &ast.CompositeLit{
-				Type: &ast.SelectorExpr{
-					X:   &ast.Ident{Name: "http"},
-					Sel: &ast.Ident{Name: "Transport"},
+			name: "Params",
+			fieldList: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							{Name: "ctx"},
+						},
+						Type: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "context"},
+							Sel: &ast.Ident{Name: "Context"},
+						},
+					},
+					{
+						Type: &ast.StarExpr{
+							X: &ast.Ident{Name: "Request"},
+						},
+					},
 				},
 			},
-		},
-		{
-			name: "Pointer",
-			typ: &ast.StarExpr{
-				X: &ast.Ident{Name: "int"},
-			},
-			expectedExpr: &ast.Ident{Name: "nil"},
-		},
-		{
-			name: "Slice",
-			typ: &ast.ArrayType{
-				Elt: &ast.Ident{Name: "int"},
-			},
-			expectedExpr: &ast.Ident{Name: "nil"},
-		},
-		{
-			name: "Map",
-			typ: &ast.MapType{
-				Key:   &ast.Ident{Name: "int"},
-				Value: &ast.Ident{Name: "string"},
-			},
-			expectedExpr: &ast.Ident{Name: "nil"},
-		},
-		{
-			name: "Channel",
-			typ: &ast.ChanType{
-				Value: &ast.Ident{Name: "error"},
+			expectedExprs: []ast.Expr{
+				&ast.Ident{Name: "ctx"},
+				&ast.Ident{Name: "request"},
 			},
-			expectedExpr: &ast.Ident{Name: "nil"},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			expr := createZeroValueExpr(tc.typ)
+			exprs := createIdentList(tc.fieldList, nil)
 
-			assert.Equal(t, tc.expectedExpr, expr)
+			assert.Equal(t, tc.expectedExprs, exprs)
 		})
 	}
 }
@@ -4,36 +4,53 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"path/filepath"
-	"strings"
+	"go/types"
 
 	"github.com/moorara/gelato/internal/log"
 	"github.com/moorara/gelato/internal/service/compiler"
 )
 
 const (
-	mainPkg = "main"
+	mainPkg  = "main"
+	spewPath = "github.com/davecgh/go-spew/spew"
+	mockPath = "github.com/moorara/gelato/pkg/mock"
 )
 
 // New creates a new compiler for generating mockers for interfaces.
-func New(level log.Level) *compiler.Compiler {
+// Generated files are written and their packages are named according to the layout.
+func New(level log.Level, layout compiler.Layout) *compiler.Compiler {
 	logger := log.NewColorful(level)
-	m := &mocker{}
-	consumer := &compiler.Consumer{
-		Name:      "mocker",
-		Package:   m.Package,
-		FilePre:   m.FilePre,
-		FilePost:  m.FilePost,
-		Import:    m.Import,
-		Interface: m.Interface,
-	}
 
-	return compiler.New(logger, consumer)
+	return compiler.NewConcurrent(logger, func() []*compiler.Consumer {
+		m := &mocker{
+			layout: layout,
+		}
+
+		return []*compiler.Consumer{
+			{
+				Name:      "mocker",
+				Key:       fmt.Sprintf("%+v", layout),
+				Directive: "mock",
+				Package:   m.Package,
+				FilePre:   m.FilePre,
+				FilePost:  m.FilePost,
+				Import:    m.Import,
+				Interface: m.Interface,
+			},
+		}
+	})
 }
 
 type mocker struct {
+	layout  compiler.Layout
 	imports []ast.Spec
 	decls   []ast.Decl
+	// pkgNames maps import paths to package names in the generated file.
+	pkgNames map[string]string
+	// usedNames keeps track of the package names taken in the generated file.
+	usedNames map[string]bool
+	// exprTypes keeps track of the types of type expressions created from type information.
+	exprTypes map[ast.Expr]types.Type
 }
 
 func (m *mocker) Package(info *compiler.PackageInfo, pkg *ast.Package) bool {
@@ -42,6 +59,17 @@ func (m *mocker) Package(info *compiler.PackageInfo, pkg *ast.Package) bool {
 
 func (m *mocker) FilePre(info *compiler.FileInfo, file *ast.File) bool {
 	m.imports, m.decls = nil, nil
+	m.pkgNames, m.usedNames = nil, nil
+	m.exprTypes = nil
+
+	// The package names that the generated file uses regardless of the source file
+	m.reserve("testing", "testing")
+	m.reserve("sync", "sync")
+	m.reserve(spewPath, "spew")
+	m.reserve(mockPath, "mock")
+	m.reserve(info.ImportPath, info.PackageName)
+	m.usedNames[m.layout.PackageName(info)] = true
+
 	return true
 }
 
@@ -64,7 +92,12 @@ func (m *mocker) FilePost(info *compiler.FileInfo, file *ast.File) error {
 		},
 		&ast.ImportSpec{
 			Path: &ast.BasicLit{
-				Value: `"github.com/davecgh/go-spew/spew"`,
+				Value: fmt.Sprintf("%q", spewPath),
+			},
+		},
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Value: fmt.Sprintf("%q", mockPath),
 			},
 		},
 	)
@@ -79,13 +112,12 @@ func (m *mocker) FilePost(info *compiler.FileInfo, file *ast.File) error {
 		Package: 45,
 		Name: &ast.Ident{
 			NamePos: 53,
-			Name:    info.PackageName + "test",
+			Name:    m.layout.PackageName(info),
 		},
 		Decls: append([]ast.Decl{importDecl}, m.decls...),
 	}
 
-	fileName := strings.Replace(info.FileName, ".go", "_mock.go", 1)
-	filePath := filepath.Join(info.BaseDir, ".gen", info.RelativeDir+"test", fileName)
+	filePath := m.layout.FilePath(info, "_mock")
 	if err := compiler.WriteFile(filePath, info.FileSet, newFile); err != nil {
 		return err
 	}
@@ -95,38 +127,175 @@ func (m *mocker) FilePost(info *compiler.FileInfo, file *ast.File) error {
 
 func (m *mocker) Import(info *compiler.FileInfo, spec *ast.ImportSpec) {
 	m.imports = append(m.imports, spec)
+
+	// Use the same package names as the source file where possible
+	if pkgName := importedPkgName(info, spec); pkgName != nil {
+		if name := pkgName.Name(); name != "_" && name != "." {
+			m.reserve(pkgName.Imported().Path(), name)
+		}
+	}
 }
 
 func (m *mocker) Interface(info *compiler.TypeInfo, node *ast.InterfaceType) {
+	// Interfaces with type constraints (e.g. interface{ ~int | ~float64 }) can only be used as constraints
+	if iface, ok := info.TypeOf(node).(*types.Interface); ok && !iface.IsMethodSet() {
+		return
+	}
+
+	methods := m.methods(info, node)
+	reserved := m.reservedNames()
+
+	typeOf := func(expr ast.Expr) types.Type {
+		if t, ok := m.exprTypes[expr]; ok {
+			return t
+		}
+		return info.TypeOf(expr)
+	}
+
 	decls := []ast.Decl{}
 	decls = append(decls, createMockerStructDecl(info.TypeName))
 	decls = append(decls, createMockFuncDecl(info.TypeName))
 	decls = append(decls, createMockerExpectMethodDecl(info.TypeName))
 	decls = append(decls, createMockerImplMethodDecl(info.PackageName, info.TypeName))
-	decls = append(decls, createMockerAssertMethodDecl(info.TypeName, node.Methods))
-	decls = append(decls, createExpectationsStructDecl(info.TypeName, node.Methods))
-	decls = append(decls, createExpectationsMethodDecls(info.TypeName, node.Methods)...)
+	decls = append(decls, createMockerAssertMethodDecl(info.TypeName, methods))
+	decls = append(decls, createExpectationsStructDecl(info.TypeName, methods))
+	decls = append(decls, createExpectationsMethodDecls(info.TypeName, methods)...)
 
-	for _, method := range node.Methods.List {
+	for _, method := range methods.List {
 		if isMethod(method) {
-			decls = append(decls, createExpectationStructDecls(method)...)
-			decls = append(decls, createExpectationWithArgsMethodDecl(method))
-			decls = append(decls, createExpectationReturnMethodDecl(method))
-			decls = append(decls, createExpectationCallMethodDecl(method))
+			decls = append(decls, createExpectationStructDecls(info.TypeName, method, reserved)...)
+			decls = append(decls, createExpectationWithArgsMethodDecl(info.TypeName, method, reserved))
+			decls = append(decls, createExpectationMatchMethodDecl(info.TypeName, method, reserved))
+			decls = append(decls, createExpectationReturnMethodDecl(info.TypeName, method, reserved))
+			decls = append(decls, createExpectationDoMethodDecl(info.TypeName, method))
+			decls = append(decls, createExpectationCallMethodDecl(info.TypeName, method))
+			decls = append(decls, createExpectationTimesMethodDecls(info.TypeName, method)...)
+			decls = append(decls, createExpectationMockCallMethodDecl(info.TypeName, method))
 		}
 	}
 
 	decls = append(decls, createImplStructDecl(info.TypeName))
 
-	for _, method := range node.Methods.List {
+	for _, method := range methods.List {
 		if isMethod(method) {
-			decls = append(decls, createImplMethodDecl(info.TypeName, method))
+			decls = append(decls, createImplMethodDecl(info.TypeName, method, reserved, typeOf))
 		}
 	}
 
+	// Mocks for generic interfaces are generic too
+	compiler.MakeGeneric(decls, m.typeParams(info), info.PackageName, info.TypeName)
+
 	m.decls = append(m.decls, decls...)
 }
 
+// typeParams returns the type parameters of a generic type with their constraints qualified for the generated file.
+// The constraints are used as they are if the type information is not known.
+func (m *mocker) typeParams(info *compiler.TypeInfo) *ast.FieldList {
+	if info.TypeParams == nil {
+		return nil
+	}
+
+	typeParams := &ast.FieldList{}
+	for _, field := range info.TypeParams.List {
+		constraint := field.Type
+		if t := info.TypeOf(field.Type); t != nil {
+			constraint = compiler.TypeExpr(t, m.qualify)
+		}
+
+		typeParams.List = append(typeParams.List, &ast.Field{
+			Names: field.Names,
+			Type:  constraint,
+		})
+	}
+
+	return typeParams
+}
+
+// methods returns all methods of an interface including the methods of embedded interfaces.
+// Embedded interfaces can only be flattened using the type information,
+// so only the methods declared explicitly are returned if the type information is not known.
+func (m *mocker) methods(info *compiler.TypeInfo, node *ast.InterfaceType) *ast.FieldList {
+	iface, ok := info.TypeOf(node).(*types.Interface)
+	if !ok {
+		return node.Methods
+	}
+
+	if m.exprTypes == nil {
+		m.exprTypes = map[ast.Expr]types.Type{}
+	}
+
+	methods := &ast.FieldList{}
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		sig := method.Type().(*types.Signature)
+		funcType := compiler.TypeExpr(sig, m.qualify).(*ast.FuncType)
+
+		// Each result is converted to a separate field
+		for j, f := range funcType.Results.List {
+			m.exprTypes[f.Type] = sig.Results().At(j).Type()
+		}
+
+		methods.List = append(methods.List, &ast.Field{
+			Names: []*ast.Ident{{Name: method.Name()}},
+			Type:  funcType,
+		})
+	}
+
+	return methods
+}
+
+// reservedNames returns the names that the parameters of generated methods cannot use.
+// These are the package names in the generated file and the receivers and variables in the generated method bodies.
+func (m *mocker) reservedNames() map[string]bool {
+	reserved := map[string]bool{"e": true, "i": true, "calls": true, "expectation": true}
+	for name := range m.usedNames {
+		reserved[name] = true
+	}
+
+	return reserved
+}
+
+// reserve assigns a package name to an import path in the generated file.
+func (m *mocker) reserve(path, name string) {
+	if m.pkgNames == nil {
+		m.pkgNames, m.usedNames = map[string]string{}, map[string]bool{}
+	}
+
+	if _, ok := m.pkgNames[path]; !ok && !m.usedNames[name] {
+		m.pkgNames[path] = name
+		m.usedNames[name] = true
+	}
+}
+
+// qualify returns the package name for qualifying the types from a package in the generated file.
+// The package is imported if it is not already imported.
+func (m *mocker) qualify(pkg *types.Package) string {
+	if name, ok := m.pkgNames[pkg.Path()]; ok {
+		return name
+	}
+
+	name := pkg.Name()
+	for i := 2; m.usedNames[name]; i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+
+	m.reserve(pkg.Path(), name)
+
+	spec := &ast.ImportSpec{
+		Path: &ast.BasicLit{
+			Value: fmt.Sprintf("%q", pkg.Path()),
+		},
+	}
+
+	if name != pkg.Name() {
+		spec.Name = &ast.Ident{Name: name}
+	}
+
+	m.imports = append(m.imports, spec)
+
+	return name
+}
+
 func createMockerStructDecl(typeName string) ast.Decl {
 	return &ast.GenDecl{
 		Tok: token.TYPE,
@@ -405,13 +574,17 @@ func createMockerAssertMethodDecl(typeName string, methods *ast.FieldList) ast.D
 				Body: &ast.BlockStmt{
 					List: []ast.Stmt{
 						&ast.IfStmt{
-							Cond: &ast.BinaryExpr{
-								X: &ast.SelectorExpr{
-									X:   &ast.Ident{Name: "e"},
-									Sel: &ast.Ident{Name: "recorded"},
+							Cond: &ast.UnaryExpr{
+								Op: token.NOT,
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "call"},
+										},
+										Sel: &ast.Ident{Name: "Satisfied"},
+									},
 								},
-								Op: token.EQL,
-								Y:  &ast.Ident{Name: "nil"},
 							},
 							Body: &ast.BlockStmt{
 								List: []ast.Stmt{
@@ -426,20 +599,20 @@ func createMockerAssertMethodDecl(typeName string, methods *ast.FieldList) ast.D
 											},
 											Args: []ast.Expr{
 												&ast.BasicLit{
-													Value: fmt.Sprintf(`"\nExpected %s method be called with %%s"`, exportedName),
+													Value: `"\nExpected %s"`,
 												},
 												&ast.CallExpr{
 													Fun: &ast.SelectorExpr{
 														X: &ast.SelectorExpr{
-															X:   &ast.Ident{Name: "m"},
-															Sel: &ast.Ident{Name: "spew"},
+															X:   &ast.Ident{Name: "e"},
+															Sel: &ast.Ident{Name: "call"},
 														},
-														Sel: &ast.Ident{Name: "Sdump"},
+														Sel: &ast.Ident{Name: "Describe"},
 													},
 													Args: []ast.Expr{
 														&ast.SelectorExpr{
-															X:   &ast.Ident{Name: "e"},
-															Sel: &ast.Ident{Name: "inputs"},
+															X:   &ast.Ident{Name: "m"},
+															Sel: &ast.Ident{Name: "spew"},
 														},
 													},
 												},
@@ -475,13 +648,29 @@ func createMockerAssertMethodDecl(typeName string, methods *ast.FieldList) ast.D
 			Params: &ast.FieldList{},
 		},
 		Body: &ast.BlockStmt{
-			List: stmts,
+			List: append(createLockStmts(&ast.SelectorExpr{
+				X:   &ast.Ident{Name: "m"},
+				Sel: &ast.Ident{Name: "expectations"},
+			}), stmts...),
 		},
 	}
 }
 
 func createExpectationsStructDecl(typeName string, methods *ast.FieldList) ast.Decl {
-	fields := &ast.FieldList{}
+	// The mutex guards the expectations shared between the mocker and the implementation
+	fields := &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Names: []*ast.Ident{
+					{Name: "mutex"},
+				},
+				Type: &ast.SelectorExpr{
+					X:   &ast.Ident{Name: "sync"},
+					Sel: &ast.Ident{Name: "Mutex"},
+				},
+			},
+		},
+	}
 
 	for _, method := range methods.List {
 		if isMethod(method) {
@@ -493,7 +682,7 @@ func createExpectationsStructDecl(typeName string, methods *ast.FieldList) ast.D
 					},
 					Type: &ast.ArrayType{
 						Elt: &ast.StarExpr{
-							X: &ast.Ident{Name: methodName + "Expectation"},
+							X: &ast.Ident{Name: typeName + methodName + "Expectation"},
 						},
 					},
 				})
@@ -543,24 +732,41 @@ func createExpectationsMethodDecls(typeName string, methods *ast.FieldList) []as
 							List: []*ast.Field{
 								{
 									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: methodName + "Expectation"},
+										X: &ast.Ident{Name: typeName + methodName + "Expectation"},
 									},
 								},
 							},
 						},
 					},
 					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
+						List: append(createLockStmts(&ast.Ident{Name: "e"}),
 							&ast.AssignStmt{
 								Lhs: []ast.Expr{
 									&ast.Ident{Name: "expectation"},
 								},
 								Tok: token.DEFINE,
 								Rhs: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.Ident{Name: "new"},
-										Args: []ast.Expr{
-											&ast.Ident{Name: methodName + "Expectation"},
+									&ast.UnaryExpr{
+										Op: token.AND,
+										X: &ast.CompositeLit{
+											Type: &ast.Ident{Name: typeName + methodName + "Expectation"},
+											Elts: []ast.Expr{
+												&ast.KeyValueExpr{
+													Key: &ast.Ident{Name: "call"},
+													Value: &ast.CallExpr{
+														Fun: &ast.SelectorExpr{
+															X:   &ast.Ident{Name: "mock"},
+															Sel: &ast.Ident{Name: "NewCall"},
+														},
+														Args: []ast.Expr{
+															&ast.BasicLit{
+																Kind:  token.STRING,
+																Value: fmt.Sprintf("%q", methodName),
+															},
+														},
+													},
+												},
+											},
 										},
 									},
 								},
@@ -591,7 +797,7 @@ func createExpectationsMethodDecls(typeName string, methods *ast.FieldList) []as
 									&ast.Ident{Name: "expectation"},
 								},
 							},
-						},
+						),
 					},
 				})
 			}
@@ -601,14 +807,13 @@ func createExpectationsMethodDecls(typeName string, methods *ast.FieldList) []as
 	return decls
 }
 
-func createExpectationStructDecls(method *ast.Field) []ast.Decl {
+func createExpectationStructDecls(typeName string, method *ast.Field, reserved map[string]bool) []ast.Decl {
 	exportedName := method.Names[0].Name
-	unexportedName := compiler.ConvertToUnexported(exportedName)
+	unexportedPrefix := compiler.ConvertToUnexported(typeName) + exportedName
 
 	// isMethod guarantees method.Type is *ast.FuncType
 	funcType := method.Type.(*ast.FuncType)
-	inputFields := normalizeFieldList(funcType.Params)
-	outputFields := normalizeFieldList(funcType.Results)
+	outputFields := normalizeFieldList(funcType.Results, reserved)
 
 	return []ast.Decl{
 		// Struct
@@ -617,40 +822,41 @@ func createExpectationStructDecls(method *ast.Field) []ast.Decl {
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
 					Name: &ast.Ident{
-						Name: exportedName + "Expectation",
+						Name: typeName + exportedName + "Expectation",
 					},
 					Type: &ast.StructType{
 						Fields: &ast.FieldList{
 							List: []*ast.Field{
 								{
 									Names: []*ast.Ident{
-										{Name: "inputs"},
+										{Name: "call"},
 									},
 									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: unexportedName + "Inputs"},
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "mock"},
+											Sel: &ast.Ident{Name: "Call"},
+										},
 									},
 								},
 								{
 									Names: []*ast.Ident{
 										{Name: "outputs"},
 									},
-									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: unexportedName + "Outputs"},
-									},
+									Type: &ast.Ident{Name: unexportedPrefix + "Outputs"},
 								},
 								{
 									Names: []*ast.Ident{
-										{Name: "callback"},
+										{Name: "do"},
+									},
+									Type: &ast.FuncType{
+										Params: funcType.Params,
 									},
-									Type: method.Type,
 								},
 								{
 									Names: []*ast.Ident{
-										{Name: "recorded"},
-									},
-									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: unexportedName + "Inputs"},
+										{Name: "callback"},
 									},
+									Type: method.Type,
 								},
 							},
 						},
@@ -658,25 +864,13 @@ func createExpectationStructDecls(method *ast.Field) []ast.Decl {
 				},
 			},
 		},
-		// Inputs struct
-		&ast.GenDecl{
-			Tok: token.TYPE,
-			Specs: []ast.Spec{
-				&ast.TypeSpec{
-					Name: &ast.Ident{Name: unexportedName + "Inputs"},
-					Type: &ast.StructType{
-						Fields: inputFields,
-					},
-				},
-			},
-		},
 		// Outputs struct
 		&ast.GenDecl{
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
 					Name: &ast.Ident{
-						Name: unexportedName + "Outputs",
+						Name: unexportedPrefix + "Outputs",
 					},
 					Type: &ast.StructType{
 						Fields: outputFields,
@@ -687,15 +881,8 @@ func createExpectationStructDecls(method *ast.Field) []ast.Decl {
 	}
 }
 
-func createExpectationWithArgsMethodDecl(method *ast.Field) ast.Decl {
-	exportedName := method.Names[0].Name
-	unexportedName := compiler.ConvertToUnexported(exportedName)
-
-	// isMethod guarantees method.Type is *ast.FuncType
-	funcType := method.Type.(*ast.FuncType)
-	inputFields := normalizeFieldList(funcType.Params)
-	keyValueList := createKeyValueExprList(funcType.Params)
-
+// createExpectationMethodDecl creates a method for an expectation that returns the expectation for chaining.
+func createExpectationMethodDecl(typeName, methodName, name string, params *ast.FieldList, stmts ...ast.Stmt) ast.Decl {
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
@@ -704,120 +891,218 @@ func createExpectationWithArgsMethodDecl(method *ast.Field) ast.Decl {
 						{Name: "e"},
 					},
 					Type: &ast.StarExpr{
-						X: &ast.Ident{Name: exportedName + "Expectation"},
+						X: &ast.Ident{Name: typeName + methodName + "Expectation"},
 					},
 				},
 			},
 		},
-		Name: &ast.Ident{Name: "WithArgs"},
+		Name: &ast.Ident{Name: name},
 		Type: &ast.FuncType{
-			Params: inputFields,
+			Params: params,
 			Results: &ast.FieldList{
 				List: []*ast.Field{
 					{
 						Type: &ast.StarExpr{
-							X: &ast.Ident{Name: exportedName + "Expectation"},
+							X: &ast.Ident{Name: typeName + methodName + "Expectation"},
 						},
 					},
 				},
 			},
 		},
 		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   &ast.Ident{Name: "e"},
-							Sel: &ast.Ident{Name: "inputs"},
-						},
-					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: &ast.Ident{Name: unexportedName + "Inputs"},
-								Elts: keyValueList,
-							},
-						},
-					},
-				},
+			List: append(stmts,
 				&ast.ReturnStmt{
 					Results: []ast.Expr{
 						&ast.Ident{Name: "e"},
 					},
 				},
+			),
+		},
+	}
+}
+
+// createLockStmts creates the statements for locking the mutex of a struct until the function returns.
+func createLockStmts(x ast.Expr) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X: &ast.SelectorExpr{
+						X:   x,
+						Sel: &ast.Ident{Name: "mutex"},
+					},
+					Sel: &ast.Ident{Name: "Lock"},
+				},
 			},
 		},
+		&ast.DeferStmt{
+			Call: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X: &ast.SelectorExpr{
+						X:   x,
+						Sel: &ast.Ident{Name: "mutex"},
+					},
+					Sel: &ast.Ident{Name: "Unlock"},
+				},
+			},
+		},
+	}
+}
+
+// createCallMethodStmt creates a statement calling a method of the mock.Call of an expectation.
+func createCallMethodStmt(name string, args ...ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.SelectorExpr{
+					X:   &ast.Ident{Name: "e"},
+					Sel: &ast.Ident{Name: "call"},
+				},
+				Sel: &ast.Ident{Name: name},
+			},
+			Args: args,
+		},
 	}
 }
 
-func createExpectationReturnMethodDecl(method *ast.Field) ast.Decl {
+func createExpectationWithArgsMethodDecl(typeName string, method *ast.Field, reserved map[string]bool) ast.Decl {
 	exportedName := method.Names[0].Name
-	unexportedName := compiler.ConvertToUnexported(exportedName)
 
 	// isMethod guarantees method.Type is *ast.FuncType
 	funcType := method.Type.(*ast.FuncType)
-	outputFields := normalizeFieldList(funcType.Results)
-	keyValueList := createKeyValueExprList(funcType.Results)
+	inputFields := normalizeFieldList(funcType.Params, reserved)
 
-	return &ast.FuncDecl{
-		Recv: &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Names: []*ast.Ident{
-						{Name: "e"},
-					},
-					Type: &ast.StarExpr{
-						X: &ast.Ident{Name: exportedName + "Expectation"},
-					},
+	return createExpectationMethodDecl(typeName, exportedName, "WithArgs", inputFields,
+		createCallMethodStmt("SetArgs", createIdentList(inputFields, reserved)...),
+	)
+}
+
+func createExpectationMatchMethodDecl(typeName string, method *ast.Field, reserved map[string]bool) ast.Decl {
+	exportedName := method.Names[0].Name
+
+	// isMethod guarantees method.Type is *ast.FuncType
+	funcType := method.Type.(*ast.FuncType)
+	inputFields := normalizeFieldList(funcType.Params, reserved)
+
+	// Each argument can be a value, a matcher, or a func(T) bool
+	params := &ast.FieldList{}
+	for _, f := range inputFields.List {
+		params.List = append(params.List, &ast.Field{
+			Names: f.Names,
+			Type:  &ast.Ident{Name: "interface{}"},
+		})
+	}
+
+	return createExpectationMethodDecl(typeName, exportedName, "Match", params,
+		createCallMethodStmt("SetArgs", createIdentList(inputFields, reserved)...),
+	)
+}
+
+func createExpectationReturnMethodDecl(typeName string, method *ast.Field, reserved map[string]bool) ast.Decl {
+	exportedName := method.Names[0].Name
+	unexportedPrefix := compiler.ConvertToUnexported(typeName) + exportedName
+
+	// isMethod guarantees method.Type is *ast.FuncType
+	funcType := method.Type.(*ast.FuncType)
+	outputFields := normalizeFieldList(funcType.Results, reserved)
+	keyValueList := createKeyValueExprList(funcType.Results, reserved)
+
+	return createExpectationMethodDecl(typeName, exportedName, "Return", outputFields,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   &ast.Ident{Name: "e"},
+					Sel: &ast.Ident{Name: "outputs"},
+				},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CompositeLit{
+					Type: &ast.Ident{Name: unexportedPrefix + "Outputs"},
+					Elts: keyValueList,
 				},
 			},
 		},
-		Name: &ast.Ident{Name: "Return"},
-		Type: &ast.FuncType{
-			Params: outputFields,
-			Results: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Type: &ast.StarExpr{
-							X: &ast.Ident{Name: exportedName + "Expectation"},
-						},
-					},
+	)
+}
+
+// createExpectationCallbackMethodDecl creates a method for setting a callback field of an expectation.
+func createExpectationCallbackMethodDecl(typeName, methodName, name, field string, callbackType ast.Expr) ast.Decl {
+	params := &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Names: []*ast.Ident{
+					{Name: "callback"},
 				},
+				Type: callbackType,
 			},
 		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   &ast.Ident{Name: "e"},
-							Sel: &ast.Ident{Name: "outputs"},
-						},
-					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: &ast.Ident{Name: unexportedName + "Outputs"},
-								Elts: keyValueList,
-							},
-						},
-					},
+	}
+
+	return createExpectationMethodDecl(typeName, methodName, name, params,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   &ast.Ident{Name: "e"},
+					Sel: &ast.Ident{Name: field},
 				},
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						&ast.Ident{Name: "e"},
-					},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.Ident{Name: "callback"},
+			},
+		},
+	)
+}
+
+func createExpectationDoMethodDecl(typeName string, method *ast.Field) ast.Decl {
+	exportedName := method.Names[0].Name
+
+	// isMethod guarantees method.Type is *ast.FuncType
+	funcType := method.Type.(*ast.FuncType)
+
+	return createExpectationCallbackMethodDecl(typeName, exportedName, "Do", "do", &ast.FuncType{
+		Params: funcType.Params,
+	})
+}
+
+func createExpectationCallMethodDecl(typeName string, method *ast.Field) ast.Decl {
+	exportedName := method.Names[0].Name
+
+	return createExpectationCallbackMethodDecl(typeName, exportedName, "Call", "callback", method.Type)
+}
+
+func createExpectationTimesMethodDecls(typeName string, method *ast.Field) []ast.Decl {
+	exportedName := method.Names[0].Name
+
+	nParams := &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Names: []*ast.Ident{
+					{Name: "n"},
 				},
+				Type: &ast.Ident{Name: "int"},
 			},
 		},
 	}
+
+	return []ast.Decl{
+		createExpectationMethodDecl(typeName, exportedName, "Times", nParams,
+			createCallMethodStmt("Times", &ast.Ident{Name: "n"}),
+		),
+		createExpectationMethodDecl(typeName, exportedName, "AtLeast", nParams,
+			createCallMethodStmt("AtLeast", &ast.Ident{Name: "n"}),
+		),
+		createExpectationMethodDecl(typeName, exportedName, "AnyTimes", &ast.FieldList{},
+			createCallMethodStmt("AnyTimes"),
+		),
+		createExpectationMethodDecl(typeName, exportedName, "Maybe", &ast.FieldList{},
+			createCallMethodStmt("Maybe"),
+		),
+	}
 }
 
-func createExpectationCallMethodDecl(method *ast.Field) ast.Decl {
+func createExpectationMockCallMethodDecl(typeName string, method *ast.Field) ast.Decl {
 	exportedName := method.Names[0].Name
 
 	return &ast.FuncDecl{
@@ -828,28 +1113,22 @@ func createExpectationCallMethodDecl(method *ast.Field) ast.Decl {
 						{Name: "e"},
 					},
 					Type: &ast.StarExpr{
-						X: &ast.Ident{Name: exportedName + "Expectation"},
+						X: &ast.Ident{Name: typeName + exportedName + "Expectation"},
 					},
 				},
 			},
 		},
-		Name: &ast.Ident{Name: "Call"},
+		Name: &ast.Ident{Name: "MockCall"},
 		Type: &ast.FuncType{
-			Params: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{
-							{Name: "callback"},
-						},
-						Type: method.Type,
-					},
-				},
-			},
+			Params: &ast.FieldList{},
 			Results: &ast.FieldList{
 				List: []*ast.Field{
 					{
 						Type: &ast.StarExpr{
-							X: &ast.Ident{Name: exportedName + "Expectation"},
+							X: &ast.SelectorExpr{
+								X:   &ast.Ident{Name: "mock"},
+								Sel: &ast.Ident{Name: "Call"},
+							},
 						},
 					},
 				},
@@ -857,22 +1136,13 @@ func createExpectationCallMethodDecl(method *ast.Field) ast.Decl {
 		},
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
 						&ast.SelectorExpr{
 							X:   &ast.Ident{Name: "e"},
-							Sel: &ast.Ident{Name: "callback"},
+							Sel: &ast.Ident{Name: "call"},
 						},
 					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						&ast.Ident{Name: "callback"},
-					},
-				},
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						&ast.Ident{Name: "e"},
-					},
 				},
 			},
 		},
@@ -928,30 +1198,29 @@ func createImplStructDecl(typeName string) ast.Decl {
 	}
 }
 
-func createImplMethodDecl(typeName string, method *ast.Field) ast.Decl {
+func createImplMethodDecl(typeName string, method *ast.Field, reserved map[string]bool, typeOf func(ast.Expr) types.Type) ast.Decl {
 	exportedName := method.Names[0].Name
 	unexportedName := compiler.ConvertToUnexported(exportedName)
 
 	// isMethod guarantees method.Type is *ast.FuncType
 	funcType := method.Type.(*ast.FuncType)
-	inputFields := normalizeFieldList(funcType.Params)
-	outputFields := normalizeFieldList(funcType.Results)
+	inputFields := normalizeFieldList(funcType.Params, reserved)
+	outputFields := normalizeFieldList(funcType.Results, reserved)
 
-	inputsAssignElts := []ast.Expr{}
-	for _, f := range inputFields.List {
-		for _, id := range f.Names {
-			inputsAssignElts = append(inputsAssignElts, &ast.KeyValueExpr{
-				Key:   &ast.Ident{Name: id.Name},
-				Value: &ast.Ident{Name: id.Name},
-			})
+	// The method parameters keep the trailing arguments (for variadic functions)
+	var ellipsis token.Pos
+	params := &ast.FieldList{}
+	for i, f := range inputFields.List {
+		typ := funcType.Params.List[i].Type
+		if _, ok := typ.(*ast.Ellipsis); ok {
+			// Any valid position makes the printer pass the trailing arguments to the callback
+			ellipsis = 1
 		}
-	}
 
-	callbackArgs := []ast.Expr{}
-	for _, f := range inputFields.List {
-		for _, id := range f.Names {
-			callbackArgs = append(callbackArgs, &ast.Ident{Name: id.Name})
-		}
+		params.List = append(params.List, &ast.Field{
+			Names: f.Names,
+			Type:  typ,
+		})
 	}
 
 	outputResults := []ast.Expr{}
@@ -970,7 +1239,40 @@ func createImplMethodDecl(typeName string, method *ast.Field) ast.Decl {
 	outputZeroResults := []ast.Expr{}
 	for _, f := range outputFields.List {
 		for range f.Names {
-			outputZeroResults = append(outputZeroResults, createZeroValueExpr(f.Type))
+			outputZeroResults = append(outputZeroResults, compiler.ZeroValueExpr(f.Type, typeOf(f.Type)))
+		}
+	}
+
+	doCall := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   &ast.Ident{Name: "e"},
+			Sel: &ast.Ident{Name: "do"},
+		},
+		Args:     createIdentList(inputFields, reserved),
+		Ellipsis: ellipsis,
+	}
+
+	callbackCall := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   &ast.Ident{Name: "e"},
+			Sel: &ast.Ident{Name: "callback"},
+		},
+		Args:     createIdentList(inputFields, reserved),
+		Ellipsis: ellipsis,
+	}
+
+	// A callback with no return values cannot be returned
+	var callbackStmts []ast.Stmt
+	if len(outputResults) == 0 {
+		callbackStmts = []ast.Stmt{
+			&ast.ExprStmt{X: callbackCall},
+			&ast.ReturnStmt{},
+		}
+	} else {
+		callbackStmts = []ast.Stmt{
+			&ast.ReturnStmt{
+				Results: []ast.Expr{callbackCall},
+			},
 		}
 	}
 
@@ -989,29 +1291,63 @@ func createImplMethodDecl(typeName string, method *ast.Field) ast.Decl {
 		},
 		Name: &ast.Ident{Name: exportedName},
 		Type: &ast.FuncType{
-			Params:  inputFields,
+			Params:  params,
 			Results: funcType.Results,
 		},
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
+				// The expectations are locked only for finding and recording the expected call,
+				// so the callbacks can call the mock again.
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.SelectorExpr{
+								X: &ast.SelectorExpr{
+									X:   &ast.Ident{Name: "i"},
+									Sel: &ast.Ident{Name: "expectations"},
+								},
+								Sel: &ast.Ident{Name: "mutex"},
+							},
+							Sel: &ast.Ident{Name: "Lock"},
+						},
+					},
+				},
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: typeName + exportedName + "Expectation"},
+								},
+							},
+						},
+					},
+				},
 				&ast.AssignStmt{
 					Lhs: []ast.Expr{
-						&ast.Ident{Name: "inputs"},
+						&ast.Ident{Name: "calls"},
 					},
 					Tok: token.DEFINE,
 					Rhs: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: &ast.Ident{Name: unexportedName + "Inputs"},
-								Elts: inputsAssignElts,
+						&ast.CompositeLit{
+							Type: &ast.ArrayType{
+								Elt: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   &ast.Ident{Name: "mock"},
+										Sel: &ast.Ident{Name: "Call"},
+									},
+								},
 							},
 						},
 					},
 				},
 				&ast.RangeStmt{
 					Key:   &ast.Ident{Name: "_"},
-					Value: &ast.Ident{Name: "e"},
+					Value: &ast.Ident{Name: "expectation"},
 					Tok:   token.DEFINE,
 					X: &ast.SelectorExpr{
 						X: &ast.SelectorExpr{
@@ -1023,71 +1359,47 @@ func createImplMethodDecl(typeName string, method *ast.Field) ast.Decl {
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
 							&ast.IfStmt{
-								Cond: &ast.BinaryExpr{
-									X: &ast.BinaryExpr{
+								Cond: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
 										X: &ast.SelectorExpr{
-											X:   &ast.Ident{Name: "e"},
-											Sel: &ast.Ident{Name: "inputs"},
-										},
-										Op: token.EQL,
-										Y:  &ast.Ident{Name: "nil"},
-									},
-									Op: token.LOR,
-									Y: &ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   &ast.Ident{Name: "reflect"},
-											Sel: &ast.Ident{Name: "DeepEqual"},
-										},
-										Args: []ast.Expr{
-											&ast.SelectorExpr{
-												X:   &ast.Ident{Name: "e"},
-												Sel: &ast.Ident{Name: "inputs"},
-											},
-											&ast.Ident{Name: "inputs"},
+											X:   &ast.Ident{Name: "expectation"},
+											Sel: &ast.Ident{Name: "call"},
 										},
+										Sel: &ast.Ident{Name: "TryRecord"},
 									},
+									Args: createIdentList(inputFields, reserved),
 								},
 								Body: &ast.BlockStmt{
 									List: []ast.Stmt{
 										&ast.AssignStmt{
 											Lhs: []ast.Expr{
-												&ast.SelectorExpr{
-													X:   &ast.Ident{Name: "e"},
-													Sel: &ast.Ident{Name: "recorded"},
-												},
+												&ast.Ident{Name: "e"},
 											},
 											Tok: token.ASSIGN,
 											Rhs: []ast.Expr{
-												&ast.Ident{Name: "inputs"},
+												&ast.Ident{Name: "expectation"},
 											},
 										},
-										&ast.IfStmt{
-											Cond: &ast.BinaryExpr{
-												X: &ast.SelectorExpr{
-													X:   &ast.Ident{Name: "e"},
-													Sel: &ast.Ident{Name: "callback"},
-												},
-												Op: token.NEQ,
-												Y:  &ast.Ident{Name: "nil"},
-											},
-											Body: &ast.BlockStmt{
-												List: []ast.Stmt{
-													&ast.ReturnStmt{
-														Results: []ast.Expr{
-															&ast.CallExpr{
-																Fun: &ast.SelectorExpr{
-																	X:   &ast.Ident{Name: "e"},
-																	Sel: &ast.Ident{Name: "callback"},
-																},
-																Args: callbackArgs,
-															},
-														},
-													},
-												},
-											},
+										&ast.BranchStmt{
+											Tok: token.BREAK,
 										},
-										&ast.ReturnStmt{
-											Results: outputResults,
+									},
+								},
+							},
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									&ast.Ident{Name: "calls"},
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun: &ast.Ident{Name: "append"},
+										Args: []ast.Expr{
+											&ast.Ident{Name: "calls"},
+											&ast.SelectorExpr{
+												X:   &ast.Ident{Name: "expectation"},
+												Sel: &ast.Ident{Name: "call"},
+											},
 										},
 									},
 								},
@@ -1099,32 +1411,93 @@ func createImplMethodDecl(typeName string, method *ast.Field) ast.Decl {
 					X: &ast.CallExpr{
 						Fun: &ast.SelectorExpr{
 							X: &ast.SelectorExpr{
-								X:   &ast.Ident{Name: "i"},
-								Sel: &ast.Ident{Name: "t"},
+								X: &ast.SelectorExpr{
+									X:   &ast.Ident{Name: "i"},
+									Sel: &ast.Ident{Name: "expectations"},
+								},
+								Sel: &ast.Ident{Name: "mutex"},
 							},
-							Sel: &ast.Ident{Name: "Errorf"},
+							Sel: &ast.Ident{Name: "Unlock"},
 						},
-						Args: []ast.Expr{
-							&ast.BasicLit{
-								Value: fmt.Sprintf(`"\nExpectation missing: %s method called with %%s"`, exportedName),
-							},
-							&ast.CallExpr{
-								Fun: &ast.SelectorExpr{
-									X: &ast.SelectorExpr{
-										X:   &ast.Ident{Name: "i"},
-										Sel: &ast.Ident{Name: "spew"},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  &ast.Ident{Name: "e"},
+						Op: token.EQL,
+						Y:  &ast.Ident{Name: "nil"},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "i"},
+											Sel: &ast.Ident{Name: "t"},
+										},
+										Sel: &ast.Ident{Name: "Errorf"},
+									},
+									Args: []ast.Expr{
+										&ast.BasicLit{
+											Value: `"\nExpectation missing: %s"`,
+										},
+										&ast.CallExpr{
+											Fun: &ast.SelectorExpr{
+												X:   &ast.Ident{Name: "mock"},
+												Sel: &ast.Ident{Name: "Diff"},
+											},
+											Args: append([]ast.Expr{
+												&ast.SelectorExpr{
+													X:   &ast.Ident{Name: "i"},
+													Sel: &ast.Ident{Name: "spew"},
+												},
+												&ast.BasicLit{
+													Kind:  token.STRING,
+													Value: fmt.Sprintf("%q", exportedName),
+												},
+												&ast.Ident{Name: "calls"},
+											}, createIdentList(inputFields, reserved)...),
+										},
 									},
-									Sel: &ast.Ident{Name: "Sdump"},
-								},
-								Args: []ast.Expr{
-									&ast.Ident{Name: "inputs"},
 								},
 							},
+							&ast.ReturnStmt{
+								Results: outputZeroResults,
+							},
 						},
 					},
 				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "e"},
+							Sel: &ast.Ident{Name: "do"},
+						},
+						Op: token.NEQ,
+						Y:  &ast.Ident{Name: "nil"},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{X: doCall},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "e"},
+							Sel: &ast.Ident{Name: "callback"},
+						},
+						Op: token.NEQ,
+						Y:  &ast.Ident{Name: "nil"},
+					},
+					Body: &ast.BlockStmt{
+						List: callbackStmts,
+					},
+				},
 				&ast.ReturnStmt{
-					Results: outputZeroResults,
+					Results: outputResults,
 				},
 			},
 		},
@@ -9,11 +9,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/moorara/gelato/internal/service/compiler"
+	"github.com/moorara/gelato/internal/service/compiler/compilertest"
 	"github.com/moorara/gelato/internal/log"
 )
 
 func TestNew(t *testing.T) {
-	c := New(log.Info)
+	c := New(log.Info, compiler.DefaultLayout())
 
 	assert.NotNil(t, c)
 	assert.IsType(t, &compiler.Compiler{}, c)
@@ -84,6 +85,7 @@ func TestMocker_FilePre(t *testing.T) {
 func TestMocker_FilePost(t *testing.T) {
 	tests := []struct {
 		name          string
+		layout        compiler.Layout
 		imports       []ast.Spec
 		decls         []ast.Decl
 		info          *compiler.FileInfo
@@ -92,6 +94,7 @@ func TestMocker_FilePost(t *testing.T) {
 	}{
 		{
 			name:          "NoDeclaration",
+			layout:        compiler.DefaultLayout(),
 			imports:       nil,
 			decls:         nil,
 			info:          &compiler.FileInfo{},
@@ -99,7 +102,8 @@ func TestMocker_FilePost(t *testing.T) {
 			expectedError: "",
 		},
 		{
-			name: "WriteFileFails",
+			name:   "WriteFileFails",
+			layout: compiler.DefaultLayout(),
 			imports: []ast.Spec{
 				&ast.ImportSpec{
 					Path: &ast.BasicLit{Value: `"fmt"`},
@@ -133,7 +137,43 @@ func TestMocker_FilePost(t *testing.T) {
 			expectedError: "mkdir /dev/null: not a directory",
 		},
 		{
-			name: "Success",
+			name:   "Success",
+			layout: compiler.DefaultLayout(),
+			imports: []ast.Spec{
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{Value: `"fmt"`},
+				},
+			},
+			decls: []ast.Decl{
+				&ast.GenDecl{
+					Tok: token.VAR,
+					Specs: []ast.Spec{
+						&ast.ValueSpec{
+							Names: []*ast.Ident{
+								&ast.Ident{Name: "dummy"},
+							},
+							Type: &ast.Ident{Name: "string"},
+						},
+					},
+				},
+			},
+			info: &compiler.FileInfo{
+				PackageInfo: compiler.PackageInfo{
+					ModuleName:  "github.com/octocat/service",
+					PackageName: "lookup",
+					ImportPath:  "github.com/octocat/service/internal/lookup",
+					BaseDir:     "./service",
+					RelativeDir: "internal/lookup",
+				},
+				FileName: "lookup.go",
+				FileSet:  token.NewFileSet(),
+			},
+			file:          &ast.File{},
+			expectedError: "",
+		},
+		{
+			name:   "Success_NextToSource",
+			layout: compiler.Layout{},
 			imports: []ast.Spec{
 				&ast.ImportSpec{
 					Path: &ast.BasicLit{Value: `"fmt"`},
@@ -171,6 +211,7 @@ func TestMocker_FilePost(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			m := &mocker{
+				layout:  tc.layout,
 				imports: tc.imports,
 				decls:   tc.decls,
 			}
@@ -543,6 +584,34 @@ func TestMocker_Interface(t *testing.T) {
 					},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X: &ast.SelectorExpr{
+												X:   &ast.Ident{Name: "m"},
+												Sel: &ast.Ident{Name: "expectations"},
+											},
+											Sel: &ast.Ident{Name: "mutex"},
+										},
+										Sel: &ast.Ident{Name: "Lock"},
+									},
+								},
+							},
+							&ast.DeferStmt{
+								Call: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X: &ast.SelectorExpr{
+												X:   &ast.Ident{Name: "m"},
+												Sel: &ast.Ident{Name: "expectations"},
+											},
+											Sel: &ast.Ident{Name: "mutex"},
+										},
+										Sel: &ast.Ident{Name: "Unlock"},
+									},
+								},
+							},
 							&ast.RangeStmt{
 								Key:   &ast.Ident{Name: "_"},
 								Value: &ast.Ident{Name: "e"},
@@ -557,13 +626,17 @@ func TestMocker_Interface(t *testing.T) {
 								Body: &ast.BlockStmt{
 									List: []ast.Stmt{
 										&ast.IfStmt{
-											Cond: &ast.BinaryExpr{
-												X: &ast.SelectorExpr{
-													X:   &ast.Ident{Name: "e"},
-													Sel: &ast.Ident{Name: "recorded"},
+											Cond: &ast.UnaryExpr{
+												Op: token.NOT,
+												X: &ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X: &ast.SelectorExpr{
+															X:   &ast.Ident{Name: "e"},
+															Sel: &ast.Ident{Name: "call"},
+														},
+														Sel: &ast.Ident{Name: "Satisfied"},
+													},
 												},
-												Op: token.EQL,
-												Y:  &ast.Ident{Name: "nil"},
 											},
 											Body: &ast.BlockStmt{
 												List: []ast.Stmt{
@@ -578,20 +651,20 @@ func TestMocker_Interface(t *testing.T) {
 															},
 															Args: []ast.Expr{
 																&ast.BasicLit{
-																	Value: `"\nExpected Lookup method be called with %s"`,
+																	Value: `"\nExpected %s"`,
 																},
 																&ast.CallExpr{
 																	Fun: &ast.SelectorExpr{
 																		X: &ast.SelectorExpr{
-																			X:   &ast.Ident{Name: "m"},
-																			Sel: &ast.Ident{Name: "spew"},
+																			X:   &ast.Ident{Name: "e"},
+																			Sel: &ast.Ident{Name: "call"},
 																		},
-																		Sel: &ast.Ident{Name: "Sdump"},
+																		Sel: &ast.Ident{Name: "Describe"},
 																	},
 																	Args: []ast.Expr{
 																		&ast.SelectorExpr{
-																			X:   &ast.Ident{Name: "e"},
-																			Sel: &ast.Ident{Name: "inputs"},
+																			X:   &ast.Ident{Name: "m"},
+																			Sel: &ast.Ident{Name: "spew"},
 																		},
 																	},
 																},
@@ -618,13 +691,22 @@ func TestMocker_Interface(t *testing.T) {
 							Type: &ast.StructType{
 								Fields: &ast.FieldList{
 									List: []*ast.Field{
+										{
+											Names: []*ast.Ident{
+												{Name: "mutex"},
+											},
+											Type: &ast.SelectorExpr{
+												X:   &ast.Ident{Name: "sync"},
+												Sel: &ast.Ident{Name: "Mutex"},
+											},
+										},
 										{
 											Names: []*ast.Ident{
 												{Name: "lookupExpectations"},
 											},
 											Type: &ast.ArrayType{
 												Elt: &ast.StarExpr{
-													X: &ast.Ident{Name: "LookupExpectation"},
+													X: &ast.Ident{Name: "ServiceLookupExpectation"},
 												},
 											},
 										},
@@ -655,7 +737,7 @@ func TestMocker_Interface(t *testing.T) {
 							List: []*ast.Field{
 								{
 									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: "LookupExpectation"},
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
 									},
 								},
 							},
@@ -663,16 +745,55 @@ func TestMocker_Interface(t *testing.T) {
 					},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "mutex"},
+										},
+										Sel: &ast.Ident{Name: "Lock"},
+									},
+								},
+							},
+							&ast.DeferStmt{
+								Call: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "mutex"},
+										},
+										Sel: &ast.Ident{Name: "Unlock"},
+									},
+								},
+							},
 							&ast.AssignStmt{
 								Lhs: []ast.Expr{
 									&ast.Ident{Name: "expectation"},
 								},
 								Tok: token.DEFINE,
 								Rhs: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.Ident{Name: "new"},
-										Args: []ast.Expr{
-											&ast.Ident{Name: "LookupExpectation"},
+									&ast.UnaryExpr{
+										Op: token.AND,
+										X: &ast.CompositeLit{
+											Type: &ast.Ident{Name: "ServiceLookupExpectation"},
+											Elts: []ast.Expr{
+												&ast.KeyValueExpr{
+													Key: &ast.Ident{Name: "call"},
+													Value: &ast.CallExpr{
+														Fun: &ast.SelectorExpr{
+															X:   &ast.Ident{Name: "mock"},
+															Sel: &ast.Ident{Name: "NewCall"},
+														},
+														Args: []ast.Expr{
+															&ast.BasicLit{
+																Kind:  token.STRING,
+																Value: `"Lookup"`,
+															},
+														},
+													},
+												},
+											},
 										},
 									},
 								},
@@ -712,25 +833,42 @@ func TestMocker_Interface(t *testing.T) {
 					Specs: []ast.Spec{
 						&ast.TypeSpec{
 							Name: &ast.Ident{
-								Name: "LookupExpectation",
+								Name: "ServiceLookupExpectation",
 							},
 							Type: &ast.StructType{
 								Fields: &ast.FieldList{
 									List: []*ast.Field{
 										{
 											Names: []*ast.Ident{
-												{Name: "inputs"},
+												{Name: "call"},
 											},
 											Type: &ast.StarExpr{
-												X: &ast.Ident{Name: "lookupInputs"},
+												X: &ast.SelectorExpr{
+													X:   &ast.Ident{Name: "mock"},
+													Sel: &ast.Ident{Name: "Call"},
+												},
 											},
 										},
 										{
 											Names: []*ast.Ident{
 												{Name: "outputs"},
 											},
-											Type: &ast.StarExpr{
-												X: &ast.Ident{Name: "lookupOutputs"},
+											Type: &ast.Ident{Name: "serviceLookupOutputs"},
+										},
+										{
+											Names: []*ast.Ident{
+												{Name: "do"},
+											},
+											Type: &ast.FuncType{
+												Params: &ast.FieldList{
+													List: []*ast.Field{
+														{
+															Type: &ast.StarExpr{
+																X: &ast.Ident{Name: "Request"},
+															},
+														},
+													},
+												},
 											},
 										},
 										{
@@ -761,38 +899,6 @@ func TestMocker_Interface(t *testing.T) {
 												},
 											},
 										},
-										{
-											Names: []*ast.Ident{
-												{Name: "recorded"},
-											},
-											Type: &ast.StarExpr{
-												X: &ast.Ident{Name: "lookupInputs"},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-				&ast.GenDecl{
-					Tok: token.TYPE,
-					Specs: []ast.Spec{
-						&ast.TypeSpec{
-							Name: &ast.Ident{
-								Name: "lookupInputs",
-							},
-							Type: &ast.StructType{
-								Fields: &ast.FieldList{
-									List: []*ast.Field{
-										{
-											Names: []*ast.Ident{
-												{Name: "request"},
-											},
-											Type: &ast.StarExpr{
-												X: &ast.Ident{Name: "Request"},
-											},
-										},
 									},
 								},
 							},
@@ -804,7 +910,7 @@ func TestMocker_Interface(t *testing.T) {
 					Specs: []ast.Spec{
 						&ast.TypeSpec{
 							Name: &ast.Ident{
-								Name: "lookupOutputs",
+								Name: "serviceLookupOutputs",
 							},
 							Type: &ast.StructType{
 								Fields: &ast.FieldList{
@@ -819,7 +925,7 @@ func TestMocker_Interface(t *testing.T) {
 										},
 										{
 											Names: []*ast.Ident{
-												{Name: "error"},
+												{Name: "err"},
 											},
 											Type: &ast.Ident{Name: "error"},
 										},
@@ -838,7 +944,7 @@ func TestMocker_Interface(t *testing.T) {
 									{Name: "e"},
 								},
 								Type: &ast.StarExpr{
-									X: &ast.Ident{Name: "LookupExpectation"},
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
 								},
 							},
 						},
@@ -861,7 +967,7 @@ func TestMocker_Interface(t *testing.T) {
 							List: []*ast.Field{
 								{
 									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: "LookupExpectation"},
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
 									},
 								},
 							},
@@ -869,26 +975,77 @@ func TestMocker_Interface(t *testing.T) {
 					},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
-							&ast.AssignStmt{
-								Lhs: []ast.Expr{
-									&ast.SelectorExpr{
-										X:   &ast.Ident{Name: "e"},
-										Sel: &ast.Ident{Name: "inputs"},
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "call"},
+										},
+										Sel: &ast.Ident{Name: "SetArgs"},
+									},
+									Args: []ast.Expr{
+										&ast.Ident{Name: "request"},
 									},
 								},
-								Tok: token.ASSIGN,
-								Rhs: []ast.Expr{
-									&ast.UnaryExpr{
-										Op: token.AND,
-										X: &ast.CompositeLit{
-											Type: &ast.Ident{Name: "lookupInputs"},
-											Elts: []ast.Expr{
-												&ast.KeyValueExpr{
-													Key:   &ast.Ident{Name: "request"},
-													Value: &ast.Ident{Name: "request"},
-												},
-											},
+							},
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.Ident{Name: "e"},
+								},
+							},
+						},
+					},
+				},
+				// Expectation Match method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "Match"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										{Name: "request"},
+									},
+									Type: &ast.Ident{Name: "interface{}"},
+								},
+							},
+						},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "call"},
 										},
+										Sel: &ast.Ident{Name: "SetArgs"},
+									},
+									Args: []ast.Expr{
+										&ast.Ident{Name: "request"},
 									},
 								},
 							},
@@ -909,7 +1066,7 @@ func TestMocker_Interface(t *testing.T) {
 									{Name: "e"},
 								},
 								Type: &ast.StarExpr{
-									X: &ast.Ident{Name: "LookupExpectation"},
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
 								},
 							},
 						},
@@ -928,7 +1085,7 @@ func TestMocker_Interface(t *testing.T) {
 								},
 								{
 									Names: []*ast.Ident{
-										{Name: "error"},
+										{Name: "err"},
 									},
 									Type: &ast.Ident{Name: "error"},
 								},
@@ -938,7 +1095,7 @@ func TestMocker_Interface(t *testing.T) {
 							List: []*ast.Field{
 								{
 									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: "LookupExpectation"},
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
 									},
 								},
 							},
@@ -955,19 +1112,16 @@ func TestMocker_Interface(t *testing.T) {
 								},
 								Tok: token.ASSIGN,
 								Rhs: []ast.Expr{
-									&ast.UnaryExpr{
-										Op: token.AND,
-										X: &ast.CompositeLit{
-											Type: &ast.Ident{Name: "lookupOutputs"},
-											Elts: []ast.Expr{
-												&ast.KeyValueExpr{
-													Key:   &ast.Ident{Name: "response"},
-													Value: &ast.Ident{Name: "response"},
-												},
-												&ast.KeyValueExpr{
-													Key:   &ast.Ident{Name: "error"},
-													Value: &ast.Ident{Name: "error"},
-												},
+									&ast.CompositeLit{
+										Type: &ast.Ident{Name: "serviceLookupOutputs"},
+										Elts: []ast.Expr{
+											&ast.KeyValueExpr{
+												Key:   &ast.Ident{Name: "response"},
+												Value: &ast.Ident{Name: "response"},
+											},
+											&ast.KeyValueExpr{
+												Key:   &ast.Ident{Name: "err"},
+												Value: &ast.Ident{Name: "err"},
 											},
 										},
 									},
@@ -981,7 +1135,7 @@ func TestMocker_Interface(t *testing.T) {
 						},
 					},
 				},
-				// Expectation Call method
+				// Expectation Do method
 				&ast.FuncDecl{
 					Recv: &ast.FieldList{
 						List: []*ast.Field{
@@ -990,12 +1144,12 @@ func TestMocker_Interface(t *testing.T) {
 									{Name: "e"},
 								},
 								Type: &ast.StarExpr{
-									X: &ast.Ident{Name: "LookupExpectation"},
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
 								},
 							},
 						},
 					},
-					Name: &ast.Ident{Name: "Call"},
+					Name: &ast.Ident{Name: "Do"},
 					Type: &ast.FuncType{
 						Params: &ast.FieldList{
 							List: []*ast.Field{
@@ -1013,18 +1167,6 @@ func TestMocker_Interface(t *testing.T) {
 												},
 											},
 										},
-										Results: &ast.FieldList{
-											List: []*ast.Field{
-												{
-													Type: &ast.StarExpr{
-														X: &ast.Ident{Name: "Response"},
-													},
-												},
-												{
-													Type: &ast.Ident{Name: "error"},
-												},
-											},
-										},
 									},
 								},
 							},
@@ -1033,7 +1175,7 @@ func TestMocker_Interface(t *testing.T) {
 							List: []*ast.Field{
 								{
 									Type: &ast.StarExpr{
-										X: &ast.Ident{Name: "LookupExpectation"},
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
 									},
 								},
 							},
@@ -1045,7 +1187,7 @@ func TestMocker_Interface(t *testing.T) {
 								Lhs: []ast.Expr{
 									&ast.SelectorExpr{
 										X:   &ast.Ident{Name: "e"},
-										Sel: &ast.Ident{Name: "callback"},
+										Sel: &ast.Ident{Name: "do"},
 									},
 								},
 								Tok: token.ASSIGN,
@@ -1061,21 +1203,360 @@ func TestMocker_Interface(t *testing.T) {
 						},
 					},
 				},
-				// Implementation struct
-				&ast.GenDecl{
-					Tok: token.TYPE,
-					Specs: []ast.Spec{
-						&ast.TypeSpec{
-							Name: &ast.Ident{
-								Name: "ServiceImpl",
+				// Expectation Call method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
+								},
 							},
-							Type: &ast.StructType{
-								Fields: &ast.FieldList{
-									List: []*ast.Field{
-										{
-											Names: []*ast.Ident{
-												{Name: "t"},
-											},
+						},
+					},
+					Name: &ast.Ident{Name: "Call"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										{Name: "callback"},
+									},
+									Type: &ast.FuncType{
+										Params: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Type: &ast.StarExpr{
+														X: &ast.Ident{Name: "Request"},
+													},
+												},
+											},
+										},
+										Results: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Type: &ast.StarExpr{
+														X: &ast.Ident{Name: "Response"},
+													},
+												},
+												{
+													Type: &ast.Ident{Name: "error"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									&ast.SelectorExpr{
+										X:   &ast.Ident{Name: "e"},
+										Sel: &ast.Ident{Name: "callback"},
+									},
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.Ident{Name: "callback"},
+								},
+							},
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.Ident{Name: "e"},
+								},
+							},
+						},
+					},
+				},
+				// Expectation Times method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "Times"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										{Name: "n"},
+									},
+									Type: &ast.Ident{Name: "int"},
+								},
+							},
+						},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "call"},
+										},
+										Sel: &ast.Ident{Name: "Times"},
+									},
+									Args: []ast.Expr{
+										&ast.Ident{Name: "n"},
+									},
+								},
+							},
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.Ident{Name: "e"},
+								},
+							},
+						},
+					},
+				},
+				// Expectation AtLeast method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "AtLeast"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										{Name: "n"},
+									},
+									Type: &ast.Ident{Name: "int"},
+								},
+							},
+						},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "call"},
+										},
+										Sel: &ast.Ident{Name: "AtLeast"},
+									},
+									Args: []ast.Expr{
+										&ast.Ident{Name: "n"},
+									},
+								},
+							},
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.Ident{Name: "e"},
+								},
+							},
+						},
+					},
+				},
+				// Expectation AnyTimes method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "AnyTimes"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "call"},
+										},
+										Sel: &ast.Ident{Name: "AnyTimes"},
+									},
+								},
+							},
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.Ident{Name: "e"},
+								},
+							},
+						},
+					},
+				},
+				// Expectation Maybe method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "Maybe"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.Ident{Name: "ServiceLookupExpectation"},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "call"},
+										},
+										Sel: &ast.Ident{Name: "Maybe"},
+									},
+								},
+							},
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.Ident{Name: "e"},
+								},
+							},
+						},
+					},
+				},
+				// Expectation MockCall method
+				&ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{Name: "e"},
+								},
+								Type: &ast.StarExpr{
+									X: &ast.Ident{Name: "ServiceLookupExpectation"},
+								},
+							},
+						},
+					},
+					Name: &ast.Ident{Name: "MockCall"},
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "mock"},
+											Sel: &ast.Ident{Name: "Call"},
+										},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.SelectorExpr{
+										X:   &ast.Ident{Name: "e"},
+										Sel: &ast.Ident{Name: "call"},
+									},
+								},
+							},
+						},
+					},
+				},
+				// Implementation struct
+				&ast.GenDecl{
+					Tok: token.TYPE,
+					Specs: []ast.Spec{
+						&ast.TypeSpec{
+							Name: &ast.Ident{
+								Name: "ServiceImpl",
+							},
+							Type: &ast.StructType{
+								Fields: &ast.FieldList{
+									List: []*ast.Field{
+										{
+											Names: []*ast.Ident{
+												{Name: "t"},
+											},
 											Type: &ast.StarExpr{
 												X: &ast.SelectorExpr{
 													X:   &ast.Ident{Name: "testing"},
@@ -1151,20 +1632,47 @@ func TestMocker_Interface(t *testing.T) {
 					},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X: &ast.SelectorExpr{
+												X:   &ast.Ident{Name: "i"},
+												Sel: &ast.Ident{Name: "expectations"},
+											},
+											Sel: &ast.Ident{Name: "mutex"},
+										},
+										Sel: &ast.Ident{Name: "Lock"},
+									},
+								},
+							},
+							&ast.DeclStmt{
+								Decl: &ast.GenDecl{
+									Tok: token.VAR,
+									Specs: []ast.Spec{
+										&ast.ValueSpec{
+											Names: []*ast.Ident{
+												{Name: "e"},
+											},
+											Type: &ast.StarExpr{
+												X: &ast.Ident{Name: "ServiceLookupExpectation"},
+											},
+										},
+									},
+								},
+							},
 							&ast.AssignStmt{
 								Lhs: []ast.Expr{
-									&ast.Ident{Name: "inputs"},
+									&ast.Ident{Name: "calls"},
 								},
 								Tok: token.DEFINE,
 								Rhs: []ast.Expr{
-									&ast.UnaryExpr{
-										Op: token.AND,
-										X: &ast.CompositeLit{
-											Type: &ast.Ident{Name: "lookupInputs"},
-											Elts: []ast.Expr{
-												&ast.KeyValueExpr{
-													Key:   &ast.Ident{Name: "request"},
-													Value: &ast.Ident{Name: "request"},
+									&ast.CompositeLit{
+										Type: &ast.ArrayType{
+											Elt: &ast.StarExpr{
+												X: &ast.SelectorExpr{
+													X:   &ast.Ident{Name: "mock"},
+													Sel: &ast.Ident{Name: "Call"},
 												},
 											},
 										},
@@ -1173,7 +1681,7 @@ func TestMocker_Interface(t *testing.T) {
 							},
 							&ast.RangeStmt{
 								Key:   &ast.Ident{Name: "_"},
-								Value: &ast.Ident{Name: "e"},
+								Value: &ast.Ident{Name: "expectation"},
 								Tok:   token.DEFINE,
 								X: &ast.SelectorExpr{
 									X: &ast.SelectorExpr{
@@ -1185,87 +1693,48 @@ func TestMocker_Interface(t *testing.T) {
 								Body: &ast.BlockStmt{
 									List: []ast.Stmt{
 										&ast.IfStmt{
-											Cond: &ast.BinaryExpr{
-												X: &ast.BinaryExpr{
+											Cond: &ast.CallExpr{
+												Fun: &ast.SelectorExpr{
 													X: &ast.SelectorExpr{
-														X:   &ast.Ident{Name: "e"},
-														Sel: &ast.Ident{Name: "inputs"},
+														X:   &ast.Ident{Name: "expectation"},
+														Sel: &ast.Ident{Name: "call"},
 													},
-													Op: token.EQL,
-													Y:  &ast.Ident{Name: "nil"},
+													Sel: &ast.Ident{Name: "TryRecord"},
 												},
-												Op: token.LOR,
-												Y: &ast.CallExpr{
-													Fun: &ast.SelectorExpr{
-														X:   &ast.Ident{Name: "reflect"},
-														Sel: &ast.Ident{Name: "DeepEqual"},
-													},
-													Args: []ast.Expr{
-														&ast.SelectorExpr{
-															X:   &ast.Ident{Name: "e"},
-															Sel: &ast.Ident{Name: "inputs"},
-														},
-														&ast.Ident{Name: "inputs"},
-													},
+												Args: []ast.Expr{
+													&ast.Ident{Name: "request"},
 												},
 											},
 											Body: &ast.BlockStmt{
 												List: []ast.Stmt{
 													&ast.AssignStmt{
 														Lhs: []ast.Expr{
-															&ast.SelectorExpr{
-																X:   &ast.Ident{Name: "e"},
-																Sel: &ast.Ident{Name: "recorded"},
-															},
+															&ast.Ident{Name: "e"},
 														},
 														Tok: token.ASSIGN,
 														Rhs: []ast.Expr{
-															&ast.Ident{Name: "inputs"},
+															&ast.Ident{Name: "expectation"},
 														},
 													},
-													&ast.IfStmt{
-														Cond: &ast.BinaryExpr{
-															X: &ast.SelectorExpr{
-																X:   &ast.Ident{Name: "e"},
-																Sel: &ast.Ident{Name: "callback"},
-															},
-															Op: token.NEQ,
-															Y:  &ast.Ident{Name: "nil"},
-														},
-														Body: &ast.BlockStmt{
-															List: []ast.Stmt{
-																&ast.ReturnStmt{
-																	Results: []ast.Expr{
-																		&ast.CallExpr{
-																			Fun: &ast.SelectorExpr{
-																				X:   &ast.Ident{Name: "e"},
-																				Sel: &ast.Ident{Name: "callback"},
-																			},
-																			Args: []ast.Expr{
-																				&ast.Ident{Name: "request"},
-																			},
-																		},
-																	},
-																},
-															},
-														},
+													&ast.BranchStmt{
+														Tok: token.BREAK,
 													},
-													&ast.ReturnStmt{
-														Results: []ast.Expr{
-															&ast.SelectorExpr{
-																X: &ast.SelectorExpr{
-																	X:   &ast.Ident{Name: "e"},
-																	Sel: &ast.Ident{Name: "outputs"},
-																},
-																Sel: &ast.Ident{Name: "response"},
-															},
-															&ast.SelectorExpr{
-																X: &ast.SelectorExpr{
-																	X:   &ast.Ident{Name: "e"},
-																	Sel: &ast.Ident{Name: "outputs"},
-																},
-																Sel: &ast.Ident{Name: "error"},
-															},
+												},
+											},
+										},
+										&ast.AssignStmt{
+											Lhs: []ast.Expr{
+												&ast.Ident{Name: "calls"},
+											},
+											Tok: token.ASSIGN,
+											Rhs: []ast.Expr{
+												&ast.CallExpr{
+													Fun: &ast.Ident{Name: "append"},
+													Args: []ast.Expr{
+														&ast.Ident{Name: "calls"},
+														&ast.SelectorExpr{
+															X:   &ast.Ident{Name: "expectation"},
+															Sel: &ast.Ident{Name: "call"},
 														},
 													},
 												},
@@ -1278,23 +1747,114 @@ func TestMocker_Interface(t *testing.T) {
 								X: &ast.CallExpr{
 									Fun: &ast.SelectorExpr{
 										X: &ast.SelectorExpr{
-											X:   &ast.Ident{Name: "i"},
-											Sel: &ast.Ident{Name: "t"},
+											X: &ast.SelectorExpr{
+												X:   &ast.Ident{Name: "i"},
+												Sel: &ast.Ident{Name: "expectations"},
+											},
+											Sel: &ast.Ident{Name: "mutex"},
 										},
-										Sel: &ast.Ident{Name: "Errorf"},
+										Sel: &ast.Ident{Name: "Unlock"},
 									},
-									Args: []ast.Expr{
-										&ast.BasicLit{Value: `"\nExpectation missing: Lookup method called with %s"`},
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X: &ast.SelectorExpr{
-													X:   &ast.Ident{Name: "i"},
-													Sel: &ast.Ident{Name: "spew"},
+								},
+							},
+							&ast.IfStmt{
+								Cond: &ast.BinaryExpr{
+									X:  &ast.Ident{Name: "e"},
+									Op: token.EQL,
+									Y:  &ast.Ident{Name: "nil"},
+								},
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.ExprStmt{
+											X: &ast.CallExpr{
+												Fun: &ast.SelectorExpr{
+													X: &ast.SelectorExpr{
+														X:   &ast.Ident{Name: "i"},
+														Sel: &ast.Ident{Name: "t"},
+													},
+													Sel: &ast.Ident{Name: "Errorf"},
+												},
+												Args: []ast.Expr{
+													&ast.BasicLit{
+														Value: `"\nExpectation missing: %s"`,
+													},
+													&ast.CallExpr{
+														Fun: &ast.SelectorExpr{
+															X:   &ast.Ident{Name: "mock"},
+															Sel: &ast.Ident{Name: "Diff"},
+														},
+														Args: []ast.Expr{
+															&ast.SelectorExpr{
+																X:   &ast.Ident{Name: "i"},
+																Sel: &ast.Ident{Name: "spew"},
+															},
+															&ast.BasicLit{
+																Kind:  token.STRING,
+																Value: `"Lookup"`,
+															},
+															&ast.Ident{Name: "calls"},
+															&ast.Ident{Name: "request"},
+														},
+													},
 												},
-												Sel: &ast.Ident{Name: "Sdump"},
 											},
-											Args: []ast.Expr{
-												&ast.Ident{Name: "inputs"},
+										},
+										&ast.ReturnStmt{
+											Results: []ast.Expr{
+												&ast.Ident{Name: "nil"},
+												&ast.Ident{Name: "nil"},
+											},
+										},
+									},
+								},
+							},
+							&ast.IfStmt{
+								Cond: &ast.BinaryExpr{
+									X: &ast.SelectorExpr{
+										X:   &ast.Ident{Name: "e"},
+										Sel: &ast.Ident{Name: "do"},
+									},
+									Op: token.NEQ,
+									Y:  &ast.Ident{Name: "nil"},
+								},
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.ExprStmt{
+											X: &ast.CallExpr{
+												Fun: &ast.SelectorExpr{
+													X:   &ast.Ident{Name: "e"},
+													Sel: &ast.Ident{Name: "do"},
+												},
+												Args: []ast.Expr{
+													&ast.Ident{Name: "request"},
+												},
+											},
+										},
+									},
+								},
+							},
+							&ast.IfStmt{
+								Cond: &ast.BinaryExpr{
+									X: &ast.SelectorExpr{
+										X:   &ast.Ident{Name: "e"},
+										Sel: &ast.Ident{Name: "callback"},
+									},
+									Op: token.NEQ,
+									Y:  &ast.Ident{Name: "nil"},
+								},
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.ReturnStmt{
+											Results: []ast.Expr{
+												&ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X:   &ast.Ident{Name: "e"},
+														Sel: &ast.Ident{Name: "callback"},
+													},
+													Args: []ast.Expr{
+														&ast.Ident{Name: "request"},
+													},
+												},
 											},
 										},
 									},
@@ -1302,8 +1862,20 @@ func TestMocker_Interface(t *testing.T) {
 							},
 							&ast.ReturnStmt{
 								Results: []ast.Expr{
-									&ast.Ident{Name: "nil"},
-									&ast.Ident{Name: "nil"},
+									&ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "outputs"},
+										},
+										Sel: &ast.Ident{Name: "response"},
+									},
+									&ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   &ast.Ident{Name: "e"},
+											Sel: &ast.Ident{Name: "outputs"},
+										},
+										Sel: &ast.Ident{Name: "err"},
+									},
 								},
 							},
 						},
@@ -1323,3 +1895,153 @@ func TestMocker_Interface(t *testing.T) {
 		})
 	}
 }
+
+const raceStoreSrc = `package store
+
+import "context"
+
+// Store is a key-value store.
+type Store interface {
+	Get(key string) (string, error)
+	Put(key, value string)
+}
+
+// Checker has parameters named after packages, predeclared identifiers, and the identifiers in generated code.
+type Checker interface {
+	CheckHealth(context.Context) error
+	Check(i int, e string, calls []string) (bool, error)
+}
+`
+
+const raceTestSrc = `package store_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/moorara/gelato/pkg/mock"
+)
+
+func TestStore_Concurrent(t *testing.T) {
+	m := MockStore(t)
+	m.Expect().Get().WithArgs("key").Return("value", nil).Times(50)
+	m.Expect().Get().Match(mock.Any()).Return("", errors.New("not found")).AnyTimes()
+
+	s := m.Impl()
+	var found int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			if v, err := s.Get("key"); err == nil && v == "value" {
+				atomic.AddInt32(&found, 1)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			m.Expect().Put().Match(mock.Any(), mock.Type[string]()).Maybe()
+			s.Put("key", "value")
+		}()
+	}
+
+	wg.Wait()
+	m.Assert()
+
+	if found != 50 {
+		t.Errorf("expected 50 values, got %d", found)
+	}
+}
+
+func TestStore_Sequential(t *testing.T) {
+	m := MockStore(t)
+	m.Expect().Get().Return("value", nil)
+	m.Expect().Get().Return("", errors.New("not found"))
+
+	s := m.Impl()
+
+	if v, err := s.Get("key"); err != nil || v != "value" {
+		t.Errorf("unexpected value %q and error %v", v, err)
+	}
+
+	if v, err := s.Get("key"); err == nil || v != "" {
+		t.Errorf("unexpected value %q and error %v", v, err)
+	}
+
+	m.Assert()
+}
+
+func TestChecker(t *testing.T) {
+	m := MockChecker(t)
+	m.Expect().CheckHealth().WithArgs(context.Background()).Return(nil)
+	m.Expect().Check().Match(1, "e", mock.Any()).Return(true, nil)
+
+	c := m.Impl()
+
+	if err := c.CheckHealth(context.Background()); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if ok, err := c.Check(1, "e", nil); !ok || err != nil {
+		t.Errorf("unexpected result %t and error %v", ok, err)
+	}
+
+	m.Assert()
+}
+
+func TestStore_ConcurrentInOrder(t *testing.T) {
+	for n := 0; n < 500; n++ {
+		a, b := MockStore(t), MockStore(t)
+
+		// The calls to a after the ordered call to b fall back to the unordered expectation
+		first := a.Expect().Put().AnyTimes()
+		a.Expect().Put().AnyTimes()
+
+		var calls int
+		second := b.Expect().Put().Do(func(string, string) {
+			calls = first.MockCall().Calls()
+		})
+
+		mock.InOrder(first, second)
+
+		sa, sb := a.Impl(), b.Impl()
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sa.Put("key", "value")
+			}()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sb.Put("key", "value")
+		}()
+
+		wg.Wait()
+		a.Assert()
+		b.Assert()
+
+		if got := first.MockCall().Calls(); got != calls {
+			t.Fatalf("expected no call to the first expectation after the second one, got %d calls after %d", got, calls)
+		}
+	}
+}
+`
+
+// TestMocker_Race generates a mock for a module and runs a concurrent test using the mock with the race detector.
+func TestMocker_Race(t *testing.T) {
+	compilertest.GoTest(t, New(log.None, compiler.Layout{}), "example.com/race", map[string]string{
+		"store/store.go":           raceStoreSrc,
+		"store/store_race_test.go": raceTestSrc,
+	})
+}
@@ -3,6 +3,8 @@ package compiler
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
 	"regexp"
 	"strings"
 )
@@ -14,6 +16,14 @@ var (
 	re4 = regexp.MustCompile(`^([A-Z]+)[A-Z][0-9a-z_]`)
 )
 
+// conventionalNames are the conventional identifiers for the names inferred from some types (e.g. ctx for context.Context).
+var conventionalNames = map[string]string{
+	"context": "ctx",
+	"error":   "err",
+	"bool":    "ok",
+	"int":     "i",
+}
+
 // IsExported determines whether or not a given name is exported.
 func IsExported(name string) bool {
 	first := name[0:1]
@@ -48,15 +58,61 @@ func ConvertToUnexported(name string) string {
 }
 
 // InferName infers an identifier name from a type expression.
+// For embedded fields, this is the field name as defined by the Go specification (the unqualified type name).
 func InferName(expr ast.Expr) string {
-	// First, get the last identifier name
-	var lastName string
-	ast.Inspect(expr, func(n ast.Node) bool {
-		if id, ok := n.(*ast.Ident); ok {
-			lastName = id.Name
+	switch e := expr.(type) {
+	case *ast.Ident:
+		// Type literals may be represented as identifiers (e.g. interface{})
+		if token.IsIdentifier(e.Name) {
+			return e.Name
 		}
-		return true
-	})
+		return "value"
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.StarExpr:
+		return InferName(e.X)
+	case *ast.ParenExpr:
+		return InferName(e.X)
+	case *ast.Ellipsis:
+		return InferName(e.Elt)
+	case *ast.ArrayType:
+		return InferName(e.Elt)
+	case *ast.MapType:
+		return InferName(e.Value)
+	case *ast.ChanType:
+		return InferName(e.Value)
+	case *ast.FuncType:
+		return "fn"
+	default:
+		return "value"
+	}
+}
+
+// SafeName returns an identifier name that does not shadow anything in the scope it is declared in.
+// The name cannot be a keyword, a predeclared identifier (e.g. error, bool), or a reserved name (e.g. the name of an imported package).
+// A name that cannot be used is replaced by its conventional name (e.g. ctx, err, i, ok) or numbered (e.g. string2).
+func SafeName(name string, reserved map[string]bool) string {
+	safe := func(n string) bool {
+		return !token.IsKeyword(n) && types.Universe.Lookup(n) == nil && !reserved[n]
+	}
+
+	if safe(name) {
+		return name
+	}
+
+	if conv, ok := conventionalNames[name]; ok && safe(conv) {
+		return conv
+	}
+
+	base := name
+	if token.IsKeyword(base) {
+		base += "_"
+	}
+
+	name = base
+	for i := 2; !safe(name); i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
 
-	return lastName
+	return name
 }
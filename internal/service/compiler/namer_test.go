@@ -107,6 +107,50 @@ func TestInferName(t *testing.T) {
 			},
 			expecteName: "Embedded",
 		},
+		{
+			name: "Slice",
+			expr: &ast.ArrayType{
+				Elt: &ast.Ident{Name: "Request"},
+			},
+			expecteName: "Request",
+		},
+		{
+			name: "Map",
+			expr: &ast.MapType{
+				Key:   &ast.Ident{Name: "string"},
+				Value: &ast.Ident{Name: "Request"},
+			},
+			expecteName: "Request",
+		},
+		{
+			name: "Channel",
+			expr: &ast.ChanType{
+				Value: &ast.Ident{Name: "error"},
+			},
+			expecteName: "error",
+		},
+		{
+			name: "Function",
+			expr: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Type: &ast.Ident{Name: "error"}},
+					},
+				},
+			},
+			expecteName: "fn",
+		},
+		{
+			name:        "EmptyInterfaceIdent",
+			expr:        &ast.Ident{Name: "interface{}"},
+			expecteName: "value",
+		},
+		{
+			name:        "EmptyInterface",
+			expr:        &ast.InterfaceType{Methods: &ast.FieldList{}},
+			expecteName: "value",
+		},
 	}
 
 	for _, tc := range tests {
@@ -117,3 +161,63 @@ func TestInferName(t *testing.T) {
 		})
 	}
 }
+
+func TestSafeName(t *testing.T) {
+	tests := []struct {
+		name         string
+		reserved     map[string]bool
+		expectedName string
+	}{
+		{
+			name:         "request",
+			reserved:     map[string]bool{},
+			expectedName: "request",
+		},
+		{
+			name:         "context",
+			reserved:     map[string]bool{"context": true},
+			expectedName: "ctx",
+		},
+		{
+			name:         "error",
+			reserved:     map[string]bool{},
+			expectedName: "err",
+		},
+		{
+			name:         "bool",
+			reserved:     map[string]bool{},
+			expectedName: "ok",
+		},
+		{
+			name:         "int",
+			reserved:     map[string]bool{},
+			expectedName: "i",
+		},
+		{
+			name:         "int",
+			reserved:     map[string]bool{"i": true},
+			expectedName: "int2",
+		},
+		{
+			name:         "string",
+			reserved:     map[string]bool{},
+			expectedName: "string2",
+		},
+		{
+			name:         "type",
+			reserved:     map[string]bool{},
+			expectedName: "type_",
+		},
+		{
+			name:         "user",
+			reserved:     map[string]bool{"user": true, "user2": true},
+			expectedName: "user3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, SafeName(tc.name, tc.reserved))
+		})
+	}
+}
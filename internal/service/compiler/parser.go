@@ -1,19 +1,30 @@
 package compiler
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 
-	"go/ast"
 	goast "go/ast"
-	goparser "go/parser"
 	gotoken "go/token"
+	"go/types"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/tools/go/packages"
 
 	"github.com/moorara/gelato/internal/log"
+	"github.com/moorara/gelato/version"
 )
 
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes
+
 // PackageInfo contains information about a parsed package.
 type PackageInfo struct {
 	ModuleName  string
@@ -21,6 +32,28 @@ type PackageInfo struct {
 	ImportPath  string
 	BaseDir     string
 	RelativeDir string
+	// Types and TypesInfo are the results of type-checking the package.
+	// They may be incomplete if the package has type errors.
+	Types     *types.Package
+	TypesInfo *types.Info
+}
+
+// TypeOf returns the type of an expression in the package or nil if it is not known.
+func (i *PackageInfo) TypeOf(expr goast.Expr) types.Type {
+	if i.TypesInfo == nil {
+		return nil
+	}
+
+	return i.TypesInfo.TypeOf(expr)
+}
+
+// ObjectOf returns the object denoted by an identifier in the package or nil if it is not known.
+func (i *PackageInfo) ObjectOf(id *goast.Ident) types.Object {
+	if i.TypesInfo == nil {
+		return nil
+	}
+
+	return i.TypesInfo.ObjectOf(id)
 }
 
 // FileInfo contains information about a parsed file.
@@ -33,7 +66,8 @@ type FileInfo struct {
 // TypeInfo contains information about a parsed type.
 type TypeInfo struct {
 	FileInfo
-	TypeName string
+	TypeName   string
+	TypeParams *goast.FieldList
 }
 
 // IsExported determines whether or not a type is exported.
@@ -61,8 +95,12 @@ func (i *FuncInfo) IsMethod() bool {
 
 // Consumer is used for processing AST nodes.
 // This is meant to be provided by downstream packages.
+// Types with a //gelato:<Directive> comment are always passed to the consumer regardless of the type filters.
+// Key identifies the configuration of the consumer (output layout, etc.) and invalidates the manifest when it changes.
 type Consumer struct {
 	Name      string
+	Key       string
+	Directive string
 	Package   func(*PackageInfo, *goast.Package) bool
 	FilePre   func(*FileInfo, *goast.File) bool
 	Import    func(*FileInfo, *goast.ImportSpec)
@@ -74,14 +112,81 @@ type Consumer struct {
 }
 
 // ParseOptions configure how Go source code files should be parsed.
+// Packages are filtered by their directories relative to the parsing path.
+// A package pattern is either a glob pattern (e.g. internal/*) or a directory followed by /... for including its subdirectories.
+// Workers is the maximum number of packages processed concurrently (defaults to the number of CPUs).
+// Manifest is the path to a file, relative to the parsing path, for keeping the source hashes of the compiled packages.
+// If set, the packages whose source code has not changed since the last compilation are skipped.
 type ParseOptions struct {
 	MergePackageFiles bool
 	SkipTestFiles     bool
+	Packages          []string
+	ExcludePackages   []string
 	TypeNames         []string
+	ExcludeTypeNames  []string
 	TypeRegexp        *regexp.Regexp
+	Workers           int
+	Manifest          string
+}
+
+// key returns a string identifying the options that affect the compiled artifacts.
+func (o ParseOptions) key() string {
+	var typeRegexp string
+	if o.TypeRegexp != nil {
+		typeRegexp = o.TypeRegexp.String()
+	}
+
+	return fmt.Sprintf("%t %t %q %q %q %q %q", o.MergePackageFiles, o.SkipTestFiles,
+		o.Packages, o.ExcludePackages, o.TypeNames, o.ExcludeTypeNames, typeRegexp)
 }
 
-func (o ParseOptions) matchType(name *goast.Ident) bool {
+func (o ParseOptions) matchPackage(relDir string) bool {
+	relDir = filepath.ToSlash(relDir)
+
+	for _, pattern := range o.ExcludePackages {
+		if matchPackagePattern(pattern, relDir) {
+			return false
+		}
+	}
+
+	// If no filter specified, it is a match
+	if len(o.Packages) == 0 {
+		return true
+	}
+
+	for _, pattern := range o.Packages {
+		if matchPackagePattern(pattern, relDir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPackagePattern(pattern, relDir string) bool {
+	pattern = path.Clean(filepath.ToSlash(pattern))
+
+	if pattern == "..." || strings.HasSuffix(pattern, "/...") {
+		dir := path.Clean(strings.TrimSuffix(pattern, "..."))
+		return dir == "." || relDir == dir || strings.HasPrefix(relDir, dir+"/")
+	}
+
+	matched, _ := path.Match(pattern, relDir)
+	return matched
+}
+
+func (o ParseOptions) matchType(name *goast.Ident, directive bool) bool {
+	// Types with a directive are always a match
+	if directive {
+		return true
+	}
+
+	for _, t := range o.ExcludeTypeNames {
+		if name.Name == t {
+			return false
+		}
+	}
+
 	// If no filter specified, it is a match
 	if len(o.TypeNames) == 0 && o.TypeRegexp == nil {
 		return true
@@ -101,12 +206,26 @@ func (o ParseOptions) matchType(name *goast.Ident) bool {
 }
 
 // Parser is used for parsing Go source code files.
+// If newConsumers is set, each worker gets its own consumers and packages are processed concurrently.
 type parser struct {
-	logger    *log.ColorfulLogger
-	consumers []*Consumer
+	logger       *log.ColorfulLogger
+	consumers    []*Consumer
+	newConsumers func() []*Consumer
 }
 
-// Parse parses all Go source code files recursively from a given path.
+// consumerKeys returns the names of consumers and a key identifying their configurations.
+func consumerKeys(consumers []*Consumer) (string, string) {
+	names := make([]string, len(consumers))
+	keys := make([]string, len(consumers))
+	for i, c := range consumers {
+		names[i] = c.Name
+		keys[i] = c.Name + "=" + c.Key
+	}
+
+	return strings.Join(names, ","), strings.Join(keys, ",")
+}
+
+// Parse parses and type-checks all Go packages recursively from a given path.
 func (p *parser) Parse(path string, opts ParseOptions) error {
 	// Sanitize the path
 	if _, err := os.Stat(path); err != nil {
@@ -120,72 +239,256 @@ func (p *parser) Parse(path string, opts ParseOptions) error {
 		return err
 	}
 
-	// Create a new file set for each package
-	fset := gotoken.NewFileSet()
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
 
-	return readPackages(path, func(baseDir, relDir string) error {
-		pkgDir := filepath.Join(baseDir, relDir)
-		importPath := filepath.Join(module, relDir)
+	// The shared consumers cannot be used concurrently
+	if p.newConsumers == nil {
+		workers = 1
+	}
 
-		// Parse all Go packages and files in the currecnt directory
-		p.logger.Cyan.Debugf("  Parsing directory: %s", pkgDir)
-		pkgs, err := goparser.ParseDir(fset, pkgDir, nil, goparser.AllErrors)
+	pool := make(chan []*Consumer, workers)
+	for i := 0; i < workers; i++ {
+		if p.newConsumers == nil {
+			pool <- p.consumers
+		} else {
+			pool <- p.newConsumers()
+		}
+	}
+
+	patterns := []string{"./..."}
+
+	var m manifest
+	var id, key, manifestPath string
+	var hashes map[string]string
+
+	if opts.Manifest != "" {
+		manifestPath = opts.Manifest
+		if !filepath.IsAbs(manifestPath) {
+			manifestPath = filepath.Join(path, manifestPath)
+		}
+
+		consumers := <-pool
+		id, key = consumerKeys(consumers)
+		pool <- consumers
+
+		modKey, err := moduleKey(path)
 		if err != nil {
 			return err
 		}
 
-		// Visit all parsed Go files in the current directory
-		for pkgName, pkg := range pkgs {
-			p.logger.Magenta.Debugf("    Package: %s", pkg.Name)
+		key = strings.Join([]string{version.Version, modKey, key, opts.key()}, "\x00")
+		if hashes, err = hashPackages(path, module, key, opts.SkipTestFiles); err != nil {
+			return err
+		}
 
-			pkgInfo := PackageInfo{
-				ModuleName:  module,
-				PackageName: pkgName,
-				ImportPath:  importPath,
-				BaseDir:     baseDir,
-				RelativeDir: relDir,
+		m = readManifest(manifestPath)
+
+		patterns = nil
+		for relDir, hash := range hashes {
+			if opts.matchPackage(relDir) && m[id][relDir] != hash {
+				if relDir == "." {
+					patterns = append(patterns, ".")
+				} else {
+					patterns = append(patterns, "./"+relDir)
+				}
 			}
+		}
 
-			// Keeps track of interested consumers in the files in the current package
-			fileConsumers := make([]*Consumer, 0)
+		if len(patterns) == 0 {
+			p.logger.White.Infof("No package has changed.")
+			return nil
+		}
 
-			// PACKAGE
-			for _, c := range p.consumers {
-				if c.Package != nil {
-					cont := c.Package(&pkgInfo, pkg)
-					if cont {
-						fileConsumers = append(fileConsumers, c)
-					}
-					p.logger.Blue.Tracef("      %s.Package: %t", c.Name, cont)
-				}
+		sort.Strings(patterns)
+		p.logger.Cyan.Debugf("  Changed packages: %d of %d", len(patterns), len(hashes))
+	}
+
+	// Create a new file set for all packages
+	fset := gotoken.NewFileSet()
+
+	pkgs, err := p.loadPackages(fset, path, opts, patterns...)
+	if err != nil {
+		return err
+	}
+
+	// Visit all loaded Go packages with a bounded number of workers
+	g := new(errgroup.Group)
+	g.SetLimit(workers)
+
+	for _, pkg := range pkgs {
+		pkg := pkg
+		g.Go(func() error {
+			consumers := <-pool
+			defer func() {
+				pool <- consumers
+			}()
+
+			return p.processPackage(module, path, fset, pkg, consumers, opts)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if opts.Manifest != "" {
+		// Packages that no longer exist are removed from the manifest
+		m[id] = hashes
+		if err := m.write(manifestPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *parser) processPackage(module, path string, fset *gotoken.FileSet, pkg *packages.Package, consumers []*Consumer, opts ParseOptions) error {
+	p.logger.Magenta.Debugf("    Package: %s", pkg.ID)
+
+	relDir, err := packageRelDir(path, pkg)
+	if err != nil {
+		return err
+	}
+
+	if !opts.matchPackage(relDir) {
+		p.logger.Magenta.Tracef("      Skipped: %s", relDir)
+		return nil
+	}
+
+	pkgInfo := PackageInfo{
+		ModuleName:  module,
+		PackageName: pkg.Name,
+		ImportPath:  strings.TrimSuffix(pkg.PkgPath, "_test"),
+		BaseDir:     path,
+		RelativeDir: relDir,
+		Types:       pkg.Types,
+		TypesInfo:   pkg.TypesInfo,
+	}
+
+	astPkg := &goast.Package{
+		Name:  pkg.Name,
+		Files: make(map[string]*goast.File, len(pkg.Syntax)),
+	}
+
+	for _, file := range pkg.Syntax {
+		astPkg.Files[fset.File(file.Pos()).Name()] = file
+	}
+
+	// Keeps track of interested consumers in the files in the current package
+	fileConsumers := make([]*Consumer, 0)
+
+	// PACKAGE
+	for _, c := range consumers {
+		if c.Package != nil {
+			cont := c.Package(&pkgInfo, astPkg)
+			if cont {
+				fileConsumers = append(fileConsumers, c)
 			}
+			p.logger.Blue.Tracef("      %s.Package: %t", c.Name, cont)
+		}
+	}
+
+	// Proceed to the next package if no consumer
+	if len(fileConsumers) == 0 {
+		return nil
+	}
+
+	// Merge all file ASTs in the package and process a single file
+	if opts.MergePackageFiles {
+		mergedFile := goast.MergePackageFiles(astPkg, goast.FilterImportDuplicates|goast.FilterUnassociatedComments)
+		return p.processFile(pkgInfo, fset, "merged.go", mergedFile, fileConsumers, opts)
+	}
+
+	for _, file := range pkg.Syntax {
+		fileName := fset.File(file.Pos()).Name()
+		if opts.SkipTestFiles && strings.HasSuffix(fileName, "_test.go") {
+			continue
+		}
+
+		if err := p.processFile(pkgInfo, fset, fileName, file, fileConsumers, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadPackages loads, parses, and type-checks the Go packages matching the patterns (all packages by default) from a given path.
+// Syntax errors fail the parsing whereas other errors (type errors, build errors, etc.) are tolerated,
+// since the source code may depend on the code that is not generated yet.
+func (p *parser) loadPackages(fset *gotoken.FileSet, path string, opts ParseOptions, patterns ...string) ([]*packages.Package, error) {
+	p.logger.Cyan.Debugf("  Loading packages: %s", path)
+
+	cfg := &packages.Config{
+		Mode:  loadMode,
+		Dir:   path,
+		Fset:  fset,
+		Tests: !opts.SkipTestFiles,
+	}
+
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
 
-			// Proceed to the next package if no consumer
-			if len(fileConsumers) == 0 {
+	var errs []string
+	seen := map[string]bool{}
+	byPath := map[string]*packages.Package{}
+
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			if e.Kind != packages.ParseError {
+				p.logger.Red.Debugf("    Package error: %s", e)
 				continue
 			}
 
-			// Merge all file ASTs in the package and process a single file
-			if opts.MergePackageFiles {
-				mergedFile := goast.MergePackageFiles(pkg, ast.FilterImportDuplicates|goast.FilterUnassociatedComments)
-				if err := p.processFile(pkgInfo, fset, "merged.go", mergedFile, fileConsumers, opts); err != nil {
-					return err
-				}
-			} else {
-				for fileName, file := range pkg.Files {
-					if opts.SkipTestFiles && strings.HasSuffix(fileName, "_test.go") {
-						continue
-					}
-
-					if err := p.processFile(pkgInfo, fset, fileName, file, fileConsumers, opts); err != nil {
-						return err
-					}
-				}
+			if msg := formatError(path, e); !seen[msg] {
+				seen[msg] = true
+				errs = append(errs, msg)
 			}
 		}
 
-		return nil
+		// Skip the generated test main packages and the directories that are not considered
+		if strings.HasSuffix(pkg.PkgPath, ".test") || len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		if relDir, err := packageRelDir(path, pkg); err != nil || !isPackageRelDir(relDir) {
+			continue
+		}
+
+		// When test files are included, a package is loaded once more with its test files.
+		// The test variant is a superset of the package, so only the test variant is kept.
+		if _, ok := byPath[pkg.PkgPath]; !ok || pkg.ID != pkg.PkgPath {
+			byPath[pkg.PkgPath] = pkg
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+	case 1:
+		return nil, errors.New(errs[0])
+	default:
+		return nil, fmt.Errorf("%s (and %d more errors)", errs[0], len(errs)-1)
+	}
+
+	result := make([]*packages.Package, 0, len(byPath))
+	for _, pkg := range byPath {
+		result = append(result, pkg)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PkgPath < result[j].PkgPath
 	})
+
+	return result, nil
 }
 
 func (p *parser) processFile(pkgInfo PackageInfo, fset *gotoken.FileSet, fileName string, file *goast.File, fileConsumers []*Consumer, opts ParseOptions) error {
@@ -216,8 +519,18 @@ func (p *parser) processFile(pkgInfo PackageInfo, fset *gotoken.FileSet, fileNam
 		return nil
 	}
 
+	// The doc comment of a type declaration is attached to the declaration if it declares a single type
+	var genDoc *goast.CommentGroup
+
 	goast.Inspect(file, func(n goast.Node) bool {
 		switch v := n.(type) {
+		case *goast.GenDecl:
+			genDoc = nil
+			if !v.Lparen.IsValid() {
+				genDoc = v.Doc
+			}
+			return true
+
 		// IMPORT
 		case *goast.ImportSpec:
 			p.logger.Yellow.Debugf("          ImportSpec: %s", v.Path.Value)
@@ -232,8 +545,9 @@ func (p *parser) processFile(pkgInfo PackageInfo, fset *gotoken.FileSet, fileNam
 		// Handle Types
 		case *goast.TypeSpec:
 			typeInfo := TypeInfo{
-				FileInfo: fileInfo,
-				TypeName: v.Name.Name,
+				FileInfo:   fileInfo,
+				TypeName:   v.Name.Name,
+				TypeParams: v.TypeParams,
 			}
 
 			switch w := v.Type.(type) {
@@ -242,7 +556,7 @@ func (p *parser) processFile(pkgInfo PackageInfo, fset *gotoken.FileSet, fileNam
 				p.logger.Yellow.Debugf("          StructType: %s", v.Name.Name)
 				for _, c := range declConsumers {
 					if c.Struct != nil {
-						if opts.matchType(v.Name) {
+						if opts.matchType(v.Name, hasDirective(c.Directive, v.Doc, genDoc)) {
 							c.Struct(&typeInfo, w)
 							p.logger.Blue.Tracef("            %s.Struct", c.Name)
 						}
@@ -255,7 +569,7 @@ func (p *parser) processFile(pkgInfo PackageInfo, fset *gotoken.FileSet, fileNam
 				p.logger.Yellow.Debugf("          InterfaceType: %s", v.Name.Name)
 				for _, c := range declConsumers {
 					if c.Interface != nil {
-						if opts.matchType(v.Name) {
+						if opts.matchType(v.Name, hasDirective(c.Directive, v.Doc, genDoc)) {
 							c.Interface(&typeInfo, w)
 							p.logger.Blue.Tracef("            %s.Interface", c.Name)
 						}
@@ -268,7 +582,7 @@ func (p *parser) processFile(pkgInfo PackageInfo, fset *gotoken.FileSet, fileNam
 				p.logger.Yellow.Debugf("          FuncType: %s", v.Name.Name)
 				for _, c := range declConsumers {
 					if c.FuncType != nil {
-						if opts.matchType(v.Name) {
+						if opts.matchType(v.Name, hasDirective(c.Directive, v.Doc, genDoc)) {
 							c.FuncType(&typeInfo, w)
 							p.logger.Blue.Tracef("            %s.FuncType", c.Name)
 						}
@@ -319,3 +633,24 @@ func (p *parser) processFile(pkgInfo PackageInfo, fset *gotoken.FileSet, fileNam
 
 	return nil
 }
+
+// hasDirective determines whether or not a directive comment (//gelato:<directive>) exists in any of the comment groups.
+func hasDirective(directive string, groups ...*goast.CommentGroup) bool {
+	if directive == "" {
+		return false
+	}
+
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, c := range group.List {
+			if text := strings.TrimSpace(c.Text); text == "//gelato:"+directive || strings.HasPrefix(text, "//gelato:"+directive+" ") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -4,7 +4,13 @@ import (
 	"errors"
 	"go/ast"
 	goast "go/ast"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -106,11 +112,85 @@ func TestFuncInfo_IsMethod(t *testing.T) {
 	}
 }
 
+func TestParseOptions_MatchPackage(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            ParseOptions
+		relDir          string
+		expectedMatched bool
+	}{
+		{
+			name:            "Matched_NoFilter",
+			opts:            ParseOptions{},
+			relDir:          "internal/lookup",
+			expectedMatched: true,
+		},
+		{
+			name: "Matched_Directory",
+			opts: ParseOptions{
+				Packages: []string{"internal/lookup"},
+			},
+			relDir:          "internal/lookup",
+			expectedMatched: true,
+		},
+		{
+			name: "Matched_Glob",
+			opts: ParseOptions{
+				Packages: []string{"internal/*"},
+			},
+			relDir:          "internal/lookup",
+			expectedMatched: true,
+		},
+		{
+			name: "Matched_Recursive",
+			opts: ParseOptions{
+				Packages: []string{"./internal/..."},
+			},
+			relDir:          "internal/service/lookup",
+			expectedMatched: true,
+		},
+		{
+			name: "Matched_RecursiveRoot",
+			opts: ParseOptions{
+				Packages: []string{"./..."},
+			},
+			relDir:          ".",
+			expectedMatched: true,
+		},
+		{
+			name: "NotMatched",
+			opts: ParseOptions{
+				Packages: []string{"internal/*", "pkg/..."},
+			},
+			relDir:          "internal/service/lookup",
+			expectedMatched: false,
+		},
+		{
+			name: "NotMatched_Excluded",
+			opts: ParseOptions{
+				Packages:        []string{"internal/..."},
+				ExcludePackages: []string{"internal/service/..."},
+			},
+			relDir:          "internal/service/lookup",
+			expectedMatched: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matched := tc.opts.matchPackage(tc.relDir)
+
+			assert.Equal(t, tc.expectedMatched, matched)
+		})
+	}
+}
+
 func TestParseOptions_MatchType(t *testing.T) {
 	tests := []struct {
 		name            string
 		opts            ParseOptions
 		typeName        *ast.Ident
+		directive       bool
 		expectedMatched bool
 	}{
 		{
@@ -135,6 +215,16 @@ func TestParseOptions_MatchType(t *testing.T) {
 			typeName:        &ast.Ident{Name: "ExampleService"},
 			expectedMatched: true,
 		},
+		{
+			name: "Matched_Directive",
+			opts: ParseOptions{
+				TypeNames:        []string{"Request", "Response"},
+				ExcludeTypeNames: []string{"Helper"},
+			},
+			typeName:        &ast.Ident{Name: "Helper"},
+			directive:       true,
+			expectedMatched: true,
+		},
 		{
 			name: "NotMatched",
 			opts: ParseOptions{
@@ -144,17 +234,72 @@ func TestParseOptions_MatchType(t *testing.T) {
 			typeName:        &ast.Ident{Name: "Helper"},
 			expectedMatched: false,
 		},
+		{
+			name: "NotMatched_Excluded",
+			opts: ParseOptions{
+				ExcludeTypeNames: []string{"Helper"},
+			},
+			typeName:        &ast.Ident{Name: "Helper"},
+			expectedMatched: false,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			matched := tc.opts.matchType(tc.typeName)
+			matched := tc.opts.matchType(tc.typeName, tc.directive)
 
 			assert.Equal(t, tc.expectedMatched, matched)
 		})
 	}
 }
 
+func TestHasDirective(t *testing.T) {
+	tests := []struct {
+		name           string
+		directive      string
+		groups         []*ast.CommentGroup
+		expectedResult bool
+	}{
+		{
+			name:           "NoDirective",
+			directive:      "",
+			groups:         []*ast.CommentGroup{{List: []*ast.Comment{{Text: "//gelato:mock"}}}},
+			expectedResult: false,
+		},
+		{
+			name:           "NoComment",
+			directive:      "mock",
+			groups:         []*ast.CommentGroup{nil, nil},
+			expectedResult: false,
+		},
+		{
+			name:      "OtherDirective",
+			directive: "mock",
+			groups: []*ast.CommentGroup{
+				{List: []*ast.Comment{{Text: "// Lookup is a service."}, {Text: "//gelato:build"}}},
+			},
+			expectedResult: false,
+		},
+		{
+			name:      "OK",
+			directive: "mock",
+			groups: []*ast.CommentGroup{
+				nil,
+				{List: []*ast.Comment{{Text: "// Lookup is a service."}, {Text: "//gelato:mock"}}},
+			},
+			expectedResult: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := hasDirective(tc.directive, tc.groups...)
+
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
 func TestParser_Parse(t *testing.T) {
 	logger := log.New(log.None)
 	clogger := &log.ColorfulLogger{
@@ -196,7 +341,20 @@ func TestParser_Parse(t *testing.T) {
 			name:          "InvalidCode",
 			path:          "./test/invalid_code",
 			opts:          ParseOptions{},
-			expectedError: "test/invalid_code/main.go:3:11: expected 'STRING', found newline (and 1 more errors)",
+			expectedError: "test/invalid_code/main.go:3:11: missing import path (and 10 more errors)",
+		},
+		{
+			name: "Success_TypeErrors",
+			consumers: []*Consumer{
+				{
+					Name:    "tester",
+					Package: func(*PackageInfo, *goast.Package) bool { return true },
+					FilePre: func(*FileInfo, *goast.File) bool { return true },
+				},
+			},
+			path:          "./test/type_error",
+			opts:          ParseOptions{},
+			expectedError: "",
 		},
 		{
 			name: "Success_SkipPackages",
@@ -326,3 +484,273 @@ func TestParser_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_Parse_TypesInfo(t *testing.T) {
+	logger := log.New(log.None)
+	clogger := &log.ColorfulLogger{
+		Red:     logger,
+		Green:   logger,
+		Yellow:  logger,
+		Blue:    logger,
+		Magenta: logger,
+		Cyan:    logger,
+		White:   logger,
+	}
+
+	tests := []struct {
+		name          string
+		opts          ParseOptions
+		expectedFiles []string
+	}{
+		{
+			name: "SkipTestFiles",
+			opts: ParseOptions{
+				SkipTestFiles: true,
+			},
+			expectedFiles: []string{"main.go", "lookup.go"},
+		},
+		{
+			name:          "WithTestFiles",
+			opts:          ParseOptions{},
+			expectedFiles: []string{"main.go", "lookup.go", "lookup_test.go"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var files []string
+			var methods []string
+
+			p := &parser{
+				logger: clogger,
+				consumers: []*Consumer{
+					{
+						Name: "tester",
+						Package: func(info *PackageInfo, pkg *goast.Package) bool {
+							assert.NotNil(t, info.Types)
+							assert.NotNil(t, info.TypesInfo)
+							return true
+						},
+						FilePre: func(info *FileInfo, file *goast.File) bool {
+							files = append(files, info.FileName)
+							return true
+						},
+						Interface: func(info *TypeInfo, node *goast.InterfaceType) {
+							iface, ok := info.TypeOf(node).(*types.Interface)
+							assert.True(t, ok)
+							for i := 0; i < iface.NumMethods(); i++ {
+								methods = append(methods, iface.Method(i).String())
+							}
+						},
+					},
+				},
+			}
+
+			err := p.Parse("./test/valid", tc.opts)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFiles, files)
+			assert.Equal(t, []string{
+				"func (github.com/octocat/test/lookup.Service).Lookup(context.Context, *github.com/octocat/test/lookup.Request) (*github.com/octocat/test/lookup.Response, error)",
+			}, methods)
+		})
+	}
+}
+
+func TestParser_Parse_Filters(t *testing.T) {
+	logger := log.New(log.None)
+	clogger := &log.ColorfulLogger{
+		Red:     logger,
+		Green:   logger,
+		Yellow:  logger,
+		Blue:    logger,
+		Magenta: logger,
+		Cyan:    logger,
+		White:   logger,
+	}
+
+	tests := []struct {
+		name          string
+		opts          ParseOptions
+		expectedFiles []string
+		expectedTypes []string
+	}{
+		{
+			name: "NoFilter",
+			opts: ParseOptions{
+				SkipTestFiles: true,
+			},
+			expectedFiles: []string{"main.go", "lookup.go"},
+			expectedTypes: []string{"Request", "Response", "service"},
+		},
+		{
+			name: "Packages",
+			opts: ParseOptions{
+				SkipTestFiles: true,
+				Packages:      []string{"lookup"},
+			},
+			expectedFiles: []string{"lookup.go"},
+			expectedTypes: []string{"Request", "Response", "service"},
+		},
+		{
+			name: "ExcludePackages",
+			opts: ParseOptions{
+				SkipTestFiles:   true,
+				ExcludePackages: []string{"./..."},
+			},
+			expectedFiles: nil,
+			expectedTypes: nil,
+		},
+		{
+			name: "TypeNames",
+			opts: ParseOptions{
+				SkipTestFiles: true,
+				TypeNames:     []string{"Request"},
+			},
+			expectedFiles: []string{"main.go", "lookup.go"},
+			expectedTypes: []string{"Request", "Response"},
+		},
+		{
+			name: "ExcludeTypeNames",
+			opts: ParseOptions{
+				SkipTestFiles:    true,
+				ExcludeTypeNames: []string{"Request", "Response", "service"},
+			},
+			expectedFiles: []string{"main.go", "lookup.go"},
+			expectedTypes: []string{"Response"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var files []string
+			var types []string
+
+			p := &parser{
+				logger: clogger,
+				consumers: []*Consumer{
+					{
+						Name:      "tester",
+						Directive: "build",
+						Package: func(info *PackageInfo, pkg *goast.Package) bool {
+							return true
+						},
+						FilePre: func(info *FileInfo, file *goast.File) bool {
+							files = append(files, info.FileName)
+							return true
+						},
+						Struct: func(info *TypeInfo, node *goast.StructType) {
+							types = append(types, info.TypeName)
+						},
+					},
+				},
+			}
+
+			err := p.Parse("./test/valid", tc.opts)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFiles, files)
+			assert.Equal(t, tc.expectedTypes, types)
+		})
+	}
+}
+
+func TestParser_Parse_Incremental(t *testing.T) {
+	logger := log.New(log.None)
+	clogger := &log.ColorfulLogger{
+		Red:     logger,
+		Green:   logger,
+		Yellow:  logger,
+		Blue:    logger,
+		Magenta: logger,
+		Cyan:    logger,
+		White:   logger,
+	}
+
+	dir := t.TempDir()
+	for _, name := range []string{"go.mod", "main.go", "lookup/lookup.go", "lookup/lookup_test.go"} {
+		data, err := ioutil.ReadFile(filepath.Join("test/valid", name))
+		assert.NoError(t, err)
+		writeFiles(t, dir, map[string]string{name: string(data)})
+	}
+
+	var mu sync.Mutex
+	var files []string
+
+	p := &parser{
+		logger: clogger,
+		newConsumers: func() []*Consumer {
+			return []*Consumer{
+				{
+					Name: "tester",
+					Package: func(info *PackageInfo, pkg *goast.Package) bool {
+						return true
+					},
+					FilePre: func(info *FileInfo, file *goast.File) bool {
+						mu.Lock()
+						defer mu.Unlock()
+						files = append(files, info.FileName)
+						return true
+					},
+				},
+			}
+		},
+	}
+
+	opts := ParseOptions{
+		SkipTestFiles: true,
+		Workers:       2,
+		Manifest:      ".gen/.manifest.json",
+	}
+
+	parse := func(t *testing.T) []string {
+		files = nil
+		assert.NoError(t, p.Parse(dir, opts))
+		sort.Strings(files)
+		return files
+	}
+
+	t.Run("AllPackages", func(t *testing.T) {
+		assert.Equal(t, []string{"lookup.go", "main.go"}, parse(t))
+		assert.FileExists(t, filepath.Join(dir, ".gen/.manifest.json"))
+	})
+
+	t.Run("NoChange", func(t *testing.T) {
+		assert.Nil(t, parse(t))
+	})
+
+	t.Run("TestFileChanged", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, "lookup/lookup_test.go"), os.O_APPEND|os.O_WRONLY, 0644)
+		assert.NoError(t, err)
+		_, err = f.WriteString("\n// Changed\n")
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		assert.Nil(t, parse(t))
+	})
+
+	t.Run("PackageChanged", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, "lookup/lookup.go"), os.O_APPEND|os.O_WRONLY, 0644)
+		assert.NoError(t, err)
+		_, err = f.WriteString("\n// Changed\n")
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		assert.Equal(t, []string{"lookup.go"}, parse(t))
+	})
+
+	t.Run("GoModChanged", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0644)
+		assert.NoError(t, err)
+		_, err = f.WriteString("\n// Changed\n")
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		assert.Equal(t, []string{"lookup.go", "main.go"}, parse(t))
+	})
+
+	t.Run("OptionsChanged", func(t *testing.T) {
+		opts.TypeNames = []string{"Request"}
+		assert.Equal(t, []string{"lookup.go", "main.go"}, parse(t))
+	})
+}
@@ -4,10 +4,11 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"golang.org/x/tools/go/packages"
 )
 
 // getModuleName returns the name of go module from a given path.
@@ -22,7 +23,6 @@ func getModuleName(path string) (string, error) {
 	if _, err := os.Stat(filename); err != nil {
 		if os.IsNotExist(err) {
 			if parent := filepath.Dir(path); parent != "/" {
-				fmt.Println(parent)
 				return getModuleName(parent)
 			}
 		}
@@ -49,34 +49,51 @@ func getModuleName(path string) (string, error) {
 	return "", errors.New("invalid go.mod file: no module name found")
 }
 
-type visitFunc func(baseDir, relDir string) error
+// packageRelDir returns the directory of a loaded package relative to a given path.
+func packageRelDir(path string, pkg *packages.Package) (string, error) {
+	if len(pkg.GoFiles) == 0 {
+		return "", fmt.Errorf("no Go files in package %s", pkg.ID)
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
 
-// readPackages visits all package directories recursively in a given path.
-func readPackages(path string, visit visitFunc) error {
-	return packageDirs(path, ".", visit)
+	return filepath.Rel(path, filepath.Dir(pkg.GoFiles[0]))
 }
 
-func packageDirs(baseDir, relDir string, visit visitFunc) error {
-	if err := visit(baseDir, relDir); err != nil {
-		return err
+// isPackageRelDir determines whether or not a relative directory is considered for parsing.
+func isPackageRelDir(relDir string) bool {
+	if relDir == "." {
+		return true
 	}
 
-	files, err := ioutil.ReadDir(filepath.Join(baseDir, relDir))
-	if err != nil {
-		return err
+	for _, name := range strings.Split(filepath.ToSlash(relDir), "/") {
+		if !isPackageDir(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatError formats a package error with its position relative to a given path.
+func formatError(path string, e packages.Error) string {
+	pos := e.Pos
+	if pos == "" || pos == "-" {
+		return e.Msg
 	}
 
-	for _, file := range files {
-		// Any directory that starts with "." is NOT considered
-		if file.IsDir() && isPackageDir(file.Name()) {
-			subdir := filepath.Join(relDir, file.Name())
-			if err := packageDirs(baseDir, subdir, visit); err != nil {
-				return err
+	if filepath.IsAbs(pos) {
+		if abs, err := filepath.Abs(path); err == nil {
+			if rel, err := filepath.Rel(abs, pos); err == nil {
+				pos = rel
 			}
 		}
 	}
 
-	return nil
+	return fmt.Sprintf("%s: %s", filepath.Join(path, pos), e.Msg)
 }
 
 func isPackageDir(name string) bool {
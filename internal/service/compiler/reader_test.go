@@ -1,10 +1,11 @@
 package compiler
 
 import (
-	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/packages"
 )
 
 func TestGetModuleName(t *testing.T) {
@@ -46,60 +47,126 @@ func TestGetModuleName(t *testing.T) {
 	}
 }
 
-func TestReadPackages(t *testing.T) {
+func TestPackageRelDir(t *testing.T) {
 	tests := []struct {
-		name          string
-		baseDir       string
-		relDir        string
-		visit         visitFunc
-		expectedError string
+		name           string
+		path           string
+		pkg            *packages.Package
+		expectedRelDir string
+		expectedError  string
 	}{
 		{
-			name:    "InvalidPath",
-			baseDir: "./dev/null",
-			visit: func(_, _ string) error {
-				return nil
-			},
-			expectedError: "open dev/null: no such file or directory",
+			name:          "NoGoFiles",
+			path:          "./test/valid",
+			pkg:           &packages.Package{ID: "github.com/octocat/test/lookup"},
+			expectedError: "no Go files in package github.com/octocat/test/lookup",
 		},
 		{
-			name:    "Success",
-			baseDir: "./test/valid",
-			visit: func(_, _ string) error {
-				return nil
+			name: "Root",
+			path: "./test/valid",
+			pkg: &packages.Package{
+				ID:      "github.com/octocat/test",
+				GoFiles: []string{abs(t, "./test/valid/main.go")},
 			},
-			expectedError: "",
+			expectedRelDir: ".",
 		},
 		{
-			name:    "VisitFails_FirstTime",
-			baseDir: "./test/valid",
-			visit: func(_, _ string) error {
-				return errors.New("generic error")
+			name: "Subdirectory",
+			path: "./test/valid",
+			pkg: &packages.Package{
+				ID:      "github.com/octocat/test/lookup",
+				GoFiles: []string{abs(t, "./test/valid/lookup/lookup.go")},
 			},
-			expectedError: "generic error",
-		},
-		{
-			name:    "VisitFails_SecondTime",
-			baseDir: "./test/valid",
-			visit: func(_, relDir string) error {
-				if relDir == "." {
-					return nil
-				}
-				return errors.New("generic error")
-			},
-			expectedError: "generic error",
+			expectedRelDir: "lookup",
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := readPackages(tc.baseDir, tc.visit)
+			relDir, err := packageRelDir(tc.path, tc.pkg)
 
 			if tc.expectedError == "" {
 				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRelDir, relDir)
 			} else {
+				assert.Empty(t, relDir)
 				assert.EqualError(t, err, tc.expectedError)
 			}
 		})
 	}
 }
+
+func TestIsPackageRelDir(t *testing.T) {
+	tests := []struct {
+		name             string
+		relDir           string
+		expectedIsPkgDir bool
+	}{
+		{
+			name:             "Root",
+			relDir:           ".",
+			expectedIsPkgDir: true,
+		},
+		{
+			name:             "Package",
+			relDir:           "internal/lookup",
+			expectedIsPkgDir: true,
+		},
+		{
+			name:             "Hidden",
+			relDir:           ".gen/lookup",
+			expectedIsPkgDir: false,
+		},
+		{
+			name:             "Bin",
+			relDir:           "bin/tools",
+			expectedIsPkgDir: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedIsPkgDir, isPackageRelDir(tc.relDir))
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		err         packages.Error
+		expectedMsg string
+	}{
+		{
+			name:        "NoPosition",
+			path:        "./test/valid",
+			err:         packages.Error{Msg: "no Go files"},
+			expectedMsg: "no Go files",
+		},
+		{
+			name:        "RelativePosition",
+			path:        "./test/valid",
+			err:         packages.Error{Pos: "main.go:3:11", Msg: "missing import path"},
+			expectedMsg: "test/valid/main.go:3:11: missing import path",
+		},
+		{
+			name:        "AbsolutePosition",
+			path:        "./test/valid",
+			err:         packages.Error{Pos: abs(t, "./test/valid/lookup/lookup.go") + ":5:1", Msg: "expected declaration"},
+			expectedMsg: "test/valid/lookup/lookup.go:5:1: expected declaration",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMsg, formatError(tc.path, tc.err))
+		})
+	}
+}
+
+func abs(t *testing.T, path string) string {
+	path, err := filepath.Abs(path)
+	assert.NoError(t, err)
+	return path
+}
@@ -0,0 +1,3 @@
+module github.com/octocat/test
+
+go 1.15
@@ -0,0 +1,6 @@
+package main
+
+func main() {
+	lookup := NewMockLookup()
+	lookup.Lookup("Jane")
+}
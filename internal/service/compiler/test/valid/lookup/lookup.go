@@ -8,6 +8,8 @@ type Request struct {
 }
 
 // Response is the lookup response.
+//
+//gelato:build
 type Response struct {
 	Name string
 }
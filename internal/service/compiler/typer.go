@@ -0,0 +1,313 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// TypeExpr converts a type to a type expression.
+// Named types are qualified with the package names returned by the qualifier (an empty name means no qualification).
+// Each parameter and result of a function type is converted to a separate field.
+func TypeExpr(t types.Type, qualifier types.Qualifier) ast.Expr {
+	switch t := t.(type) {
+	case *types.Basic:
+		if t.Kind() == types.UnsafePointer {
+			return qualifiedIdent(types.Unsafe, "Pointer", qualifier)
+		}
+		return &ast.Ident{Name: t.Name()}
+
+	case *types.Named:
+		typ := qualifiedIdent(t.Obj().Pkg(), t.Obj().Name(), qualifier)
+
+		// Instantiated generic type
+		args := make([]ast.Expr, t.TypeArgs().Len())
+		for i := range args {
+			args[i] = TypeExpr(t.TypeArgs().At(i), qualifier)
+		}
+
+		return Instantiate(typ, args)
+
+	case *types.TypeParam:
+		return &ast.Ident{Name: t.Obj().Name()}
+
+	case *types.Alias:
+		return qualifiedIdent(t.Obj().Pkg(), t.Obj().Name(), qualifier)
+
+	case *types.Pointer:
+		return &ast.StarExpr{
+			X: TypeExpr(t.Elem(), qualifier),
+		}
+
+	case *types.Slice:
+		return &ast.ArrayType{
+			Elt: TypeExpr(t.Elem(), qualifier),
+		}
+
+	case *types.Array:
+		return &ast.ArrayType{
+			Len: &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(t.Len(), 10)},
+			Elt: TypeExpr(t.Elem(), qualifier),
+		}
+
+	case *types.Map:
+		return &ast.MapType{
+			Key:   TypeExpr(t.Key(), qualifier),
+			Value: TypeExpr(t.Elem(), qualifier),
+		}
+
+	case *types.Chan:
+		dir := ast.SEND | ast.RECV
+		switch t.Dir() {
+		case types.SendOnly:
+			dir = ast.SEND
+		case types.RecvOnly:
+			dir = ast.RECV
+		}
+
+		return &ast.ChanType{
+			Dir:   dir,
+			Value: TypeExpr(t.Elem(), qualifier),
+		}
+
+	case *types.Signature:
+		return &ast.FuncType{
+			Params:  tupleFieldList(t.Params(), t.Variadic(), qualifier),
+			Results: tupleFieldList(t.Results(), false, qualifier),
+		}
+
+	case *types.Struct:
+		// Empty structs and interfaces are printed on a single line only if they have valid positions
+		if t.NumFields() == 0 {
+			return &ast.Ident{Name: "struct{}"}
+		}
+
+		fields := &ast.FieldList{}
+		for i := 0; i < t.NumFields(); i++ {
+			v := t.Field(i)
+			field := &ast.Field{
+				Type: TypeExpr(v.Type(), qualifier),
+			}
+
+			if !v.Embedded() {
+				field.Names = []*ast.Ident{{Name: v.Name()}}
+			}
+
+			if tag := t.Tag(i); tag != "" {
+				field.Tag = &ast.BasicLit{Kind: token.STRING, Value: quoteTag(tag)}
+			}
+
+			fields.List = append(fields.List, field)
+		}
+
+		return &ast.StructType{
+			Fields: fields,
+		}
+
+	case *types.Union:
+		var expr ast.Expr
+		for i := 0; i < t.Len(); i++ {
+			term := TypeExpr(t.Term(i).Type(), qualifier)
+			if t.Term(i).Tilde() {
+				term = &ast.UnaryExpr{Op: token.TILDE, X: term}
+			}
+
+			if expr == nil {
+				expr = term
+			} else {
+				expr = &ast.BinaryExpr{X: expr, Op: token.OR, Y: term}
+			}
+		}
+
+		return expr
+
+	case *types.Interface:
+		// Implicit interfaces only appear in constraints (e.g. [T ~int | ~string])
+		if t.IsImplicit() && t.NumEmbeddeds() == 1 {
+			return TypeExpr(t.EmbeddedType(0), qualifier)
+		}
+
+		if t.NumEmbeddeds() == 0 && t.NumExplicitMethods() == 0 {
+			return &ast.Ident{Name: "interface{}"}
+		}
+
+		methods := &ast.FieldList{}
+		for i := 0; i < t.NumEmbeddeds(); i++ {
+			methods.List = append(methods.List, &ast.Field{
+				Type: TypeExpr(t.EmbeddedType(i), qualifier),
+			})
+		}
+
+		for i := 0; i < t.NumExplicitMethods(); i++ {
+			m := t.ExplicitMethod(i)
+			methods.List = append(methods.List, &ast.Field{
+				Names: []*ast.Ident{{Name: m.Name()}},
+				Type:  TypeExpr(m.Type(), qualifier),
+			})
+		}
+
+		return &ast.InterfaceType{
+			Methods: methods,
+		}
+	}
+
+	// Fallback to the string representation of the type
+	return &ast.Ident{Name: types.TypeString(t, qualifier)}
+}
+
+func qualifiedIdent(pkg *types.Package, name string, qualifier types.Qualifier) ast.Expr {
+	if pkg != nil && qualifier != nil {
+		if pkgName := qualifier(pkg); pkgName != "" {
+			return &ast.SelectorExpr{
+				X:   &ast.Ident{Name: pkgName},
+				Sel: &ast.Ident{Name: name},
+			}
+		}
+	}
+
+	return &ast.Ident{Name: name}
+}
+
+func tupleFieldList(tuple *types.Tuple, variadic bool, qualifier types.Qualifier) *ast.FieldList {
+	fields := &ast.FieldList{}
+
+	// Either all or none of the parameters are named
+	named := false
+	for i := 0; i < tuple.Len(); i++ {
+		if tuple.At(i).Name() != "" {
+			named = true
+		}
+	}
+
+	for i := 0; i < tuple.Len(); i++ {
+		v := tuple.At(i)
+		field := &ast.Field{
+			Type: TypeExpr(v.Type(), qualifier),
+		}
+
+		if variadic && i == tuple.Len()-1 {
+			if s, ok := v.Type().(*types.Slice); ok {
+				field.Type = &ast.Ellipsis{
+					Elt: TypeExpr(s.Elem(), qualifier),
+				}
+			}
+		}
+
+		if named {
+			name := v.Name()
+			if name == "" {
+				name = "_"
+			}
+			field.Names = []*ast.Ident{{Name: name}}
+		}
+
+		fields.List = append(fields.List, field)
+	}
+
+	return fields
+}
+
+func quoteTag(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// ZeroValueExpr creates the zero value expression for a type.
+// The type information is used if it is known, otherwise the zero value is inferred from the type expression.
+func ZeroValueExpr(typ ast.Expr, t types.Type) ast.Expr {
+	if t != nil {
+		if expr := typedZeroValueExpr(typ, t); expr != nil {
+			return expr
+		}
+	}
+
+	switch e := typ.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "error":
+			return &ast.Ident{Name: "nil"}
+		case "bool":
+			return &ast.Ident{Name: "false"}
+		case "string":
+			return &ast.BasicLit{Kind: token.STRING, Value: `""`}
+		case "byte", "rune":
+			fallthrough
+		case "int", "int8", "int16", "int32", "int64":
+			fallthrough
+		case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+			return &ast.BasicLit{Kind: token.INT, Value: "0"}
+		case "float32", "float64":
+			return &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"}
+		case "complex64", "complex128":
+			return &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"}
+		default: // struct
+			return &ast.CompositeLit{Type: e}
+		}
+
+	case *ast.SelectorExpr, *ast.StructType:
+		return &ast.CompositeLit{Type: e}
+
+	case *ast.ArrayType:
+		// Array
+		if e.Len != nil {
+			return &ast.CompositeLit{Type: e}
+		}
+		// Slice
+		return &ast.Ident{Name: "nil"}
+
+	case *ast.Ellipsis, *ast.StarExpr, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+		return &ast.Ident{Name: "nil"}
+	}
+
+	// Type parameters, generic instantiations (e.g. Page[T]), etc.
+	return newZeroValueExpr(typ)
+}
+
+// newZeroValueExpr creates *new(T) which is the zero value of any type.
+func newZeroValueExpr(typ ast.Expr) ast.Expr {
+	return &ast.StarExpr{
+		X: &ast.CallExpr{
+			Fun:  &ast.Ident{Name: "new"},
+			Args: []ast.Expr{typ},
+		},
+	}
+}
+
+// typedZeroValueExpr creates the zero value expression for a type using its type information.
+// It returns nil if the zero value cannot be determined.
+func typedZeroValueExpr(typ ast.Expr, t types.Type) ast.Expr {
+	// The underlying type of a type parameter is its constraint interface
+	if _, ok := t.(*types.TypeParam); ok {
+		return newZeroValueExpr(typ)
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsBoolean != 0:
+			return &ast.Ident{Name: "false"}
+		case info&types.IsString != 0:
+			return &ast.BasicLit{Kind: token.STRING, Value: `""`}
+		case info&types.IsInteger != 0:
+			return &ast.BasicLit{Kind: token.INT, Value: "0"}
+		case info&types.IsFloat != 0:
+			return &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"}
+		case info&types.IsComplex != 0:
+			return &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"}
+		case u.Kind() == types.UnsafePointer:
+			return &ast.Ident{Name: "nil"}
+		}
+
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return &ast.Ident{Name: "nil"}
+
+	case *types.Struct, *types.Array:
+		return &ast.CompositeLit{Type: typ}
+	}
+
+	return nil
+}
@@ -0,0 +1,489 @@
+package compiler
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	goparser "go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const typerSrc = `package lookup
+
+import "unsafe"
+
+type Request struct {
+	ID string ` + "`json:\"id\"`" + `
+	Metadata
+}
+
+type Metadata struct{}
+
+type ID = string
+
+type Closer interface {
+	Close() error
+}
+
+var (
+	Basic     int
+	Unsafe    unsafe.Pointer
+	Named     Request
+	Alias     ID
+	Pointer   *Request
+	Slice     []Request
+	Array     [16]byte
+	Map       map[string]*Request
+	Chan      chan error
+	SendChan  chan<- error
+	RecvChan  <-chan error
+	Func      func(int, string) error
+	NamedFunc func(id string, opts ...string) (req *Request, err error)
+	Struct    struct{ Request; Name string ` + "`json:\"name\"`" + ` }
+	Interface interface{ Closer; Lookup(string) *Request }
+	Empty       interface{}
+	EmptyStruct struct{}
+)
+`
+
+func TestTypeExpr(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "lookup.go", typerSrc, 0)
+	assert.NoError(t, err)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("github.com/octocat/lookup", fset, []*ast.File{file}, nil)
+	assert.NoError(t, err)
+
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return "lookup"
+		}
+		return p.Name()
+	}
+
+	tests := []struct {
+		name         string
+		qualifier    types.Qualifier
+		expectedExpr string
+	}{
+		{"Basic", qualifier, "int"},
+		{"Unsafe", qualifier, "unsafe.Pointer"},
+		{"Named", qualifier, "lookup.Request"},
+		{"Named", nil, "Request"},
+		{"Alias", qualifier, "lookup.ID"},
+		{"Pointer", qualifier, "*lookup.Request"},
+		{"Slice", qualifier, "[]lookup.Request"},
+		{"Array", qualifier, "[16]byte"},
+		{"Map", qualifier, "map[string]*lookup.Request"},
+		{"Chan", qualifier, "chan error"},
+		{"SendChan", qualifier, "chan<- error"},
+		{"RecvChan", qualifier, "<-chan error"},
+		{"Func", qualifier, "func(int, string) error"},
+		{"NamedFunc", qualifier, "func(id string, opts ...string) (req *lookup.Request, err error)"},
+		{"Struct", qualifier, "struct {\n\tlookup.Request\n\tName string `json:\"name\"`\n}"},
+		{"Interface", qualifier, "interface {\n\tlookup.Closer\n\tLookup(string) *lookup.Request\n}"},
+		{"Empty", qualifier, "interface{}"},
+		{"EmptyStruct", qualifier, "struct{}"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			typ := pkg.Scope().Lookup(tc.name).Type()
+			expr := TypeExpr(typ, tc.qualifier)
+
+			buf := new(bytes.Buffer)
+			assert.NoError(t, format.Node(buf, token.NewFileSet(), expr))
+			assert.Equal(t, tc.expectedExpr, buf.String())
+		})
+	}
+}
+
+const typerGenericSrc = `package lookup
+
+type Page[T any] struct {
+	Items []T
+}
+
+type Number interface {
+	~int | ~float64
+}
+
+var Generic Page[string]
+
+func Max[T ~int | ~float64, N Number](a T, b N) Page[T] {
+	return Page[T]{}
+}
+`
+
+func TestTypeExpr_Generics(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := goparser.ParseFile(fset, "lookup.go", typerGenericSrc, 0)
+	assert.NoError(t, err)
+
+	conf := types.Config{}
+	pkg, err := conf.Check("github.com/octocat/lookup", fset, []*ast.File{file}, nil)
+	assert.NoError(t, err)
+
+	qualifier := func(p *types.Package) string {
+		return p.Name()
+	}
+
+	sig := pkg.Scope().Lookup("Max").Type().(*types.Signature)
+
+	tests := []struct {
+		name         string
+		typ          types.Type
+		expectedExpr string
+	}{
+		{"Instantiated", pkg.Scope().Lookup("Generic").Type(), "lookup.Page[string]"},
+		{"Generic", pkg.Scope().Lookup("Page").Type(), "lookup.Page"},
+		{"Signature", sig, "func(a T, b N) lookup.Page[T]"},
+		{"TypeParam", sig.TypeParams().At(0), "T"},
+		{"ImplicitConstraint", sig.TypeParams().At(0).Constraint(), "~int | ~float64"},
+		{"NamedConstraint", sig.TypeParams().At(1).Constraint(), "lookup.Number"},
+		{"Union", pkg.Scope().Lookup("Number").Type().Underlying(), "interface {\n\t~int | ~float64\n}"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr := TypeExpr(tc.typ, qualifier)
+
+			buf := new(bytes.Buffer)
+			assert.NoError(t, format.Node(buf, token.NewFileSet(), expr))
+			assert.Equal(t, tc.expectedExpr, buf.String())
+		})
+	}
+}
+
+func TestZeroValueExpr(t *testing.T) {
+	tests := []struct {
+		name         string
+		typ          ast.Expr
+		t            types.Type
+		expectedExpr ast.Expr
+	}{
+		{
+			name:         "error",
+			typ:          &ast.Ident{Name: "error"},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name:         "bool",
+			typ:          &ast.Ident{Name: "bool"},
+			expectedExpr: &ast.Ident{Name: "false"},
+		},
+		{
+			name:         "string",
+			typ:          &ast.Ident{Name: "string"},
+			expectedExpr: &ast.BasicLit{Kind: token.STRING, Value: `""`},
+		},
+		{
+			name:         "byte",
+			typ:          &ast.Ident{Name: "byte"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "rune",
+			typ:          &ast.Ident{Name: "rune"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "int",
+			typ:          &ast.Ident{Name: "int"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "int8",
+			typ:          &ast.Ident{Name: "int8"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "int16",
+			typ:          &ast.Ident{Name: "int16"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "int32",
+			typ:          &ast.Ident{Name: "int32"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "int64",
+			typ:          &ast.Ident{Name: "int64"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "uint",
+			typ:          &ast.Ident{Name: "int"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "uint8",
+			typ:          &ast.Ident{Name: "int8"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "uint16",
+			typ:          &ast.Ident{Name: "int16"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "uint32",
+			typ:          &ast.Ident{Name: "int32"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "uint64",
+			typ:          &ast.Ident{Name: "int64"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "uintptr",
+			typ:          &ast.Ident{Name: "uintptr"},
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "float32",
+			typ:          &ast.Ident{Name: "float32"},
+			expectedExpr: &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"},
+		},
+		{
+			name:         "float64",
+			typ:          &ast.Ident{Name: "float64"},
+			expectedExpr: &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"},
+		},
+		{
+			name:         "complex64",
+			typ:          &ast.Ident{Name: "complex64"},
+			expectedExpr: &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"},
+		},
+		{
+			name:         "complex128",
+			typ:          &ast.Ident{Name: "complex128"},
+			expectedExpr: &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"},
+		},
+		{
+			name: "Struct_SamePackage",
+			typ:  &ast.Ident{Name: "Address"},
+			expectedExpr: &ast.CompositeLit{
+				Type: &ast.Ident{Name: "Address"},
+			},
+		},
+		{
+			name: "Struct_OtherPackage",
+			typ: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "http"},
+				Sel: &ast.Ident{Name: "Transport"},
+			},
+			expectedExpr: &ast.CompositeLit{
+				Type: &ast.SelectorExpr{
+					X:   &ast.Ident{Name: "http"},
+					Sel: &ast.Ident{Name: "Transport"},
+				},
+			},
+		},
+		{
+			name: "Pointer",
+			typ: &ast.StarExpr{
+				X: &ast.Ident{Name: "int"},
+			},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name: "Slice",
+			typ: &ast.ArrayType{
+				Elt: &ast.Ident{Name: "int"},
+			},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name: "Map",
+			typ: &ast.MapType{
+				Key:   &ast.Ident{Name: "int"},
+				Value: &ast.Ident{Name: "string"},
+			},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name: "Channel",
+			typ: &ast.ChanType{
+				Value: &ast.Ident{Name: "error"},
+			},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name: "Array",
+			typ: &ast.ArrayType{
+				Len: &ast.BasicLit{Kind: token.INT, Value: "16"},
+				Elt: &ast.Ident{Name: "byte"},
+			},
+			expectedExpr: &ast.CompositeLit{
+				Type: &ast.ArrayType{
+					Len: &ast.BasicLit{Kind: token.INT, Value: "16"},
+					Elt: &ast.Ident{Name: "byte"},
+				},
+			},
+		},
+		{
+			name:         "Function",
+			typ:          &ast.FuncType{},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name:         "Interface",
+			typ:          &ast.InterfaceType{},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name: "Ellipsis",
+			typ: &ast.Ellipsis{
+				Elt: &ast.Ident{Name: "string"},
+			},
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name: "AnonymousStruct",
+			typ: &ast.StructType{
+				Fields: &ast.FieldList{},
+			},
+			expectedExpr: &ast.CompositeLit{
+				Type: &ast.StructType{
+					Fields: &ast.FieldList{},
+				},
+			},
+		},
+		{
+			name: "GenericInstantiation",
+			typ: &ast.IndexListExpr{
+				X: &ast.SelectorExpr{
+					X:   &ast.Ident{Name: "cache"},
+					Sel: &ast.Ident{Name: "Map"},
+				},
+				Indices: []ast.Expr{
+					&ast.Ident{Name: "string"},
+					&ast.Ident{Name: "T"},
+				},
+			},
+			expectedExpr: &ast.StarExpr{
+				X: &ast.CallExpr{
+					Fun: &ast.Ident{Name: "new"},
+					Args: []ast.Expr{
+						&ast.IndexListExpr{
+							X: &ast.SelectorExpr{
+								X:   &ast.Ident{Name: "cache"},
+								Sel: &ast.Ident{Name: "Map"},
+							},
+							Indices: []ast.Expr{
+								&ast.Ident{Name: "string"},
+								&ast.Ident{Name: "T"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Unknown",
+			typ: &ast.ParenExpr{
+				X: &ast.Ident{Name: "Status"},
+			},
+			expectedExpr: &ast.StarExpr{
+				X: &ast.CallExpr{
+					Fun: &ast.Ident{Name: "new"},
+					Args: []ast.Expr{
+						&ast.ParenExpr{
+							X: &ast.Ident{Name: "Status"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "Typed_Bool",
+			typ:          &ast.Ident{Name: "Enabled"},
+			t:            types.NewNamed(types.NewTypeName(0, nil, "Enabled", nil), types.Typ[types.Bool], nil),
+			expectedExpr: &ast.Ident{Name: "false"},
+		},
+		{
+			name:         "Typed_String",
+			typ:          &ast.Ident{Name: "Status"},
+			t:            types.NewNamed(types.NewTypeName(0, nil, "Status", nil), types.Typ[types.String], nil),
+			expectedExpr: &ast.BasicLit{Kind: token.STRING, Value: `""`},
+		},
+		{
+			name: "Typed_Integer",
+			typ: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "time"},
+				Sel: &ast.Ident{Name: "Duration"},
+			},
+			t:            types.NewNamed(types.NewTypeName(0, nil, "Duration", nil), types.Typ[types.Int64], nil),
+			expectedExpr: &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		{
+			name:         "Typed_Float",
+			typ:          &ast.Ident{Name: "Ratio"},
+			t:            types.NewNamed(types.NewTypeName(0, nil, "Ratio", nil), types.Typ[types.Float64], nil),
+			expectedExpr: &ast.BasicLit{Kind: token.FLOAT, Value: "0.0"},
+		},
+		{
+			name:         "Typed_Complex",
+			typ:          &ast.Ident{Name: "Point"},
+			t:            types.NewNamed(types.NewTypeName(0, nil, "Point", nil), types.Typ[types.Complex128], nil),
+			expectedExpr: &ast.BasicLit{Kind: token.IMAG, Value: "0.0i"},
+		},
+		{
+			name: "Typed_UnsafePointer",
+			typ: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "unsafe"},
+				Sel: &ast.Ident{Name: "Pointer"},
+			},
+			t:            types.Typ[types.UnsafePointer],
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name:         "Typed_Interface",
+			typ:          &ast.Ident{Name: "Service"},
+			t:            types.NewNamed(types.NewTypeName(0, nil, "Service", nil), types.NewInterfaceType(nil, nil), nil),
+			expectedExpr: &ast.Ident{Name: "nil"},
+		},
+		{
+			name: "Typed_Struct",
+			typ:  &ast.Ident{Name: "Address"},
+			t:    types.NewNamed(types.NewTypeName(0, nil, "Address", nil), types.NewStruct(nil, nil), nil),
+			expectedExpr: &ast.CompositeLit{
+				Type: &ast.Ident{Name: "Address"},
+			},
+		},
+		{
+			name: "Typed_TypeParam",
+			typ:  &ast.Ident{Name: "T"},
+			t:    types.NewTypeParam(types.NewTypeName(0, nil, "T", nil), types.NewInterfaceType(nil, nil)),
+			expectedExpr: &ast.StarExpr{
+				X: &ast.CallExpr{
+					Fun:  &ast.Ident{Name: "new"},
+					Args: []ast.Expr{&ast.Ident{Name: "T"}},
+				},
+			},
+		},
+		{
+			name:         "Typed_Invalid",
+			typ:          &ast.Ident{Name: "string"},
+			t:            types.Typ[types.Invalid],
+			expectedExpr: &ast.BasicLit{Kind: token.STRING, Value: `""`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expr := ZeroValueExpr(tc.typ, tc.t)
+
+			assert.Equal(t, tc.expectedExpr, expr)
+		})
+	}
+}
@@ -21,6 +21,7 @@ func getDebugFilename(path string) string {
 }
 
 // WriteFile formats and writes a Go source code file to disk.
+// The file is not rewritten if its content has not changed.
 func WriteFile(path string, fset *token.FileSet, file *ast.File) error {
 	buf := new(bytes.Buffer)
 	if err := format.Node(buf, fset, file); err != nil {
@@ -41,19 +42,19 @@ func WriteFile(path string, fset *token.FileSet, file *ast.File) error {
 		return fmt.Errorf("goimports error: %s", err)
 	}
 
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
+	return writeFileIfChanged(path, b)
+}
 
-	f, err := os.Create(path)
-	if err != nil {
-		return err
+// writeFileIfChanged writes data to a file only if the file does not exist or its content is different.
+// Leaving unchanged files untouched keeps their modification times, so editors and build tools do not reload them.
+func writeFileIfChanged(path string, data []byte) error {
+	if existing, err := ioutil.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
 	}
 
-	if _, err := f.Write(b); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
 	}
 
-	return nil
+	return ioutil.WriteFile(path, data, 0644)
 }
@@ -3,8 +3,11 @@ package compiler
 import (
 	"go/ast"
 	"go/token"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -97,3 +100,27 @@ func TestWriteFile(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteFileIfChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gen", "main.go")
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	assert.NoError(t, writeFileIfChanged(path, []byte("package main\n")))
+	assert.NoError(t, os.Chtimes(path, old, old))
+
+	t.Run("Unchanged", func(t *testing.T) {
+		assert.NoError(t, writeFileIfChanged(path, []byte("package main\n")))
+
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.True(t, info.ModTime().Equal(old))
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		assert.NoError(t, writeFileIfChanged(path, []byte("package main\n\nfunc main() {}\n")))
+
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "package main\n\nfunc main() {}\n", string(data))
+	})
+}
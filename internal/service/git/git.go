@@ -1,6 +1,7 @@
 package git
 
 import (
+	"container/heap"
 	"context"
 	"errors"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
 var (
@@ -103,6 +105,50 @@ func Init(path string) (*Git, error) {
 	}, nil
 }
 
+// Clone clones a remote git repository into a given path and returns a Git service for it.
+// If a ref (a branch, a tag, or a commit hash) is specified, the working tree is checked out at that ref.
+func Clone(ctx context.Context, url, ref, path string) (*Git, error) {
+	repo, err := git.PlainCloneContext(ctx, path, false, &git.CloneOptions{
+		URL: url,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ref != "" {
+		hash, err := resolveRef(repo, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		worktree, err := repo.Worktree()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := worktree.Checkout(&git.CheckoutOptions{Hash: hash}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Git{
+		repo: repo,
+	}, nil
+}
+
+// resolveRef resolves a ref in a cloned repository.
+// Only the default branch is created locally when cloning, so the other branches are looked up on the origin remote.
+func resolveRef(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	for _, rev := range []string{ref, "origin/" + ref} {
+		if hash, err := repo.ResolveRevision(plumbing.Revision(rev)); err == nil {
+			return *hash, nil
+		}
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf("ref not found: %s", ref)
+}
+
 // Path returns the root path of the Git repository.
 func (g *Git) Path() (string, error) {
 	worktree, err := g.repo.Worktree()
@@ -351,15 +397,69 @@ func (g *Git) CommitsIn(rev string) (Commits, error) {
 		return nil, err
 	}
 
-	commitsMap := make(map[plumbing.Hash]*object.Commit)
-	err = g.parentCommits(commitsMap, *h)
+	// Walk the commit graph iteratively, so the stack does not grow with the size of the history
+	visited := map[plumbing.Hash]bool{*h: true}
+	queue := []plumbing.Hash{*h}
+	commits := make([]Commit, 0)
+
+	for len(queue) > 0 {
+		c, err := g.repo.CommitObject(queue[0])
+		if err != nil {
+			return nil, err
+		}
+
+		queue = queue[1:]
+		commits = append(commits, toCommit(c))
+
+		for _, p := range c.ParentHashes {
+			if !visited[p] {
+				visited[p] = true
+				queue = append(queue, p)
+			}
+		}
+	}
+
+	// Sort commits
+	sort.Slice(commits, func(i, j int) bool {
+		// The order of the commits should be from the most recent to the least recent
+		return commits[i].Committer.After(commits[j].Committer)
+	})
+
+	return commits, nil
+}
+
+// CommitsInPath returns all commits reachable from a revision that modify at least one file in a given directory.
+// The directory is relative to the root of the git repository.
+func (g *Git) CommitsInPath(rev, path string) (Commits, error) {
+	h, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &git.LogOptions{From: *h}
+
+	// The root directory of the repository includes all files
+	if dir := filepath.ToSlash(filepath.Clean(path)); dir != "." && dir != "/" {
+		prefix := strings.TrimSuffix(dir, "/") + "/"
+		opts.PathFilter = func(p string) bool {
+			return strings.HasPrefix(p, prefix)
+		}
+	}
+
+	iter, err := g.repo.Log(opts)
+
 	if err != nil {
 		return nil, err
 	}
 
 	commits := make([]Commit, 0)
-	for _, c := range commitsMap {
+	err = iter.ForEach(func(c *object.Commit) error {
 		commits = append(commits, toCommit(c))
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	// Sort commits
@@ -371,25 +471,307 @@ func (g *Git) CommitsIn(rev string) (Commits, error) {
 	return commits, nil
 }
 
-func (g *Git) parentCommits(commitsMap map[plumbing.Hash]*object.Commit, h plumbing.Hash) error {
-	if _, ok := commitsMap[h]; ok {
+// DescribeOptions are the options for describing a revision.
+type DescribeOptions struct {
+	// Match is an optional predicate for only considering the tags with certain names.
+	Match func(name string) bool
+	// Path is an optional directory relative to the root of the git repository.
+	// If set, only the commits that modify at least one file in this directory are counted.
+	Path string
+}
+
+// Describe finds the nearest tags reachable from a revision similar to git describe --tags.
+//
+// The commit graph is searched breadth-first from the revision and the search stops at the first commit that has a matching tag.
+// The distance is the number of commits reachable from the revision, but not from the tagged commit.
+// If no matching tag is reachable from the revision, the distance is the number of all commits reachable from the revision.
+func (g *Git) Describe(rev string, opts DescribeOptions) (Description, error) {
+	h, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return Description{}, err
+	}
+
+	head, err := g.repo.CommitObject(*h)
+	if err != nil {
+		return Description{}, err
+	}
+
+	// Create a lookup table from commit hashes to the references of matching tags pointing to them
+	tagRefs := map[plumbing.Hash][]*plumbing.Reference{}
+
+	refs, err := g.repo.Tags()
+	if err != nil {
+		return Description{}, err
+	}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if opts.Match != nil && !opts.Match(ref.Name().Short()) {
+			return nil
+		}
+
+		target, err := g.peelTag(ref.Hash())
+		if err != nil {
+			return err
+		}
+
+		if !target.IsZero() {
+			tagRefs[target] = append(tagRefs[target], ref)
+		}
+
 		return nil
+	})
+
+	if err != nil {
+		return Description{}, err
 	}
 
-	c, err := g.repo.CommitObject(h)
+	dir := filepath.ToSlash(filepath.Clean(opts.Path))
+	if dir == "." || dir == "/" {
+		dir = ""
+	}
+
+	// Search the commit graph breadth-first for the nearest tagged commit
+	var tagged *object.Commit
+	walked := []*object.Commit{}
+	visited := map[plumbing.Hash]bool{head.Hash: true}
+	queue := []*object.Commit{head}
+
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		if _, ok := tagRefs[c.Hash]; ok {
+			tagged = c
+			break
+		}
+
+		walked = append(walked, c)
+
+		for _, p := range c.ParentHashes {
+			if !visited[p] {
+				visited[p] = true
+				pc, err := g.repo.CommitObject(p)
+				if err != nil {
+					return Description{}, err
+				}
+				queue = append(queue, pc)
+			}
+		}
+	}
+
+	desc := Description{
+		Commit: toCommit(head),
+		Tags:   Tags{},
+	}
+
+	// No matching tag is reachable, so all commits are counted
+	if tagged == nil {
+		for _, c := range walked {
+			ok, err := modifiesDir(c, dir)
+			if err != nil {
+				return Description{}, err
+			}
+			if ok {
+				desc.Distance++
+			}
+		}
+
+		return desc, nil
+	}
+
+	for _, ref := range tagRefs[tagged.Hash] {
+		tag, err := g.Tag(ref.Name().Short())
+		if err != nil {
+			return Description{}, err
+		}
+		desc.Tags = append(desc.Tags, tag)
+	}
+
+	if desc.Distance, err = g.distance(head, tagged, dir); err != nil {
+		return Description{}, err
+	}
+
+	return desc, nil
+}
+
+// peelTag resolves a tag reference hash to the hash of the commit it points to.
+// If the tag does not point to a commit, a zero hash is returned.
+func (g *Git) peelTag(h plumbing.Hash) (plumbing.Hash, error) {
+	for {
+		t, err := g.repo.TagObject(h)
+		switch err {
+		// Annotated tag
+		case nil:
+			switch t.TargetType {
+			case plumbing.TagObject:
+				h = t.Target
+			case plumbing.CommitObject:
+				return t.Target, nil
+			default:
+				return plumbing.ZeroHash, nil
+			}
+
+		// Lightweight tag
+		case plumbing.ErrObjectNotFound:
+			return h, nil
+
+		default:
+			return plumbing.ZeroHash, err
+		}
+	}
+}
+
+const (
+	reachableFromRev uint8 = 1 << iota
+	reachableFromTag
+)
+
+// distance counts the number of commits reachable from a revision commit, but not from a tagged commit.
+// The commit graph is walked from both commits in the committer time order until all the remaining commits are reachable from the tagged commit.
+func (g *Git) distance(rev, tagged *object.Commit, dir string) (int, error) {
+	if rev.Hash == tagged.Hash {
+		return 0, nil
+	}
+
+	flags := map[plumbing.Hash]uint8{
+		rev.Hash:    reachableFromRev,
+		tagged.Hash: reachableFromTag,
+	}
+
+	queue := &commitQueue{}
+	heap.Push(queue, rev)
+	heap.Push(queue, tagged)
+
+	// queued keeps track of the commits in the queue and revOnly is the number of them that are not reachable from the tagged commit.
+	// The walk is done when no commit in the queue is only reachable from the revision commit.
+	queued := map[plumbing.Hash]bool{rev.Hash: true, tagged.Hash: true}
+	revOnly := 1
+
+	var count int
+
+	for queue.Len() > 0 && revOnly > 0 {
+		c := heap.Pop(queue).(*object.Commit)
+		f := flags[c.Hash]
+
+		delete(queued, c.Hash)
+		if f == reachableFromRev {
+			revOnly--
+
+			ok, err := modifiesDir(c, dir)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				count++
+			}
+		}
+
+		for _, p := range c.ParentHashes {
+			pf, seen := flags[p]
+			flags[p] = pf | f
+
+			if !seen {
+				pc, err := g.repo.CommitObject(p)
+				if err != nil {
+					return 0, err
+				}
+				heap.Push(queue, pc)
+				queued[p] = true
+				if f == reachableFromRev {
+					revOnly++
+				}
+			} else if queued[p] && pf == reachableFromRev && flags[p] != reachableFromRev {
+				revOnly--
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// commitQueue is a priority queue of commits ordered from the most recent to the least recent.
+// It implements heap.Interface.
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int {
+	return len(q)
+}
+
+func (q commitQueue) Less(i, j int) bool {
+	return q[i].Committer.When.After(q[j].Committer.When)
+}
+
+func (q commitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *commitQueue) Push(x interface{}) {
+	*q = append(*q, x.(*object.Commit))
+}
+
+func (q *commitQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	c := old[n-1]
+	*q = old[:n-1]
+	return c
+}
+
+// modifiesDir determines whether or not a commit modifies at least one file in a directory.
+// A commit modifies a directory if the directory content is different from all of its parents.
+// An empty directory implies the root of the repository which is modified by all commits.
+func modifiesDir(c *object.Commit, dir string) (bool, error) {
+	if dir == "" {
+		return true, nil
+	}
+
+	h, err := dirHash(c, dir)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	commitsMap[c.Hash] = c
+	if c.NumParents() == 0 {
+		return !h.IsZero(), nil
+	}
 
-	for _, h := range c.ParentHashes {
-		if err := g.parentCommits(commitsMap, h); err != nil {
+	// The commit does not modify the directory if the directory is the same as in any of its parents
+	sameAsParent := false
+	err = c.Parents().ForEach(func(p *object.Commit) error {
+		ph, err := dirHash(p, dir)
+		if err != nil {
 			return err
 		}
+		if ph == h {
+			sameAsParent = true
+			return storer.ErrStop
+		}
+		return nil
+	})
+
+	if err != nil {
+		return false, err
 	}
 
-	return nil
+	return !sameAsParent, nil
+}
+
+// dirHash returns the hash of a directory tree in a commit.
+// If the directory does not exist in the commit, a zero hash is returned.
+func dirHash(c *object.Commit, dir string) (plumbing.Hash, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	entry, err := tree.FindEntry(dir)
+	switch err {
+	case nil:
+		return entry.Hash, nil
+	case object.ErrEntryNotFound, object.ErrDirectoryNotFound:
+		return plumbing.ZeroHash, nil
+	default:
+		return plumbing.ZeroHash, err
+	}
 }
 
 // AddRemote creates a new remote.
@@ -1,13 +1,19 @@
 package git
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -327,6 +333,80 @@ func TestInit(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	_, cleanup, err := setupGitRepo()
+	assert.NoError(t, err)
+	defer cleanup()
+
+	url, err := filepath.Abs(testPath)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		url           string
+		ref           string
+		expectedFiles []string
+		missingFiles  []string
+		expectedError string
+	}{
+		{
+			name:          "InvalidURL",
+			url:           "/foo",
+			expectedError: "repository not found",
+		},
+		{
+			name:          "InvalidRef",
+			url:           url,
+			ref:           "v1.0.0",
+			expectedError: "ref not found: v1.0.0",
+		},
+		{
+			name:          "DefaultBranch",
+			url:           url,
+			expectedFiles: []string{".gitmodules", "LICENSE", "README.md"},
+		},
+		{
+			name:          "Tag",
+			url:           url,
+			ref:           "v0.1.0",
+			expectedFiles: []string{"README.md"},
+			missingFiles:  []string{".gitmodules", "LICENSE"},
+		},
+		{
+			name:          "Branch",
+			url:           url,
+			ref:           "feature-branch",
+			expectedFiles: []string{".gitmodules", "LICENSE", "README.md"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := ioutil.TempDir("", "gelato-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(path)
+
+			g, err := Clone(context.Background(), tc.url, tc.ref, path)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, g)
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, g)
+
+				for _, file := range tc.expectedFiles {
+					assert.FileExists(t, filepath.Join(path, file))
+				}
+
+				for _, file := range tc.missingFiles {
+					assert.NoFileExists(t, filepath.Join(path, file))
+				}
+			}
+		})
+	}
+}
+
 func TestGit_Path(t *testing.T) {
 	repo, cleanup, err := setupGitRepo()
 	assert.NoError(t, err)
@@ -635,6 +715,236 @@ func TestGit_CommitsIn(t *testing.T) {
 	assert.Len(t, commits, 3)
 }
 
+func TestGit_CommitsInPath(t *testing.T) {
+	repo, cleanup, err := setupGitRepo()
+	assert.NoError(t, err)
+	defer cleanup()
+
+	g := &Git{repo: repo}
+
+	t.Run("Root", func(t *testing.T) {
+		commits, err := g.CommitsInPath("HEAD", ".")
+		assert.NoError(t, err)
+		assert.Len(t, commits, 3)
+	})
+
+	t.Run("NoCommit", func(t *testing.T) {
+		commits, err := g.CommitsInPath("HEAD", "make")
+		assert.NoError(t, err)
+		assert.Len(t, commits, 0)
+	})
+
+	t.Run("InvalidRevision", func(t *testing.T) {
+		commits, err := g.CommitsInPath("invalid", ".")
+		assert.Error(t, err)
+		assert.Nil(t, commits)
+	})
+}
+
+func TestGit_Describe(t *testing.T) {
+	repo, cleanup, err := setupGitRepo()
+	assert.NoError(t, err)
+	defer cleanup()
+
+	g := &Git{repo: repo}
+
+	tests := []struct {
+		name             string
+		rev              string
+		opts             DescribeOptions
+		expectedError    bool
+		expectedTags     []string
+		expectedDistance int
+	}{
+		{
+			name:          "InvalidRevision",
+			rev:           "invalid",
+			expectedError: true,
+		},
+		{
+			name:             "AllTags",
+			rev:              "HEAD",
+			opts:             DescribeOptions{},
+			expectedTags:     []string{"v0.2.0"},
+			expectedDistance: 1,
+		},
+		{
+			name: "MatchingTags",
+			rev:  "HEAD",
+			opts: DescribeOptions{
+				Match: func(name string) bool {
+					return name == "v0.1.0"
+				},
+			},
+			expectedTags:     []string{"v0.1.0"},
+			expectedDistance: 2,
+		},
+		{
+			name: "NoMatchingTag",
+			rev:  "HEAD",
+			opts: DescribeOptions{
+				Match: func(name string) bool {
+					return false
+				},
+			},
+			expectedTags:     []string{},
+			expectedDistance: 3,
+		},
+		{
+			name:             "TaggedRevision",
+			rev:              "v0.2.0",
+			opts:             DescribeOptions{},
+			expectedTags:     []string{"v0.2.0"},
+			expectedDistance: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			desc, err := g.Describe(tc.rev, tc.opts)
+
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Empty(t, desc)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, desc.Commit.Hash)
+				assert.Equal(t, tc.expectedDistance, desc.Distance)
+
+				names := []string{}
+				for _, tag := range desc.Tags {
+					names = append(names, tag.Name)
+				}
+				assert.Equal(t, tc.expectedTags, names)
+			}
+		})
+	}
+}
+
+func TestGit_Describe_Graph(t *testing.T) {
+	repo, err := git.PlainInit(testPath, false)
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	when := time.Date(2020, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+	// commit writes a file and creates a new commit with a distinct time.
+	commit := func(file string, parents ...plumbing.Hash) plumbing.Hash {
+		path := filepath.Join(testPath, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(file), 0644))
+
+		_, err := worktree.Add(file)
+		assert.NoError(t, err)
+
+		when = when.Add(time.Hour)
+		sig := &object.Signature{Name: "Jane Doe", Email: "jane.doe@example.com", When: when}
+		h, err := worktree.Commit(file, &git.CommitOptions{Author: sig, Committer: sig, Parents: parents})
+		assert.NoError(t, err)
+
+		return h
+	}
+
+	//  c1 ── c2 ── c3 ─────── m
+	//         \              /
+	//          f1 ── f2 ─────
+	c1 := commit("README.md")
+	c2 := commit("api/main.go")
+	f1 := commit("web/index.html")
+	f2 := commit("api/handler.go")
+	assert.NoError(t, worktree.Checkout(&git.CheckoutOptions{Hash: c2}))
+	c3 := commit("web/style.css", c2)
+	m := commit("api/server.go", c3, f2)
+
+	_, err = repo.CreateTag("v0.1.0", c1, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("api/v0.1.0", c2, nil)
+	assert.NoError(t, err)
+	_, err = repo.CreateTag("api/v0.1.0-rc.1", c2, nil)
+	assert.NoError(t, err)
+
+	g := &Git{repo: repo}
+
+	apiTags := func(name string) bool {
+		return strings.HasPrefix(name, "api/")
+	}
+
+	tests := []struct {
+		name             string
+		rev              string
+		opts             DescribeOptions
+		expectedTags     []string
+		expectedDistance int
+	}{
+		{
+			name:             "AllTags",
+			rev:              m.String(),
+			opts:             DescribeOptions{},
+			expectedTags:     []string{"api/v0.1.0", "api/v0.1.0-rc.1"},
+			expectedDistance: 4,
+		},
+		{
+			name:             "RootTags",
+			rev:              m.String(),
+			opts:             DescribeOptions{Match: func(name string) bool { return !strings.Contains(name, "/") }},
+			expectedTags:     []string{"v0.1.0"},
+			expectedDistance: 5,
+		},
+		{
+			name:             "ModuleTags_WithPath",
+			rev:              m.String(),
+			opts:             DescribeOptions{Match: apiTags, Path: "api"},
+			expectedTags:     []string{"api/v0.1.0", "api/v0.1.0-rc.1"},
+			expectedDistance: 2,
+		},
+		{
+			name:             "ModuleTags_WithOtherPath",
+			rev:              m.String(),
+			opts:             DescribeOptions{Match: apiTags, Path: "web"},
+			expectedTags:     []string{"api/v0.1.0", "api/v0.1.0-rc.1"},
+			expectedDistance: 2,
+		},
+		{
+			name:             "NoMatchingTag_WithPath",
+			rev:              m.String(),
+			opts:             DescribeOptions{Match: func(string) bool { return false }, Path: "api/"},
+			expectedTags:     []string{},
+			expectedDistance: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			desc, err := g.Describe(tc.rev, tc.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.rev, desc.Commit.Hash)
+			assert.Equal(t, tc.expectedDistance, desc.Distance)
+
+			names := []string{}
+			for _, tag := range desc.Tags {
+				names = append(names, tag.Name)
+			}
+			sort.Strings(names)
+			assert.Equal(t, tc.expectedTags, names)
+		})
+	}
+
+	// The queued commits that become reachable from the tagged commit end the walk
+	t.Run("Distance_SideBranchTag", func(t *testing.T) {
+		rev, err := repo.CommitObject(c3)
+		assert.NoError(t, err)
+		tagged, err := repo.CommitObject(f1)
+		assert.NoError(t, err)
+
+		distance, err := g.distance(rev, tagged, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, distance)
+	})
+}
+
 func TestGit_AddRemote(t *testing.T) {
 	repo, cleanup, err := setupGitRepo()
 	assert.NoError(t, err)
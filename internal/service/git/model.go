@@ -241,6 +241,16 @@ func (t Tags) Select(f func(Tag) bool) (Tags, Tags) {
 	return selected, unselected
 }
 
+// Description is the result of describing a revision using the nearest tags reachable from it.
+type Description struct {
+	// Commit is the described commit.
+	Commit Commit
+	// Tags are the tags pointing to the nearest tagged commit reachable from the described commit.
+	Tags Tags
+	// Distance is the number of commits since the tagged commit.
+	Distance int
+}
+
 // Submodule represents a git submodule.
 type Submodule struct {
 	Name   string
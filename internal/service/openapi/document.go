@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the subset of an OpenAPI 3 document used for generating code.
+// See https://swagger.io/specification
+type Document struct {
+	OpenAPI    string              `yaml:"openapi"`
+	Info       Info                `yaml:"info"`
+	Servers    []Server            `yaml:"servers"`
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components Components          `yaml:"components"`
+}
+
+// Info is the metadata of an API.
+type Info struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Version     string `yaml:"version"`
+}
+
+// Server is a server hosting an API.
+type Server struct {
+	URL string `yaml:"url"`
+}
+
+// PathItem has the operations available on a single path.
+type PathItem struct {
+	Get        *Operation  `yaml:"get"`
+	Put        *Operation  `yaml:"put"`
+	Post       *Operation  `yaml:"post"`
+	Delete     *Operation  `yaml:"delete"`
+	Options    *Operation  `yaml:"options"`
+	Head       *Operation  `yaml:"head"`
+	Patch      *Operation  `yaml:"patch"`
+	Trace      *Operation  `yaml:"trace"`
+	Parameters []Parameter `yaml:"parameters"`
+}
+
+// Operations returns the operations of a path by their HTTP methods in a fixed order.
+func (p PathItem) Operations() ([]string, []*Operation) {
+	methods := []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+	all := []*Operation{p.Get, p.Put, p.Post, p.Delete, p.Options, p.Head, p.Patch, p.Trace}
+
+	ms, ops := []string{}, []*Operation{}
+	for i, op := range all {
+		if op != nil {
+			ms = append(ms, methods[i])
+			ops = append(ops, op)
+		}
+	}
+
+	return ms, ops
+}
+
+// Operation is a single API operation on a path.
+type Operation struct {
+	OperationID string              `yaml:"operationId"`
+	Tags        []string            `yaml:"tags"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	Parameters  []Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody        `yaml:"requestBody"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+// Parameter is a path, query, header, or cookie parameter of an operation.
+type Parameter struct {
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"`
+	Description string  `yaml:"description"`
+	Required    bool    `yaml:"required"`
+	Schema      *Schema `yaml:"schema"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Description string               `yaml:"description"`
+	Required    bool                 `yaml:"required"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+// Response is a single response of an operation.
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+// MediaType has the schema of a request or response body for a media type.
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Components holds the reusable objects of an API.
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas"`
+}
+
+// Schema is the definition of an input or output data type.
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Description          string             `yaml:"description"`
+	Required             []string           `yaml:"required"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	Items                *Schema            `yaml:"items"`
+	AdditionalProperties *Schema            `yaml:"-"`
+
+	// order is the order of properties in the document.
+	order []string
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+// It accepts both boolean and schema values for additionalProperties and keeps the order of properties.
+func (s *Schema) UnmarshalYAML(value *yaml.Node) error {
+	type schema Schema
+	var raw struct {
+		schema               `yaml:",inline"`
+		AdditionalProperties yaml.Node `yaml:"additionalProperties"`
+	}
+
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+
+	*s = Schema(raw.schema)
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if value.Content[i].Value == "properties" && value.Content[i+1].Kind == yaml.MappingNode {
+			props := value.Content[i+1].Content
+			for j := 0; j < len(props); j += 2 {
+				s.order = append(s.order, props[j].Value)
+			}
+		}
+	}
+
+	switch raw.AdditionalProperties.Kind {
+	case 0:
+	case yaml.ScalarNode:
+		var b bool
+		if err := raw.AdditionalProperties.Decode(&b); err != nil {
+			return err
+		}
+		if b {
+			s.AdditionalProperties = &Schema{}
+		}
+	default:
+		s.AdditionalProperties = new(Schema)
+		if err := raw.AdditionalProperties.Decode(s.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsRequired determines whether or not a property of an object schema is required.
+func (s *Schema) IsRequired(name string) bool {
+	for _, r := range s.Required {
+		if r == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PropertyNames returns the names of properties of an object schema in the order of the document.
+func (s *Schema) PropertyNames() []string {
+	if len(s.order) == len(s.Properties) {
+		return s.order
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// jsonSchema returns the schema of the JSON content of a request or response body if there is one.
+func jsonSchema(content map[string]MediaType) *Schema {
+	for mediaType, m := range content {
+		if strings.HasPrefix(mediaType, "application/json") {
+			return m.Schema
+		}
+	}
+
+	return nil
+}
+
+// ReadDocument reads and parses an OpenAPI 3 document in YAML or JSON format.
+func ReadDocument(path string) (*Document, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := new(Document)
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s: unsupported OpenAPI version: %q", path, doc.OpenAPI)
+	}
+
+	return doc, nil
+}
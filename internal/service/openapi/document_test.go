@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name           string
+		yaml           string
+		expectedSchema *Schema
+		expectedError  string
+	}{
+		{
+			name:          "InvalidSchema",
+			yaml:          `properties: []`,
+			expectedError: "yaml: unmarshal errors:\n  line 1: cannot unmarshal !!seq into map[string]*openapi.Schema",
+		},
+		{
+			name:          "InvalidAdditionalProperties",
+			yaml:          `additionalProperties: maybe`,
+			expectedError: "yaml: unmarshal errors:\n  line 1: cannot unmarshal !!str `maybe` into bool",
+		},
+		{
+			name: "Properties",
+			yaml: "type: object\nrequired: [name]\nproperties:\n  name:\n    type: string\n  age:\n    type: integer\n",
+			expectedSchema: &Schema{
+				Type:     "object",
+				Required: []string{"name"},
+				Properties: map[string]*Schema{
+					"name": {Type: "string"},
+					"age":  {Type: "integer"},
+				},
+				order: []string{"name", "age"},
+			},
+		},
+		{
+			name: "AdditionalProperties_True",
+			yaml: "type: object\nadditionalProperties: true\n",
+			expectedSchema: &Schema{
+				Type:                 "object",
+				AdditionalProperties: &Schema{},
+			},
+		},
+		{
+			name: "AdditionalProperties_False",
+			yaml: "type: object\nadditionalProperties: false\n",
+			expectedSchema: &Schema{
+				Type: "object",
+			},
+		},
+		{
+			name: "AdditionalProperties_Schema",
+			yaml: "type: object\nadditionalProperties:\n  type: number\n",
+			expectedSchema: &Schema{
+				Type:                 "object",
+				AdditionalProperties: &Schema{Type: "number"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := new(Schema)
+			err := yaml.Unmarshal([]byte(tc.yaml), s)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedSchema, s)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestSchema_PropertyNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		schema        *Schema
+		expectedNames []string
+	}{
+		{
+			name: "DocumentOrder",
+			schema: &Schema{
+				Properties: map[string]*Schema{"b": {}, "a": {}},
+				order:      []string{"b", "a"},
+			},
+			expectedNames: []string{"b", "a"},
+		},
+		{
+			name: "SortedOrder",
+			schema: &Schema{
+				Properties: map[string]*Schema{"b": {}, "a": {}},
+			},
+			expectedNames: []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedNames, tc.schema.PropertyNames())
+		})
+	}
+}
+
+func TestPathItem_Operations(t *testing.T) {
+	get, post, del := &Operation{}, &Operation{}, &Operation{}
+	item := PathItem{Get: get, Post: post, Delete: del}
+
+	methods, ops := item.Operations()
+
+	assert.Equal(t, []string{"GET", "POST", "DELETE"}, methods)
+	assert.Equal(t, []*Operation{get, post, del}, ops)
+}
+
+func TestReadDocument(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"invalid.yaml": "openapi: [",
+		"swagger.yaml": "swagger: \"2.0\"\n",
+		"greeting.yaml": `openapi: 3.0.0
+info:
+  title: Greeting API
+  version: 1.0.0
+servers:
+  - url: api.example.com/v1
+paths:
+  /greet:
+    post:
+      summary: Greets a name.
+      responses:
+        '200':
+          description: Successful response.
+`,
+	}
+
+	for name, content := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	tests := []struct {
+		name             string
+		path             string
+		expectedDocument *Document
+		expectedError    string
+	}{
+		{
+			name:          "NoFile",
+			path:          filepath.Join(dir, "missing.yaml"),
+			expectedError: "open " + filepath.Join(dir, "missing.yaml") + ": no such file or directory",
+		},
+		{
+			name:          "InvalidYAML",
+			path:          filepath.Join(dir, "invalid.yaml"),
+			expectedError: filepath.Join(dir, "invalid.yaml") + ": yaml: line 1: did not find expected node content",
+		},
+		{
+			name:          "UnsupportedVersion",
+			path:          filepath.Join(dir, "swagger.yaml"),
+			expectedError: filepath.Join(dir, "swagger.yaml") + `: unsupported OpenAPI version: ""`,
+		},
+		{
+			name: "Success",
+			path: filepath.Join(dir, "greeting.yaml"),
+			expectedDocument: &Document{
+				OpenAPI: "3.0.0",
+				Info: Info{
+					Title:   "Greeting API",
+					Version: "1.0.0",
+				},
+				Servers: []Server{
+					{URL: "api.example.com/v1"},
+				},
+				Paths: map[string]PathItem{
+					"/greet": {
+						Post: &Operation{
+							Summary: "Greets a name.",
+							Responses: map[string]Response{
+								"200": {Description: "Successful response."},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ReadDocument(tc.path)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedDocument, doc)
+			} else {
+				assert.Nil(t, doc)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// initialisms are the words written in all capitals in Go names.
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true, "json": true,
+	"sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// splitWords splits a name in camel case, kebab case, snake case, or a path into lowercase words.
+func splitWords(name string) []string {
+	words := []string{}
+	word := []rune{}
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+
+	flush()
+
+	return words
+}
+
+// pascal joins words in pascal case.
+func pascal(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		if initialisms[w] {
+			b.WriteString(strings.ToUpper(w))
+		} else {
+			r := []rune(w)
+			b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+		}
+	}
+
+	return b.String()
+}
+
+// exported returns the exported Go name for a name (e.g. user_id becomes UserID).
+func exported(name string) string {
+	s := pascal(splitWords(name))
+	if s != "" && !unicode.IsUpper([]rune(s)[0]) {
+		s = "X" + s
+	}
+
+	return s
+}
+
+// unexported returns the unexported Go name for a name (e.g. UserID becomes userID).
+// Names conflicting with Go keywords or reserved names are suffixed.
+func unexported(name string, reserved map[string]bool) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return ""
+	}
+
+	s := words[0] + pascal(words[1:])
+	if !unicode.IsLetter([]rune(s)[0]) {
+		s = "x" + s
+	}
+
+	if token.IsKeyword(s) || reserved[s] {
+		s += "Param"
+	}
+
+	return s
+}
+
+// article prefixes a noun phrase with an indefinite article (e.g. event becomes an event).
+// Words starting with u are assumed to sound like you (e.g. a user).
+func article(s string) string {
+	if s != "" && strings.ContainsRune("aeioAEIO", []rune(s)[0]) {
+		return "an " + s
+	}
+
+	return "a " + s
+}
+
+// sentence returns a sentence for a summary with the first letter in lowercase (e.g. Greets a name becomes greets a name).
+func sentence(summary string) string {
+	runes := []rune(strings.TrimSpace(summary))
+	if len(runes) > 1 && unicode.IsUpper(runes[0]) && !unicode.IsUpper(runes[1]) {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+
+	s := string(runes)
+	if s != "" && !strings.HasSuffix(s, ".") {
+		s += "."
+	}
+
+	return s
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectedWords []string
+	}{
+		{"", []string{}},
+		{"greeting", []string{"greeting"}},
+		{"purchaseOrder", []string{"purchase", "order"}},
+		{"PurchaseOrder", []string{"purchase", "order"}},
+		{"purchase-order", []string{"purchase", "order"}},
+		{"purchase_order", []string{"purchase", "order"}},
+		{"/users/{user_id}", []string{"users", "user", "id"}},
+		{"userID", []string{"user", "id"}},
+		{"HTTPServer", []string{"http", "server"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedWords, splitWords(tc.name))
+		})
+	}
+}
+
+func TestExported(t *testing.T) {
+	tests := []struct {
+		name         string
+		expectedName string
+	}{
+		{"", ""},
+		{"name", "Name"},
+		{"user_id", "UserID"},
+		{"createdAt", "CreatedAt"},
+		{"api-url", "APIURL"},
+		{"2fa", "X2fa"},
+		{"über", "Über"},
+		{"ßtraße", "Xßtraße"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, exported(tc.name))
+		})
+	}
+}
+
+func TestUnexported(t *testing.T) {
+	tests := []struct {
+		name         string
+		reserved     map[string]bool
+		expectedName string
+	}{
+		{"", nil, ""},
+		{"Greet", nil, "greet"},
+		{"user_id", nil, "userID"},
+		{"2fa", nil, "x2fa"},
+		{"type", nil, "typeParam"},
+		{"url", map[string]bool{"url": true}, "urlParam"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, unexported(tc.name, tc.reserved))
+		})
+	}
+}
+
+func TestArticle(t *testing.T) {
+	tests := []struct {
+		noun           string
+		expectedPhrase string
+	}{
+		{"user", "a user"},
+		{"event", "an event"},
+		{"Insert request", "an Insert request"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.noun, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPhrase, article(tc.noun))
+		})
+	}
+}
+
+func TestSentence(t *testing.T) {
+	tests := []struct {
+		summary          string
+		expectedSentence string
+	}{
+		{"", ""},
+		{"Greets a name.", "greets a name."},
+		{"Lists users", "lists users."},
+		{"HTTP check.", "HTTP check."},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.summary, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSentence, sentence(tc.summary))
+		})
+	}
+}
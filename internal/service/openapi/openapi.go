@@ -0,0 +1,693 @@
+// Package openapi generates Go code from OpenAPI 3 specifications.
+// It generates the HTTP models, the handler interfaces, the routes, and the typed clients of the idl package in applications with horizontal layout.
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/moorara/gelato/internal/log"
+)
+
+const (
+	specDir   = "idl"
+	outputDir = "internal/idl"
+	refPrefix = "#/components/schemas/"
+)
+
+// reservedNames are the identifiers used by the generated client methods.
+var reservedNames = map[string]bool{
+	"body": true, "bytes": true, "c": true, "context": true, "ctx": true, "err": true, "fmt": true,
+	"http": true, "json": true, "mux": true, "out": true, "q": true, "r": true, "req": true,
+	"resp": true, "strings": true, "time": true, "u": true, "url": true, "xhttp": true,
+}
+
+// Generator is used for generating Go code from the OpenAPI specifications of an application.
+type Generator struct {
+	logger *log.ColorfulLogger
+}
+
+// NewGenerator creates a new generator.
+func NewGenerator(level log.Level) *Generator {
+	logger := log.NewColorful(level)
+
+	return &Generator{
+		logger: logger,
+	}
+}
+
+// Generate generates Go code for the OpenAPI specifications (idl/*.yaml) of an application into its internal/idl package.
+// A Go file is generated for each specification and it is overwritten if it already exists.
+// If the application does not have any specification or an idl package, nothing is generated.
+// It returns the paths of the generated files relative to the application root.
+func (g *Generator) Generate(root string) ([]string, error) {
+	specs, err := filepath.Glob(filepath.Join(root, specDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(specs) == 0 {
+		g.logger.Yellow.Debugf("No OpenAPI specification found in %s", specDir)
+		return nil, nil
+	}
+
+	if info, err := os.Stat(filepath.Join(root, outputDir)); err != nil || !info.IsDir() {
+		g.logger.Yellow.Debugf("No %s package found", outputDir)
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+
+	module := modfile.ModulePath(data)
+	if module == "" {
+		return nil, fmt.Errorf("no module found in %s", root)
+	}
+
+	// Generate all files in memory first, so nothing is written if any of them fails
+	paths := make([]string, len(specs))
+	contents := make([][]byte, len(specs))
+	for i, spec := range specs {
+		doc, err := ReadDocument(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.TrimSuffix(filepath.Base(spec), ".yaml")
+		source := filepath.ToSlash(filepath.Join(specDir, filepath.Base(spec)))
+
+		a, err := newAPI(module, name, source, doc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", source, err)
+		}
+
+		paths[i] = filepath.Join(outputDir, strings.Join(splitWords(name), "_")+".go")
+		if contents[i], err = render(paths[i], a); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, path := range paths {
+		g.logger.Green.Debugf("Generating %s", path)
+		if err := ioutil.WriteFile(filepath.Join(root, path), contents[i], 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	return paths, nil
+}
+
+// api is the model for generating the Go code of an OpenAPI specification.
+type api struct {
+	// Module is the Go module path of the application.
+	Module string
+	// Source is the path of the specification relative to the application root.
+	Source string
+	// Name is the exported Go name of the API (e.g. Greeting).
+	Name string
+	// Label is the API name in plain text (e.g. greeting).
+	Label string
+	// Title is the title of the API.
+	Title string
+	// Imports are the import paths used by the generated code.
+	Imports    []string
+	Types      []*typeDef
+	Operations []*operation
+
+	names   map[string]bool
+	structs map[string]bool
+	doc     *Document
+}
+
+// typeDef is a Go type defined for a schema.
+type typeDef struct {
+	Name   string
+	Doc    []string
+	Type   string
+	Fields []field
+}
+
+// field is a field of a Go struct defined for an object schema.
+type field struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// operation is an API operation.
+type operation struct {
+	Name   string
+	Var    string
+	Doc    string
+	Method string
+	Path   string
+	// URL is the Go expression for the URL path of the operation in the client.
+	URL    string
+	Params []param
+	Query  []param
+	// Request and Response are the Go types of request and response bodies.
+	Request      string
+	Response     string
+	ResponseZero string
+}
+
+// param is a path or query parameter of an operation.
+type param struct {
+	Name     string
+	Var      string
+	Type     string
+	Required bool
+	// String is the Go expression for the string value of the parameter.
+	String string
+	// IsSet is the Go expression determining whether or not an optional parameter is set.
+	IsSet string
+}
+
+func newAPI(module, name, source string, doc *Document) (*api, error) {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("invalid specification name: %q", name)
+	}
+
+	a := &api{
+		Module:  module,
+		Source:  source,
+		Name:    pascal(words),
+		Label:   strings.Join(words, " "),
+		Title:   doc.Info.Title,
+		names:   map[string]bool{},
+		structs: map[string]bool{},
+		doc:     doc,
+	}
+
+	// Components
+	schemaNames := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+
+	for _, name := range schemaNames {
+		if a.isStruct(doc.Components.Schemas[name]) {
+			a.structs[exported(name)] = true
+		}
+	}
+
+	for _, name := range schemaNames {
+		s := doc.Components.Schemas[name]
+		typeName := exported(name)
+		desc := article(strings.Join(splitWords(name), " "))
+
+		if a.isStruct(s) && s.Ref == "" {
+			if _, err := a.goType(s, typeName, desc); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		t, err := a.goType(s, typeName+"Item", "an item of "+typeName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.define(&typeDef{Name: typeName, Doc: a.typeDoc(typeName, desc, s), Type: t}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Operations
+	basePath := ""
+	if len(doc.Servers) > 0 {
+		basePath = serverPath(doc.Servers[0].URL)
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	type entry struct {
+		path   string
+		method string
+		op     *Operation
+		item   PathItem
+	}
+
+	entries, counts := []entry{}, map[string]int{}
+	for _, path := range paths {
+		item := doc.Paths[path]
+		methods, ops := item.Operations()
+		for i, op := range ops {
+			entries = append(entries, entry{path, methods[i], op, item})
+			counts[operationName(path, "", op)]++
+		}
+	}
+
+	opNames := map[string]bool{}
+	for _, e := range entries {
+		name := operationName(e.path, "", e.op)
+		if counts[name] > 1 && e.op.OperationID == "" {
+			name = operationName(e.path, e.method, e.op)
+		}
+
+		if name == "" || opNames[name] {
+			return nil, fmt.Errorf("duplicate operation name %q for %s %s (operationId can be used for naming operations)", name, e.method, e.path)
+		}
+		opNames[name] = true
+
+		op, err := a.newOperation(name, e.method, basePath, e.path, e.op, e.item.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		a.Operations = append(a.Operations, op)
+	}
+
+	a.Imports = a.imports()
+
+	return a, nil
+}
+
+// serverPath returns the path of a server URL (e.g. api.example.com/v1 becomes /v1).
+func serverPath(url string) string {
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+	}
+
+	if i := strings.Index(url, "/"); i >= 0 {
+		return strings.TrimSuffix(url[i:], "/")
+	}
+
+	return ""
+}
+
+// operationName returns the name of an operation by its operationId or otherwise by its path (e.g. /greet becomes Greet).
+// If a method is given, it prefixes the name (e.g. GET /users becomes GetUsers).
+func operationName(path, method string, op *Operation) string {
+	if op.OperationID != "" {
+		return exported(op.OperationID)
+	}
+
+	words := []string{}
+	if method != "" {
+		words = append(words, strings.ToLower(method))
+	}
+
+	for _, seg := range strings.Split(path, "/") {
+		if !strings.HasPrefix(seg, "{") {
+			words = append(words, splitWords(seg)...)
+		}
+	}
+
+	return pascal(words)
+}
+
+func (a *api) newOperation(name, method, basePath, path string, op *Operation, common []Parameter) (*operation, error) {
+	o := &operation{
+		Name:   name,
+		Var:    unexported(name, nil) + "Handler",
+		Method: method,
+		Path:   basePath + path,
+	}
+
+	if op.Summary != "" {
+		o.Doc = sentence(op.Summary)
+	} else if op.Description != "" {
+		o.Doc = sentence(strings.SplitN(op.Description, "\n", 2)[0])
+	}
+
+	// Operation parameters override the path parameters with the same names
+	params := append([]Parameter{}, op.Parameters...)
+	for _, p := range common {
+		overridden := false
+		for _, q := range op.Parameters {
+			if q.Name == p.Name && q.In == p.In {
+				overridden = true
+			}
+		}
+		if !overridden {
+			params = append(params, p)
+		}
+	}
+
+	vars := map[string]bool{}
+	for k, v := range reservedNames {
+		vars[k] = v
+	}
+
+	for _, p := range params {
+		if p.In != "path" && p.In != "query" {
+			continue
+		}
+
+		t, err := a.goType(p.Schema, "", "")
+		if err != nil {
+			return nil, err
+		}
+
+		prm := param{
+			Name:     p.Name,
+			Var:      unexported(p.Name, vars),
+			Type:     t,
+			Required: p.Required || p.In == "path",
+		}
+
+		if prm.Var == "" || vars[prm.Var] {
+			return nil, fmt.Errorf("invalid parameter name %q for %s %s", p.Name, method, path)
+		}
+		vars[prm.Var] = true
+
+		switch t {
+		case "string":
+			prm.String, prm.IsSet = prm.Var, prm.Var+` != ""`
+		case "bool":
+			prm.String, prm.IsSet = "fmt.Sprint("+prm.Var+")", prm.Var
+		case "int", "int32", "int64", "float32", "float64":
+			prm.String, prm.IsSet = "fmt.Sprint("+prm.Var+")", prm.Var+" != 0"
+		case "time.Time":
+			prm.String, prm.IsSet = prm.Var+".Format(time.RFC3339)", "!"+prm.Var+".IsZero()"
+		default:
+			return nil, fmt.Errorf("unsupported type for parameter %q: %s", p.Name, t)
+		}
+
+		if p.In == "path" {
+			o.Params = append(o.Params, prm)
+		} else {
+			o.Query = append(o.Query, prm)
+		}
+	}
+
+	// The URL path in the client
+	url := []string{}
+	rest := o.Path
+	for _, prm := range o.Params {
+		placeholder := "{" + prm.Name + "}"
+		i := strings.Index(rest, placeholder)
+		if i < 0 {
+			return nil, fmt.Errorf("path parameter %q not found in %s", prm.Name, path)
+		}
+		if i > 0 {
+			url = append(url, strconv.Quote(rest[:i]))
+		}
+		url = append(url, "url.PathEscape("+prm.String+")")
+		rest = rest[i+len(placeholder):]
+	}
+	if rest != "" {
+		url = append(url, strconv.Quote(rest))
+	}
+	o.URL = strings.Join(url, " + ")
+
+	if op.RequestBody != nil {
+		if s := jsonSchema(op.RequestBody.Content); s != nil {
+			t, err := a.goType(s, name+"Request", article(name+" request"))
+			if err != nil {
+				return nil, err
+			}
+			o.Request = a.pointer(t)
+		}
+	}
+
+	if s := a.responseSchema(op); s != nil {
+		t, err := a.goType(s, name+"Response", article(name+" response"))
+		if err != nil {
+			return nil, err
+		}
+		o.Response = a.pointer(t)
+		o.ResponseZero = a.zeroValue(o.Response)
+	}
+
+	return o, nil
+}
+
+// responseSchema returns the schema of the first successful response of an operation with a JSON body.
+func (a *api) responseSchema(op *Operation) *Schema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if s := jsonSchema(op.Responses[code].Content); s != nil {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the schema referenced by a schema.
+func (a *api) resolve(s *Schema) *Schema {
+	for i := 0; s != nil && s.Ref != "" && i < 10; i++ {
+		s = a.doc.Components.Schemas[strings.TrimPrefix(s.Ref, refPrefix)]
+	}
+
+	return s
+}
+
+// isStruct determines whether or not a Go struct is defined for a schema.
+func (a *api) isStruct(s *Schema) bool {
+	s = a.resolve(s)
+	return s != nil && (s.Type == "object" || s.Type == "") && len(s.Properties) > 0
+}
+
+// pointer returns the pointer type for a struct type.
+func (a *api) pointer(t string) string {
+	if a.structs[t] {
+		return "*" + t
+	}
+
+	return t
+}
+
+func (a *api) define(t *typeDef) error {
+	if a.names[t.Name] {
+		return fmt.Errorf("duplicate type name %q", t.Name)
+	}
+
+	a.names[t.Name] = true
+	a.Types = append(a.Types, t)
+
+	return nil
+}
+
+func (a *api) typeDoc(name, desc string, s *Schema) []string {
+	doc := []string{fmt.Sprintf("%s is the HTTP (wire/transport protocol) model for %s.", name, desc)}
+	if s != nil && s.Description != "" {
+		doc = append(doc, strings.Split(strings.TrimSpace(s.Description), "\n")...)
+	}
+
+	return doc
+}
+
+// goType returns the Go type for a schema.
+// Go structs are defined for inline object schemas using the given name and description.
+func (a *api) goType(s *Schema, name, desc string) (string, error) {
+	if s == nil {
+		return "interface{}", nil
+	}
+
+	if s.Ref != "" {
+		if !strings.HasPrefix(s.Ref, refPrefix) {
+			return "", fmt.Errorf("unsupported reference: %s", s.Ref)
+		}
+
+		ref := strings.TrimPrefix(s.Ref, refPrefix)
+		if _, ok := a.doc.Components.Schemas[ref]; !ok {
+			return "", fmt.Errorf("schema not found: %s", s.Ref)
+		}
+
+		return exported(ref), nil
+	}
+
+	switch s.Type {
+	case "", "object":
+		if len(s.Properties) > 0 {
+			return name, a.defineStruct(s, name, desc)
+		}
+
+		if s.AdditionalProperties != nil {
+			t, err := a.goType(s.AdditionalProperties, name+"Value", "a value of "+name)
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + a.pointer(t), nil
+		}
+
+		if s.Type == "object" {
+			return "map[string]interface{}", nil
+		}
+
+		return "interface{}", nil
+
+	case "array":
+		t, err := a.goType(s.Items, name+"Item", "an item of "+name)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + a.pointer(t), nil
+
+	case "string":
+		if s.Format == "date-time" {
+			return "time.Time", nil
+		}
+		return "string", nil
+
+	case "integer":
+		if s.Format == "int32" || s.Format == "int64" {
+			return s.Format, nil
+		}
+		return "int", nil
+
+	case "number":
+		if s.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+
+	case "boolean":
+		return "bool", nil
+	}
+
+	return "", fmt.Errorf("unsupported schema type: %s", s.Type)
+}
+
+func (a *api) defineStruct(s *Schema, name, desc string) error {
+	if name == "" {
+		return fmt.Errorf("unsupported inline object")
+	}
+
+	a.structs[name] = true
+
+	t := &typeDef{
+		Name: name,
+		Doc:  a.typeDoc(name, desc, s),
+	}
+
+	if err := a.define(t); err != nil {
+		return err
+	}
+
+	fieldNames := map[string]bool{}
+	for _, prop := range s.PropertyNames() {
+		f := field{
+			Name: exported(prop),
+			Tag:  "`json:\"" + prop + "\"`",
+		}
+
+		if f.Name == "" || fieldNames[f.Name] {
+			return fmt.Errorf("invalid property name %q in %s", prop, name)
+		}
+		fieldNames[f.Name] = true
+
+		ps := s.Properties[prop]
+		ft, err := a.goType(ps, name+f.Name, "the "+prop+" property of "+name)
+		if err != nil {
+			return err
+		}
+
+		f.Type = ft
+		if !s.IsRequired(prop) {
+			f.Type = a.pointer(ft)
+			f.Tag = "`json:\"" + prop + ",omitempty\"`"
+		}
+
+		t.Fields = append(t.Fields, f)
+	}
+
+	return nil
+}
+
+// zeroValue returns the Go expression for the zero value of a type.
+// The named types defined for non-object schemas are resolved to their underlying types.
+func (a *api) zeroValue(t string) string {
+	for _, def := range a.Types {
+		if def.Name == t && def.Fields == nil && !a.structs[t] {
+			return a.zeroValue(def.Type)
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(t, "*"), strings.HasPrefix(t, "[]"), strings.HasPrefix(t, "map["), t == "interface{}":
+		return "nil"
+	case t == "string":
+		return `""`
+	case t == "bool":
+		return "false"
+	case t == "int", t == "int32", t == "int64", t == "float32", t == "float64":
+		return "0"
+	}
+
+	return t + "{}"
+}
+
+// imports returns the import paths used by the generated code.
+func (a *api) imports() []string {
+	uses := func(s string) bool {
+		for _, t := range a.Types {
+			for _, f := range t.Fields {
+				if strings.Contains(f.Type, s) {
+					return true
+				}
+			}
+			if strings.Contains(t.Type, s) {
+				return true
+			}
+		}
+
+		for _, op := range a.Operations {
+			for _, p := range append(op.Params, op.Query...) {
+				if strings.Contains(p.Type, s) || strings.Contains(p.String, s) {
+					return true
+				}
+			}
+			if strings.Contains(op.Request, s) || strings.Contains(op.Response, s) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	hasRequest, hasBody, hasParams := false, false, false
+	for _, op := range a.Operations {
+		hasRequest = hasRequest || op.Request != ""
+		hasBody = hasBody || op.Request != "" || op.Response != ""
+		hasParams = hasParams || len(op.Params) > 0 || len(op.Query) > 0
+	}
+
+	imports := []string{}
+	add := func(cond bool, path string) {
+		if cond {
+			imports = append(imports, path)
+		}
+	}
+
+	ops := len(a.Operations) > 0
+	add(hasRequest, "bytes")
+	add(ops, "context")
+	add(hasBody, "encoding/json")
+	add(uses("fmt."), "fmt")
+	add(ops, "net/http")
+	add(hasParams, "net/url")
+	add(ops, "strings")
+	add(uses("time."), "time")
+	add(ops, "")
+	add(ops, "github.com/gorilla/mux")
+	add(ops, "")
+	add(ops, a.Module+"/pkg/xhttp")
+
+	return imports
+}
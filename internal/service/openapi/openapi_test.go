@@ -0,0 +1,482 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/moorara/gelato/internal/log"
+)
+
+const templateDir = "../../../templates/go/horizontal/http-service"
+
+const usersSpec = `openapi: 3.0.3
+info:
+  title: User API
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1/
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      summary: Lists users.
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: type
+          in: query
+          required: true
+          schema:
+            type: string
+        - name: X-Request-ID
+          in: header
+          schema:
+            type: string
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/{user_id}:
+    parameters:
+      - name: user_id
+        in: path
+        required: true
+        schema:
+          type: integer
+          format: int64
+    get:
+      description: |
+        Gets a user.
+        Returns 404 if the user is not found.
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+    delete:
+      responses:
+        '204':
+          description: Deleted
+components:
+  schemas:
+    Status:
+      type: string
+    User:
+      type: object
+      description: A registered user.
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+        status:
+          $ref: '#/components/schemas/Status'
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+        manager:
+          $ref: '#/components/schemas/User'
+        scores:
+          type: object
+          additionalProperties:
+            type: number
+            format: float
+        createdAt:
+          type: string
+          format: date-time
+`
+
+func TestNewGenerator(t *testing.T) {
+	g := NewGenerator(log.None)
+
+	assert.NotNil(t, g)
+	assert.NotNil(t, g.logger)
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	greetingSpec, err := ioutil.ReadFile(filepath.Join(templateDir, "idl/greeting.yaml"))
+	assert.NoError(t, err)
+
+	greetingIDL, err := ioutil.ReadFile(filepath.Join(templateDir, "internal/idl/greeting.go"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedPaths []string
+		expectedFiles map[string]string
+		expectedError string
+	}{
+		{
+			name: "NoSpecification",
+			files: map[string]string{
+				"go.mod":              "module horizontal/http-service\n",
+				"internal/idl/doc.go": "package idl\n",
+			},
+			expectedPaths: nil,
+		},
+		{
+			name: "NoIDLPackage",
+			files: map[string]string{
+				"go.mod":            "module horizontal/http-service\n",
+				"idl/greeting.yaml": string(greetingSpec),
+			},
+			expectedPaths: nil,
+		},
+		{
+			name: "NoModule",
+			files: map[string]string{
+				"idl/greeting.yaml":   string(greetingSpec),
+				"internal/idl/doc.go": "package idl\n",
+			},
+			expectedError: "no such file or directory",
+		},
+		{
+			name: "InvalidModule",
+			files: map[string]string{
+				"go.mod":              "go 1.25\n",
+				"idl/greeting.yaml":   string(greetingSpec),
+				"internal/idl/doc.go": "package idl\n",
+			},
+			expectedError: "no module found in",
+		},
+		{
+			name: "InvalidSpecification",
+			files: map[string]string{
+				"go.mod":              "module horizontal/http-service\n",
+				"idl/greeting.yaml":   "openapi: 2.0\n",
+				"internal/idl/doc.go": "package idl\n",
+			},
+			expectedError: `unsupported OpenAPI version: "2.0"`,
+		},
+		{
+			name: "InvalidAPI",
+			files: map[string]string{
+				"go.mod":              "module horizontal/http-service\n",
+				"idl/greeting.yaml":   "openapi: 3.0.0\npaths:\n  /greet:\n    post:\n      operationId: greet\n  /greeting:\n    post:\n      operationId: greet\n",
+				"internal/idl/doc.go": "package idl\n",
+			},
+			expectedError: `idl/greeting.yaml: duplicate operation name "Greet" for POST /greeting (operationId can be used for naming operations)`,
+		},
+		{
+			name: "Success",
+			files: map[string]string{
+				"go.mod":                   "module horizontal/http-service\n",
+				"idl/greeting.yaml":        string(greetingSpec),
+				"idl/user-account.yaml":    usersSpec,
+				"internal/idl/doc.go":      "package idl\n",
+				"internal/idl/greeting.go": "package idl\n",
+			},
+			expectedPaths: []string{
+				"internal/idl/greeting.go",
+				"internal/idl/user_account.go",
+			},
+			expectedFiles: map[string]string{
+				// The greeting idl in the template is generated from the greeting specification
+				"internal/idl/greeting.go": string(greetingIDL),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(root, name)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+			}
+
+			g := &Generator{
+				logger: log.NewColorful(log.None),
+			}
+
+			paths, err := g.Generate(root)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPaths, paths)
+
+				for _, path := range paths {
+					assert.FileExists(t, filepath.Join(root, path))
+				}
+
+				for name, content := range tc.expectedFiles {
+					data, err := ioutil.ReadFile(filepath.Join(root, name))
+					assert.NoError(t, err)
+					assert.Equal(t, content, string(data))
+				}
+			} else {
+				assert.Nil(t, paths)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestNewAPI(t *testing.T) {
+	doc := new(Document)
+	assert.NoError(t, yaml.Unmarshal([]byte(usersSpec), doc))
+
+	a, err := newAPI("example.com/app", "user-account", "idl/user-account.yaml", doc)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "UserAccount", a.Name)
+	assert.Equal(t, "user account", a.Label)
+	assert.Equal(t, "User API", a.Title)
+	assert.Equal(t, []string{
+		"bytes", "context", "encoding/json", "fmt", "net/http", "net/url", "strings", "time",
+		"", "github.com/gorilla/mux",
+		"", "example.com/app/pkg/xhttp",
+	}, a.Imports)
+
+	assert.Equal(t, []*typeDef{
+		{
+			Name: "Status",
+			Doc:  []string{"Status is the HTTP (wire/transport protocol) model for a status."},
+			Type: "string",
+		},
+		{
+			Name: "User",
+			Doc: []string{
+				"User is the HTTP (wire/transport protocol) model for a user.",
+				"A registered user.",
+			},
+			Fields: []field{
+				{Name: "ID", Type: "int64", Tag: "`json:\"id\"`"},
+				{Name: "Name", Type: "string", Tag: "`json:\"name\"`"},
+				{Name: "Status", Type: "Status", Tag: "`json:\"status,omitempty\"`"},
+				{Name: "Address", Type: "*UserAddress", Tag: "`json:\"address,omitempty\"`"},
+				{Name: "Manager", Type: "*User", Tag: "`json:\"manager,omitempty\"`"},
+				{Name: "Scores", Type: "map[string]float32", Tag: "`json:\"scores,omitempty\"`"},
+				{Name: "CreatedAt", Type: "time.Time", Tag: "`json:\"createdAt,omitempty\"`"},
+			},
+		},
+		{
+			Name: "UserAddress",
+			Doc:  []string{"UserAddress is the HTTP (wire/transport protocol) model for the address property of User."},
+			Fields: []field{
+				{Name: "City", Type: "string", Tag: "`json:\"city,omitempty\"`"},
+			},
+		},
+	}, a.Types)
+
+	assert.Equal(t, []*operation{
+		{
+			Name:   "ListUsers",
+			Var:    "listUsersHandler",
+			Doc:    "lists users.",
+			Method: "GET",
+			Path:   "/v1/users",
+			URL:    `"/v1/users"`,
+			Query: []param{
+				{Name: "limit", Var: "limit", Type: "int", String: "fmt.Sprint(limit)", IsSet: "limit != 0"},
+				{Name: "type", Var: "typeParam", Type: "string", Required: true, String: "typeParam", IsSet: `typeParam != ""`},
+			},
+			Response:     "[]*User",
+			ResponseZero: "nil",
+		},
+		{
+			Name:         "CreateUser",
+			Var:          "createUserHandler",
+			Method:       "POST",
+			Path:         "/v1/users",
+			URL:          `"/v1/users"`,
+			Request:      "*User",
+			Response:     "*User",
+			ResponseZero: "nil",
+		},
+		{
+			Name:   "GetUsers",
+			Var:    "getUsersHandler",
+			Doc:    "gets a user.",
+			Method: "GET",
+			Path:   "/v1/users/{user_id}",
+			URL:    `"/v1/users/" + url.PathEscape(fmt.Sprint(userID))`,
+			Params: []param{
+				{Name: "user_id", Var: "userID", Type: "int64", Required: true, String: "fmt.Sprint(userID)", IsSet: "userID != 0"},
+			},
+			Response:     "*User",
+			ResponseZero: "nil",
+		},
+		{
+			Name:   "DeleteUsers",
+			Var:    "deleteUsersHandler",
+			Method: "DELETE",
+			Path:   "/v1/users/{user_id}",
+			URL:    `"/v1/users/" + url.PathEscape(fmt.Sprint(userID))`,
+			Params: []param{
+				{Name: "user_id", Var: "userID", Type: "int64", Required: true, String: "fmt.Sprint(userID)", IsSet: "userID != 0"},
+			},
+		},
+	}, a.Operations)
+}
+
+func TestNewAPI_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expectedError string
+	}{
+		{
+			name:          "UnsupportedReference",
+			spec:          "paths:\n  /greet:\n    get:\n      responses:\n        '200':\n          content:\n            application/json:\n              schema:\n                $ref: 'other.yaml#/Greeting'\n",
+			expectedError: "unsupported reference: other.yaml#/Greeting",
+		},
+		{
+			name:          "SchemaNotFound",
+			spec:          "paths:\n  /greet:\n    get:\n      responses:\n        '200':\n          content:\n            application/json:\n              schema:\n                $ref: '#/components/schemas/Greeting'\n",
+			expectedError: "schema not found: #/components/schemas/Greeting",
+		},
+		{
+			name:          "UnsupportedSchemaType",
+			spec:          "components:\n  schemas:\n    Greeting:\n      type: file\n",
+			expectedError: "unsupported schema type: file",
+		},
+		{
+			name:          "DuplicateTypeName",
+			spec:          "components:\n  schemas:\n    GreetRequest:\n      type: string\npaths:\n  /greet:\n    post:\n      requestBody:\n        content:\n          application/json:\n            schema:\n              properties:\n                name:\n                  type: string\n",
+			expectedError: `duplicate type name "GreetRequest"`,
+		},
+		{
+			name:          "DuplicateOperationName",
+			spec:          "paths:\n  /greet:\n    get:\n      operationId: greet\n    post:\n      operationId: greet\n",
+			expectedError: `duplicate operation name "Greet" for POST /greet (operationId can be used for naming operations)`,
+		},
+		{
+			name:          "UnsupportedParameterType",
+			spec:          "paths:\n  /greet:\n    get:\n      parameters:\n        - name: names\n          in: query\n          schema:\n            type: array\n            items:\n              type: string\n",
+			expectedError: `unsupported type for parameter "names": []string`,
+		},
+		{
+			name:          "InvalidParameterName",
+			spec:          "paths:\n  /greet:\n    get:\n      parameters:\n        - name: '-'\n          in: query\n          schema:\n            type: string\n",
+			expectedError: `invalid parameter name "-" for GET /greet`,
+		},
+		{
+			name:          "PathParameterNotFound",
+			spec:          "paths:\n  /greet:\n    get:\n      parameters:\n        - name: id\n          in: path\n          schema:\n            type: string\n",
+			expectedError: `path parameter "id" not found in /greet`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := new(Document)
+			assert.NoError(t, yaml.Unmarshal([]byte(tc.spec), doc))
+
+			a, err := newAPI("example.com/app", "greeting", "idl/greeting.yaml", doc)
+
+			assert.Nil(t, a)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestServerPath(t *testing.T) {
+	tests := []struct {
+		url          string
+		expectedPath string
+	}{
+		{"", ""},
+		{"api.example.com", ""},
+		{"api.example.com/v1", "/v1"},
+		{"https://api.example.com/v1/", "/v1"},
+		{"/v1", "/v1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPath, serverPath(tc.url))
+		})
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		method       string
+		op           *Operation
+		expectedName string
+	}{
+		{"OperationID", "/users", "GET", &Operation{OperationID: "list_users"}, "ListUsers"},
+		{"Path", "/greet", "", &Operation{}, "Greet"},
+		{"PathWithParameters", "/users/{id}/labels", "", &Operation{}, "UsersLabels"},
+		{"Method", "/users/{id}", "GET", &Operation{}, "GetUsers"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedName, operationName(tc.path, tc.method, tc.op))
+		})
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	tests := []struct {
+		typ          string
+		expectedZero string
+	}{
+		{"*User", "nil"},
+		{"[]*User", "nil"},
+		{"map[string]string", "nil"},
+		{"interface{}", "nil"},
+		{"string", `""`},
+		{"bool", "false"},
+		{"int64", "0"},
+		{"float64", "0"},
+		{"time.Time", "time.Time{}"},
+		{"User", "User{}"},
+		{"Status", `""`},
+		{"Users", "nil"},
+	}
+
+	a := &api{
+		Types: []*typeDef{
+			{Name: "Status", Type: "string"},
+			{Name: "User", Fields: []field{{Name: "Name", Type: "string"}}},
+			{Name: "Users", Type: "[]*User"},
+		},
+		structs: map[string]bool{"User": true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.typ, func(t *testing.T) {
+			assert.Equal(t, tc.expectedZero, a.zeroValue(tc.typ))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	"isPointer": func(t string) bool {
+		return strings.HasPrefix(t, "*")
+	},
+	"elem": func(t string) string {
+		return strings.TrimPrefix(t, "*")
+	},
+}
+
+// render renders and formats the Go source code file for an API.
+func render(name string, a *api) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Parse(apiTmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, a); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// The template for the Go code generated for an OpenAPI specification.
+// It follows the same conventions as the greeting idl in the horizontal http-service template.
+const apiTmpl = `// DO NOT EDIT
+
+// Code generated by Gelato from {{.Source}}
+
+package idl
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	{{if .}}"{{.}}"{{end}}
+{{- end}}
+)
+{{end}}
+{{- range .Types}}
+{{range .Doc}}
+// {{.}}
+{{- end}}
+{{- if .Fields}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+{{- else}}
+type {{.Name}} {{.Type}}
+{{- end}}
+{{end}}
+{{- if .Operations}}
+// {{.Name}}Handler is the interface for {{.Label}} handler functions.
+type {{.Name}}Handler interface {
+{{- range .Operations}}
+	{{- if .Doc}}
+	// {{.Name}} {{.Doc}}
+	{{- end}}
+	{{.Name}}(http.ResponseWriter, *http.Request)
+{{- end}}
+}
+
+// Register{{.Name}}Handler registers the HTTP routes for {{.Label}} handler.
+// Middleware are applied from left to right (the first middleware is the most inner and the last middleware is the most outter).
+func Register{{.Name}}Handler(router *mux.Router, handler {{.Name}}Handler, middleware ...xhttp.Middleware) {
+{{- range .Operations}}
+	{{.Var}} := handler.{{.Name}}
+{{- end}}
+
+	for _, mid := range middleware {
+{{- range .Operations}}
+		{{.Var}} = mid.Wrap({{.Var}})
+{{- end}}
+	}
+{{range .Operations}}
+	router.Name("{{.Name}}").Methods("{{.Method}}").Path("{{.Path}}").HandlerFunc({{.Var}})
+{{- end}}
+}
+
+// {{.Name}}Client is a typed HTTP client for {{if .Title}}{{.Title}}{{else}}{{.Label}} API{{end}}.
+type {{.Name}}Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+// New{{.Name}}Client creates a new {{.Label}} client.
+// The base URL is the address of the server (e.g. http://localhost:4000).
+// If the HTTP client is nil, the default HTTP client will be used.
+func New{{.Name}}Client(baseURL string, client *http.Client) *{{.Name}}Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &{{.Name}}Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+}
+{{$name := .Name}}
+{{- range .Operations}}
+{{if .Doc}}
+// {{.Name}} {{.Doc}}
+{{- else}}
+// {{.Name}} calls the {{.Name}} endpoint.
+{{- end}}
+func (c *{{$name}}Client) {{.Name}}(ctx context.Context{{range .Params}}, {{.Var}} {{.Type}}{{end}}{{range .Query}}, {{.Var}} {{.Type}}{{end}}{{if .Request}}, req {{.Request}}{{end}}) {{if .Response}}({{.Response}}, error){{else}}error{{end}} {
+	{{- $zero := ""}}{{if .Response}}{{$zero = print .ResponseZero ", "}}{{end}}
+	u := c.baseURL + {{.URL}}
+	{{- if .Query}}
+
+	q := url.Values{}
+	{{- range .Query}}
+	{{- if .Required}}
+	q.Set("{{.Name}}", {{.String}})
+	{{- else}}
+	if {{.IsSet}} {
+		q.Set("{{.Name}}", {{.String}})
+	}
+	{{- end}}
+	{{- end}}
+
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	{{- end}}
+	{{- if .Request}}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return {{$zero}}err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, "{{.Method}}", u, bytes.NewReader(body))
+	if err != nil {
+		return {{$zero}}err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	{{- else}}
+
+	r, err := http.NewRequestWithContext(ctx, "{{.Method}}", u, nil)
+	if err != nil {
+		return {{$zero}}err
+	}
+	{{- end}}
+
+	resp, err := c.client.Do(r)
+	if err != nil {
+		return {{$zero}}err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return {{$zero}}xhttp.NewClientError(resp)
+	}
+	{{- if .Response}}
+	{{- if isPointer .Response}}
+
+	out := new({{elem .Response}})
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, err
+	}
+	{{- else}}
+
+	var out {{.Response}}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return {{$zero}}err
+	}
+	{{- end}}
+
+	return out, nil
+	{{- else}}
+
+	return nil
+	{{- end}}
+}
+{{- end}}
+{{- end}}
+`
@@ -0,0 +1,365 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+
+	"github.com/moorara/gelato/internal/log"
+)
+
+const (
+	// ManifestFile is the name of the file describing a template.
+	// The manifest file is not included in the rendered directory.
+	ManifestFile = "template.yaml"
+
+	// Ext is the extension of the files rendered as templates.
+	// A rendered file replaces a plain file with the same name, so a template can still be built and tested as is.
+	Ext = ".tmpl"
+)
+
+var varNameRE = regexp.MustCompile(`^[A-Za-z_][0-9A-Za-z_]*$`)
+
+// Manifest describes a template directory.
+type Manifest struct {
+	// Module is the Go module path used by the template itself.
+	Module string `yaml:"module"`
+	// Variables are the custom variables for rendering the template.
+	Variables []Variable `yaml:"variables"`
+	// Files are the conditional files and directories in the template.
+	Files []File `yaml:"files"`
+}
+
+// Variable is a custom variable for rendering a template.
+type Variable struct {
+	Name     string `yaml:"name"`
+	Prompt   string `yaml:"prompt"`
+	Default  string `yaml:"default"`
+	Pattern  string `yaml:"pattern"`
+	Required bool   `yaml:"required"`
+}
+
+// Validate checks whether or not a value is valid for the variable.
+func (v Variable) Validate(value string) error {
+	if v.Required && value == "" {
+		return fmt.Errorf("%s is required", v.Name)
+	}
+
+	if v.Pattern != "" && value != "" {
+		re, err := regexp.Compile(v.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern for %s: %s", v.Name, err)
+		}
+
+		if !re.MatchString(value) {
+			return fmt.Errorf("invalid value for %s: %q does not match %s", v.Name, value, v.Pattern)
+		}
+	}
+
+	return nil
+}
+
+// File is a conditional file or directory in a template.
+type File struct {
+	// Path is a glob pattern relative to the template directory.
+	Path string `yaml:"path"`
+	// If is a template pipeline such as .Monorepo or (not .Monorepo).
+	// The matching files and directories are removed if it evaluates to an empty value.
+	If string `yaml:"if"`
+}
+
+// Renderer is used for rendering template directories.
+type Renderer struct {
+	logger *log.ColorfulLogger
+}
+
+// NewRenderer creates a new renderer.
+func NewRenderer(level log.Level) *Renderer {
+	logger := log.NewColorful(level)
+
+	return &Renderer{
+		logger: logger,
+	}
+}
+
+// ReadManifest reads the manifest file of a template directory.
+// If the template does not have a manifest file, an empty manifest will be returned.
+func (r *Renderer) ReadManifest(root string) (Manifest, error) {
+	var m Manifest
+
+	data, err := ioutil.ReadFile(filepath.Join(root, ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Manifest{}, nil
+		}
+		return Manifest{}, err
+	}
+
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("invalid template manifest: %s", err)
+	}
+
+	for _, v := range m.Variables {
+		if !varNameRE.MatchString(v.Name) {
+			return Manifest{}, fmt.Errorf("invalid template variable name: %q", v.Name)
+		}
+
+		if _, err := regexp.Compile(v.Pattern); err != nil {
+			return Manifest{}, fmt.Errorf("invalid pattern for %s: %s", v.Name, err)
+		}
+	}
+
+	for _, f := range m.Files {
+		if f.Path == "" || f.If == "" {
+			return Manifest{}, errors.New("invalid template file: path and if are required")
+		}
+	}
+
+	return m, nil
+}
+
+// Render renders a template directory in place.
+// The conditional files and directories are removed first and then all template files are rendered using the given data.
+func (r *Renderer) Render(root string, m Manifest, data interface{}) error {
+	if err := os.RemoveAll(filepath.Join(root, ManifestFile)); err != nil {
+		return err
+	}
+
+	for _, f := range m.Files {
+		ok, err := evalCondition(f.If, data)
+		if err != nil {
+			return fmt.Errorf("invalid condition for %s: %s", f.Path, err)
+		}
+
+		if ok {
+			continue
+		}
+
+		for _, glob := range []string{f.Path, f.Path + Ext} {
+			matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(glob)))
+			if err != nil {
+				return err
+			}
+
+			for _, match := range matches {
+				r.logger.Magenta.Debugf("Removing %s", match)
+				if err := os.RemoveAll(match); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var paths []string
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() && strings.HasSuffix(path, Ext) {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := r.renderFile(root, path, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Renderer) renderFile(root, path string, data interface{}) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	name, err := filepath.Rel(root, path)
+	if err != nil {
+		return err
+	}
+
+	t, err := template.New(filepath.ToSlash(name)).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	dest := strings.TrimSuffix(path, Ext)
+	r.logger.Green.Debugf("Rendering %s", dest)
+
+	if err := ioutil.WriteFile(dest, buf.Bytes(), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	// WriteFile does not change the mode of an existing file
+	if err := os.Chmod(dest, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// evalCondition evaluates a template pipeline using the given data.
+func evalCondition(cond string, data interface{}) (bool, error) {
+	t, err := template.New("if").Option("missingkey=error").Parse("{{if " + cond + "}}true{{end}}")
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return false, err
+	}
+
+	return buf.String() == "true", nil
+}
+
+// RenameModule replaces a Go module path with a new one in a directory.
+// The module path is replaced in the go.mod file, the import paths of Go files, and the go_package options of Protocol Buffers files.
+func (r *Renderer) RenameModule(root, oldPath, newPath string) error {
+	if oldPath == "" || oldPath == newPath {
+		return nil
+	}
+
+	protoRE := regexp.MustCompile(`(option\s+go_package\s*=\s*")` + regexp.QuoteMeta(oldPath) + `((/[^"]*)?")`)
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if name := info.Name(); path != root && (name == "vendor" || name == ".git") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var rename func([]byte) ([]byte, error)
+
+		switch {
+		case info.Name() == "go.mod":
+			rename = func(data []byte) ([]byte, error) {
+				return renameInModFile(path, data, oldPath, newPath)
+			}
+		case strings.HasSuffix(path, ".go"):
+			rename = func(data []byte) ([]byte, error) {
+				return renameInGoFile(path, data, oldPath, newPath)
+			}
+		case strings.HasSuffix(path, ".proto"):
+			rename = func(data []byte) ([]byte, error) {
+				return protoRE.ReplaceAll(data, []byte("${1}"+newPath+"${2}")), nil
+			}
+		default:
+			return nil
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		newData, err := rename(data)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(data, newData) {
+			return nil
+		}
+
+		r.logger.Green.Debugf("Renaming module in %s", path)
+
+		return ioutil.WriteFile(path, newData, info.Mode().Perm())
+	})
+}
+
+func renameInModFile(path string, data []byte, oldPath, newPath string) ([]byte, error) {
+	f, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Module == nil || f.Module.Mod.Path != oldPath {
+		return data, nil
+	}
+
+	if err := f.AddModuleStmt(newPath); err != nil {
+		return nil, err
+	}
+
+	return f.Format()
+}
+
+func renameInGoFile(path string, data []byte, oldPath, newPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, data, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+
+	var edits []edit
+	for _, imp := range f.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if importPath == oldPath || strings.HasPrefix(importPath, oldPath+"/") {
+			edits = append(edits, edit{
+				start: fset.Position(imp.Path.Pos()).Offset,
+				end:   fset.Position(imp.Path.End()).Offset,
+				text:  strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath)),
+			})
+		}
+	}
+
+	// Apply the edits from the end, so the offsets remain valid
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start > edits[j].start
+	})
+
+	out := append([]byte{}, data...)
+	for _, e := range edits {
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+	}
+
+	return out, nil
+}
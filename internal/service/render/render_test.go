@@ -0,0 +1,435 @@
+package render
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/log"
+)
+
+func newTestRenderer() *Renderer {
+	logger := log.New(log.None)
+	return &Renderer{
+		logger: &log.ColorfulLogger{
+			Red:     logger,
+			Green:   logger,
+			Yellow:  logger,
+			Blue:    logger,
+			Magenta: logger,
+			Cyan:    logger,
+			White:   logger,
+		},
+	}
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	tests := []struct {
+		name  string
+		level log.Level
+	}{
+		{
+			name:  "OK",
+			level: log.None,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			renderer := NewRenderer(tc.level)
+
+			assert.NotNil(t, renderer)
+		})
+	}
+}
+
+func TestVariable_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		variable      Variable
+		value         string
+		expectedError string
+	}{
+		{
+			name:          "Required",
+			variable:      Variable{Name: "team", Required: true},
+			value:         "",
+			expectedError: "team is required",
+		},
+		{
+			name:          "InvalidPattern",
+			variable:      Variable{Name: "port", Pattern: "[0-9"},
+			value:         "8080",
+			expectedError: "invalid pattern for port: error parsing regexp: missing closing ]: `[0-9`",
+		},
+		{
+			name:          "NoMatch",
+			variable:      Variable{Name: "port", Pattern: "^[0-9]+$"},
+			value:         "http",
+			expectedError: `invalid value for port: "http" does not match ^[0-9]+$`,
+		},
+		{
+			name:     "Optional",
+			variable: Variable{Name: "port", Pattern: "^[0-9]+$"},
+			value:    "",
+		},
+		{
+			name:     "Valid",
+			variable: Variable{Name: "port", Pattern: "^[0-9]+$", Required: true},
+			value:    "8080",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.variable.Validate(tc.value)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRenderer_ReadManifest(t *testing.T) {
+	tests := []struct {
+		name             string
+		manifest         string
+		expectedManifest Manifest
+		expectedError    string
+	}{
+		{
+			name:             "NoManifest",
+			expectedManifest: Manifest{},
+		},
+		{
+			name:          "InvalidYAML",
+			manifest:      "module: [",
+			expectedError: "invalid template manifest: yaml: line 1: did not find expected node content",
+		},
+		{
+			name: "InvalidVariableName",
+			manifest: `variables:
+  - name: team-name
+`,
+			expectedError: `invalid template variable name: "team-name"`,
+		},
+		{
+			name: "InvalidVariablePattern",
+			manifest: `variables:
+  - name: port
+    pattern: "[0-9"
+`,
+			expectedError: "invalid pattern for port: error parsing regexp: missing closing ]: `[0-9`",
+		},
+		{
+			name: "InvalidFile",
+			manifest: `files:
+  - path: .gitmodules
+`,
+			expectedError: "invalid template file: path and if are required",
+		},
+		{
+			name: "Success",
+			manifest: `module: vertical/http-service
+variables:
+  - name: team
+    prompt: Team name
+    default: platform
+    pattern: ^[a-z]+$
+    required: true
+files:
+  - path: .gitmodules
+    if: not .Monorepo
+`,
+			expectedManifest: Manifest{
+				Module: "vertical/http-service",
+				Variables: []Variable{
+					{Name: "team", Prompt: "Team name", Default: "platform", Pattern: "^[a-z]+$", Required: true},
+				},
+				Files: []File{
+					{Path: ".gitmodules", If: "not .Monorepo"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := ioutil.TempDir("", "gelato-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(root)
+
+			if tc.manifest != "" {
+				writeFiles(t, root, map[string]string{ManifestFile: tc.manifest})
+			}
+
+			m, err := newTestRenderer().ReadManifest(root)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedManifest, m)
+			}
+		})
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	type data struct {
+		Name     string
+		Monorepo bool
+		Vars     map[string]string
+	}
+
+	tests := []struct {
+		name          string
+		files         map[string]string
+		manifest      Manifest
+		data          data
+		expectedFiles map[string]string
+		missingFiles  []string
+		expectedError string
+	}{
+		{
+			name: "InvalidCondition",
+			manifest: Manifest{
+				Files: []File{
+					{Path: ".gitmodules", If: "not .Foo"},
+				},
+			},
+			expectedError: `invalid condition for .gitmodules: template: if:1:9: executing "if" at <.Foo>: can't evaluate field Foo in type render.data`,
+		},
+		{
+			name: "InvalidTemplate",
+			files: map[string]string{
+				"README.md.tmpl": "# {{.Name}",
+			},
+			expectedError: `template: README.md.tmpl:1: bad character U+007D '}'`,
+		},
+		{
+			name: "MissingKey",
+			files: map[string]string{
+				"README.md.tmpl": "# {{.Vars.team}}",
+			},
+			data: data{
+				Vars: map[string]string{},
+			},
+			expectedError: `template: README.md.tmpl:1:9: executing "README.md.tmpl" at <.Vars.team>: map has no entry for key "team"`,
+		},
+		{
+			name: "Microrepo",
+			files: map[string]string{
+				ManifestFile:                         "",
+				".gitmodules":                        "[submodule \"make\"]",
+				".github/workflows/monorepo.yml":     "name: {{.Name}}",
+				".github/workflows/push.yml":         "name: Main",
+				"Makefile":                           "name := service",
+				"Makefile.tmpl":                      "name := {{.Name}}",
+				"README.md.tmpl":                     "# {{.Name}}\n\nOwned by {{.Vars.team}}.\n",
+				"internal/handler/handler.go":        "package handler",
+				"docs/{{.Name}}.md":                  "Not a template",
+				".github/ISSUE_TEMPLATE/bug.md.tmpl": "about: Report a bug for {{.Name}}",
+			},
+			manifest: Manifest{
+				Files: []File{
+					{Path: ".gitmodules", If: "not .Monorepo"},
+					{Path: ".github/workflows/monorepo.yml", If: ".Monorepo"},
+				},
+			},
+			data: data{
+				Name:     "my-service",
+				Monorepo: false,
+				Vars:     map[string]string{"team": "platform"},
+			},
+			expectedFiles: map[string]string{
+				".gitmodules":                   "[submodule \"make\"]",
+				".github/workflows/push.yml":    "name: Main",
+				"Makefile":                      "name := my-service",
+				"README.md":                     "# my-service\n\nOwned by platform.\n",
+				"internal/handler/handler.go":   "package handler",
+				"docs/{{.Name}}.md":             "Not a template",
+				".github/ISSUE_TEMPLATE/bug.md": "about: Report a bug for my-service",
+			},
+			missingFiles: []string{
+				ManifestFile,
+				".github/workflows/monorepo.yml",
+				"Makefile.tmpl",
+				"README.md.tmpl",
+			},
+		},
+		{
+			name: "Monorepo",
+			files: map[string]string{
+				".gitmodules":                         "[submodule \"make\"]",
+				".github/workflows/monorepo.yml.tmpl": "name: {{.Name}}",
+				".github/workflows/push.yml":          "name: Main",
+			},
+			manifest: Manifest{
+				Files: []File{
+					{Path: ".gitmodules", If: "not .Monorepo"},
+					{Path: ".github/workflows/monorepo.yml", If: ".Monorepo"},
+					{Path: ".github/workflows/push.yml", If: "not .Monorepo"},
+				},
+			},
+			data: data{
+				Name:     "my-service",
+				Monorepo: true,
+			},
+			expectedFiles: map[string]string{
+				".github/workflows/monorepo.yml": "name: my-service",
+			},
+			missingFiles: []string{
+				".gitmodules",
+				".github/workflows/push.yml",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := ioutil.TempDir("", "gelato-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(root)
+
+			writeFiles(t, root, tc.files)
+
+			err = newTestRenderer().Render(root, tc.manifest, tc.data)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+
+				for name, content := range tc.expectedFiles {
+					data, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+					assert.NoError(t, err)
+					assert.Equal(t, content, string(data))
+				}
+
+				for _, name := range tc.missingFiles {
+					assert.NoFileExists(t, filepath.Join(root, filepath.FromSlash(name)))
+				}
+			}
+		})
+	}
+}
+
+func TestRenderer_RenameModule(t *testing.T) {
+	files := map[string]string{
+		"go.mod": "module vertical/http-service\n\ngo 1.15\n\nrequire github.com/gorilla/mux v1.8.0\n",
+		"main.go": `package main
+
+import (
+	"github.com/gorilla/mux"
+
+	"vertical/http-service-client"
+	"vertical/http-service/internal/server"
+	ver "vertical/http-service/version"
+)
+
+// vertical/http-service is not an import
+func main() {}
+`,
+		"idl/greeting.proto":     "syntax = \"proto3\";\noption go_package = \"vertical/http-service/internal/idl/greetingpb\";\n",
+		"vendor/example/main.go": "package example\n\nimport \"vertical/http-service/version\"\n",
+	}
+
+	tests := []struct {
+		name          string
+		files         map[string]string
+		oldPath       string
+		newPath       string
+		expectedFiles map[string]string
+		expectedError string
+	}{
+		{
+			name:          "NoModule",
+			files:         files,
+			oldPath:       "",
+			newPath:       "github.com/octocat/service",
+			expectedFiles: files,
+		},
+		{
+			name: "InvalidGoMod",
+			files: map[string]string{
+				"go.mod": "module",
+			},
+			oldPath:       "vertical/http-service",
+			newPath:       "github.com/octocat/service",
+			expectedError: "go.mod:1: usage: module module/path",
+		},
+		{
+			name: "InvalidGoFile",
+			files: map[string]string{
+				"main.go": "package",
+			},
+			oldPath:       "vertical/http-service",
+			newPath:       "github.com/octocat/service",
+			expectedError: "main.go:1:8: expected 'IDENT', found 'EOF'",
+		},
+		{
+			name:    "Success",
+			files:   files,
+			oldPath: "vertical/http-service",
+			newPath: "github.com/octocat/service",
+			expectedFiles: map[string]string{
+				"go.mod": "module github.com/octocat/service\n\ngo 1.15\n\nrequire github.com/gorilla/mux v1.8.0\n",
+				"main.go": `package main
+
+import (
+	"github.com/gorilla/mux"
+
+	"vertical/http-service-client"
+	"github.com/octocat/service/internal/server"
+	ver "github.com/octocat/service/version"
+)
+
+// vertical/http-service is not an import
+func main() {}
+`,
+				"idl/greeting.proto":     "syntax = \"proto3\";\noption go_package = \"github.com/octocat/service/internal/idl/greetingpb\";\n",
+				"vendor/example/main.go": "package example\n\nimport \"vertical/http-service/version\"\n",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root, err := ioutil.TempDir("", "gelato-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(root)
+
+			writeFiles(t, root, tc.files)
+
+			err = newTestRenderer().RenameModule(root, tc.oldPath, tc.newPath)
+
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+
+				for name, content := range tc.expectedFiles {
+					data, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+					assert.NoError(t, err)
+					assert.Equal(t, content, string(data))
+				}
+			}
+		})
+	}
+}
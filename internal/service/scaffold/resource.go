@@ -0,0 +1,123 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var wordRE = regexp.MustCompile(`^[a-z][0-9a-z]*$`)
+
+// reservedNames are the names that conflict with the packages and identifiers used by the generated code.
+var reservedNames = map[string]bool{
+	"context": true, "controller": true, "ctx": true, "entity": true, "err": true, "errors": true,
+	"fmt": true, "gateway": true, "graceful": true, "handler": true, "health": true, "http": true,
+	"id": true, "idl": true, "in": true, "mapper": true, "mux": true, "repository": true,
+	"server": true, "sync": true, "xhttp": true,
+}
+
+// Resource is a resource generated across the layers of an application with horizontal layout.
+type Resource struct {
+	// Module is the Go module path of the application.
+	Module string
+	// Name is the exported Go name of the resource (e.g. PurchaseOrder).
+	Name string
+	// Var is the unexported Go name of the resource (e.g. purchaseOrder).
+	Var string
+	// Label is the resource name in kebab case (e.g. purchase-order).
+	Label string
+	// Singular is the resource name in plain text (e.g. purchase order).
+	Singular string
+	// Plural is the plural resource name in plain text (e.g. purchase orders).
+	Plural string
+	// Path is the plural resource name for HTTP paths (e.g. purchase-orders).
+	Path string
+	// File is the base name of the generated files (e.g. purchase_order).
+	File string
+	// Gateway determines whether or not a gateway is generated for the resource.
+	Gateway bool
+}
+
+// NewResource creates a new resource for an application.
+// The name can be in camel case (purchaseOrder or PurchaseOrder), kebab case (purchase-order), or snake case (purchase_order).
+func NewResource(module, name string) (Resource, error) {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return Resource{}, fmt.Errorf("invalid resource name: %q", name)
+	}
+
+	for _, w := range words {
+		if !wordRE.MatchString(w) {
+			return Resource{}, fmt.Errorf("invalid resource name: %q", name)
+		}
+	}
+
+	var exported string
+	for _, w := range words {
+		exported += strings.ToUpper(w[:1]) + w[1:]
+	}
+
+	unexported := words[0] + exported[len(words[0]):]
+	if token.IsKeyword(unexported) || reservedNames[unexported] {
+		return Resource{}, fmt.Errorf("reserved resource name: %q", name)
+	}
+
+	plural := append([]string{}, words...)
+	plural[len(plural)-1] = pluralize(plural[len(plural)-1])
+
+	return Resource{
+		Module:   module,
+		Name:     exported,
+		Var:      unexported,
+		Label:    strings.Join(words, "-"),
+		Singular: strings.Join(words, " "),
+		Plural:   strings.Join(plural, " "),
+		Path:     strings.Join(plural, "-"),
+		File:     strings.Join(words, "_"),
+	}, nil
+}
+
+// splitWords splits a name into lower-case words.
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == '_' || unicode.IsSpace(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+
+	flush()
+
+	return words
+}
+
+// pluralize returns the plural form of an English noun using the regular rules.
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsAny(word[len(word)-2:len(word)-1], "aeiou"):
+		return word[:len(word)-1] + "ies"
+	default:
+		return word + "s"
+	}
+}
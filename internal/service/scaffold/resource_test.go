@@ -0,0 +1,136 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResource(t *testing.T) {
+	tests := []struct {
+		name             string
+		module           string
+		resName          string
+		expectedError    string
+		expectedResource Resource
+	}{
+		{
+			name:          "Empty",
+			module:        "example.com/app",
+			resName:       "",
+			expectedError: `invalid resource name: ""`,
+		},
+		{
+			name:          "Invalid",
+			module:        "example.com/app",
+			resName:       "1st-order",
+			expectedError: `invalid resource name: "1st-order"`,
+		},
+		{
+			name:          "Keyword",
+			module:        "example.com/app",
+			resName:       "type",
+			expectedError: `reserved resource name: "type"`,
+		},
+		{
+			name:          "Reserved",
+			module:        "example.com/app",
+			resName:       "Entity",
+			expectedError: `reserved resource name: "Entity"`,
+		},
+		{
+			name:    "Simple",
+			module:  "example.com/app",
+			resName: "order",
+			expectedResource: Resource{
+				Module:   "example.com/app",
+				Name:     "Order",
+				Var:      "order",
+				Label:    "order",
+				Singular: "order",
+				Plural:   "orders",
+				Path:     "orders",
+				File:     "order",
+			},
+		},
+		{
+			name:    "CamelCase",
+			module:  "example.com/app",
+			resName: "PurchaseOrder",
+			expectedResource: Resource{
+				Module:   "example.com/app",
+				Name:     "PurchaseOrder",
+				Var:      "purchaseOrder",
+				Label:    "purchase-order",
+				Singular: "purchase order",
+				Plural:   "purchase orders",
+				Path:     "purchase-orders",
+				File:     "purchase_order",
+			},
+		},
+		{
+			name:    "KebabCase",
+			module:  "example.com/app",
+			resName: "product-category",
+			expectedResource: Resource{
+				Module:   "example.com/app",
+				Name:     "ProductCategory",
+				Var:      "productCategory",
+				Label:    "product-category",
+				Singular: "product category",
+				Plural:   "product categories",
+				Path:     "product-categories",
+				File:     "product_category",
+			},
+		},
+		{
+			name:    "SnakeCase",
+			module:  "example.com/app",
+			resName: "tax_box",
+			expectedResource: Resource{
+				Module:   "example.com/app",
+				Name:     "TaxBox",
+				Var:      "taxBox",
+				Label:    "tax-box",
+				Singular: "tax box",
+				Plural:   "tax boxes",
+				Path:     "tax-boxes",
+				File:     "tax_box",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := NewResource(tc.module, tc.resName)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResource, res)
+			}
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"order", "orders"},
+		{"key", "keys"},
+		{"category", "categories"},
+		{"address", "addresses"},
+		{"box", "boxes"},
+		{"match", "matches"},
+		{"wish", "wishes"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.word, func(t *testing.T) {
+			assert.Equal(t, tc.expected, pluralize(tc.word))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/moorara/gelato/internal/log"
+	"github.com/moorara/gelato/internal/service/compiler"
+)
+
+const (
+	mainFile       = "main.go"
+	serverFile     = "internal/server/http.go"
+	serverTestFile = "internal/server/http_test.go"
+)
+
+// RequiredLayers are the layers an application with horizontal layout should have for adding a resource.
+var RequiredLayers = []string{"controller", "entity", "handler", "idl", "mapper", "repository", "server"}
+
+var funcs = template.FuncMap{
+	"tag": func(name string) string {
+		return "`json:\"" + name + "\"`"
+	},
+	"tick": func() string {
+		return "`"
+	},
+}
+
+// Layers returns the layers (packages in the internal directory) of an application with horizontal layout.
+func Layers(root string) map[string]bool {
+	layers := map[string]bool{}
+	for _, layer := range append(RequiredLayers, "gateway") {
+		if info, err := os.Stat(filepath.Join(root, "internal", layer)); err == nil && info.IsDir() {
+			layers[layer] = true
+		}
+	}
+
+	return layers
+}
+
+// genFile is a Go source code file generated for a resource.
+type genFile struct {
+	path string
+	tmpl string
+}
+
+// Scaffolder is used for adding resources to applications with horizontal layout.
+type Scaffolder struct {
+	logger *log.ColorfulLogger
+}
+
+// NewScaffolder creates a new scaffolder.
+func NewScaffolder(level log.Level) *Scaffolder {
+	logger := log.NewColorful(level)
+
+	return &Scaffolder{
+		logger: logger,
+	}
+}
+
+func (s *Scaffolder) files(res Resource) []genFile {
+	path := func(layer, suffix string) string {
+		return filepath.Join("internal", layer, res.File+suffix)
+	}
+
+	files := []genFile{
+		{path("entity", ".go"), entityTmpl},
+		{path("entity", "_test.go"), entityTestTmpl},
+		{path("idl", ".go"), idlTmpl},
+		{path("mapper", ".go"), mapperTmpl},
+		{path("mapper", "_test.go"), mapperTestTmpl},
+		{path("repository", ".go"), repositoryTmpl},
+		{path("repository", "_test.go"), repositoryTestTmpl},
+	}
+
+	if res.Gateway {
+		files = append(files,
+			genFile{path("gateway", ".go"), gatewayTmpl},
+			genFile{path("gateway", "_test.go"), gatewayTestTmpl},
+		)
+	}
+
+	return append(files,
+		genFile{path("controller", ".go"), controllerTmpl},
+		genFile{path("controller", "_test.go"), controllerTestTmpl},
+		genFile{path("controller", "_mock_test.go"), controllerMockTmpl},
+		genFile{path("handler", ".go"), handlerTmpl},
+		genFile{path("handler", "_test.go"), handlerTestTmpl},
+		genFile{path("handler", "_mock_test.go"), handlerMockTmpl},
+		genFile{path("server", "_mock_test.go"), serverMockTmpl},
+	)
+}
+
+// Add generates a resource across the layers of an application and wires it into the main function and the HTTP server.
+// The existing files are never overwritten and the edited files are restored if adding the resource fails at any point.
+// It returns the paths of the generated files relative to the application root.
+func (s *Scaffolder) Add(root string, res Resource) (paths []string, err error) {
+	files := s.files(res)
+
+	// Generate all files in memory first, so nothing is written if any of them fails
+	contents := make([][]byte, len(files))
+	for i, f := range files {
+		if _, err := os.Stat(filepath.Join(root, f.path)); err == nil {
+			return nil, fmt.Errorf("file already exists: %s", f.path)
+		}
+
+		if contents[i], err = render(f.path, f.tmpl, res); err != nil {
+			return nil, err
+		}
+	}
+
+	edits := []struct {
+		path   string
+		locate compiler.LocateFunc
+	}{
+		{mainFile, wireMain(res)},
+		{serverFile, wireServer(res)},
+		{serverTestFile, wireServerTest(res)},
+	}
+
+	backups := map[string][]byte{}
+	for _, e := range edits {
+		data, err := ioutil.ReadFile(filepath.Join(root, e.path))
+		if err != nil {
+			// The server tests are optional
+			if os.IsNotExist(err) && e.path == serverTestFile {
+				continue
+			}
+			return nil, err
+		}
+		backups[e.path] = data
+	}
+
+	// Roll back all changes on failure
+	defer func() {
+		if err != nil {
+			for _, path := range paths {
+				_ = os.Remove(filepath.Join(root, path))
+			}
+			for path, data := range backups {
+				_ = ioutil.WriteFile(filepath.Join(root, path), data, 0644)
+			}
+			paths = nil
+		}
+	}()
+
+	for i, f := range files {
+		s.logger.Green.Debugf("Generating %s", f.path)
+		if err = ioutil.WriteFile(filepath.Join(root, f.path), contents[i], 0644); err != nil {
+			return paths, err
+		}
+		paths = append(paths, f.path)
+	}
+
+	for _, e := range edits {
+		if _, ok := backups[e.path]; !ok {
+			continue
+		}
+
+		s.logger.Cyan.Debugf("Editing %s", e.path)
+		if err = compiler.EditFile(filepath.Join(root, e.path), e.locate); err != nil {
+			return paths, fmt.Errorf("%s: %s", e.path, err)
+		}
+	}
+
+	return paths, nil
+}
+
+// render renders and formats a Go source code file for a resource.
+func render(name, text string, res Resource) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, res); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
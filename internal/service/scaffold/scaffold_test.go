@@ -0,0 +1,335 @@
+package scaffold
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moorara/gelato/internal/log"
+)
+
+// copyApp copies the test application into a temporary directory.
+func copyApp(t *testing.T) string {
+	dest, err := ioutil.TempDir("", "gelato-scaffold-")
+	assert.NoError(t, err)
+
+	err = filepath.Walk("./test/app", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel("./test/app", path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dest, rel), 0755)
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(filepath.Join(dest, rel), data, 0644)
+	})
+
+	assert.NoError(t, err)
+
+	return dest
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+const noComponentsMain = `package main
+
+import "example.com/app/internal/server"
+
+func main() {
+	observer := observer.New(true)
+
+	// CREATE SERVERS
+
+	httpServer, err := server.NewHTTPServer(server.HTTPServerOptions{})
+	if err != nil {
+		panic(err)
+	}
+
+	_ = httpServer.ListenAndServe()
+}
+`
+
+func TestLayers(t *testing.T) {
+	tests := []struct {
+		name           string
+		root           string
+		expectedLayers map[string]bool
+	}{
+		{
+			name:           "NoLayer",
+			root:           "./test",
+			expectedLayers: map[string]bool{},
+		},
+		{
+			name: "Horizontal",
+			root: "./test/app",
+			expectedLayers: map[string]bool{
+				"controller": true,
+				"entity":     true,
+				"gateway":    true,
+				"handler":    true,
+				"idl":        true,
+				"mapper":     true,
+				"repository": true,
+				"server":     true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedLayers, Layers(tc.root))
+		})
+	}
+}
+
+func TestNewScaffolder(t *testing.T) {
+	s := NewScaffolder(log.None)
+
+	assert.NotNil(t, s)
+	assert.NotNil(t, s.logger)
+}
+
+func TestScaffolder_Add(t *testing.T) {
+	res := Resource{
+		Module:   "example.com/app",
+		Name:     "PurchaseOrder",
+		Var:      "purchaseOrder",
+		Label:    "purchase-order",
+		Singular: "purchase order",
+		Plural:   "purchase orders",
+		Path:     "purchase-orders",
+		File:     "purchase_order",
+	}
+
+	withGateway := res
+	withGateway.Gateway = true
+
+	tests := []struct {
+		name          string
+		prepare       func(root string)
+		res           Resource
+		expectedError string
+		expectedPaths []string
+		expectedMain  []string
+		expectedHTTP  []string
+		expectedTest  []string
+	}{
+		{
+			name: "FileExists",
+			prepare: func(root string) {
+				_ = ioutil.WriteFile(filepath.Join(root, "internal/entity/purchase_order.go"), []byte("package entity\n"), 0644)
+			},
+			res:           res,
+			expectedError: "file already exists: internal/entity/purchase_order.go",
+		},
+		{
+			name: "MainNotFound",
+			prepare: func(root string) {
+				_ = os.Remove(filepath.Join(root, "main.go"))
+			},
+			res:           res,
+			expectedError: "no such file or directory",
+		},
+		{
+			name: "WiringFails",
+			prepare: func(root string) {
+				_ = ioutil.WriteFile(filepath.Join(root, "internal/server/http.go"), []byte("package server\n"), 0644)
+			},
+			res:           res,
+			expectedError: "internal/server/http.go: cannot find the HTTP server constructor",
+		},
+		{
+			name: "WithoutGateway",
+			res:  res,
+			expectedPaths: []string{
+				"internal/entity/purchase_order.go",
+				"internal/entity/purchase_order_test.go",
+				"internal/idl/purchase_order.go",
+				"internal/mapper/purchase_order.go",
+				"internal/mapper/purchase_order_test.go",
+				"internal/repository/purchase_order.go",
+				"internal/repository/purchase_order_test.go",
+				"internal/controller/purchase_order.go",
+				"internal/controller/purchase_order_test.go",
+				"internal/controller/purchase_order_mock_test.go",
+				"internal/handler/purchase_order.go",
+				"internal/handler/purchase_order_test.go",
+				"internal/handler/purchase_order_mock_test.go",
+				"internal/server/purchase_order_mock_test.go",
+			},
+			expectedMain: []string{
+				"purchaseOrderRepository, err := repository.NewPurchaseOrderRepository()",
+				"purchaseOrderController, err := controller.NewPurchaseOrderController(purchaseOrderRepository)",
+				"purchaseOrderHandler, err := handler.NewPurchaseOrderHandler(purchaseOrderController)",
+				`observer.Logger().Fatal("failed to create purchase order handler", zap.Error(err))`,
+				"health.RegisterChecker(translateGateway, greetingRepository, purchaseOrderRepository)",
+				"graceful.RegisterClient(translateGateway, greetingRepository, purchaseOrderRepository)",
+				"server.NewHTTPServer(healthHandler, greetingHandler, purchaseOrderHandler, server.HTTPServerOptions{",
+			},
+			expectedHTTP: []string{
+				"greetingHandler handler.GreetingHandler, purchaseOrderHandler handler.PurchaseOrderHandler, opts HTTPServerOptions",
+				"idl.RegisterPurchaseOrderHandler(router, purchaseOrderHandler, opts.Middleware...)",
+			},
+			expectedTest: []string{
+				"NewHTTPServer(tc.healthHandler, tc.greetingHandler, &MockPurchaseOrderHandler{}, tc.opts)",
+			},
+		},
+		{
+			name: "NoComponents",
+			prepare: func(root string) {
+				_ = ioutil.WriteFile(filepath.Join(root, "main.go"), []byte(noComponentsMain), 0644)
+			},
+			res: res,
+			expectedPaths: []string{
+				"internal/entity/purchase_order.go",
+				"internal/entity/purchase_order_test.go",
+				"internal/idl/purchase_order.go",
+				"internal/mapper/purchase_order.go",
+				"internal/mapper/purchase_order_test.go",
+				"internal/repository/purchase_order.go",
+				"internal/repository/purchase_order_test.go",
+				"internal/controller/purchase_order.go",
+				"internal/controller/purchase_order_test.go",
+				"internal/controller/purchase_order_mock_test.go",
+				"internal/handler/purchase_order.go",
+				"internal/handler/purchase_order_test.go",
+				"internal/handler/purchase_order_mock_test.go",
+				"internal/server/purchase_order_mock_test.go",
+			},
+			expectedMain: []string{
+				`	observer := observer.New(true)
+
+	purchaseOrderRepository, err := repository.NewPurchaseOrderRepository()
+	if err != nil {
+		observer.Logger().Fatal("failed to create purchase order repository", zap.Error(err))
+	}
+
+	purchaseOrderController, err := controller.NewPurchaseOrderController(purchaseOrderRepository)
+	if err != nil {
+		observer.Logger().Fatal("failed to create purchase order controller", zap.Error(err))
+	}
+
+	purchaseOrderHandler, err := handler.NewPurchaseOrderHandler(purchaseOrderController)
+	if err != nil {
+		observer.Logger().Fatal("failed to create purchase order handler", zap.Error(err))
+	}
+
+	// CREATE SERVERS
+`,
+				`"example.com/app/internal/repository"`,
+				`"go.uber.org/zap"`,
+				"server.NewHTTPServer(purchaseOrderHandler, server.HTTPServerOptions{})",
+			},
+		},
+		{
+			name: "WithGateway",
+			res:  withGateway,
+			expectedPaths: []string{
+				"internal/entity/purchase_order.go",
+				"internal/entity/purchase_order_test.go",
+				"internal/idl/purchase_order.go",
+				"internal/mapper/purchase_order.go",
+				"internal/mapper/purchase_order_test.go",
+				"internal/repository/purchase_order.go",
+				"internal/repository/purchase_order_test.go",
+				"internal/gateway/purchase_order.go",
+				"internal/gateway/purchase_order_test.go",
+				"internal/controller/purchase_order.go",
+				"internal/controller/purchase_order_test.go",
+				"internal/controller/purchase_order_mock_test.go",
+				"internal/handler/purchase_order.go",
+				"internal/handler/purchase_order_test.go",
+				"internal/handler/purchase_order_mock_test.go",
+				"internal/server/purchase_order_mock_test.go",
+			},
+			expectedMain: []string{
+				"purchaseOrderGateway, err := gateway.NewPurchaseOrderGateway()",
+				"purchaseOrderController, err := controller.NewPurchaseOrderController(purchaseOrderGateway, purchaseOrderRepository)",
+				"health.RegisterChecker(translateGateway, greetingRepository, purchaseOrderGateway, purchaseOrderRepository)",
+				"graceful.RegisterClient(translateGateway, greetingRepository, purchaseOrderGateway, purchaseOrderRepository)",
+			},
+			expectedHTTP: []string{
+				"idl.RegisterPurchaseOrderHandler(router, purchaseOrderHandler, opts.Middleware...)",
+			},
+			expectedTest: []string{
+				"&MockPurchaseOrderHandler{}, tc.opts)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := copyApp(t)
+			defer os.RemoveAll(root)
+
+			if tc.prepare != nil {
+				tc.prepare(root)
+			}
+
+			mainBefore, _ := ioutil.ReadFile(filepath.Join(root, mainFile))
+			httpBefore := readFile(t, filepath.Join(root, serverFile))
+
+			s := NewScaffolder(log.None)
+			paths, err := s.Add(root, tc.res)
+
+			if tc.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				assert.Nil(t, paths)
+
+				// Nothing should be left behind
+				mainAfter, _ := ioutil.ReadFile(filepath.Join(root, mainFile))
+				assert.Equal(t, string(mainBefore), string(mainAfter))
+				assert.Equal(t, httpBefore, readFile(t, filepath.Join(root, serverFile)))
+				_, err := os.Stat(filepath.Join(root, "internal/controller/purchase_order.go"))
+				assert.True(t, os.IsNotExist(err))
+			} else {
+				assert.NoError(t, err)
+
+				var expectedPaths []string
+				for _, p := range tc.expectedPaths {
+					expectedPaths = append(expectedPaths, filepath.FromSlash(p))
+				}
+				assert.Equal(t, expectedPaths, paths)
+
+				for _, p := range paths {
+					assert.FileExists(t, filepath.Join(root, p))
+				}
+
+				main := readFile(t, filepath.Join(root, mainFile))
+				for _, s := range tc.expectedMain {
+					assert.Contains(t, main, s)
+				}
+
+				http := readFile(t, filepath.Join(root, serverFile))
+				for _, s := range tc.expectedHTTP {
+					assert.Contains(t, http, s)
+				}
+
+				test := readFile(t, filepath.Join(root, serverTestFile))
+				for _, s := range tc.expectedTest {
+					assert.Contains(t, test, s)
+				}
+			}
+		})
+	}
+}
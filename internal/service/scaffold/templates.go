@@ -0,0 +1,1274 @@
+package scaffold
+
+// The templates for generating a resource in an application with horizontal layout.
+// They follow the same conventions as the greeting resource in the horizontal templates.
+
+const entityTmpl = `package entity
+
+import "fmt"
+
+// {{.Name}} is the domain model for {{.Plural}}.
+type {{.Name}} struct {
+	ID   string
+	Name string
+}
+
+// String implements fmt.Stringer interface.
+func (e *{{.Name}}) String() string {
+	return fmt.Sprintf("{{.Name}}{id=%s, name=%s}", e.ID, e.Name)
+}
+`
+
+const entityTestTmpl = `package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test{{.Name}}(t *testing.T) {
+	tests := []struct {
+		name           string
+		entity         {{.Name}}
+		expectedString string
+	}{
+		{
+			name: "OK",
+			entity: {{.Name}}{
+				ID:   "a1b2c3d4",
+				Name: "Jane",
+			},
+			expectedString: "{{.Name}}{id=a1b2c3d4, name=Jane}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.entity.String())
+		})
+	}
+}
+`
+
+const idlTmpl = `package idl
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"{{.Module}}/pkg/xhttp"
+)
+
+// {{.Name}} is the HTTP (wire/transport protocol) model for {{.Plural}}.
+type {{.Name}} struct {
+	ID   string {{tag "id,omitempty"}}
+	Name string {{tag "name"}}
+}
+
+// {{.Name}}Handler is the interface for {{.Singular}} handler functions.
+type {{.Name}}Handler interface {
+	Create(http.ResponseWriter, *http.Request)
+	Get(http.ResponseWriter, *http.Request)
+}
+
+// Register{{.Name}}Handler registers the HTTP routes for {{.Singular}} handler.
+// Middleware are applied from left to right (the first middleware is the most inner and the last middleware is the most outter).
+func Register{{.Name}}Handler(router *mux.Router, handler {{.Name}}Handler, middleware ...xhttp.Middleware) {
+	createHandler := handler.Create
+	getHandler := handler.Get
+
+	for _, mid := range middleware {
+		createHandler = mid.Wrap(createHandler)
+		getHandler = mid.Wrap(getHandler)
+	}
+
+	router.Name("Create{{.Name}}").Methods("POST").Path("/v1/{{.Path}}").HandlerFunc(createHandler)
+	router.Name("Get{{.Name}}").Methods("GET").Path("/v1/{{.Path}}/{id}").HandlerFunc(getHandler)
+}
+`
+
+const mapperTmpl = `package mapper
+
+import (
+	"errors"
+
+	"{{.Module}}/internal/entity"
+	"{{.Module}}/internal/idl"
+)
+
+// {{.Name}}IDLToDomain transforms the IDL-specific (wire or transport protocol) representation of {{.Name}} to its domain-specific representation.
+func {{.Name}}IDLToDomain(in *idl.{{.Name}}) (*entity.{{.Name}}, error) {
+	if in == nil {
+		return nil, errors.New("{{.Singular}} cannot be nil")
+	}
+
+	if in.Name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+
+	return &entity.{{.Name}}{
+		ID:   in.ID,
+		Name: in.Name,
+	}, nil
+}
+
+// {{.Name}}DomainToIDL transforms the domain-specific representation of {{.Name}} to its IDL-specific (wire or transport protocol) representation.
+func {{.Name}}DomainToIDL(in *entity.{{.Name}}) (*idl.{{.Name}}, error) {
+	if in == nil {
+		return nil, errors.New("{{.Singular}} cannot be nil")
+	}
+
+	if in.ID == "" {
+		return nil, errors.New("id cannot be empty")
+	}
+
+	return &idl.{{.Name}}{
+		ID:   in.ID,
+		Name: in.Name,
+	}, nil
+}
+`
+
+const mapperTestTmpl = `package mapper
+
+import (
+	"errors"
+	"testing"
+
+	"{{.Module}}/internal/entity"
+	"{{.Module}}/internal/idl"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test{{.Name}}IDLToDomain(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *idl.{{.Name}}
+		expectedOut   *entity.{{.Name}}
+		expectedError error
+	}{
+		{
+			name:          "Nil{{.Name}}",
+			in:            nil,
+			expectedOut:   nil,
+			expectedError: errors.New("{{.Singular}} cannot be nil"),
+		},
+		{
+			name:          "EmptyName",
+			in:            &idl.{{.Name}}{},
+			expectedOut:   nil,
+			expectedError: errors.New("name cannot be empty"),
+		},
+		{
+			name: "OK",
+			in: &idl.{{.Name}}{
+				Name: "Jane",
+			},
+			expectedOut: &entity.{{.Name}}{
+				Name: "Jane",
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := {{.Name}}IDLToDomain(tc.in)
+
+			assert.Equal(t, tc.expectedOut, out)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func Test{{.Name}}DomainToIDL(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *entity.{{.Name}}
+		expectedOut   *idl.{{.Name}}
+		expectedError error
+	}{
+		{
+			name:          "Nil{{.Name}}",
+			in:            nil,
+			expectedOut:   nil,
+			expectedError: errors.New("{{.Singular}} cannot be nil"),
+		},
+		{
+			name:          "EmptyID",
+			in:            &entity.{{.Name}}{},
+			expectedOut:   nil,
+			expectedError: errors.New("id cannot be empty"),
+		},
+		{
+			name: "OK",
+			in: &entity.{{.Name}}{
+				ID:   "a1b2c3d4",
+				Name: "Jane",
+			},
+			expectedOut: &idl.{{.Name}}{
+				ID:   "a1b2c3d4",
+				Name: "Jane",
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := {{.Name}}DomainToIDL(tc.in)
+
+			assert.Equal(t, tc.expectedOut, out)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+`
+
+const repositoryTmpl = `package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/moorara/graceful"
+	"github.com/moorara/health"
+
+	"{{.Module}}/internal/entity"
+)
+
+// {{.Name}}Repository is the interface for interacting with the data store for {{.Plural}}.
+type {{.Name}}Repository interface {
+	graceful.Client
+	health.Checker
+	Create(ctx context.Context, {{.Var}} *entity.{{.Name}}) error
+	Get(ctx context.Context, id string) (*entity.{{.Name}}, error)
+}
+
+// {{.Var}}Repository is an in-memory data store for {{.Plural}} that implements {{.Name}}Repository interface.
+type {{.Var}}Repository struct {
+	sync.Mutex
+	store map[string]*entity.{{.Name}}
+}
+
+// New{{.Name}}Repository creates a new repository for storing and retrieving {{.Plural}}.
+func New{{.Name}}Repository() ({{.Name}}Repository, error) {
+	return &{{.Var}}Repository{
+		store: make(map[string]*entity.{{.Name}}),
+	}, nil
+}
+
+// String returns the name of the repository.
+func (r *{{.Var}}Repository) String() string {
+	return "{{.Label}}-repository"
+}
+
+// Connect opens a long-lived connection to the repository backend.
+func (r *{{.Var}}Repository) Connect() error {
+	return nil
+}
+
+// Disconnect closes the long-lived connection to the repository backend.
+// If the context is cancelled, an error will be returned.
+func (r *{{.Var}}Repository) Disconnect(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// CheckHealth checks the health of connection to the repository backend.
+// If the context is cancelled, an error will be returned.
+func (r *{{.Var}}Repository) CheckHealth(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Create stores a new {{.Singular}}.
+func (r *{{.Var}}Repository) Create(ctx context.Context, {{.Var}} *entity.{{.Name}}) error {
+	if {{.Var}} == nil || {{.Var}}.ID == "" {
+		return errors.New("cannot store {{.Singular}} without id")
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.store[{{.Var}}.ID]; ok {
+		return fmt.Errorf("{{.Singular}} already exists: %s", {{.Var}}.ID)
+	}
+
+	r.store[{{.Var}}.ID] = {{.Var}}
+	return nil
+}
+
+// Get retrieves a {{.Singular}} by its id.
+func (r *{{.Var}}Repository) Get(ctx context.Context, id string) (*entity.{{.Name}}, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	{{.Var}}, ok := r.store[id]
+	if !ok {
+		return nil, fmt.Errorf("no {{.Singular}} found for %s", id)
+	}
+
+	return {{.Var}}, nil
+}
+`
+
+const repositoryTestTmpl = `package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"{{.Module}}/internal/entity"
+)
+
+func TestNew{{.Name}}Repository(t *testing.T) {
+	repository, err := New{{.Name}}Repository()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, repository)
+}
+
+func Test{{.Name}}RepositoryString(t *testing.T) {
+	repository := &{{.Var}}Repository{}
+
+	assert.Equal(t, "{{.Label}}-repository", repository.String())
+}
+
+func Test{{.Name}}RepositoryConnect(t *testing.T) {
+	repository := &{{.Var}}Repository{}
+
+	assert.NoError(t, repository.Connect())
+}
+
+func Test{{.Name}}RepositoryDisconnect(t *testing.T) {
+	repository := &{{.Var}}Repository{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, repository.Disconnect(ctx))
+
+	cancel()
+	assert.Equal(t, context.Canceled, repository.Disconnect(ctx))
+}
+
+func Test{{.Name}}RepositoryCheckHealth(t *testing.T) {
+	repository := &{{.Var}}Repository{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, repository.CheckHealth(ctx))
+
+	cancel()
+	assert.Equal(t, context.Canceled, repository.CheckHealth(ctx))
+}
+
+func Test{{.Name}}RepositoryCreate(t *testing.T) {
+	tests := []struct {
+		name          string
+		store         map[string]*entity.{{.Name}}
+		ctx           context.Context
+		{{.Var}} *entity.{{.Name}}
+		expectedError error
+	}{
+		{
+			name:          "Nil{{.Name}}",
+			store:         map[string]*entity.{{.Name}}{},
+			ctx:           context.Background(),
+			{{.Var}}: nil,
+			expectedError: errors.New("cannot store {{.Singular}} without id"),
+		},
+		{
+			name: "AlreadyExists",
+			store: map[string]*entity.{{.Name}}{
+				"a1b2c3d4": {ID: "a1b2c3d4", Name: "Jane"},
+			},
+			ctx:           context.Background(),
+			{{.Var}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"},
+			expectedError: errors.New("{{.Singular}} already exists: a1b2c3d4"),
+		},
+		{
+			name:          "Success",
+			store:         map[string]*entity.{{.Name}}{},
+			ctx:           context.Background(),
+			{{.Var}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &{{.Var}}Repository{
+				store: tc.store,
+			}
+
+			err := repository.Create(tc.ctx, tc.{{.Var}})
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.{{.Var}}, repository.store[tc.{{.Var}}.ID])
+			}
+		})
+	}
+}
+
+func Test{{.Name}}RepositoryGet(t *testing.T) {
+	tests := []struct {
+		name          string
+		store         map[string]*entity.{{.Name}}
+		ctx           context.Context
+		id            string
+		expected{{.Name}} *entity.{{.Name}}
+		expectedError error
+	}{
+		{
+			name:          "NotFound",
+			store:         map[string]*entity.{{.Name}}{},
+			ctx:           context.Background(),
+			id:            "a1b2c3d4",
+			expectedError: errors.New("no {{.Singular}} found for a1b2c3d4"),
+		},
+		{
+			name: "Success",
+			store: map[string]*entity.{{.Name}}{
+				"a1b2c3d4": {ID: "a1b2c3d4", Name: "Jane"},
+			},
+			ctx:           context.Background(),
+			id:            "a1b2c3d4",
+			expected{{.Name}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repository := &{{.Var}}Repository{
+				store: tc.store,
+			}
+
+			{{.Var}}, err := repository.Get(tc.ctx, tc.id)
+
+			assert.Equal(t, tc.expected{{.Name}}, {{.Var}})
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+`
+
+const gatewayTmpl = `package gateway
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moorara/graceful"
+	"github.com/moorara/health"
+
+	"{{.Module}}/internal/entity"
+)
+
+// {{.Name}}Gateway is the interface for calling an external service for {{.Plural}}.
+type {{.Name}}Gateway interface {
+	graceful.Client
+	health.Checker
+	Notify(ctx context.Context, {{.Var}} *entity.{{.Name}}) error
+}
+
+// {{.Var}}Gateway implements {{.Name}}Gateway interface.
+type {{.Var}}Gateway struct{}
+
+// New{{.Name}}Gateway creates a new instance of {{.Name}}Gateway.
+func New{{.Name}}Gateway() ({{.Name}}Gateway, error) {
+	return &{{.Var}}Gateway{}, nil
+}
+
+// String returns the name of the gateway.
+func (g *{{.Var}}Gateway) String() string {
+	return "{{.Label}}-gateway"
+}
+
+// Connect opens a long-lived connection to the external service.
+func (g *{{.Var}}Gateway) Connect() error {
+	return nil
+}
+
+// Disconnect closes the long-lived connection to the external service.
+// If the context is cancelled, an error will be returned.
+func (g *{{.Var}}Gateway) Disconnect(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// CheckHealth checks the health of connection to the external service.
+// If the context is cancelled, an error will be returned.
+func (g *{{.Var}}Gateway) CheckHealth(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Notify notifies the external service of a new {{.Singular}}.
+func (g *{{.Var}}Gateway) Notify(ctx context.Context, {{.Var}} *entity.{{.Name}}) error {
+	if {{.Var}} == nil || {{.Var}}.ID == "" {
+		return errors.New("invalid {{.Singular}}")
+	}
+
+	// Make a call to the service using the connection
+	return nil
+}
+`
+
+const gatewayTestTmpl = `package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"{{.Module}}/internal/entity"
+)
+
+func TestNew{{.Name}}Gateway(t *testing.T) {
+	gateway, err := New{{.Name}}Gateway()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, gateway)
+}
+
+func Test{{.Name}}GatewayString(t *testing.T) {
+	gateway := &{{.Var}}Gateway{}
+
+	assert.Equal(t, "{{.Label}}-gateway", gateway.String())
+}
+
+func Test{{.Name}}GatewayConnect(t *testing.T) {
+	gateway := &{{.Var}}Gateway{}
+
+	assert.NoError(t, gateway.Connect())
+}
+
+func Test{{.Name}}GatewayDisconnect(t *testing.T) {
+	gateway := &{{.Var}}Gateway{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, gateway.Disconnect(ctx))
+
+	cancel()
+	assert.Equal(t, context.Canceled, gateway.Disconnect(ctx))
+}
+
+func Test{{.Name}}GatewayCheckHealth(t *testing.T) {
+	gateway := &{{.Var}}Gateway{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, gateway.CheckHealth(ctx))
+
+	cancel()
+	assert.Equal(t, context.Canceled, gateway.CheckHealth(ctx))
+}
+
+func Test{{.Name}}GatewayNotify(t *testing.T) {
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		{{.Var}} *entity.{{.Name}}
+		expectedError error
+	}{
+		{
+			name:          "Invalid{{.Name}}",
+			ctx:           context.Background(),
+			{{.Var}}: &entity.{{.Name}}{},
+			expectedError: errors.New("invalid {{.Singular}}"),
+		},
+		{
+			name:          "Success",
+			ctx:           context.Background(),
+			{{.Var}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := &{{.Var}}Gateway{}
+			err := gateway.Notify(tc.ctx, tc.{{.Var}})
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+`
+
+const controllerTmpl = `package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"{{.Module}}/internal/entity"
+{{- if .Gateway}}
+	"{{.Module}}/internal/gateway"
+{{- end}}
+	"{{.Module}}/internal/repository"
+)
+
+// {{.Name}}Controller is the interface for {{.Singular}} business logic.
+type {{.Name}}Controller interface {
+	Create(context.Context, *entity.{{.Name}}) (*entity.{{.Name}}, error)
+	Get(context.Context, string) (*entity.{{.Name}}, error)
+}
+
+// {{.Var}}Controller implements {{.Name}}Controller interface.
+type {{.Var}}Controller struct {
+{{- if .Gateway}}
+	{{.Var}}Gateway    gateway.{{.Name}}Gateway
+{{- end}}
+	{{.Var}}Repository repository.{{.Name}}Repository
+}
+
+// New{{.Name}}Controller creates a new instance of {{.Name}}Controller.
+func New{{.Name}}Controller({{if .Gateway}}{{.Var}}Gateway gateway.{{.Name}}Gateway, {{end}}{{.Var}}Repository repository.{{.Name}}Repository) ({{.Name}}Controller, error) {
+	return &{{.Var}}Controller{
+{{- if .Gateway}}
+		{{.Var}}Gateway:    {{.Var}}Gateway,
+{{- end}}
+		{{.Var}}Repository: {{.Var}}Repository,
+	}, nil
+}
+
+// new{{.Name}}ID generates a new random id for a {{.Singular}}.
+func new{{.Name}}ID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Create creates a new {{.Singular}} with a new id.
+func (c *{{.Var}}Controller) Create(ctx context.Context, {{.Var}} *entity.{{.Name}}) (*entity.{{.Name}}, error) {
+	id, err := new{{.Name}}ID()
+	if err != nil {
+		return nil, err
+	}
+
+	created := &entity.{{.Name}}{
+		ID:   id,
+		Name: {{.Var}}.Name,
+	}
+
+	// Interact with a data store through its repository
+	if err := c.{{.Var}}Repository.Create(ctx, created); err != nil {
+		return nil, err
+	}
+{{- if .Gateway}}
+
+	// Call an external service through its gateway
+	if err := c.{{.Var}}Gateway.Notify(ctx, created); err != nil {
+		return nil, err
+	}
+{{- end}}
+
+	return created, nil
+}
+
+// Get retrieves a {{.Singular}} by its id.
+func (c *{{.Var}}Controller) Get(ctx context.Context, id string) (*entity.{{.Name}}, error) {
+	return c.{{.Var}}Repository.Get(ctx, id)
+}
+`
+
+const controllerMockTmpl = `package controller
+
+import (
+	"context"
+
+	"{{.Module}}/internal/entity"
+)
+{{- if .Gateway}}
+
+type {{.Name}}GatewayNotifyMock struct {
+	InCtx    context.Context
+	In{{.Name}} *entity.{{.Name}}
+	OutError error
+}
+
+// Mock{{.Name}}Gateway is a mock implementation for gateway.{{.Name}}Gateway interface.
+type Mock{{.Name}}Gateway struct {
+	NotifyCounter int
+	NotifyMocks   []{{.Name}}GatewayNotifyMock
+}
+
+func (m *Mock{{.Name}}Gateway) String() string {
+	return "{{.Label}}-gateway"
+}
+
+func (m *Mock{{.Name}}Gateway) Connect() error {
+	return nil
+}
+
+func (m *Mock{{.Name}}Gateway) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (m *Mock{{.Name}}Gateway) CheckHealth(ctx context.Context) error {
+	return nil
+}
+
+func (m *Mock{{.Name}}Gateway) Notify(ctx context.Context, {{.Var}} *entity.{{.Name}}) error {
+	i := m.NotifyCounter
+	m.NotifyCounter++
+	m.NotifyMocks[i].InCtx = ctx
+	m.NotifyMocks[i].In{{.Name}} = {{.Var}}
+	return m.NotifyMocks[i].OutError
+}
+{{- end}}
+
+type {{.Name}}RepositoryCreateMock struct {
+	InCtx    context.Context
+	In{{.Name}} *entity.{{.Name}}
+	OutError error
+}
+
+type {{.Name}}RepositoryGetMock struct {
+	InCtx     context.Context
+	InID      string
+	Out{{.Name}} *entity.{{.Name}}
+	OutError  error
+}
+
+// Mock{{.Name}}Repository is a mock implementation for repository.{{.Name}}Repository interface.
+type Mock{{.Name}}Repository struct {
+	CreateCounter int
+	CreateMocks   []{{.Name}}RepositoryCreateMock
+
+	GetCounter int
+	GetMocks   []{{.Name}}RepositoryGetMock
+}
+
+func (m *Mock{{.Name}}Repository) String() string {
+	return "{{.Label}}-repository"
+}
+
+func (m *Mock{{.Name}}Repository) Connect() error {
+	return nil
+}
+
+func (m *Mock{{.Name}}Repository) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (m *Mock{{.Name}}Repository) CheckHealth(ctx context.Context) error {
+	return nil
+}
+
+func (m *Mock{{.Name}}Repository) Create(ctx context.Context, {{.Var}} *entity.{{.Name}}) error {
+	i := m.CreateCounter
+	m.CreateCounter++
+	m.CreateMocks[i].InCtx = ctx
+	m.CreateMocks[i].In{{.Name}} = {{.Var}}
+	return m.CreateMocks[i].OutError
+}
+
+func (m *Mock{{.Name}}Repository) Get(ctx context.Context, id string) (*entity.{{.Name}}, error) {
+	i := m.GetCounter
+	m.GetCounter++
+	m.GetMocks[i].InCtx = ctx
+	m.GetMocks[i].InID = id
+	return m.GetMocks[i].Out{{.Name}}, m.GetMocks[i].OutError
+}
+`
+
+const controllerTestTmpl = `package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"{{.Module}}/internal/entity"
+)
+
+func TestNew{{.Name}}Controller(t *testing.T) {
+	controller, err := New{{.Name}}Controller({{if .Gateway}}&Mock{{.Name}}Gateway{}, {{end}}&Mock{{.Name}}Repository{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, controller)
+}
+
+func Test{{.Name}}ControllerCreate(t *testing.T) {
+	tests := []struct {
+		name               string
+{{- if .Gateway}}
+		mock{{.Name}}Gateway    *Mock{{.Name}}Gateway
+{{- end}}
+		mock{{.Name}}Repository *Mock{{.Name}}Repository
+		ctx                context.Context
+		{{.Var}}              *entity.{{.Name}}
+		expectedError      error
+	}{
+		{
+			name: "RepositoryFails",
+{{- if .Gateway}}
+			mock{{.Name}}Gateway: &Mock{{.Name}}Gateway{},
+{{- end}}
+			mock{{.Name}}Repository: &Mock{{.Name}}Repository{
+				CreateMocks: []{{.Name}}RepositoryCreateMock{
+					{OutError: errors.New("error on storing data")},
+				},
+			},
+			ctx:           context.Background(),
+			{{.Var}}:         &entity.{{.Name}}{Name: "Jane"},
+			expectedError: errors.New("error on storing data"),
+		},
+{{- if .Gateway}}
+		{
+			name: "GatewayFails",
+			mock{{.Name}}Gateway: &Mock{{.Name}}Gateway{
+				NotifyMocks: []{{.Name}}GatewayNotifyMock{
+					{OutError: errors.New("error on calling external service")},
+				},
+			},
+			mock{{.Name}}Repository: &Mock{{.Name}}Repository{
+				CreateMocks: []{{.Name}}RepositoryCreateMock{
+					{OutError: nil},
+				},
+			},
+			ctx:           context.Background(),
+			{{.Var}}:         &entity.{{.Name}}{Name: "Jane"},
+			expectedError: errors.New("error on calling external service"),
+		},
+{{- end}}
+		{
+			name: "Success",
+{{- if .Gateway}}
+			mock{{.Name}}Gateway: &Mock{{.Name}}Gateway{
+				NotifyMocks: []{{.Name}}GatewayNotifyMock{
+					{OutError: nil},
+				},
+			},
+{{- end}}
+			mock{{.Name}}Repository: &Mock{{.Name}}Repository{
+				CreateMocks: []{{.Name}}RepositoryCreateMock{
+					{OutError: nil},
+				},
+			},
+			ctx:           context.Background(),
+			{{.Var}}:         &entity.{{.Name}}{Name: "Jane"},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &{{.Var}}Controller{
+{{- if .Gateway}}
+				{{.Var}}Gateway:    tc.mock{{.Name}}Gateway,
+{{- end}}
+				{{.Var}}Repository: tc.mock{{.Name}}Repository,
+			}
+
+			{{.Var}}, err := controller.Create(tc.ctx, tc.{{.Var}})
+
+			if tc.expectedError != nil {
+				assert.Nil(t, {{.Var}})
+				assert.Equal(t, tc.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, {{.Var}}.ID)
+				assert.Equal(t, tc.{{.Var}}.Name, {{.Var}}.Name)
+			}
+		})
+	}
+}
+
+func Test{{.Name}}ControllerGet(t *testing.T) {
+	tests := []struct {
+		name               string
+		mock{{.Name}}Repository *Mock{{.Name}}Repository
+		ctx                context.Context
+		id                 string
+		expected{{.Name}}     *entity.{{.Name}}
+		expectedError      error
+	}{
+		{
+			name: "RepositoryFails",
+			mock{{.Name}}Repository: &Mock{{.Name}}Repository{
+				GetMocks: []{{.Name}}RepositoryGetMock{
+					{OutError: errors.New("error on retrieving data")},
+				},
+			},
+			ctx:           context.Background(),
+			id:            "a1b2c3d4",
+			expectedError: errors.New("error on retrieving data"),
+		},
+		{
+			name: "Success",
+			mock{{.Name}}Repository: &Mock{{.Name}}Repository{
+				GetMocks: []{{.Name}}RepositoryGetMock{
+					{Out{{.Name}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"}},
+				},
+			},
+			ctx:              context.Background(),
+			id:               "a1b2c3d4",
+			expected{{.Name}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"},
+			expectedError:    nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &{{.Var}}Controller{
+				{{.Var}}Repository: tc.mock{{.Name}}Repository,
+			}
+
+			{{.Var}}, err := controller.Get(tc.ctx, tc.id)
+
+			assert.Equal(t, tc.expected{{.Name}}, {{.Var}})
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+`
+
+const handlerTmpl = `package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"{{.Module}}/internal/controller"
+	"{{.Module}}/internal/idl"
+	"{{.Module}}/internal/mapper"
+	"{{.Module}}/pkg/xhttp"
+)
+
+// {{.Name}}Handler is an alias for the HTTP service interface.
+type {{.Name}}Handler = idl.{{.Name}}Handler
+
+// {{.Var}}Handler implements {{.Name}}Handler (idl.{{.Name}}Handler) interface.
+type {{.Var}}Handler struct {
+	{{.Var}}Controller controller.{{.Name}}Controller
+}
+
+// New{{.Name}}Handler creates a new instance of {{.Name}}Handler.
+func New{{.Name}}Handler({{.Var}}Controller controller.{{.Name}}Controller) ({{.Name}}Handler, error) {
+	return &{{.Var}}Handler{
+		{{.Var}}Controller: {{.Var}}Controller,
+	}, nil
+}
+
+// Create is the handler for {{.Name}}Service::Create endpoint.
+func (h *{{.Var}}Handler) Create(w http.ResponseWriter, r *http.Request) {
+	req := new(idl.{{.Name}})
+	err := json.NewDecoder(r.Body).Decode(req)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	domainReq, err := mapper.{{.Name}}IDLToDomain(req)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	domainResp, err := h.{{.Var}}Controller.Create(r.Context(), domainReq)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp, err := mapper.{{.Name}}DomainToIDL(domainResp)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Get is the handler for {{.Name}}Service::Get endpoint.
+func (h *{{.Var}}Handler) Get(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	domainResp, err := h.{{.Var}}Controller.Get(r.Context(), id)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusNotFound)
+		return
+	}
+
+	resp, err := mapper.{{.Name}}DomainToIDL(domainResp)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+`
+
+const handlerMockTmpl = `package handler
+
+import (
+	"context"
+
+	"{{.Module}}/internal/entity"
+)
+
+type Create{{.Name}}Mock struct {
+	InCtx     context.Context
+	In{{.Name}}  *entity.{{.Name}}
+	Out{{.Name}} *entity.{{.Name}}
+	OutError  error
+}
+
+type Get{{.Name}}Mock struct {
+	InCtx     context.Context
+	InID      string
+	Out{{.Name}} *entity.{{.Name}}
+	OutError  error
+}
+
+// Mock{{.Name}}Controller is a mock implementation for controller.{{.Name}}Controller.
+type Mock{{.Name}}Controller struct {
+	CreateCounter int
+	CreateMocks   []Create{{.Name}}Mock
+
+	GetCounter int
+	GetMocks   []Get{{.Name}}Mock
+}
+
+func (m *Mock{{.Name}}Controller) Create(ctx context.Context, {{.Var}} *entity.{{.Name}}) (*entity.{{.Name}}, error) {
+	i := m.CreateCounter
+	m.CreateCounter++
+	m.CreateMocks[i].InCtx = ctx
+	m.CreateMocks[i].In{{.Name}} = {{.Var}}
+	return m.CreateMocks[i].Out{{.Name}}, m.CreateMocks[i].OutError
+}
+
+func (m *Mock{{.Name}}Controller) Get(ctx context.Context, id string) (*entity.{{.Name}}, error) {
+	i := m.GetCounter
+	m.GetCounter++
+	m.GetMocks[i].InCtx = ctx
+	m.GetMocks[i].InID = id
+	return m.GetMocks[i].Out{{.Name}}, m.GetMocks[i].OutError
+}
+`
+
+const handlerTestTmpl = `package handler
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"{{.Module}}/internal/entity"
+	"{{.Module}}/pkg/xhttp"
+)
+
+func TestNew{{.Name}}Handler(t *testing.T) {
+	handler, err := New{{.Name}}Handler(&Mock{{.Name}}Controller{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, handler)
+}
+
+func Test{{.Name}}HandlerCreate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		mock{{.Name}}Controller *Mock{{.Name}}Controller
+		req                  *http.Request
+		expectedStatusCode   int
+		expectedBody         string
+	}{
+		{
+			name:               "RequestDecodingFails",
+			req:                httptest.NewRequest("POST", "/v1/{{.Path}}", strings.NewReader({{tick}}{{"{"}}{{tick}})),
+			expectedStatusCode: 400,
+			expectedBody:       "unexpected EOF\n",
+		},
+		{
+			name:               "RequestMappingFails",
+			req:                httptest.NewRequest("POST", "/v1/{{.Path}}", strings.NewReader({{tick}}{ "name": "" }{{tick}})),
+			expectedStatusCode: 400,
+			expectedBody:       "name cannot be empty\n",
+		},
+		{
+			name: "ControllerFails",
+			mock{{.Name}}Controller: &Mock{{.Name}}Controller{
+				CreateMocks: []Create{{.Name}}Mock{
+					{OutError: xhttp.NewServerError(errors.New("controller failed"), 500)},
+				},
+			},
+			req:                httptest.NewRequest("POST", "/v1/{{.Path}}", strings.NewReader({{tick}}{ "name": "Jane" }{{tick}})),
+			expectedStatusCode: 500,
+			expectedBody:       "controller failed\n",
+		},
+		{
+			name: "ResponseMappingFails",
+			mock{{.Name}}Controller: &Mock{{.Name}}Controller{
+				CreateMocks: []Create{{.Name}}Mock{
+					{Out{{.Name}}: &entity.{{.Name}}{}},
+				},
+			},
+			req:                httptest.NewRequest("POST", "/v1/{{.Path}}", strings.NewReader({{tick}}{ "name": "Jane" }{{tick}})),
+			expectedStatusCode: 500,
+			expectedBody:       "id cannot be empty\n",
+		},
+		{
+			name: "Success",
+			mock{{.Name}}Controller: &Mock{{.Name}}Controller{
+				CreateMocks: []Create{{.Name}}Mock{
+					{Out{{.Name}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"}},
+				},
+			},
+			req:                httptest.NewRequest("POST", "/v1/{{.Path}}", strings.NewReader({{tick}}{ "name": "Jane" }{{tick}})),
+			expectedStatusCode: 201,
+			expectedBody:       "{\"id\":\"a1b2c3d4\",\"name\":\"Jane\"}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &{{.Var}}Handler{
+				{{.Var}}Controller: tc.mock{{.Name}}Controller,
+			}
+
+			rec := httptest.NewRecorder()
+			handler.Create(rec, tc.req)
+
+			res := rec.Result()
+			b, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+			body := string(b)
+
+			assert.Equal(t, tc.expectedStatusCode, res.StatusCode)
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func Test{{.Name}}HandlerGet(t *testing.T) {
+	tests := []struct {
+		name                 string
+		mock{{.Name}}Controller *Mock{{.Name}}Controller
+		id                   string
+		expectedStatusCode   int
+		expectedBody         string
+	}{
+		{
+			name: "ControllerFails",
+			mock{{.Name}}Controller: &Mock{{.Name}}Controller{
+				GetMocks: []Get{{.Name}}Mock{
+					{OutError: errors.New("no {{.Singular}} found for a1b2c3d4")},
+				},
+			},
+			id:                 "a1b2c3d4",
+			expectedStatusCode: 404,
+			expectedBody:       "no {{.Singular}} found for a1b2c3d4\n",
+		},
+		{
+			name: "ResponseMappingFails",
+			mock{{.Name}}Controller: &Mock{{.Name}}Controller{
+				GetMocks: []Get{{.Name}}Mock{
+					{Out{{.Name}}: &entity.{{.Name}}{}},
+				},
+			},
+			id:                 "a1b2c3d4",
+			expectedStatusCode: 500,
+			expectedBody:       "id cannot be empty\n",
+		},
+		{
+			name: "Success",
+			mock{{.Name}}Controller: &Mock{{.Name}}Controller{
+				GetMocks: []Get{{.Name}}Mock{
+					{Out{{.Name}}: &entity.{{.Name}}{ID: "a1b2c3d4", Name: "Jane"}},
+				},
+			},
+			id:                 "a1b2c3d4",
+			expectedStatusCode: 200,
+			expectedBody:       "{\"id\":\"a1b2c3d4\",\"name\":\"Jane\"}\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &{{.Var}}Handler{
+				{{.Var}}Controller: tc.mock{{.Name}}Controller,
+			}
+
+			req := httptest.NewRequest("GET", "/v1/{{.Path}}/"+tc.id, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.id})
+
+			rec := httptest.NewRecorder()
+			handler.Get(rec, req)
+
+			res := rec.Result()
+			b, err := ioutil.ReadAll(res.Body)
+			assert.NoError(t, err)
+			body := string(b)
+
+			assert.Equal(t, tc.expectedStatusCode, res.StatusCode)
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+`
+
+const serverMockTmpl = `package server
+
+import "net/http"
+
+type Create{{.Name}}Mock struct {
+	InResponseWriter http.ResponseWriter
+	InRequest        *http.Request
+}
+
+type Get{{.Name}}Mock struct {
+	InResponseWriter http.ResponseWriter
+	InRequest        *http.Request
+}
+
+// Mock{{.Name}}Handler is a mock implementation for handler.{{.Name}}Handler interface.
+type Mock{{.Name}}Handler struct {
+	CreateCounter int
+	CreateMocks   []Create{{.Name}}Mock
+
+	GetCounter int
+	GetMocks   []Get{{.Name}}Mock
+}
+
+func (m *Mock{{.Name}}Handler) Create(w http.ResponseWriter, r *http.Request) {
+	i := m.CreateCounter
+	m.CreateCounter++
+	m.CreateMocks[i].InResponseWriter = w
+	m.CreateMocks[i].InRequest = r
+}
+
+func (m *Mock{{.Name}}Handler) Get(w http.ResponseWriter, r *http.Request) {
+	i := m.GetCounter
+	m.GetCounter++
+	m.GetMocks[i].InResponseWriter = w
+	m.GetMocks[i].InRequest = r
+}
+`
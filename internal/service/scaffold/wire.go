@@ -0,0 +1,296 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"strings"
+
+	"github.com/moorara/gelato/internal/service/compiler"
+)
+
+// selectorCall returns the call expression of a statement if it calls a function from a package (pkg.Func(...)).
+func selectorCall(stmt ast.Stmt) (*ast.CallExpr, string, string, bool) {
+	var expr ast.Expr
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		if len(s.Rhs) == 1 {
+			expr = s.Rhs[0]
+		}
+	case *ast.ExprStmt:
+		expr = s.X
+	}
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, "", "", false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", "", false
+	}
+
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, "", "", false
+	}
+
+	return call, pkg.Name, sel.Sel.Name, true
+}
+
+// findCall returns the index of the first or the last statement calling a function from a package with a name prefix.
+func findCall(stmts []ast.Stmt, last bool, pkg, prefix string) int {
+	index := -1
+	for i, stmt := range stmts {
+		if _, p, f, ok := selectorCall(stmt); ok && p == pkg && strings.HasPrefix(f, prefix) {
+			if index = i; !last {
+				break
+			}
+		}
+	}
+
+	return index
+}
+
+// findFunc returns a top-level function declaration by name.
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name && fn.Body != nil {
+			return fn
+		}
+	}
+
+	return nil
+}
+
+// appendArgs returns an edit for appending arguments to a call expression.
+func appendArgs(call *ast.CallExpr, args ...string) compiler.Edit {
+	text := strings.Join(args, ", ")
+	if len(call.Args) > 0 {
+		text = ", " + text
+	}
+
+	return compiler.Edit{Pos: call.Rparen, Text: text}
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := format.Node(buf, fset, expr); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// wireMain creates the components of a resource in the main function and passes the handler to the HTTP server.
+// The new components are created after the existing components of the same layer, so the main function keeps its structure.
+func wireMain(res Resource) compiler.LocateFunc {
+	return func(fset *token.FileSet, file *ast.File) ([]compiler.Edit, []string, error) {
+		fn := findFunc(file, "main")
+		if fn == nil {
+			return nil, nil, errors.New("cannot find the main function")
+		}
+
+		stmts := fn.Body.List
+
+		type layer struct {
+			pkg, name, args string
+		}
+
+		var layers []layer
+		if res.Gateway {
+			layers = append(layers, layer{"gateway", "Gateway", ""})
+		}
+
+		controllerArgs := res.Var + "Repository"
+		if res.Gateway {
+			controllerArgs = res.Var + "Gateway, " + controllerArgs
+		}
+
+		layers = append(layers,
+			layer{"repository", "Repository", ""},
+			layer{"controller", "Controller", controllerArgs},
+			layer{"handler", "Handler", res.Var + "Controller"},
+		)
+
+		// anchor returns the end of a constructor statement including its error check
+		anchor := func(i int) token.Pos {
+			if i+1 < len(stmts) {
+				if _, ok := stmts[i+1].(*ast.IfStmt); ok {
+					return stmts[i+1].End()
+				}
+			}
+			return stmts[i].End()
+		}
+
+		var edits []compiler.Edit
+		imports := []string{"go.uber.org/zap"}
+
+		var prev token.Pos
+		for i, l := range layers {
+			var pos token.Pos
+
+			if j := findCall(stmts, true, l.pkg, "New"); j >= 0 {
+				pos = anchor(j)
+			} else if prev.IsValid() {
+				pos = prev
+			} else {
+				// Create the first layer right before the next layers or the servers
+				next := []string{"server"}
+				for _, l := range layers[i+1:] {
+					next = append(next, l.pkg)
+				}
+
+				first := len(stmts)
+				for _, pkg := range next {
+					if j := findCall(stmts, false, pkg, "New"); j >= 0 && j < first {
+						first = j
+					}
+				}
+
+				if first == 0 || first == len(stmts) {
+					return nil, nil, fmt.Errorf("cannot find where to create %s %s", res.Singular, l.pkg)
+				}
+
+				pos = stmts[first-1].End()
+			}
+
+			edits = append(edits, compiler.Edit{
+				Pos: pos,
+				Text: fmt.Sprintf("\n\n%s%s, err := %s.New%s%s(%s)\nif err != nil {\nobserver.Logger().Fatal(\"failed to create %s %s\", zap.Error(err))\n}",
+					res.Var, l.name, l.pkg, res.Name, l.name, l.args, res.Singular, l.pkg),
+			})
+
+			imports = append(imports, res.Module+"/internal/"+l.pkg)
+			prev = pos
+		}
+
+		// Register the clients for health checking and graceful connection management
+		clients := []string{res.Var + "Repository"}
+		if res.Gateway {
+			clients = []string{res.Var + "Gateway", res.Var + "Repository"}
+		}
+
+		for _, stmt := range stmts {
+			if call, pkg, f, ok := selectorCall(stmt); ok {
+				if (pkg == "health" && f == "RegisterChecker") || (pkg == "graceful" && f == "RegisterClient") {
+					edits = append(edits, appendArgs(call, clients...))
+				}
+			}
+		}
+
+		var found bool
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "NewHTTPServer" && len(call.Args) > 0 {
+					if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "server" {
+						// The handler is passed right before the server options
+						edits = append(edits, compiler.Edit{
+							Pos:  call.Args[len(call.Args)-1].Pos(),
+							Text: res.Var + "Handler, ",
+						})
+						found = true
+					}
+				}
+			}
+			return true
+		})
+
+		if !found {
+			return nil, nil, errors.New("cannot find the HTTP server")
+		}
+
+		return edits, imports, nil
+	}
+}
+
+// wireServer adds a handler parameter for a resource to the HTTP server constructor and registers its routes.
+func wireServer(res Resource) compiler.LocateFunc {
+	return func(fset *token.FileSet, file *ast.File) ([]compiler.Edit, []string, error) {
+		fn := findFunc(file, "NewHTTPServer")
+		if fn == nil || len(fn.Type.Params.List) == 0 {
+			return nil, nil, errors.New("cannot find the HTTP server constructor")
+		}
+
+		params := fn.Type.Params.List
+		stmts := fn.Body.List
+
+		i := findCall(stmts, true, "idl", "Register")
+		if i < 0 {
+			return nil, nil, errors.New("cannot find where to register the HTTP routes")
+		}
+
+		// The new routes are registered the same way as the existing ones
+		call, _, _, _ := selectorCall(stmts[i])
+		if len(call.Args) < 2 {
+			return nil, nil, errors.New("cannot find where to register the HTTP routes")
+		}
+
+		args := []string{"", res.Var + "Handler"}
+		for j, arg := range call.Args {
+			if j == 1 {
+				continue
+			}
+
+			s, err := exprString(fset, arg)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if j == 0 {
+				args[0] = s
+			} else {
+				args = append(args, s)
+			}
+		}
+
+		var ellipsis string
+		if call.Ellipsis.IsValid() {
+			ellipsis = "..."
+		}
+
+		edits := []compiler.Edit{
+			{
+				// The handler parameter comes right before the server options
+				Pos:  params[len(params)-1].Pos(),
+				Text: fmt.Sprintf("%sHandler handler.%sHandler, ", res.Var, res.Name),
+			},
+			{
+				Pos:  stmts[i].End(),
+				Text: fmt.Sprintf("\nidl.Register%sHandler(%s%s)", res.Name, strings.Join(args, ", "), ellipsis),
+			},
+		}
+
+		imports := []string{
+			res.Module + "/internal/handler",
+			res.Module + "/internal/idl",
+		}
+
+		return edits, imports, nil
+	}
+}
+
+// wireServerTest passes a mock handler for a resource to the HTTP server constructor in tests.
+func wireServerTest(res Resource) compiler.LocateFunc {
+	return func(fset *token.FileSet, file *ast.File) ([]compiler.Edit, []string, error) {
+		var edits []compiler.Edit
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "NewHTTPServer" && len(call.Args) > 0 {
+					edits = append(edits, compiler.Edit{
+						Pos:  call.Args[len(call.Args)-1].Pos(),
+						Text: fmt.Sprintf("&Mock%sHandler{}, ", res.Name),
+					})
+				}
+			}
+			return true
+		})
+
+		return edits, nil, nil
+	}
+}
@@ -6,13 +6,15 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 var (
-	specFiles        = []string{"gelato.yml", "gelato.yaml", "gelato.json"}
-	defaultPlatforms = []string{"linux-386", "linux-amd64", "linux-arm", "linux-arm64", "darwin-amd64", "windows-386", "windows-amd64"}
+	specFiles         = []string{"gelato.yml", "gelato.yaml", "gelato.json"}
+	defaultPlatforms  = []string{"linux-386", "linux-amd64", "linux-arm", "linux-arm64", "darwin-amd64", "windows-386", "windows-amd64"}
+	defaultGenerators = []string{GenGeneratorOpenAPI, GenGeneratorBuilder, GenGeneratorMocker, GenGeneratorFaker}
 )
 
 // Spec is the model for all specifications.
@@ -21,7 +23,9 @@ type Spec struct {
 	Gelato     Gelato  `json:"-" yaml:"-"`
 	App        App     `json:"app" yaml:"app"`
 	Build      Build   `json:"build" yaml:"build"`
+	Gen        Gen     `json:"gen" yaml:"gen"`
 	Release    Release `json:"release" yaml:"release"`
+	Update     Update  `json:"update" yaml:"update"`
 }
 
 // FromFile reads and returns specifications from a file.
@@ -66,7 +70,9 @@ func (s Spec) WithDefaults() Spec {
 
 	s.App = s.App.WithDefaults()
 	s.Build = s.Build.WithDefaults()
+	s.Gen = s.Gen.WithDefaults()
 	s.Release = s.Release.WithDefaults()
+	s.Update = s.Update.WithDefaults()
 
 	return s
 }
@@ -79,9 +85,22 @@ type Gelato struct {
 
 // App has the specifications for an application.
 type App struct {
-	Language string `json:"language" yaml:"language"`
-	Type     string `json:"type" yaml:"type"`
-	Layout   string `json:"layout" yaml:"layout"`
+	Language  string     `json:"language" yaml:"language"`
+	Type      string     `json:"type" yaml:"type"`
+	Layout    string     `json:"layout" yaml:"layout"`
+	Template  string     `json:"template" yaml:"template"`
+	Templates []Template `json:"templates" yaml:"templates"`
+}
+
+// Template has the specifications for a named application template.
+// Source can be a local directory, a local tarball, or a git repository.
+// Path is the directory of the template in the source and it can refer to the application language, type, and layout.
+type Template struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Source      string `json:"source" yaml:"source"`
+	Ref         string `json:"ref" yaml:"ref"`
+	Path        string `json:"path" yaml:"path"`
 }
 
 const (
@@ -90,10 +109,14 @@ const (
 
 	// AppTypeCLI represents a command-line application.
 	AppTypeCLI = "cli"
+	// AppTypeLibrary represents a library (a module with no main package).
+	AppTypeLibrary = "library"
 	// AppTypeHTTPService represents an HTTP service.
 	AppTypeHTTPService = "http-service"
 	// AppTypeGRPCService represents a gRPC service.
 	AppTypeGRPCService = "grpc-service"
+	// AppTypeWorker represents a worker consuming messages from a queue.
+	AppTypeWorker = "worker"
 
 	// AppLayoutVertical represents a vertical application layout.
 	AppLayoutVertical = "vertical"
@@ -116,12 +139,14 @@ func (a *App) FlagSet() *flag.FlagSet {
 	fs.StringVar(&a.Language, "language", a.Language, "")
 	fs.StringVar(&a.Type, "type", a.Type, "")
 	fs.StringVar(&a.Layout, "layout", a.Layout, "")
+	fs.StringVar(&a.Template, "template", a.Template, "")
 
 	return fs
 }
 
 // Build has the specifications for the build command.
 type Build struct {
+	Check        bool     `json:"check" yaml:"check"`
 	CrossCompile bool     `json:"crossCompile" yaml:"cross_compile"`
 	Decorate     bool     `json:"decorate" yaml:"decorate"`
 	Platforms    []string `json:"platforms" yaml:"platforms"`
@@ -139,12 +164,99 @@ func (b Build) WithDefaults() Build {
 // FlagSet returns a flag set for the build command arguments.
 func (b *Build) FlagSet() *flag.FlagSet {
 	fs := flag.NewFlagSet("build", flag.ContinueOnError)
+	fs.BoolVar(&b.Check, "check", b.Check, "")
 	fs.BoolVar(&b.CrossCompile, "cross-compile", b.CrossCompile, "")
 	fs.BoolVar(&b.Decorate, "decorate", b.Decorate, "")
 
 	return fs
 }
 
+// Gen has the specifications for the gen command.
+// Packages are specified by their directories relative to the module and they can be glob patterns (internal/*) or recursive (internal/...).
+// Types with a //gelato:mock, //gelato:build, or //gelato:fake directive comment are always included.
+// The openapi generator is not affected by the filters and it always writes the generated files into the internal/idl package.
+// If InPlace is true, the generated files are written next to the source files as test files.
+type Gen struct {
+	Generators      []string `json:"generators" yaml:"generators"`
+	Packages        []string `json:"packages" yaml:"packages"`
+	ExcludePackages []string `json:"excludePackages" yaml:"exclude_packages"`
+	Types           []string `json:"types" yaml:"types"`
+	ExcludeTypes    []string `json:"excludeTypes" yaml:"exclude_types"`
+	TypePattern     string   `json:"typePattern" yaml:"type_pattern"`
+	Output          string   `json:"output" yaml:"output"`
+	InPlace         bool     `json:"inPlace" yaml:"in_place"`
+	PackageSuffix   string   `json:"packageSuffix" yaml:"package_suffix"`
+}
+
+const (
+	// GenGeneratorBuilder represents the generator for builders of structs.
+	GenGeneratorBuilder = "builder"
+	// GenGeneratorMocker represents the generator for mocks of interfaces.
+	GenGeneratorMocker = "mocker"
+	// GenGeneratorFaker represents the generator for in-memory fakes of repository interfaces.
+	GenGeneratorFaker = "faker"
+	// GenGeneratorOpenAPI represents the generator for the idl package from OpenAPI specifications.
+	GenGeneratorOpenAPI = "openapi"
+)
+
+// WithDefaults returns a new object with default values.
+func (g Gen) WithDefaults() Gen {
+	if len(g.Generators) == 0 {
+		g.Generators = defaultGenerators
+	}
+
+	if g.Output == "" {
+		g.Output = ".gen"
+	}
+
+	if g.PackageSuffix == "" {
+		g.PackageSuffix = "test"
+	}
+
+	return g
+}
+
+// FlagSet returns a flag set for the gen command arguments.
+// The list flags accept comma-separated values.
+func (g *Gen) FlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.Var(&listValue{&g.Generators}, "generators", "")
+	fs.Var(&listValue{&g.Packages}, "packages", "")
+	fs.Var(&listValue{&g.ExcludePackages}, "exclude-packages", "")
+	fs.Var(&listValue{&g.Types}, "types", "")
+	fs.Var(&listValue{&g.ExcludeTypes}, "exclude-types", "")
+	fs.StringVar(&g.TypePattern, "type-pattern", g.TypePattern, "")
+	fs.StringVar(&g.Output, "output", g.Output, "")
+	fs.BoolVar(&g.InPlace, "in-place", g.InPlace, "")
+	fs.StringVar(&g.PackageSuffix, "package-suffix", g.PackageSuffix, "")
+
+	return fs
+}
+
+// listValue implements the flag.Value interface for comma-separated lists.
+type listValue struct {
+	list *[]string
+}
+
+func (v *listValue) String() string {
+	if v.list == nil {
+		return ""
+	}
+
+	return strings.Join(*v.list, ",")
+}
+
+func (v *listValue) Set(val string) error {
+	*v.list = nil
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*v.list = append(*v.list, item)
+		}
+	}
+
+	return nil
+}
+
 // Release has the specifications for the release command.
 type Release struct {
 	Artifacts bool `json:"artifacts" yaml:"artifacts"`
@@ -162,3 +274,33 @@ func (r *Release) FlagSet() *flag.FlagSet {
 
 	return fs
 }
+
+// Update has the specifications for the update command.
+type Update struct {
+	Channel      string `json:"channel" yaml:"channel"`
+	DisableCheck bool   `json:"disableCheck" yaml:"disable_check"`
+}
+
+const (
+	// UpdateChannelStable represents the channel for stable releases.
+	UpdateChannelStable = "stable"
+	// UpdateChannelPrerelease represents the channel for pre-releases as well as stable releases.
+	UpdateChannelPrerelease = "prerelease"
+)
+
+// WithDefaults returns a new object with default values.
+func (u Update) WithDefaults() Update {
+	if u.Channel == "" {
+		u.Channel = UpdateChannelStable
+	}
+
+	return u
+}
+
+// FlagSet returns a flag set for the update command arguments.
+func (u *Update) FlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	fs.StringVar(&u.Channel, "channel", u.Channel, "")
+
+	return fs
+}
@@ -52,15 +52,40 @@ func TestFromFile(t *testing.T) {
 					Language: AppLanguageGo,
 					Type:     AppTypeGRPCService,
 					Layout:   AppLayoutHorizontal,
+					Template: "grpc",
+					Templates: []Template{
+						{
+							Name:        "grpc",
+							Description: "gRPC service skeleton",
+							Source:      "https://github.com/octocat/templates.git",
+							Ref:         "v1.0.0",
+							Path:        "go/grpc-service",
+						},
+					},
 				},
 				Build: Build{
 					CrossCompile: true,
 					Decorate:     true,
 					Platforms:    []string{"linux-386", "linux-amd64", "linux-arm", "linux-arm64", "darwin-amd64", "windows-386", "windows-amd64"},
 				},
+				Gen: Gen{
+					Generators:      []string{GenGeneratorMocker},
+					Packages:        []string{"internal/..."},
+					ExcludePackages: []string{"internal/test"},
+					Types:           []string{"Store"},
+					ExcludeTypes:    []string{"Helper"},
+					TypePattern:     "Service$",
+					Output:          "test/gen",
+					InPlace:         true,
+					PackageSuffix:   "mock",
+				},
 				Release: Release{
 					Artifacts: true,
 				},
+				Update: Update{
+					Channel:      UpdateChannelPrerelease,
+					DisableCheck: true,
+				},
 			},
 		},
 		{
@@ -72,15 +97,40 @@ func TestFromFile(t *testing.T) {
 					Language: AppLanguageGo,
 					Type:     AppTypeGRPCService,
 					Layout:   AppLayoutHorizontal,
+					Template: "grpc",
+					Templates: []Template{
+						{
+							Name:        "grpc",
+							Description: "gRPC service skeleton",
+							Source:      "https://github.com/octocat/templates.git",
+							Ref:         "v1.0.0",
+							Path:        "go/grpc-service",
+						},
+					},
 				},
 				Build: Build{
 					CrossCompile: true,
 					Decorate:     true,
 					Platforms:    []string{"linux-386", "linux-amd64", "linux-arm", "linux-arm64", "darwin-amd64", "windows-386", "windows-amd64"},
 				},
+				Gen: Gen{
+					Generators:      []string{GenGeneratorMocker},
+					Packages:        []string{"internal/..."},
+					ExcludePackages: []string{"internal/test"},
+					Types:           []string{"Store"},
+					ExcludeTypes:    []string{"Helper"},
+					TypePattern:     "Service$",
+					Output:          "test/gen",
+					InPlace:         true,
+					PackageSuffix:   "mock",
+				},
 				Release: Release{
 					Artifacts: true,
 				},
+				Update: Update{
+					Channel:      UpdateChannelPrerelease,
+					DisableCheck: true,
+				},
 			},
 		},
 	}
@@ -126,9 +176,18 @@ func TestSpecWithDefaults(t *testing.T) {
 					Decorate:     false,
 					Platforms:    defaultPlatforms,
 				},
+				Gen: Gen{
+					Generators:    defaultGenerators,
+					Output:        ".gen",
+					PackageSuffix: "test",
+				},
 				Release: Release{
 					Artifacts: false,
 				},
+				Update: Update{
+					Channel:      UpdateChannelStable,
+					DisableCheck: false,
+				},
 			},
 		},
 		{
@@ -149,9 +208,18 @@ func TestSpecWithDefaults(t *testing.T) {
 					Decorate:     true,
 					Platforms:    []string{"linux-amd64", "darwin-amd64", "windows-amd64"},
 				},
+				Gen: Gen{
+					Generators:    []string{GenGeneratorMocker},
+					Output:        "test/gen",
+					PackageSuffix: "mock",
+				},
 				Release: Release{
 					Artifacts: true,
 				},
+				Update: Update{
+					Channel:      UpdateChannelPrerelease,
+					DisableCheck: true,
+				},
 			},
 			Spec{
 				APIVersion: "2.0",
@@ -169,9 +237,18 @@ func TestSpecWithDefaults(t *testing.T) {
 					Decorate:     true,
 					Platforms:    []string{"linux-amd64", "darwin-amd64", "windows-amd64"},
 				},
+				Gen: Gen{
+					Generators:    []string{GenGeneratorMocker},
+					Output:        "test/gen",
+					PackageSuffix: "mock",
+				},
 				Release: Release{
 					Artifacts: true,
 				},
+				Update: Update{
+					Channel:      UpdateChannelPrerelease,
+					DisableCheck: true,
+				},
 			},
 		},
 	}
@@ -261,11 +338,13 @@ func TestBuildWithDefaults(t *testing.T) {
 		{
 			"DefaultsNotRequired",
 			Build{
+				Check:        true,
 				CrossCompile: true,
 				Decorate:     true,
 				Platforms:    []string{"linux-amd64", "darwin-amd64", "windows-amd64"},
 			},
 			Build{
+				Check:        true,
 				CrossCompile: true,
 				Decorate:     true,
 				Platforms:    []string{"linux-amd64", "darwin-amd64", "windows-amd64"},
@@ -303,6 +382,97 @@ func TestBuildFlagSet(t *testing.T) {
 	}
 }
 
+func TestGenWithDefaults(t *testing.T) {
+	tests := []struct {
+		name        string
+		gen         Gen
+		expectedGen Gen
+	}{
+		{
+			"DefaultsRequired",
+			Gen{},
+			Gen{
+				Generators:    defaultGenerators,
+				Output:        ".gen",
+				PackageSuffix: "test",
+			},
+		},
+		{
+			"DefaultsNotRequired",
+			Gen{
+				Generators:    []string{GenGeneratorBuilder},
+				Types:         []string{"Store"},
+				Output:        "test/gen",
+				PackageSuffix: "mock",
+			},
+			Gen{
+				Generators:    []string{GenGeneratorBuilder},
+				Types:         []string{"Store"},
+				Output:        "test/gen",
+				PackageSuffix: "mock",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedGen, tc.gen.WithDefaults())
+		})
+	}
+}
+
+func TestGenFlagSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		gen         Gen
+		args        []string
+		expectedGen Gen
+	}{
+		{
+			name:        "NoFlag",
+			gen:         Gen{Generators: defaultGenerators},
+			args:        []string{},
+			expectedGen: Gen{Generators: defaultGenerators},
+		},
+		{
+			name: "Flags",
+			gen:  Gen{Generators: defaultGenerators, Output: ".gen"},
+			args: []string{
+				"-generators", "mocker",
+				"-packages", "internal/..., pkg/*",
+				"-exclude-packages", "internal/test",
+				"-types", "Store,Cache",
+				"-exclude-types", "Helper",
+				"-type-pattern", "Service$",
+				"-in-place",
+				"-package-suffix", "mock",
+			},
+			expectedGen: Gen{
+				Generators:      []string{GenGeneratorMocker},
+				Packages:        []string{"internal/...", "pkg/*"},
+				ExcludePackages: []string{"internal/test"},
+				Types:           []string{"Store", "Cache"},
+				ExcludeTypes:    []string{"Helper"},
+				TypePattern:     "Service$",
+				Output:          ".gen",
+				InPlace:         true,
+				PackageSuffix:   "mock",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := tc.gen.FlagSet()
+			assert.NotNil(t, fs)
+
+			err := fs.Parse(tc.args)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedGen, tc.gen)
+		})
+	}
+}
+
 func TestReleaseWithDefaults(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -354,3 +524,58 @@ func TestReleaseFlagSet(t *testing.T) {
 		assert.NotNil(t, fs)
 	}
 }
+
+func TestUpdateWithDefaults(t *testing.T) {
+	tests := []struct {
+		name           string
+		update         Update
+		expectedUpdate Update
+	}{
+		{
+			"DefaultsRequired",
+			Update{},
+			Update{
+				Channel:      UpdateChannelStable,
+				DisableCheck: false,
+			},
+		},
+		{
+			"DefaultsNotRequired",
+			Update{
+				Channel:      UpdateChannelPrerelease,
+				DisableCheck: true,
+			},
+			Update{
+				Channel:      UpdateChannelPrerelease,
+				DisableCheck: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedUpdate, tc.update.WithDefaults())
+		})
+	}
+}
+
+func TestUpdateFlagSet(t *testing.T) {
+	tests := []struct {
+		update Update
+	}{
+		{
+			update: Update{},
+		},
+		{
+			update: Update{
+				Channel: UpdateChannelPrerelease,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		fs := tc.update.FlagSet()
+
+		assert.NotNil(t, fs)
+	}
+}
@@ -0,0 +1,201 @@
+// Package fake provides the runtime support for the fakes generated by Gelato.
+// It implements a goroutine-safe in-memory store for the entities of repository interfaces.
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrExists is returned when an entity with the same key already exists.
+	ErrExists = errors.New("entity already exists")
+	// ErrNotFound is returned when no entity exists for a key.
+	ErrNotFound = errors.New("entity not found")
+)
+
+// Store is an in-memory store of entities by their keys.
+// Entities are listed in the order they are created.
+// Store is safe for concurrent use.
+type Store[K comparable, V any] struct {
+	mutex  sync.RWMutex
+	keyOf  func(V) K
+	setKey func(*V, K)
+	seq    int64
+	keys   []K
+	values map[K]V
+}
+
+// NewStore creates a new in-memory store.
+// keyOf returns the key of an entity and setKey sets the key of an entity.
+// Both can be nil if entities do not contain their keys.
+func NewStore[K comparable, V any](keyOf func(V) K, setKey func(*V, K)) *Store[K, V] {
+	return &Store[K, V]{
+		keyOf:  keyOf,
+		setKey: setKey,
+		values: map[K]V{},
+	}
+}
+
+// Create stores a new entity and returns its key and the stored entity.
+// The key is taken from the entity if it is set, otherwise a new key is generated and set on the entity.
+func (s *Store[K, V]) Create(value V) (K, V, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var key, zero K
+	if s.keyOf != nil {
+		key = s.keyOf(value)
+	}
+
+	if key == zero {
+		var err error
+		if key, err = s.nextKey(); err != nil {
+			return zero, value, err
+		}
+
+		if s.setKey != nil {
+			s.setKey(&value, key)
+		}
+	}
+
+	if err := s.create(key, value); err != nil {
+		return zero, value, err
+	}
+
+	return key, value, nil
+}
+
+// CreateWithKey stores a new entity with a given key.
+func (s *Store[K, V]) CreateWithKey(key K, value V) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.create(key, value)
+}
+
+func (s *Store[K, V]) create(key K, value V) error {
+	if _, ok := s.values[key]; ok {
+		return fmt.Errorf("%w: %v", ErrExists, key)
+	}
+
+	s.keys = append(s.keys, key)
+	s.values[key] = value
+
+	return nil
+}
+
+// nextKey generates a new key that is not used yet.
+// Keys can be generated for integer, string, and time.Time types.
+func (s *Store[K, V]) nextKey() (K, error) {
+	var key K
+	v := reflect.ValueOf(&key).Elem()
+
+	for {
+		s.seq++
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetInt(s.seq)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			v.SetUint(uint64(s.seq))
+		case reflect.String:
+			v.SetString(strconv.FormatInt(s.seq, 10))
+		default:
+			t, ok := interface{}(&key).(*time.Time)
+			if !ok {
+				return key, fmt.Errorf("cannot generate keys of type %T", key)
+			}
+			*t = time.Now()
+		}
+
+		if _, ok := s.values[key]; !ok {
+			return key, nil
+		}
+	}
+}
+
+// Get returns the entity stored for a key.
+func (s *Store[K, V]) Get(key K) (V, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return value, fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+
+	return value, nil
+}
+
+// List returns all entities in the order they are created.
+func (s *Store[K, V]) List() []V {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	values := make([]V, len(s.keys))
+	for i, key := range s.keys {
+		values[i] = s.values[key]
+	}
+
+	return values
+}
+
+// Update replaces an existing entity.
+// The key is taken from the entity, so the store must be created with a keyOf function.
+func (s *Store[K, V]) Update(value V) error {
+	if s.keyOf == nil {
+		return errors.New("cannot update entities without keys")
+	}
+
+	return s.UpdateWithKey(s.keyOf(value), value)
+}
+
+// UpdateWithKey replaces the entity stored for a key.
+func (s *Store[K, V]) UpdateWithKey(key K, value V) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.values[key]; !ok {
+		return fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+
+	s.values[key] = value
+
+	return nil
+}
+
+// Delete removes the entity stored for a key.
+func (s *Store[K, V]) Delete(key K) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.values[key]; !ok {
+		return fmt.Errorf("%w: %v", ErrNotFound, key)
+	}
+
+	delete(s.values, key)
+	for i, k := range s.keys {
+		if k == key {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// Pointers returns pointers to the entities in a slice.
+// It is used for listing entities stored by value from methods returning pointers.
+func Pointers[V any](values []V) []*V {
+	pointers := make([]*V, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	return pointers
+}
@@ -0,0 +1,169 @@
+package fake
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	ID   string
+	Name string
+}
+
+func userStore() *Store[string, *user] {
+	return NewStore(
+		func(v *user) string { return v.ID },
+		func(v **user, k string) { (*v).ID = k },
+	)
+}
+
+func TestStore_Create(t *testing.T) {
+	s := userStore()
+
+	key, value, err := s.Create(&user{Name: "Jane"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", key)
+	assert.Equal(t, &user{ID: "1", Name: "Jane"}, value)
+
+	key, value, err = s.Create(&user{ID: "jdoe", Name: "John"})
+	assert.NoError(t, err)
+	assert.Equal(t, "jdoe", key)
+	assert.Equal(t, &user{ID: "jdoe", Name: "John"}, value)
+
+	_, _, err = s.Create(&user{ID: "jdoe"})
+	assert.True(t, errors.Is(err, ErrExists))
+
+	// Generated keys skip the keys already used
+	assert.NoError(t, s.CreateWithKey("2", &user{ID: "2"}))
+	key, _, err = s.Create(&user{})
+	assert.NoError(t, err)
+	assert.Equal(t, "3", key)
+}
+
+func TestStore_Create_KeyTypes(t *testing.T) {
+	ints := NewStore[int64, string](nil, nil)
+	key, _, err := ints.Create("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), key)
+
+	uints := NewStore[uint, string](nil, nil)
+	ukey, _, err := uints.Create("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), ukey)
+
+	times := NewStore[time.Time, string](nil, nil)
+	tkey, _, err := times.Create("foo")
+	assert.NoError(t, err)
+	assert.False(t, tkey.IsZero())
+	value, err := times.Get(tkey)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", value)
+
+	floats := NewStore[float64, string](nil, nil)
+	_, _, err = floats.Create("foo")
+	assert.EqualError(t, err, "cannot generate keys of type float64")
+}
+
+func TestStore_Get(t *testing.T) {
+	s := userStore()
+	_, _, _ = s.Create(&user{Name: "Jane"})
+
+	value, err := s.Get("1")
+	assert.NoError(t, err)
+	assert.Equal(t, &user{ID: "1", Name: "Jane"}, value)
+
+	value, err = s.Get("2")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, value)
+}
+
+func TestStore_List(t *testing.T) {
+	s := userStore()
+	assert.Equal(t, []*user{}, s.List())
+
+	_, _, _ = s.Create(&user{Name: "Jane"})
+	_, _, _ = s.Create(&user{Name: "John"})
+	_, _, _ = s.Create(&user{Name: "Jack"})
+	assert.NoError(t, s.Delete("2"))
+
+	assert.Equal(t, []*user{
+		{ID: "1", Name: "Jane"},
+		{ID: "3", Name: "Jack"},
+	}, s.List())
+}
+
+func TestStore_Update(t *testing.T) {
+	s := userStore()
+	_, _, _ = s.Create(&user{Name: "Jane"})
+
+	assert.NoError(t, s.Update(&user{ID: "1", Name: "Janet"}))
+	value, _ := s.Get("1")
+	assert.Equal(t, &user{ID: "1", Name: "Janet"}, value)
+
+	assert.NoError(t, s.UpdateWithKey("1", &user{ID: "1", Name: "Jane"}))
+	value, _ = s.Get("1")
+	assert.Equal(t, &user{ID: "1", Name: "Jane"}, value)
+
+	err := s.Update(&user{ID: "2"})
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	err = NewStore[string, string](nil, nil).Update("foo")
+	assert.EqualError(t, err, "cannot update entities without keys")
+}
+
+func TestStore_Delete(t *testing.T) {
+	s := userStore()
+	_, _, _ = s.Create(&user{Name: "Jane"})
+
+	assert.NoError(t, s.Delete("1"))
+
+	_, err := s.Get("1")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	err = s.Delete("1")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestStore_Concurrent(t *testing.T) {
+	s := NewStore[int, int](nil, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key, _, err := s.Create(i)
+			assert.NoError(t, err)
+			_, _ = s.Get(key)
+			_ = s.List()
+			_ = s.UpdateWithKey(key, -i)
+			if i%2 == 0 {
+				_ = s.Delete(key)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Len(t, s.List(), 50)
+}
+
+func TestPointers(t *testing.T) {
+	values := []user{
+		{ID: "1", Name: "Jane"},
+		{ID: "2", Name: "John"},
+	}
+
+	pointers := Pointers(values)
+	assert.Equal(t, []*user{
+		{ID: "1", Name: "Jane"},
+		{ID: "2", Name: "John"},
+	}, pointers)
+	assert.Same(t, &values[1], pointers[1])
+
+	assert.Equal(t, []*user{}, Pointers([]user{}))
+}
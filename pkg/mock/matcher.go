@@ -0,0 +1,128 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Matcher determines whether or not an argument matches an expectation.
+type Matcher interface {
+	// Match returns true if an argument matches.
+	Match(x interface{}) bool
+	// String describes what the matcher matches.
+	String() string
+}
+
+type anyMatcher struct{}
+
+func (anyMatcher) Match(interface{}) bool {
+	return true
+}
+
+func (anyMatcher) String() string {
+	return "any value"
+}
+
+// Any returns a matcher that matches any argument.
+func Any() Matcher {
+	return anyMatcher{}
+}
+
+type eqMatcher struct {
+	value interface{}
+}
+
+func (m eqMatcher) Match(x interface{}) bool {
+	return reflect.DeepEqual(m.value, x)
+}
+
+func (m eqMatcher) String() string {
+	return fmt.Sprintf("%v", m.value)
+}
+
+// Eq returns a matcher that matches an argument deeply equal to a value.
+func Eq(value interface{}) Matcher {
+	return eqMatcher{
+		value: value,
+	}
+}
+
+type funcMatcher struct {
+	typ reflect.Type
+	f   reflect.Value
+}
+
+func (m funcMatcher) Match(x interface{}) bool {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() {
+		// nil can only be passed to a function accepting nil values
+		switch m.typ.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			v = reflect.Zero(m.typ)
+		default:
+			return false
+		}
+	}
+
+	if !v.Type().AssignableTo(m.typ) {
+		return false
+	}
+
+	return m.f.Call([]reflect.Value{v})[0].Bool()
+}
+
+func (m funcMatcher) String() string {
+	return fmt.Sprintf("func(%s) bool", m.typ)
+}
+
+// Func returns a matcher that matches an argument for which a function returns true.
+func Func[T any](f func(T) bool) Matcher {
+	return funcMatcher{
+		typ: reflect.TypeOf(f).In(0),
+		f:   reflect.ValueOf(f),
+	}
+}
+
+type typeMatcher struct {
+	typ  string
+	test func(x interface{}) bool
+}
+
+func (m typeMatcher) Match(x interface{}) bool {
+	return m.test(x)
+}
+
+func (m typeMatcher) String() string {
+	return "any " + m.typ
+}
+
+// Type returns a matcher that matches any argument of a type.
+// If the type is an interface type, any argument implementing the interface is matched.
+func Type[T any]() Matcher {
+	return typeMatcher{
+		typ: reflect.TypeOf((*T)(nil)).Elem().String(),
+		test: func(x interface{}) bool {
+			_, ok := x.(T)
+			return ok
+		},
+	}
+}
+
+// toMatcher converts an expected argument to a matcher.
+// A function of the form func(T) bool is converted to a function matcher and any other value to an equality matcher.
+func toMatcher(arg interface{}) Matcher {
+	if m, ok := arg.(Matcher); ok {
+		return m
+	}
+
+	if t := reflect.TypeOf(arg); t != nil && t.Kind() == reflect.Func {
+		if t.NumIn() == 1 && t.NumOut() == 1 && t.Out(0).Kind() == reflect.Bool && !t.IsVariadic() && !reflect.ValueOf(arg).IsNil() {
+			return funcMatcher{
+				typ: t.In(0),
+				f:   reflect.ValueOf(arg),
+			}
+		}
+	}
+
+	return Eq(arg)
+}
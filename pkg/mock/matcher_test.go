@@ -0,0 +1,149 @@
+package mock
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		name           string
+		matcher        Matcher
+		x              interface{}
+		expectedMatch  bool
+		expectedString string
+	}{
+		{
+			name:           "Any",
+			matcher:        Any(),
+			x:              "foo",
+			expectedMatch:  true,
+			expectedString: "any value",
+		},
+		{
+			name:           "Eq_Match",
+			matcher:        Eq([]string{"foo"}),
+			x:              []string{"foo"},
+			expectedMatch:  true,
+			expectedString: "[foo]",
+		},
+		{
+			name:           "Eq_NoMatch",
+			matcher:        Eq(1),
+			x:              int64(1),
+			expectedMatch:  false,
+			expectedString: "1",
+		},
+		{
+			name:           "Func_Match",
+			matcher:        Func(func(s string) bool { return strings.HasPrefix(s, "f") }),
+			x:              "foo",
+			expectedMatch:  true,
+			expectedString: "func(string) bool",
+		},
+		{
+			name:           "Func_NoMatch",
+			matcher:        Func(func(s string) bool { return strings.HasPrefix(s, "f") }),
+			x:              "bar",
+			expectedMatch:  false,
+			expectedString: "func(string) bool",
+		},
+		{
+			name:           "Func_WrongType",
+			matcher:        Func(func(s string) bool { return true }),
+			x:              1,
+			expectedMatch:  false,
+			expectedString: "func(string) bool",
+		},
+		{
+			name:           "Func_Nil",
+			matcher:        Func(func(err error) bool { return err == nil }),
+			x:              nil,
+			expectedMatch:  true,
+			expectedString: "func(error) bool",
+		},
+		{
+			name:           "Func_NilNotAllowed",
+			matcher:        Func(func(n int) bool { return true }),
+			x:              nil,
+			expectedMatch:  false,
+			expectedString: "func(int) bool",
+		},
+		{
+			name:           "Type_Match",
+			matcher:        Type[int](),
+			x:              1,
+			expectedMatch:  true,
+			expectedString: "any int",
+		},
+		{
+			name:           "Type_NoMatch",
+			matcher:        Type[int](),
+			x:              "1",
+			expectedMatch:  false,
+			expectedString: "any int",
+		},
+		{
+			name:           "Type_Interface",
+			matcher:        Type[io.Reader](),
+			x:              strings.NewReader(""),
+			expectedMatch:  true,
+			expectedString: "any io.Reader",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMatch, tc.matcher.Match(tc.x))
+			assert.Equal(t, tc.expectedString, tc.matcher.String())
+		})
+	}
+}
+
+func TestToMatcher(t *testing.T) {
+	var nilFunc func(string) bool
+
+	tests := []struct {
+		name          string
+		arg           interface{}
+		x             interface{}
+		expectedMatch bool
+	}{
+		{
+			name:          "Matcher",
+			arg:           Any(),
+			x:             "foo",
+			expectedMatch: true,
+		},
+		{
+			name:          "Func",
+			arg:           func(err error) bool { return err != nil },
+			x:             errors.New("error"),
+			expectedMatch: true,
+		},
+		{
+			name:          "NilFunc",
+			arg:           nilFunc,
+			x:             nilFunc,
+			expectedMatch: true,
+		},
+		{
+			name:          "Value",
+			arg:           "foo",
+			x:             "bar",
+			expectedMatch: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := toMatcher(tc.arg)
+
+			assert.Equal(t, tc.expectedMatch, m.Match(tc.x))
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Package mock provides the runtime support for the mocks generated by Gelato.
+// It implements argument matchers, call counts, and call ordering for expectations.
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Unlimited means there is no upper limit on the number of times an expected call can be made.
+const Unlimited = -1
+
+// mutex guards the state of all calls.
+// Calls of different mocks depend on each other when they are ordered, so they share the same mutex.
+var mutex sync.Mutex
+
+// Expectation is implemented by the expectations of generated mocks.
+type Expectation interface {
+	MockCall() *Call
+}
+
+// InOrder declares that a sequence of expected calls should be made in order.
+// The expectations can belong to different mocks.
+// Once a call is made, the calls before it in the sequence cannot be made anymore.
+func InOrder(expectations ...Expectation) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for i := 1; i < len(expectations); i++ {
+		c := expectations[i].MockCall()
+		c.prereqs = append(c.prereqs, expectations[i-1].MockCall())
+	}
+}
+
+// Call keeps track of an expected call to a mocked method.
+// By default, a call is expected exactly once with any arguments,
+// so the expectations set for the same method are used one after another.
+// Call is safe for concurrent use.
+type Call struct {
+	method  string
+	args    []Matcher
+	min     int
+	max     int
+	calls   int
+	closed  bool
+	prereqs []*Call
+}
+
+// NewCall creates a new expected call for a method.
+func NewCall(method string) *Call {
+	return &Call{
+		method: method,
+		min:    1,
+		max:    1,
+	}
+}
+
+// SetArgs sets the expected arguments of the call.
+// An argument can be a Matcher, a function of the form func(T) bool, or a value matched by deep equality.
+func (c *Call) SetArgs(args ...interface{}) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	c.args = make([]Matcher, len(args))
+	for i, arg := range args {
+		c.args[i] = toMatcher(arg)
+	}
+}
+
+// Times sets the exact number of times the call is expected.
+func (c *Call) Times(n int) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	c.min, c.max = n, n
+}
+
+// AtLeast sets the minimum number of times the call is expected.
+func (c *Call) AtLeast(n int) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	c.min, c.max = n, Unlimited
+}
+
+// AnyTimes allows the call to be made any number of times, including zero.
+func (c *Call) AnyTimes() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	c.min, c.max = 0, Unlimited
+}
+
+// Maybe makes the call optional.
+func (c *Call) Maybe() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	c.min = 0
+}
+
+// Satisfied returns true if the call is made at least as many times as expected.
+func (c *Call) Satisfied() bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return c.satisfied()
+}
+
+// Calls returns the number of times the call is made.
+func (c *Call) Calls() int {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return c.calls
+}
+
+func (c *Call) satisfied() bool {
+	return c.calls >= c.min
+}
+
+// Matches returns true if the call can be made with a list of arguments.
+func (c *Call) Matches(args ...interface{}) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return c.reason(args) == ""
+}
+
+// Record records that the call is made.
+// The calls before it in any ordered sequence are closed.
+func (c *Call) Record() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	c.record()
+}
+
+// TryRecord records that the call is made if the call can be made with a list of arguments.
+// Unlike Matches followed by Record, the call is checked and recorded atomically,
+// so concurrent calls to ordered expectations of different mocks cannot both pass the check.
+func (c *Call) TryRecord(args ...interface{}) bool {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if c.reason(args) != "" {
+		return false
+	}
+
+	c.record()
+
+	return true
+}
+
+func (c *Call) record() {
+	c.calls++
+	for _, p := range c.prereqs {
+		p.close()
+	}
+}
+
+func (c *Call) close() {
+	if !c.closed {
+		c.closed = true
+		for _, p := range c.prereqs {
+			p.close()
+		}
+	}
+}
+
+// reason returns the reason why the call cannot be made with a list of arguments.
+// An empty string means the call can be made.
+func (c *Call) reason(args []interface{}) string {
+	if c.closed {
+		return "a call after it in order is already made"
+	}
+
+	if c.max != Unlimited && c.calls >= c.max {
+		return fmt.Sprintf("already called %s", times(c.calls))
+	}
+
+	for _, p := range c.prereqs {
+		if !p.satisfied() {
+			return fmt.Sprintf("%s method should be called before", p.method)
+		}
+	}
+
+	if c.args != nil {
+		if len(args) != len(c.args) {
+			return "number of arguments mismatch"
+		}
+
+		for i, m := range c.args {
+			if !m.Match(args[i]) {
+				return "arguments mismatch"
+			}
+		}
+	}
+
+	return ""
+}
+
+// Describe describes the call using a spew config for formatting the expected arguments.
+func (c *Call) Describe(cs *spew.ConfigState) string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var expected string
+	switch {
+	case c.min == c.max:
+		expected = times(c.min)
+	case c.min == 0 && c.max == Unlimited:
+		expected = "any number of times"
+	case c.max == Unlimited:
+		expected = "at least " + times(c.min)
+	default:
+		expected = fmt.Sprintf("between %d and %d times", c.min, c.max)
+	}
+
+	return fmt.Sprintf("%s method be called %s (called %s) with\n%s", c.method, expected, times(c.calls), c.formatArgs(cs))
+}
+
+func (c *Call) formatArgs(cs *spew.ConfigState) string {
+	if c.args == nil {
+		return "any arguments\n"
+	}
+
+	var b strings.Builder
+	for _, m := range c.args {
+		if eq, ok := m.(eqMatcher); ok {
+			b.WriteString(cs.Sdump(eq.value))
+		} else {
+			b.WriteString("<" + m.String() + ">\n")
+		}
+	}
+
+	return b.String()
+}
+
+// Diff describes why none of the expected calls for a method can be made with a list of arguments.
+// The actual arguments are diffed against the expected arguments of each call using a spew config.
+func Diff(cs *spew.ConfigState, method string, calls []*Call, args ...interface{}) string {
+	var actual strings.Builder
+	for _, arg := range args {
+		actual.WriteString(cs.Sdump(arg))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s method called with\n%s", method, actual.String())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for i, c := range calls {
+		reason := c.reason(args)
+		fmt.Fprintf(&b, "\nExpectation #%d: %s\n", i+1, reason)
+
+		if c.args != nil {
+			diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(strings.TrimSuffix(c.formatArgs(cs), "\n")),
+				B:        difflib.SplitLines(strings.TrimSuffix(actual.String(), "\n")),
+				FromFile: "Expected",
+				ToFile:   "Actual",
+				Context:  1,
+			})
+			b.WriteString(diff)
+		}
+	}
+
+	return b.String()
+}
+
+func times(n int) string {
+	switch n {
+	case 1:
+		return "once"
+	case 2:
+		return "twice"
+	default:
+		return fmt.Sprintf("%d times", n)
+	}
+}
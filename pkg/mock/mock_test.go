@@ -0,0 +1,324 @@
+package mock
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/stretchr/testify/assert"
+)
+
+var cs = &spew.ConfigState{
+	Indent:                  "  ",
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+	SortKeys:                true,
+}
+
+type expectation struct {
+	call *Call
+}
+
+func (e *expectation) MockCall() *Call {
+	return e.call
+}
+
+func TestInOrder(t *testing.T) {
+	e1 := &expectation{call: NewCall("Open")}
+	e2 := &expectation{call: NewCall("Read")}
+	e3 := &expectation{call: NewCall("Close")}
+
+	InOrder(e1, e2, e3)
+
+	assert.False(t, e2.call.Matches())
+	assert.False(t, e3.call.Matches())
+
+	assert.True(t, e1.call.Matches())
+	e1.call.Record()
+
+	assert.True(t, e2.call.Matches())
+	e2.call.Record()
+
+	assert.False(t, e1.call.Matches())
+	assert.False(t, e2.call.Matches())
+	assert.True(t, e3.call.Matches())
+	e3.call.Record()
+
+	assert.False(t, e2.call.Matches())
+	assert.True(t, e1.call.Satisfied())
+	assert.True(t, e2.call.Satisfied())
+	assert.True(t, e3.call.Satisfied())
+}
+
+func TestCall(t *testing.T) {
+	tests := []struct {
+		name              string
+		call              func() *Call
+		args              []interface{}
+		calls             int
+		expectedMatches   bool
+		expectedSatisfied bool
+	}{
+		{
+			name:              "Default_NotCalled",
+			call:              func() *Call { return NewCall("Get") },
+			args:              []interface{}{"foo"},
+			calls:             0,
+			expectedMatches:   true,
+			expectedSatisfied: false,
+		},
+		{
+			name:              "Default_CalledOnce",
+			call:              func() *Call { return NewCall("Get") },
+			args:              []interface{}{"foo"},
+			calls:             1,
+			expectedMatches:   false,
+			expectedSatisfied: true,
+		},
+		{
+			name: "Args_Match",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.SetArgs(Any(), "foo", func(n int) bool { return n > 0 })
+				return c
+			},
+			args:              []interface{}{nil, "foo", 1},
+			calls:             0,
+			expectedMatches:   true,
+			expectedSatisfied: false,
+		},
+		{
+			name: "Args_NoMatch",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.SetArgs("foo")
+				return c
+			},
+			args:              []interface{}{"bar"},
+			calls:             0,
+			expectedMatches:   false,
+			expectedSatisfied: false,
+		},
+		{
+			name: "Args_LengthMismatch",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.SetArgs("foo")
+				return c
+			},
+			args:              []interface{}{"foo", "bar"},
+			calls:             0,
+			expectedMatches:   false,
+			expectedSatisfied: false,
+		},
+		{
+			name: "Times_NotExhausted",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.Times(2)
+				return c
+			},
+			args:              []interface{}{},
+			calls:             1,
+			expectedMatches:   true,
+			expectedSatisfied: false,
+		},
+		{
+			name: "Times_Exhausted",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.Times(2)
+				return c
+			},
+			args:              []interface{}{},
+			calls:             2,
+			expectedMatches:   false,
+			expectedSatisfied: true,
+		},
+		{
+			name: "AtLeast",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.AtLeast(2)
+				return c
+			},
+			args:              []interface{}{},
+			calls:             1,
+			expectedMatches:   true,
+			expectedSatisfied: false,
+		},
+		{
+			name: "AnyTimes",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.AnyTimes()
+				return c
+			},
+			args:              []interface{}{},
+			calls:             3,
+			expectedMatches:   true,
+			expectedSatisfied: true,
+		},
+		{
+			name: "Maybe",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.Maybe()
+				return c
+			},
+			args:              []interface{}{},
+			calls:             0,
+			expectedMatches:   true,
+			expectedSatisfied: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.call()
+			for i := 0; i < tc.calls; i++ {
+				c.Record()
+			}
+
+			assert.Equal(t, tc.expectedMatches, c.Matches(tc.args...))
+			assert.Equal(t, tc.expectedSatisfied, c.Satisfied())
+		})
+	}
+}
+
+func TestCall_Concurrent(t *testing.T) {
+	e1 := &expectation{call: NewCall("Open")}
+	e2 := &expectation{call: NewCall("Read")}
+	e2.call.SetArgs(Any())
+	e2.call.Times(50)
+
+	InOrder(e1, e2)
+	e1.call.Record()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			e2.call.TryRecord(i)
+			_ = e2.call.Describe(cs)
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.True(t, e2.call.Satisfied())
+	assert.Equal(t, 50, e2.call.Calls())
+	assert.False(t, e1.call.Matches())
+}
+
+func TestCall_TryRecord(t *testing.T) {
+	e1 := &expectation{call: NewCall("Open")}
+	e2 := &expectation{call: NewCall("Read")}
+	e2.call.SetArgs("file")
+	e2.call.Times(1)
+
+	InOrder(e1, e2)
+
+	assert.False(t, e2.call.TryRecord("file"))
+	assert.Equal(t, 0, e2.call.Calls())
+
+	assert.True(t, e1.call.TryRecord())
+	assert.False(t, e2.call.TryRecord("other"))
+	assert.True(t, e2.call.TryRecord("file"))
+	assert.False(t, e2.call.TryRecord("file"))
+	assert.False(t, e1.call.TryRecord())
+
+	assert.Equal(t, 1, e1.call.Calls())
+	assert.Equal(t, 1, e2.call.Calls())
+}
+
+func TestCall_Sequential(t *testing.T) {
+	c1 := NewCall("CheckHealth")
+	c2 := NewCall("CheckHealth")
+
+	assert.True(t, c1.TryRecord())
+	assert.False(t, c1.TryRecord())
+	assert.True(t, c2.TryRecord())
+	assert.False(t, c2.TryRecord())
+
+	assert.True(t, c1.Satisfied())
+	assert.True(t, c2.Satisfied())
+}
+
+func TestCall_Describe(t *testing.T) {
+	tests := []struct {
+		name                string
+		call                func() *Call
+		expectedDescription string
+	}{
+		{
+			name:                "AnyArgs",
+			call:                func() *Call { return NewCall("Close") },
+			expectedDescription: "Close method be called once (called 0 times) with\nany arguments\n",
+		},
+		{
+			name: "AnyTimes",
+			call: func() *Call {
+				c := NewCall("Close")
+				c.AnyTimes()
+				return c
+			},
+			expectedDescription: "Close method be called any number of times (called 0 times) with\nany arguments\n",
+		},
+		{
+			name: "Times",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.SetArgs("foo", Any())
+				c.Times(2)
+				return c
+			},
+			expectedDescription: "Get method be called twice (called 0 times) with\n(string) (len=3) \"foo\"\n<any value>\n",
+		},
+		{
+			name: "Between",
+			call: func() *Call {
+				c := NewCall("Get")
+				c.Times(3)
+				c.Maybe()
+				return c
+			},
+			expectedDescription: "Get method be called between 0 and 3 times (called 0 times) with\nany arguments\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDescription, tc.call().Describe(cs))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	c1 := NewCall("Get")
+	c1.SetArgs("foo", 1)
+
+	c2 := NewCall("Get")
+	c2.SetArgs("bar", 2)
+	c2.Times(1)
+	c2.Record()
+
+	diff := Diff(cs, "Get", []*Call{c1, c2}, "bar", 2)
+
+	assert.Equal(t, `Get method called with
+(string) (len=3) "bar"
+(int) 2
+
+Expectation #1: arguments mismatch
+--- Expected
++++ Actual
+@@ -1,2 +1,2 @@
+-(string) (len=3) "foo"
+-(int) 1
++(string) (len=3) "bar"
++(int) 2
+
+Expectation #2: already called once
+`, diff)
+}
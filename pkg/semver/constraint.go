@@ -0,0 +1,258 @@
+package semver
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	opSpaceRE    = regexp.MustCompile(`(\^|~|>=|<=|!=|=|>|<)\s+`)
+	comparatorRE = regexp.MustCompile(`^(\^|~|>=|<=|!=|=|>|<)?v?([0-9]+|[xX*])(\.([0-9]+|[xX*]))?(\.([0-9]+|[xX*]))?(\-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+)
+
+// comparator is a single primitive condition such as >=1.2.0.
+type comparator struct {
+	op      string
+	version SemVer
+}
+
+func (c comparator) check(v SemVer) bool {
+	switch c.op {
+	case "=":
+		return v.Compare(c.version) == 0
+	case "!=":
+		return v.Compare(c.version) != 0
+	case ">":
+		return v.Compare(c.version) > 0
+	case ">=":
+		return v.Compare(c.version) >= 0
+	case "<":
+		return v.Compare(c.version) < 0
+	case "<=":
+		return v.Compare(c.version) <= 0
+	default:
+		return false
+	}
+}
+
+// Constraint represents a set of conditions that a semantic version can be checked against.
+//
+// A constraint is a list of ranges separated by ||, and a version satisfies the constraint if it satisfies any of the ranges.
+// A range is a list of comparators separated by space or comma, and a version satisfies the range if it satisfies all of the comparators.
+//
+// The following comparators are supported:
+//
+//	1.2.3     =1.2.3     exactly 1.2.3 (build metadata are ignored)
+//	!=1.2.3              anything but 1.2.3
+//	>1.2.3    >=1.2.3    greater than (or equal to) 1.2.3
+//	<1.2.3    <=1.2.3    less than (or equal to) 1.2.3
+//	~1.2.3               >=1.2.3 <1.3.0-0 (patch-level changes)
+//	^1.2.3               >=1.2.3 <2.0.0-0 (changes that do not modify the left-most non-zero part)
+//	1.2       1.2.x      >=1.2.0 <1.3.0-0
+//	*         x          any version
+//
+// Versions are compared using the precedence rules of Semantic Versioning 2.0.0.
+// The upper bounds implied by ~, ^, and partial versions exclude the pre-release versions of the upper bound (e.g. 2.0.0-rc.1 does not satisfy ^1.4).
+type Constraint struct {
+	raw    string
+	ranges [][]comparator
+}
+
+// ParseConstraint gets a constraint string and returns a Constraint.
+// If the second return value is false, it implies that the input constraint was incorrect.
+func ParseConstraint(constraint string) (Constraint, bool) {
+	ranges := [][]comparator{}
+
+	for _, r := range strings.Split(constraint, "||") {
+		r = opSpaceRE.ReplaceAllString(strings.TrimSpace(r), "$1")
+		tokens := strings.FieldsFunc(r, func(c rune) bool {
+			return c == ' ' || c == '\t' || c == ','
+		})
+
+		if len(tokens) == 0 {
+			return Constraint{}, false
+		}
+
+		comparators := []comparator{}
+		for _, token := range tokens {
+			cs, ok := parseComparator(token)
+			if !ok {
+				return Constraint{}, false
+			}
+			comparators = append(comparators, cs...)
+		}
+
+		ranges = append(ranges, comparators)
+	}
+
+	return Constraint{
+		raw:    strings.TrimSpace(constraint),
+		ranges: ranges,
+	}, true
+}
+
+// parseComparator parses a single comparator token and expands it into a list of primitive comparators.
+func parseComparator(token string) ([]comparator, bool) {
+	subs := comparatorRE.FindStringSubmatch(token)
+	if subs == nil {
+		return nil, false
+	}
+
+	op := subs[1]
+	prerelease, metadata := subs[7], subs[9]
+
+	// parts keeps the version numbers provided until the first wildcard or missing number
+	parts := []uint{}
+	wildcard := false
+	for _, s := range []string{subs[2], subs[4], subs[6]} {
+		n, err := strconv.ParseUint(s, 10, 64)
+		switch {
+		case err != nil:
+			wildcard = true
+		case wildcard: // A number cannot come after a wildcard (e.g. 1.x.3)
+			return nil, false
+		default:
+			parts = append(parts, uint(n))
+		}
+	}
+
+	// Pre-release and metadata identifiers are only allowed for a full version
+	if len(parts) < 3 && (prerelease != "" || metadata != "") {
+		return nil, false
+	}
+
+	var lower SemVer
+	if len(parts) > 0 {
+		lower.Major = parts[0]
+	}
+	if len(parts) > 1 {
+		lower.Minor = parts[1]
+	}
+	if len(parts) > 2 {
+		lower.Patch = parts[2]
+	}
+	if prerelease != "" {
+		lower.Prerelease = strings.Split(prerelease[1:], ".")
+	}
+	if metadata != "" {
+		lower.Metadata = strings.Split(metadata[1:], ".")
+	}
+
+	// upper returns the exclusive upper bound when the given number of leading parts are fixed.
+	upper := func(fixed int) SemVer {
+		var u SemVer
+		switch fixed {
+		case 1:
+			u = SemVer{Major: lower.Major + 1}
+		case 2:
+			u = SemVer{Major: lower.Major, Minor: lower.Minor + 1}
+		default:
+			u = SemVer{Major: lower.Major, Minor: lower.Minor, Patch: lower.Patch + 1}
+		}
+		// Exclude the pre-release versions of the upper bound
+		u.Prerelease = []string{"0"}
+		return u
+	}
+
+	// A wildcard matches any version regardless of the operator (except for the ones that can never match)
+	if len(parts) == 0 {
+		switch op {
+		case ">", "<", "!=":
+			return []comparator{{op: "<", version: SemVer{Prerelease: []string{"0"}}}}, true
+		default:
+			return []comparator{}, true
+		}
+	}
+
+	switch op {
+	case "", "=":
+		if len(parts) == 3 {
+			return []comparator{{op: "=", version: lower}}, true
+		}
+		return []comparator{{op: ">=", version: lower}, {op: "<", version: upper(len(parts))}}, true
+
+	case "!=":
+		if len(parts) == 3 {
+			return []comparator{{op: "!=", version: lower}}, true
+		}
+		// A partial version cannot be expressed as a single range when negated
+		return nil, false
+
+	case ">":
+		if len(parts) == 3 {
+			return []comparator{{op: ">", version: lower}}, true
+		}
+		u := upper(len(parts))
+		u.Prerelease = nil
+		return []comparator{{op: ">=", version: u}}, true
+
+	case ">=":
+		return []comparator{{op: ">=", version: lower}}, true
+
+	case "<":
+		if len(parts) == 3 {
+			return []comparator{{op: "<", version: lower}}, true
+		}
+		l := lower
+		l.Prerelease = []string{"0"}
+		return []comparator{{op: "<", version: l}}, true
+
+	case "<=":
+		if len(parts) == 3 {
+			return []comparator{{op: "<=", version: lower}}, true
+		}
+		return []comparator{{op: "<", version: upper(len(parts))}}, true
+
+	case "~":
+		fixed := 2
+		if len(parts) == 1 {
+			fixed = 1
+		}
+		return []comparator{{op: ">=", version: lower}, {op: "<", version: upper(fixed)}}, true
+
+	case "^":
+		// Changes are allowed as long as they do not modify the left-most non-zero part
+		var fixed int
+		switch {
+		case lower.Major > 0 || len(parts) == 1:
+			fixed = 1
+		case lower.Minor > 0 || len(parts) == 2:
+			fixed = 2
+		default:
+			fixed = 3
+		}
+		return []comparator{{op: ">=", version: lower}, {op: "<", version: upper(fixed)}}, true
+	}
+
+	return nil, false
+}
+
+// Check determines whether or not a semantic version satisfies the constraint.
+func (c Constraint) Check(v SemVer) bool {
+	for _, r := range c.ranges {
+		ok := true
+		for _, comp := range r {
+			if !comp.check(v) {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the original string representation of the constraint.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// Satisfies determines whether or not the current semantic version satisfies a given constraint.
+func (v SemVer) Satisfies(c Constraint) bool {
+	return c.Check(v)
+}
@@ -0,0 +1,243 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		name               string
+		constraint         string
+		expectedConstraint Constraint
+		expectedOK         bool
+	}{
+		{
+			name:               "Empty",
+			constraint:         "",
+			expectedConstraint: Constraint{},
+			expectedOK:         false,
+		},
+		{
+			name:               "EmptyRange",
+			constraint:         "1.0.0 ||",
+			expectedConstraint: Constraint{},
+			expectedOK:         false,
+		},
+		{
+			name:               "InvalidOperator",
+			constraint:         "=>1.0.0",
+			expectedConstraint: Constraint{},
+			expectedOK:         false,
+		},
+		{
+			name:               "InvalidVersion",
+			constraint:         ">=1.0.0.0",
+			expectedConstraint: Constraint{},
+			expectedOK:         false,
+		},
+		{
+			name:               "NumberAfterWildcard",
+			constraint:         "1.x.3",
+			expectedConstraint: Constraint{},
+			expectedOK:         false,
+		},
+		{
+			name:               "PrereleaseWithPartialVersion",
+			constraint:         ">=1.2-rc.1",
+			expectedConstraint: Constraint{},
+			expectedOK:         false,
+		},
+		{
+			name:               "NotEqualPartialVersion",
+			constraint:         "!=1.2",
+			expectedConstraint: Constraint{},
+			expectedOK:         false,
+		},
+		{
+			name:       "Exact",
+			constraint: "1.2.3",
+			expectedConstraint: Constraint{
+				raw: "1.2.3",
+				ranges: [][]comparator{
+					{
+						{op: "=", version: SemVer{Major: 1, Minor: 2, Patch: 3}},
+					},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name:       "Range",
+			constraint: ">= 1.2.0 < 2.0.0",
+			expectedConstraint: Constraint{
+				raw: ">= 1.2.0 < 2.0.0",
+				ranges: [][]comparator{
+					{
+						{op: ">=", version: SemVer{Major: 1, Minor: 2, Patch: 0}},
+						{op: "<", version: SemVer{Major: 2, Minor: 0, Patch: 0}},
+					},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name:       "CommaSeparatedRange",
+			constraint: ">=v1.2.0,<v2.0.0",
+			expectedConstraint: Constraint{
+				raw: ">=v1.2.0,<v2.0.0",
+				ranges: [][]comparator{
+					{
+						{op: ">=", version: SemVer{Major: 1, Minor: 2, Patch: 0}},
+						{op: "<", version: SemVer{Major: 2, Minor: 0, Patch: 0}},
+					},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name:       "Caret",
+			constraint: "^1.4",
+			expectedConstraint: Constraint{
+				raw: "^1.4",
+				ranges: [][]comparator{
+					{
+						{op: ">=", version: SemVer{Major: 1, Minor: 4, Patch: 0}},
+						{op: "<", version: SemVer{Major: 2, Minor: 0, Patch: 0, Prerelease: []string{"0"}}},
+					},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name:       "Tilde",
+			constraint: "~1.4.2",
+			expectedConstraint: Constraint{
+				raw: "~1.4.2",
+				ranges: [][]comparator{
+					{
+						{op: ">=", version: SemVer{Major: 1, Minor: 4, Patch: 2}},
+						{op: "<", version: SemVer{Major: 1, Minor: 5, Patch: 0, Prerelease: []string{"0"}}},
+					},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name:       "Alternatives",
+			constraint: "^0.2 || >=1.0.0-rc.1",
+			expectedConstraint: Constraint{
+				raw: "^0.2 || >=1.0.0-rc.1",
+				ranges: [][]comparator{
+					{
+						{op: ">=", version: SemVer{Major: 0, Minor: 2, Patch: 0}},
+						{op: "<", version: SemVer{Major: 0, Minor: 3, Patch: 0, Prerelease: []string{"0"}}},
+					},
+					{
+						{op: ">=", version: SemVer{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"rc", "1"}}},
+					},
+				},
+			},
+			expectedOK: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			constraint, ok := ParseConstraint(tc.constraint)
+
+			assert.Equal(t, tc.expectedConstraint, constraint)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestConstraint_Check(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"*", "0.0.0", true},
+		{"x", "1.2.3-rc.1", true},
+		{">*", "1.2.3", false},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.3+20200920", true},
+		{"=1.2.3", "1.2.4", false},
+		{"!=1.2.3", "1.2.3", false},
+		{"!=1.2.3", "1.2.4", true},
+		{"1.2", "1.2.0", true},
+		{"1.2.x", "1.2.9", true},
+		{"1.2", "1.3.0", false},
+		{"1", "1.9.9", true},
+		{"1", "2.0.0-alpha", false},
+		{">1.2.3", "1.2.3", false},
+		{">1.2.3", "1.2.4-alpha", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{">=1.2.3", "1.2.3", true},
+		{">=1.2.3", "1.2.3-rc.1", false},
+		{"<1.2.3", "1.2.3-rc.1", true},
+		{"<1.2", "1.2.0-alpha", false},
+		{"<1.2", "1.1.9", true},
+		{"<=1.2.3", "1.2.3", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0-alpha", false},
+		{">=1.2.0 <2.0.0", "1.9.9", true},
+		{">=1.2.0 <2.0.0", "2.0.0", false},
+		{">=1.2.0 <2.0.0", "1.1.9", false},
+		{"~1.4.2", "1.4.2", true},
+		{"~1.4.2", "1.4.9", true},
+		{"~1.4.2", "1.5.0", false},
+		{"~1.4.2", "1.4.1", false},
+		{"~1.4", "1.4.0", true},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"^1.4", "1.4.0", true},
+		{"^1.4", "1.9.9", true},
+		{"^1.4", "1.3.9", false},
+		{"^1.4", "2.0.0-rc.1", false},
+		{"^1.4", "2.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^0", "0.9.9", true},
+		{"^0", "1.0.0", false},
+		{"^1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"^1.2.3-beta.2", "1.2.3-beta.1", false},
+		{"^0.2 || ^1.0", "0.2.5", true},
+		{"^0.2 || ^1.0", "1.5.0", true},
+		{"^0.2 || ^1.0", "0.5.0", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.constraint+"_"+tc.version, func(t *testing.T) {
+			c, ok := ParseConstraint(tc.constraint)
+			assert.True(t, ok)
+
+			v, ok := Parse(tc.version)
+			assert.True(t, ok)
+
+			assert.Equal(t, tc.expected, c.Check(v))
+		})
+	}
+}
+
+func TestConstraint_String(t *testing.T) {
+	c, ok := ParseConstraint("  >=1.2.0 <2.0.0  ")
+
+	assert.True(t, ok)
+	assert.Equal(t, ">=1.2.0 <2.0.0", c.String())
+}
+
+func TestSemVer_Satisfies(t *testing.T) {
+	c, ok := ParseConstraint("^1.4")
+	assert.True(t, ok)
+
+	assert.True(t, SemVer{Major: 1, Minor: 4, Patch: 2}.Satisfies(c))
+	assert.False(t, SemVer{Major: 2, Minor: 0, Patch: 0}.Satisfies(c))
+}
@@ -49,3 +49,25 @@ func ExampleSemVer_ReleaseMajor() {
 	v := semver.SemVer{Major: 0, Minor: 1, Patch: 0}
 	fmt.Printf("Major Release: %s\n", v.ReleaseMajor())
 }
+
+func ExampleSemVer_Compare() {
+	v, _ := semver.Parse("1.0.0-rc.1")
+	u, _ := semver.Parse("1.0.0")
+	fmt.Printf("Compare: %d\n", v.Compare(u))
+}
+
+func ExampleSort() {
+	versions := []semver.SemVer{
+		{Major: 1, Minor: 0, Patch: 0},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"rc", "1"}},
+		{Major: 0, Minor: 10, Patch: 0},
+	}
+	semver.Sort(versions)
+	fmt.Printf("Sorted: %s\n", versions)
+}
+
+func ExampleParseConstraint() {
+	c, _ := semver.ParseConstraint(">=1.2.0 <2.0.0")
+	v, _ := semver.Parse("1.4.2")
+	fmt.Printf("%s satisfies %s: %t\n", v, c, v.Satisfies(c))
+}
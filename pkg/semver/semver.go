@@ -5,6 +5,7 @@ package semver
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -115,3 +116,126 @@ func (v SemVer) String() string {
 
 	return fmt.Sprintf("%d.%d.%d%s", v.Major, v.Minor, v.Patch, tail)
 }
+
+// Compare compares two semantic versions according to the precedence rules described in https://semver.org/#spec-item-11.
+// It returns -1 if v has lower precedence than u, 0 if they have the same precedence, and +1 if v has higher precedence than u.
+// Build metadata are ignored when determining precedence.
+func (v SemVer) Compare(u SemVer) int {
+	if c := compareUint(v.Major, u.Major); c != 0 {
+		return c
+	}
+
+	if c := compareUint(v.Minor, u.Minor); c != 0 {
+		return c
+	}
+
+	if c := compareUint(v.Patch, u.Patch); c != 0 {
+		return c
+	}
+
+	// A version without pre-release identifiers has a higher precedence than one with pre-release identifiers.
+	switch {
+	case len(v.Prerelease) == 0 && len(u.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(u.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(u.Prerelease); i++ {
+		if c := compareIdentifier(v.Prerelease[i], u.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A larger set of pre-release identifiers has a higher precedence than a smaller set if all of the preceding identifiers are equal.
+	return compareUint(uint(len(v.Prerelease)), uint(len(u.Prerelease)))
+}
+
+// Equal determines if two semantic versions have the same precedence.
+// Build metadata are ignored, so 1.0.0+20200920 and 1.0.0+20201020 are considered equal.
+func (v SemVer) Equal(u SemVer) bool {
+	return v.Compare(u) == 0
+}
+
+// LessThan determines if a semantic version has a lower precedence than another semantic version.
+func (v SemVer) LessThan(u SemVer) bool {
+	return v.Compare(u) < 0
+}
+
+// GreaterThan determines if a semantic version has a higher precedence than another semantic version.
+func (v SemVer) GreaterThan(u SemVer) bool {
+	return v.Compare(u) > 0
+}
+
+// IsPrerelease determines whether or not a semantic version is a pre-release version.
+func (v SemVer) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+func compareUint(a, b uint) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareIdentifier compares two pre-release identifiers.
+// Identifiers consisting of only digits are compared numerically and identifiers with letters or hyphens are compared lexically in ASCII sort order.
+// Numeric identifiers always have a lower precedence than non-numeric identifiers.
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareUint(uint(an), uint(bn))
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// SemVers is a list of semantic versions.
+// It implements the sort.Interface for sorting semantic versions in ascending order of precedence.
+type SemVers []SemVer
+
+func (s SemVers) Len() int           { return len(s) }
+func (s SemVers) Less(i, j int) bool { return s[i].LessThan(s[j]) }
+func (s SemVers) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Max returns the semantic version with the highest precedence.
+// If the list is empty, the second return value will be false.
+func (s SemVers) Max() (SemVer, bool) {
+	if len(s) == 0 {
+		return SemVer{}, false
+	}
+
+	max := s[0]
+	for _, v := range s[1:] {
+		if v.GreaterThan(max) {
+			max = v
+		}
+	}
+
+	return max, true
+}
+
+// Sort sorts a list of semantic versions in ascending order of precedence.
+// The sort is stable, so semantic versions with equal precedence (differing only in metadata) keep their original order.
+func Sort(versions []SemVer) {
+	sort.Stable(SemVers(versions))
+}
+
+// SortDesc sorts a list of semantic versions in descending order of precedence.
+func SortDesc(versions []SemVer) {
+	sort.Stable(sort.Reverse(SemVers(versions)))
+}
@@ -495,3 +495,226 @@ func TestSemVer_String(t *testing.T) {
 		})
 	}
 }
+
+func TestSemVer_Compare(t *testing.T) {
+	tests := []struct {
+		name            string
+		v, u            string
+		expectedCompare int
+	}{
+		{"Equal", "1.0.0", "1.0.0", 0},
+		{"EqualWithMetadata", "1.0.0+20200920", "1.0.0+20201020", 0},
+		{"EqualWithPrerelease", "1.0.0-rc.1", "1.0.0-rc.1", 0},
+		{"MajorLess", "1.0.0", "2.0.0", -1},
+		{"MajorGreater", "2.0.0", "1.9.9", 1},
+		{"MinorLess", "2.0.0", "2.1.0", -1},
+		{"MinorGreater", "2.10.0", "2.9.0", 1},
+		{"PatchLess", "2.1.0", "2.1.1", -1},
+		{"PatchGreater", "2.1.10", "2.1.2", 1},
+		{"PrereleaseLessThanRelease", "1.0.0-alpha", "1.0.0", -1},
+		{"ReleaseGreaterThanPrerelease", "1.0.0", "1.0.0-rc.1", 1},
+		{"AlphaLessThanAlphaOne", "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"AlphaOneLessThanAlphaBeta", "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"AlphaBetaLessThanBeta", "1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"BetaLessThanBetaTwo", "1.0.0-beta", "1.0.0-beta.2", -1},
+		{"BetaTwoLessThanBetaEleven", "1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"BetaElevenLessThanRCOne", "1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"NumericLessThanAlphanumeric", "1.0.0-1", "1.0.0-a", -1},
+		{"AlphanumericGreaterThanNumeric", "1.0.0-a", "1.0.0-1", 1},
+		{"LexicalOrder", "1.0.0-rc-2", "1.0.0-rc-10", 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v, ok := Parse(tc.v)
+			assert.True(t, ok)
+
+			u, ok := Parse(tc.u)
+			assert.True(t, ok)
+
+			assert.Equal(t, tc.expectedCompare, v.Compare(u))
+			assert.Equal(t, -tc.expectedCompare, u.Compare(v))
+		})
+	}
+}
+
+func TestSemVer_Equal(t *testing.T) {
+	tests := []struct {
+		name          string
+		v, u          SemVer
+		expectedEqual bool
+	}{
+		{
+			name:          "Equal",
+			v:             SemVer{Major: 0, Minor: 1, Patch: 0},
+			u:             SemVer{Major: 0, Minor: 1, Patch: 0},
+			expectedEqual: true,
+		},
+		{
+			name:          "EqualWithDifferentMetadata",
+			v:             SemVer{Major: 0, Minor: 1, Patch: 0, Metadata: []string{"20200920"}},
+			u:             SemVer{Major: 0, Minor: 1, Patch: 0, Metadata: []string{"20201020"}},
+			expectedEqual: true,
+		},
+		{
+			name:          "NotEqual",
+			v:             SemVer{Major: 0, Minor: 1, Patch: 0},
+			u:             SemVer{Major: 0, Minor: 1, Patch: 0, Prerelease: []string{"rc", "1"}},
+			expectedEqual: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedEqual, tc.v.Equal(tc.u))
+		})
+	}
+}
+
+func TestSemVer_LessThan(t *testing.T) {
+	tests := []struct {
+		name         string
+		v, u         SemVer
+		expectedLess bool
+	}{
+		{
+			name:         "Less",
+			v:            SemVer{Major: 0, Minor: 1, Patch: 0, Prerelease: []string{"rc", "1"}},
+			u:            SemVer{Major: 0, Minor: 1, Patch: 0},
+			expectedLess: true,
+		},
+		{
+			name:         "Equal",
+			v:            SemVer{Major: 0, Minor: 1, Patch: 0},
+			u:            SemVer{Major: 0, Minor: 1, Patch: 0},
+			expectedLess: false,
+		},
+		{
+			name:         "Greater",
+			v:            SemVer{Major: 0, Minor: 2, Patch: 0},
+			u:            SemVer{Major: 0, Minor: 1, Patch: 9},
+			expectedLess: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedLess, tc.v.LessThan(tc.u))
+		})
+	}
+}
+
+func TestSemVer_GreaterThan(t *testing.T) {
+	tests := []struct {
+		name            string
+		v, u            SemVer
+		expectedGreater bool
+	}{
+		{
+			name:            "Less",
+			v:               SemVer{Major: 0, Minor: 1, Patch: 0, Prerelease: []string{"rc", "1"}},
+			u:               SemVer{Major: 0, Minor: 1, Patch: 0},
+			expectedGreater: false,
+		},
+		{
+			name:            "Equal",
+			v:               SemVer{Major: 0, Minor: 1, Patch: 0},
+			u:               SemVer{Major: 0, Minor: 1, Patch: 0},
+			expectedGreater: false,
+		},
+		{
+			name:            "Greater",
+			v:               SemVer{Major: 0, Minor: 2, Patch: 0},
+			u:               SemVer{Major: 0, Minor: 1, Patch: 9},
+			expectedGreater: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedGreater, tc.v.GreaterThan(tc.u))
+		})
+	}
+}
+
+func TestSemVer_IsPrerelease(t *testing.T) {
+	assert.False(t, SemVer{Major: 0, Minor: 1, Patch: 0}.IsPrerelease())
+	assert.False(t, SemVer{Major: 0, Minor: 1, Patch: 0, Metadata: []string{"20200920"}}.IsPrerelease())
+	assert.True(t, SemVer{Major: 0, Minor: 1, Patch: 0, Prerelease: []string{"rc", "1"}}.IsPrerelease())
+}
+
+func TestSemVers_Max(t *testing.T) {
+	tests := []struct {
+		name        string
+		versions    SemVers
+		expectedMax SemVer
+		expectedOK  bool
+	}{
+		{
+			name:        "Empty",
+			versions:    SemVers{},
+			expectedMax: SemVer{},
+			expectedOK:  false,
+		},
+		{
+			name: "OK",
+			versions: SemVers{
+				{Major: 0, Minor: 9, Patch: 0},
+				{Major: 0, Minor: 10, Patch: 0, Prerelease: []string{"rc", "1"}},
+				{Major: 0, Minor: 10, Patch: 0},
+				{Major: 0, Minor: 2, Patch: 7},
+			},
+			expectedMax: SemVer{Major: 0, Minor: 10, Patch: 0},
+			expectedOK:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			max, ok := tc.versions.Max()
+
+			assert.Equal(t, tc.expectedMax, max)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	versions := []SemVer{
+		{Major: 1, Minor: 0, Patch: 0},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"beta", "11"}},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"alpha"}},
+		{Major: 0, Minor: 10, Patch: 0},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"beta", "2"}},
+		{Major: 0, Minor: 9, Patch: 1},
+	}
+
+	Sort(versions)
+
+	assert.Equal(t, []SemVer{
+		{Major: 0, Minor: 9, Patch: 1},
+		{Major: 0, Minor: 10, Patch: 0},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"alpha"}},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"beta", "2"}},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"beta", "11"}},
+		{Major: 1, Minor: 0, Patch: 0},
+	}, versions)
+}
+
+func TestSortDesc(t *testing.T) {
+	versions := []SemVer{
+		{Major: 0, Minor: 9, Patch: 1},
+		{Major: 1, Minor: 0, Patch: 0},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"rc", "1"}},
+		{Major: 0, Minor: 10, Patch: 0},
+	}
+
+	SortDesc(versions)
+
+	assert.Equal(t, []SemVer{
+		{Major: 1, Minor: 0, Patch: 0},
+		{Major: 1, Minor: 0, Patch: 0, Prerelease: []string{"rc", "1"}},
+		{Major: 0, Minor: 10, Patch: 0},
+		{Major: 0, Minor: 9, Patch: 1},
+	}, versions)
+}
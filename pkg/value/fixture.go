@@ -0,0 +1,51 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	firstNames = []string{"Alice", "Bob", "Carol", "Dave", "Erin", "Frank", "Grace", "Heidi", "Ivan", "Judy", "Mallory", "Oscar"}
+	lastNames  = []string{"Anderson", "Brown", "Clark", "Davis", "Evans", "Garcia", "Harris", "Jones", "Lee", "Miller", "Smith", "Young"}
+	domains    = []string{"example.com", "example.org", "example.net"}
+)
+
+// Name returns a random full name.
+func Name() string {
+	return pick(firstNames) + " " + pick(lastNames)
+}
+
+// Email returns a random email address.
+func Email() string {
+	user := fmt.Sprintf("%s.%s%d", pick(firstNames), pick(lastNames), intn(100))
+	return strings.ToLower(user) + "@" + pick(domains)
+}
+
+// UUID returns a random version 4 UUID.
+func UUID() string {
+	b := make([]byte, 16)
+	for i := range b {
+		b[i] = Byte()
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // Variant 10
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// URL returns a random URL.
+func URL() string {
+	return fmt.Sprintf("https://%s/%s", pick(domains), String())
+}
+
+// Phone returns a random phone number.
+func Phone() string {
+	return fmt.Sprintf("+1-555-%03d-%04d", intn(1000), intn(10000))
+}
+
+// IP returns a random private IPv4 address.
+func IP() string {
+	return fmt.Sprintf("10.%d.%d.%d", intn(256), intn(256), 1+intn(254))
+}
@@ -0,0 +1,64 @@
+package value
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixtures(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() string
+		pattern  string
+	}{
+		{
+			name:     "Name",
+			generate: Name,
+			pattern:  `^[A-Z][a-z]+ [A-Z][a-z]+$`,
+		},
+		{
+			name:     "Email",
+			generate: Email,
+			pattern:  `^[a-z]+\.[a-z]+[0-9]{1,2}@example\.(com|org|net)$`,
+		},
+		{
+			name:     "UUID",
+			generate: UUID,
+			pattern:  `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+		},
+		{
+			name:     "URL",
+			generate: URL,
+			pattern:  `^https://example\.(com|org|net)/[a-z0-9]{10}$`,
+		},
+		{
+			name:     "Phone",
+			generate: Phone,
+			pattern:  `^\+1-555-[0-9]{3}-[0-9]{4}$`,
+		},
+		{
+			name:     "IP",
+			generate: IP,
+			pattern:  `^10\.[0-9]{1,3}\.[0-9]{1,3}\.[0-9]{1,3}$`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				assert.Regexp(t, regexp.MustCompile(tc.pattern), tc.generate())
+			}
+		})
+	}
+}
+
+func TestFixtures_Parse(t *testing.T) {
+	_, err := url.Parse(URL())
+	assert.NoError(t, err)
+
+	assert.NotNil(t, net.ParseIP(IP()))
+}
@@ -0,0 +1,176 @@
+// Package value provides the runtime support for the builders generated by Gelato.
+// It generates pseudo-random values for initializing the fields of structs.
+// The values are reproducible by seeding the generator.
+package value
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+var (
+	// mutex guards the generator, so values can be generated concurrently.
+	mutex sync.Mutex
+	rnd   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Seed seeds the generator of values.
+// The same values are generated in the same order after seeding with the same seed.
+func Seed(seed int64) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	rnd = rand.New(rand.NewSource(seed))
+}
+
+func intn(n int) int {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return rnd.Intn(n)
+}
+
+func int31() int32 {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return rnd.Int31()
+}
+
+func uint64n() uint64 {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return rnd.Uint64()
+}
+
+func float64n() float64 {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return rnd.Float64()
+}
+
+func pick(list []string) string {
+	return list[intn(len(list))]
+}
+
+// Ptr returns a pointer to a value.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Error returns a random error.
+func Error() error {
+	return errors.New(String())
+}
+
+// Bool returns a random bool.
+func Bool() bool {
+	return intn(2) == 1
+}
+
+// String returns a random alphanumeric string.
+func String() string {
+	b := make([]byte, 10)
+	for i := range b {
+		b[i] = letters[intn(len(letters))]
+	}
+
+	return string(b)
+}
+
+// Byte returns a random byte.
+func Byte() byte {
+	return byte(intn(1 << 8))
+}
+
+// Rune returns a random lowercase letter.
+func Rune() rune {
+	return rune('a' + intn(26))
+}
+
+// Int returns a random non-negative int.
+func Int() int {
+	return int(int31())
+}
+
+// Int8 returns a random non-negative int8.
+func Int8() int8 {
+	return int8(intn(1 << 7))
+}
+
+// Int16 returns a random non-negative int16.
+func Int16() int16 {
+	return int16(intn(1 << 15))
+}
+
+// Int32 returns a random non-negative int32.
+func Int32() int32 {
+	return int31()
+}
+
+// Int64 returns a random non-negative int64.
+func Int64() int64 {
+	return int64(uint64n() >> 1)
+}
+
+// Uint returns a random uint.
+func Uint() uint {
+	return uint(int31())
+}
+
+// Uint8 returns a random uint8.
+func Uint8() uint8 {
+	return uint8(intn(1 << 8))
+}
+
+// Uint16 returns a random uint16.
+func Uint16() uint16 {
+	return uint16(intn(1 << 16))
+}
+
+// Uint32 returns a random uint32.
+func Uint32() uint32 {
+	return uint32(uint64n() >> 32)
+}
+
+// Uint64 returns a random uint64.
+func Uint64() uint64 {
+	return uint64n()
+}
+
+// Uintptr returns a random uintptr.
+func Uintptr() uintptr {
+	return uintptr(int31())
+}
+
+// Float32 returns a random float32 in [0, 1000).
+func Float32() float32 {
+	return float32(float64n() * 1000)
+}
+
+// Float64 returns a random float64 in [0, 1000).
+func Float64() float64 {
+	return float64n() * 1000
+}
+
+// Complex64 returns a random complex64.
+func Complex64() complex64 {
+	return complex(Float32(), Float32())
+}
+
+// Complex128 returns a random complex128.
+func Complex128() complex128 {
+	return complex(Float64(), Float64())
+}
+
+// Time returns a random time between 2000 and 2030 in UTC.
+func Time() time.Time {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration(intn(30*365*24*60*60)) * time.Second)
+}
@@ -0,0 +1,69 @@
+package value
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeed(t *testing.T) {
+	generate := func() []interface{} {
+		return []interface{}{
+			Bool(), String(), Int(), Int64(), Uint64(), Float64(), Complex128(), Time(), Email(), UUID(),
+		}
+	}
+
+	Seed(42)
+	first := generate()
+
+	Seed(42)
+	second := generate()
+
+	Seed(27)
+	third := generate()
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, third)
+}
+
+func TestPtr(t *testing.T) {
+	p := Ptr("foo")
+
+	assert.Equal(t, "foo", *p)
+}
+
+func TestValues(t *testing.T) {
+	assert.Error(t, Error())
+	assert.Len(t, String(), 10)
+	assert.True(t, Rune() >= 'a' && Rune() <= 'z')
+	assert.True(t, Int() >= 0)
+	assert.True(t, Int8() >= 0)
+	assert.True(t, Int16() >= 0)
+	assert.True(t, Int32() >= 0)
+	assert.True(t, Int64() >= 0)
+	assert.True(t, Float32() < 1000)
+	assert.True(t, Float64() < 1000)
+	assert.True(t, real(Complex64()) < 1000)
+
+	tm := Time()
+	assert.True(t, tm.Year() >= 2000 && tm.Year() < 2031)
+	assert.Equal(t, time.UTC, tm.Location())
+}
+
+func TestValues_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%10 == 0 {
+				Seed(int64(i))
+			}
+			_, _, _ = String(), Uint(), Uintptr()
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+
+	"horizontal/cli/internal/controller"
+	"horizontal/cli/internal/entity"
+)
+
+const (
+	greetSynopsis = `Greet someone`
+	greetHelp     = `
+  Use this command for greeting someone.
+
+  Usage:  greet [flags]
+
+  Flags:
+    -name    the name of the person to greet (prompted if not provided)
+    -yell    greet in uppercase
+
+  Examples:
+    greet
+    greet -name=Jane
+    greet -name=Jane -yell
+  `
+)
+
+// GreetCommand is the cli.Command implementation for greet command.
+type GreetCommand struct {
+	ui         cli.Ui
+	controller controller.GreetingController
+}
+
+// NewGreetCommand creates a greet command.
+func NewGreetCommand(ui cli.Ui, controller controller.GreetingController) (*GreetCommand, error) {
+	return &GreetCommand{
+		ui:         ui,
+		controller: controller,
+	}, nil
+}
+
+// Synopsis returns a short one-line synopsis of the command.
+func (c *GreetCommand) Synopsis() string {
+	return greetSynopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *GreetCommand) Help() string {
+	return greetHelp
+}
+
+// Run runs the actual command with the given command-line arguments.
+func (c *GreetCommand) Run(args []string) int {
+	req := new(entity.GreetRequest)
+
+	fs := flag.NewFlagSet("greet", flag.ContinueOnError)
+	fs.StringVar(&req.Name, "name", "", "")
+	fs.BoolVar(&req.Yell, "yell", false, "")
+	fs.Usage = func() {
+		c.ui.Output(c.Help())
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return FlagError
+	}
+
+	if req.Name == "" {
+		name, err := c.ui.Ask("Name:")
+		if err != nil {
+			c.ui.Error(fmt.Sprintf("invalid name: %s", err))
+			return InputError
+		}
+		req.Name = name
+	}
+
+	resp, err := c.controller.Greet(context.Background(), req)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return InputError
+	}
+
+	c.ui.Info(resp.Greeting)
+
+	return Success
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+
+	"horizontal/cli/internal/entity"
+)
+
+func TestNewGreetCommand(t *testing.T) {
+	ui := cli.NewMockUi()
+	c, err := NewGreetCommand(ui, &MockGreetingController{})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestGreetCommand_Synopsis(t *testing.T) {
+	c := new(GreetCommand)
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestGreetCommand_Help(t *testing.T) {
+	c := new(GreetCommand)
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestGreetCommand_Run(t *testing.T) {
+	tests := []struct {
+		name             string
+		controller       *MockGreetingController
+		args             []string
+		inputs           string
+		expectedExitCode int
+		expectedRequest  *entity.GreetRequest
+		expectedOutput   string
+	}{
+		{
+			name:             "UndefinedFlag",
+			controller:       &MockGreetingController{},
+			args:             []string{"-undefined"},
+			expectedExitCode: FlagError,
+		},
+		{
+			name:             "NoInput",
+			controller:       &MockGreetingController{},
+			args:             []string{},
+			expectedExitCode: InputError,
+		},
+		{
+			name: "GreetFails",
+			controller: &MockGreetingController{
+				GreetMocks: []GreetMock{
+					{OutError: errors.New("name is required")},
+				},
+			},
+			args:             []string{},
+			inputs:           "\n",
+			expectedExitCode: InputError,
+			expectedRequest:  &entity.GreetRequest{},
+		},
+		{
+			name: "Prompt",
+			controller: &MockGreetingController{
+				GreetMocks: []GreetMock{
+					{OutResponse: &entity.GreetResponse{Greeting: "Hello, Jane!"}},
+				},
+			},
+			args:             []string{},
+			inputs:           "Jane\n",
+			expectedExitCode: Success,
+			expectedRequest:  &entity.GreetRequest{Name: "Jane"},
+			expectedOutput:   "Hello, Jane!\n",
+		},
+		{
+			name: "Yell",
+			controller: &MockGreetingController{
+				GreetMocks: []GreetMock{
+					{OutResponse: &entity.GreetResponse{Greeting: "HELLO, JANE!"}},
+				},
+			},
+			args:             []string{"-name=Jane", "-yell"},
+			expectedExitCode: Success,
+			expectedRequest:  &entity.GreetRequest{Name: "Jane", Yell: true},
+			expectedOutput:   "HELLO, JANE!\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			ui.InputReader = strings.NewReader(tc.inputs)
+
+			c, err := NewGreetCommand(ui, tc.controller)
+			assert.NoError(t, err)
+
+			exitCode := c.Run(tc.args)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Contains(t, ui.OutputWriter.String(), tc.expectedOutput)
+
+			if tc.expectedRequest != nil {
+				assert.Equal(t, tc.expectedRequest, tc.controller.GreetMocks[0].InRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultGreeting = "Hello"
+
+// Config is the configurations for the application.
+type Config struct {
+	// Greeting is the word used for greeting.
+	Greeting string `yaml:"greeting"`
+	// NoColor disables the colored output.
+	NoColor bool `yaml:"no_color"`
+}
+
+// Default returns the default configurations.
+func Default() Config {
+	return Config{
+		Greeting: defaultGreeting,
+	}
+}
+
+// FromFile reads the configurations from a file named .<name>.yaml.
+// The file is first looked up in the current directory and then in the home directory.
+// If no file is found, the default configurations will be returned.
+func FromFile(name string) (Config, error) {
+	dirs := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, "."+name+".yaml")
+		if _, err := os.Stat(path); err == nil {
+			return Read(path)
+		}
+	}
+
+	return Default(), nil
+}
+
+// Read reads the configurations from a YAML file.
+// The missing values are set to their defaults.
+func Read(path string) (Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config := Default()
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"horizontal/cli/internal/entity"
+)
+
+// GreetingController is the interface for greeting business logic.
+type GreetingController interface {
+	Greet(context.Context, *entity.GreetRequest) (*entity.GreetResponse, error)
+}
+
+// greetingController implements GreetingController interface.
+type greetingController struct {
+	greeting string
+}
+
+// NewGreetingController creates a new instance of GreetingController.
+func NewGreetingController(greeting string) (GreetingController, error) {
+	return &greetingController{
+		greeting: greeting,
+	}, nil
+}
+
+// Greet creates and returns a greeting for a given name!
+func (c *greetingController) Greet(ctx context.Context, req *entity.GreetRequest) (*entity.GreetResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	greeting := fmt.Sprintf("%s, %s!", c.greeting, name)
+	if req.Yell {
+		greeting = strings.ToUpper(greeting)
+	}
+
+	resp := &entity.GreetResponse{
+		Greeting: greeting,
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"horizontal/cli/internal/entity"
+)
+
+func TestNewGreetingController(t *testing.T) {
+	tests := []struct {
+		name     string
+		greeting string
+	}{
+		{
+			name:     "OK",
+			greeting: "Hello",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller, err := NewGreetingController(tc.greeting)
+
+			assert.NoError(t, err)
+			assert.NotNil(t, controller)
+		})
+	}
+}
+
+func TestGreetingController_Greet(t *testing.T) {
+	tests := []struct {
+		name             string
+		greeting         string
+		ctx              context.Context
+		req              *entity.GreetRequest
+		expectedResponse *entity.GreetResponse
+		expectedError    string
+	}{
+		{
+			name:          "NoName",
+			greeting:      "Hello",
+			ctx:           context.Background(),
+			req:           &entity.GreetRequest{Name: " "},
+			expectedError: "name is required",
+		},
+		{
+			name:             "Success",
+			greeting:         "Hi",
+			ctx:              context.Background(),
+			req:              &entity.GreetRequest{Name: "Jane"},
+			expectedResponse: &entity.GreetResponse{Greeting: "Hi, Jane!"},
+		},
+		{
+			name:             "Yell",
+			greeting:         "Hi",
+			ctx:              context.Background(),
+			req:              &entity.GreetRequest{Name: "Jane", Yell: true},
+			expectedResponse: &entity.GreetResponse{Greeting: "HI, JANE!"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &greetingController{
+				greeting: tc.greeting,
+			}
+
+			resp, err := controller.Greet(tc.ctx, tc.req)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResponse, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"runtime/debug"
+
+	"github.com/mitchellh/cli"
+
+	"horizontal/cli/internal/command"
+	"horizontal/cli/internal/config"
+	"horizontal/cli/internal/controller"
+	"horizontal/cli/version"
+)
+
+// name is the application name.
+var name = "cli"
+
+func init() {
+	// The application name is the last element of the module path
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path != "" {
+		name = path.Base(info.Main.Path)
+	}
+}
+
+func newUI(config config.Config) cli.Ui {
+	var ui cli.Ui = &cli.BasicUi{
+		Reader:      os.Stdin,
+		Writer:      os.Stdout,
+		ErrorWriter: os.Stderr,
+	}
+
+	// See https://no-color.org
+	if _, ok := os.LookupEnv("NO_COLOR"); !ok && !config.NoColor {
+		ui = &cli.ColoredUi{
+			Ui:          ui,
+			OutputColor: cli.UiColorNone,
+			InfoColor:   cli.UiColorGreen,
+			WarnColor:   cli.UiColorYellow,
+			ErrorColor:  cli.UiColorRed,
+		}
+	}
+
+	return &cli.ConcurrentUi{
+		Ui: ui,
+	}
+}
+
+func main() {
+	// Read the configurations from file if any
+	config, err := config.FromFile(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot read the config file: %s\n", err)
+		os.Exit(command.ConfigError)
+	}
+
+	ui := newUI(config)
+
+	greetingController, err := controller.NewGreetingController(config.Greeting)
+	if err != nil {
+		ui.Error(fmt.Sprintf("Cannot create greeting controller: %s", err))
+		os.Exit(command.ConfigError)
+	}
+
+	c := cli.NewCLI(name, version.String())
+	c.Args = os.Args[1:]
+	c.Commands = map[string]cli.CommandFactory{
+		"greet": func() (cli.Command, error) {
+			return command.NewGreetCommand(ui, greetingController)
+		},
+	}
+
+	code, err := c.Run()
+	if err != nil {
+		ui.Error(err.Error())
+	}
+
+	os.Exit(code)
+}
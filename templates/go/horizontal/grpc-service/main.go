@@ -3,6 +3,8 @@ package main
 import (
 	"flag"
 	"os"
+	"path"
+	"runtime/debug"
 
 	"github.com/moorara/graceful"
 	"github.com/moorara/health"
@@ -37,6 +39,13 @@ var config = struct {
 	LogLevel:    "debug", // default
 }
 
+func init() {
+	// The application name is the last element of the module path
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path != "" {
+		config.Name = path.Base(info.Main.Path)
+	}
+}
+
 func main() {
 	// Get configurations
 	_ = konfig.Pick(&config)
@@ -1,12 +1,15 @@
-package idl
+// DO NOT EDIT
+
+// Code generated by Gelato from idl/greeting.yaml
 
-/*
-The files in this package should ideally be auto-generated
-from the OpenAPI specifications in the top-level `idl` directory.
-*/
+package idl
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -25,6 +28,7 @@ type GreetResponse struct {
 
 // GreetingHandler is the interface for greeting handler functions.
 type GreetingHandler interface {
+	// Greet greets a name.
 	Greet(http.ResponseWriter, *http.Request)
 }
 
@@ -39,3 +43,57 @@ func RegisterGreetingHandler(router *mux.Router, handler GreetingHandler, middle
 
 	router.Name("Greet").Methods("POST").Path("/v1/greet").HandlerFunc(greetHandler)
 }
+
+// GreetingClient is a typed HTTP client for Greeting API.
+type GreetingClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewGreetingClient creates a new greeting client.
+// The base URL is the address of the server (e.g. http://localhost:4000).
+// If the HTTP client is nil, the default HTTP client will be used.
+func NewGreetingClient(baseURL string, client *http.Client) *GreetingClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &GreetingClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+}
+
+// Greet greets a name.
+func (c *GreetingClient) Greet(ctx context.Context, req *GreetRequest) (*GreetResponse, error) {
+	u := c.baseURL + "/v1/greet"
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, xhttp.NewClientError(resp)
+	}
+
+	out := new(GreetResponse)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
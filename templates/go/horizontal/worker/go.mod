@@ -0,0 +1,12 @@
+module horizontal/worker
+
+go 1.15
+
+require (
+	github.com/moorara/graceful v0.1.1
+	github.com/moorara/health v0.1.0
+	github.com/moorara/konfig v0.4.4
+	github.com/moorara/observer v0.3.4
+	github.com/stretchr/testify v1.7.0
+	go.uber.org/zap v1.19.0
+)
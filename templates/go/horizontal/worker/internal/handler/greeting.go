@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"horizontal/worker/internal/controller"
+	"horizontal/worker/internal/idl"
+	"horizontal/worker/internal/mapper"
+	"horizontal/worker/pkg/queue"
+)
+
+// GreetingHandler is an alias for the queue message handler interface.
+type GreetingHandler = queue.Handler
+
+// greetingHandler implements GreetingHandler (queue.Handler) interface.
+type greetingHandler struct {
+	greetingController controller.GreetingController
+}
+
+// NewGreetingHandler creates a new instance of GreetingHandler.
+func NewGreetingHandler(greetingController controller.GreetingController) (GreetingHandler, error) {
+	return &greetingHandler{
+		greetingController: greetingController,
+	}, nil
+}
+
+// Handle is the handler for Greet messages.
+func (h *greetingHandler) Handle(ctx context.Context, msg *queue.Message) error {
+	req := new(idl.GreetRequest)
+	if err := json.Unmarshal(msg.Body, req); err != nil {
+		return err
+	}
+
+	domainReq, err := mapper.GreetRequestIDLToDomain(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.greetingController.Greet(ctx, domainReq)
+	return err
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"horizontal/worker/internal/controller"
+	"horizontal/worker/internal/entity"
+	"horizontal/worker/pkg/queue"
+)
+
+func TestNewGreetingHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		greetingController controller.GreetingController
+	}{
+		{
+			name:               "OK",
+			greetingController: &MockGreetingController{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, err := NewGreetingHandler(tc.greetingController)
+
+			assert.NoError(t, err)
+			assert.NotNil(t, handler)
+		})
+	}
+}
+
+func TestGreetingHandlerHandle(t *testing.T) {
+	tests := []struct {
+		name                   string
+		mockGreetingController *MockGreetingController
+		ctx                    context.Context
+		msg                    *queue.Message
+		expectedError          string
+	}{
+		{
+			name:          "MessageDecodingFails",
+			ctx:           context.Background(),
+			msg:           &queue.Message{Body: []byte(`{`)},
+			expectedError: "unexpected end of JSON input",
+		},
+		{
+			name:          "MessageMappingFails",
+			ctx:           context.Background(),
+			msg:           &queue.Message{Body: []byte(`{ "name": "" }`)},
+			expectedError: "name cannot be empty",
+		},
+		{
+			name: "ControllerFails",
+			mockGreetingController: &MockGreetingController{
+				GreetMocks: []GreetMock{
+					{OutError: errors.New("controller failed")},
+				},
+			},
+			ctx:           context.Background(),
+			msg:           &queue.Message{Body: []byte(`{ "name": "Jane" }`)},
+			expectedError: "controller failed",
+		},
+		{
+			name: "Success",
+			mockGreetingController: &MockGreetingController{
+				GreetMocks: []GreetMock{
+					{
+						OutResponse: &entity.GreetResponse{
+							Greeting: "Hello, Jane!",
+						},
+					},
+				},
+			},
+			ctx:           context.Background(),
+			msg:           &queue.Message{Body: []byte(`{ "name": "Jane" }`)},
+			expectedError: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &greetingHandler{
+				greetingController: tc.mockGreetingController,
+			}
+
+			err := h.Handle(tc.ctx, tc.msg)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
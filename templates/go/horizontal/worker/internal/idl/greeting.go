@@ -0,0 +1,11 @@
+package idl
+
+/*
+The files in this package should ideally be auto-generated
+from the message schemas shared with the producers of the messages.
+*/
+
+// GreetRequest is the message (wire/transport protocol) model for a Greet request.
+type GreetRequest struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,23 @@
+package mapper
+
+import (
+	"errors"
+
+	"horizontal/worker/internal/entity"
+	"horizontal/worker/internal/idl"
+)
+
+// GreetRequestIDLToDomain transforms the IDL-specific (wire or transport protocol) representation of GreetRequest to its domain-specific representation.
+func GreetRequestIDLToDomain(req *idl.GreetRequest) (*entity.GreetRequest, error) {
+	if req == nil {
+		return nil, errors.New("greet request cannot be nil")
+	}
+
+	if req.Name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+
+	return &entity.GreetRequest{
+		Name: req.Name,
+	}, nil
+}
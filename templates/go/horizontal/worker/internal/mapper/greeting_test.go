@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"errors"
+	"testing"
+
+	"horizontal/worker/internal/entity"
+	"horizontal/worker/internal/idl"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreetRequestIDLToDomain(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *idl.GreetRequest
+		expectedReq   *entity.GreetRequest
+		expectedError error
+	}{
+		{
+			name:          "NilRequest",
+			req:           nil,
+			expectedReq:   nil,
+			expectedError: errors.New("greet request cannot be nil"),
+		},
+		{
+			name:          "EmptyName",
+			req:           &idl.GreetRequest{},
+			expectedReq:   nil,
+			expectedError: errors.New("name cannot be empty"),
+		},
+		{
+			name: "OK",
+			req: &idl.GreetRequest{
+				Name: "Jane",
+			},
+			expectedReq: &entity.GreetRequest{
+				Name: "Jane",
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := GreetRequestIDLToDomain(tc.req)
+
+			assert.Equal(t, tc.expectedReq, req)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"horizontal/worker/pkg/queue"
+)
+
+const (
+	defaultWorkerConcurrency = 1
+	defaultWorkerMaxRetries  = 5
+	defaultWorkerBackoff     = 100 * time.Millisecond
+	maxWorkerBackoff         = 30 * time.Second
+)
+
+// Worker is a message consumer implementing graceful.Server interface.
+type Worker struct {
+	queue       queue.Queue
+	handler     queue.Handler
+	concurrency int
+	maxRetries  int
+	backoff     time.Duration
+
+	// The number of times each in-flight message has failed by message id
+	failures   map[string]int
+	failuresMu sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// WorkerOptions are optional settings for creating a worker.
+type WorkerOptions struct {
+	// The number of messages processed concurrently.
+	// The default concurrency is 1.
+	Concurrency int
+	// The maximum number of times a failed message is delivered again before it is dropped.
+	// The default is 5.
+	MaxRetries int
+	// The delay before a failed message is delivered again, doubled after each failure up to 30s.
+	// The default backoff is 100ms.
+	Backoff time.Duration
+	// Middleware are applied from left to right (the first middleware is the most inner and the last middleware is the most outter).
+	Middleware []queue.Middleware
+}
+
+// NewWorker creates a new instance of Worker.
+func NewWorker(q queue.Queue, handler queue.Handler, opts WorkerOptions) (*Worker, error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultWorkerConcurrency
+	}
+
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = defaultWorkerMaxRetries
+	}
+
+	if opts.Backoff <= 0 {
+		opts.Backoff = defaultWorkerBackoff
+	}
+
+	for _, mid := range opts.Middleware {
+		handler = mid.Wrap(handler)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Worker{
+		queue:       q,
+		handler:     handler,
+		concurrency: opts.Concurrency,
+		maxRetries:  opts.MaxRetries,
+		backoff:     opts.Backoff,
+		failures:    map[string]int{},
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// String returns the name of the server.
+func (w *Worker) String() string {
+	return "worker"
+}
+
+// ListenAndServe starts receiving and processing messages synchronously.
+// It blocks the current goroutine until the worker is shut down or an error is returned.
+func (w *Worker) ListenAndServe() error {
+	defer close(w.done)
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, w.concurrency)
+
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.consume(); err != nil {
+				errCh <- err
+				// Stop the other consumers too
+				w.cancel()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	// Return the first error if any
+	return <-errCh
+}
+
+// consume receives and processes messages until the worker is shut down or an error is returned.
+func (w *Worker) consume() error {
+	for {
+		msg, err := w.queue.Receive(w.ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) && w.ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		// The in-flight messages are processed to completion even if the worker is being shut down
+		ctx := context.Background()
+
+		if err := w.handler.Handle(ctx, msg); err != nil {
+			if err := w.retry(msg); err != nil {
+				return err
+			}
+		} else {
+			w.forget(msg)
+			if err := w.queue.Ack(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// retry puts a failed message back in the queue after a backoff.
+// A message that keeps failing is dropped once it is retried too many times.
+func (w *Worker) retry(msg *queue.Message) error {
+	w.failuresMu.Lock()
+	w.failures[msg.ID]++
+	failures := w.failures[msg.ID]
+	w.failuresMu.Unlock()
+
+	if failures > w.maxRetries {
+		w.forget(msg)
+		return w.queue.Ack(context.Background(), msg)
+	}
+
+	backoff := w.backoff
+	for i := 1; i < failures && backoff < maxWorkerBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxWorkerBackoff {
+		backoff = maxWorkerBackoff
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	// The backoff is cut short if the worker is being shut down
+	select {
+	case <-timer.C:
+	case <-w.ctx.Done():
+	}
+
+	// Nack may block (i.e. when the queue is full), so it is cancelled if the worker is being shut down
+	if err := w.queue.Nack(w.ctx, msg); err != nil {
+		if errors.Is(err, context.Canceled) && w.ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// forget removes the failure count of a message.
+func (w *Worker) forget(msg *queue.Message) {
+	w.failuresMu.Lock()
+	defer w.failuresMu.Unlock()
+
+	delete(w.failures, msg.ID)
+}
+
+// Shutdown gracefully stops the worker.
+// It stops receiving new messages and blocks the current goroutine until all the in-flight messages are processed.
+// If the context is cancelled, an error will be returned.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
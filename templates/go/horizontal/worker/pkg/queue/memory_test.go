@@ -0,0 +1,108 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMemoryQueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		queueName    string
+		size         int
+		expectedSize int
+	}{
+		{
+			name:         "DefaultSize",
+			queueName:    "test",
+			size:         0,
+			expectedSize: 100,
+		},
+		{
+			name:         "OK",
+			queueName:    "test",
+			size:         10,
+			expectedSize: 10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewMemoryQueue(tc.queueName, tc.size)
+
+			assert.NotNil(t, q)
+			assert.Equal(t, tc.queueName, q.String())
+			assert.Equal(t, tc.expectedSize, cap(q.messages))
+		})
+	}
+}
+
+func TestMemoryQueue_Lifecycle(t *testing.T) {
+	q := NewMemoryQueue("test", 1)
+	ctx := context.Background()
+
+	assert.NoError(t, q.Connect())
+	assert.NoError(t, q.CheckHealth(ctx))
+
+	assert.NoError(t, q.Disconnect(ctx))
+	assert.Equal(t, ErrClosed, q.CheckHealth(ctx))
+	assert.Equal(t, ErrClosed, q.Send(ctx, &Message{}))
+	_, err := q.Receive(ctx)
+	assert.Equal(t, ErrClosed, err)
+
+	assert.NoError(t, q.Connect())
+	assert.NoError(t, q.CheckHealth(ctx))
+}
+
+func TestMemoryQueue_SendReceive(t *testing.T) {
+	q := NewMemoryQueue("test", 1)
+	ctx := context.Background()
+
+	t.Run("Ack", func(t *testing.T) {
+		assert.NoError(t, q.Send(ctx, &Message{Body: []byte("first")}))
+
+		msg, err := q.Receive(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", msg.ID)
+		assert.Equal(t, []byte("first"), msg.Body)
+		assert.Len(t, q.inflight, 1)
+
+		assert.NoError(t, q.Ack(ctx, msg))
+		assert.Len(t, q.inflight, 0)
+	})
+
+	t.Run("Nack", func(t *testing.T) {
+		assert.NoError(t, q.Send(ctx, &Message{Body: []byte("second")}))
+
+		msg, err := q.Receive(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", msg.ID)
+
+		assert.NoError(t, q.Nack(ctx, msg))
+		assert.Len(t, q.inflight, 0)
+
+		// The message is delivered again
+		msg, err = q.Receive(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "2", msg.ID)
+		assert.Equal(t, []byte("second"), msg.Body)
+		assert.NoError(t, q.Ack(ctx, msg))
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// The queue is empty
+		_, err := q.Receive(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+
+		// The queue is full
+		assert.NoError(t, q.Send(ctx, &Message{}))
+		err = q.Send(ctx, &Message{})
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+}
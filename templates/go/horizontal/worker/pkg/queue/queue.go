@@ -0,0 +1,57 @@
+// Package queue defines the interfaces for consuming messages from a message queue.
+// It also provides an in-memory implementation of the Queue interface for development and testing.
+package queue
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClosed is returned when receiving from or sending to a closed queue.
+var ErrClosed = errors.New("queue is closed")
+
+// Message is a message received from or sent to a queue.
+type Message struct {
+	ID         string
+	Body       []byte
+	Attributes map[string]string
+}
+
+// Queue is the interface for a message queue.
+// A real implementation (SQS, Pub/Sub, RabbitMQ, Kafka, etc.) can be plugged in by implementing this interface.
+type Queue interface {
+	// Send sends a new message to the queue.
+	Send(context.Context, *Message) error
+	// Receive blocks until a message is available or the context is cancelled.
+	Receive(context.Context) (*Message, error)
+	// Ack acknowledges a message was processed successfully, so it will not be delivered again.
+	Ack(context.Context, *Message) error
+	// Nack acknowledges a message was not processed successfully, so it will be delivered again.
+	Nack(context.Context, *Message) error
+}
+
+// Handler is the interface for processing messages received from a queue.
+type Handler interface {
+	Handle(context.Context, *Message) error
+}
+
+// HandlerFunc is an adapter to allow the use of ordinary functions as message handlers.
+type HandlerFunc func(context.Context, *Message) error
+
+// Handle calls f(ctx, msg).
+func (f HandlerFunc) Handle(ctx context.Context, msg *Message) error {
+	return f(ctx, msg)
+}
+
+// Middleware is the interface for wrapping message handlers.
+type Middleware interface {
+	Wrap(Handler) Handler
+}
+
+// MiddlewareFunc is an adapter to allow the use of ordinary functions as middleware.
+type MiddlewareFunc func(Handler) Handler
+
+// Wrap calls f(h).
+func (f MiddlewareFunc) Wrap(h Handler) Handler {
+	return f(h)
+}
@@ -0,0 +1,12 @@
+package command
+
+const (
+	// Success is the exit code when a command execution is successful.
+	Success int = iota
+	// ConfigError is the exit code when reading the configurations fails.
+	ConfigError
+	// FlagError is the exit code when an undefined or invalid flag is provided to a command.
+	FlagError
+	// InputError is the exit code when a user provides an invalid input.
+	InputError
+)
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	config := Default()
+
+	assert.Equal(t, Config{Greeting: "Hello"}, config)
+}
+
+func TestFromFile(t *testing.T) {
+	tests := []struct {
+		name           string
+		file           string
+		content        string
+		expectedConfig Config
+		expectedError  string
+	}{
+		{
+			name:           "NoFile",
+			expectedConfig: Config{Greeting: "Hello"},
+		},
+		{
+			name:          "InvalidFile",
+			file:          ".app.yaml",
+			content:       "greeting: [",
+			expectedError: "yaml: line 1: did not find expected node content",
+		},
+		{
+			name:           "Success",
+			file:           ".app.yaml",
+			content:        "greeting: Hi\nno_color: true\n",
+			expectedConfig: Config{Greeting: "Hi", NoColor: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "config-")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			wd, err := os.Getwd()
+			assert.NoError(t, err)
+			assert.NoError(t, os.Chdir(dir))
+			defer func() {
+				assert.NoError(t, os.Chdir(wd))
+			}()
+
+			// Isolate the test from the configurations in the home directory
+			home := os.Getenv("HOME")
+			os.Setenv("HOME", dir)
+			defer os.Setenv("HOME", home)
+
+			if tc.file != "" {
+				err := ioutil.WriteFile(filepath.Join(dir, tc.file), []byte(tc.content), 0644)
+				assert.NoError(t, err)
+			}
+
+			config, err := FromFile("app")
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedConfig, config)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectedConfig Config
+		expectedError  string
+	}{
+		{
+			name:          "NoFile",
+			path:          "/dev/null/config.yaml",
+			expectedError: "open /dev/null/config.yaml: not a directory",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := Read(tc.path)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedConfig, config)
+			}
+		})
+	}
+}
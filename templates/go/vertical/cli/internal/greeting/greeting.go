@@ -0,0 +1,108 @@
+package greeting
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"vertical/cli/internal/command"
+	"vertical/cli/internal/config"
+)
+
+const (
+	greetSynopsis = `Greet someone`
+	greetHelp     = `
+  Use this command for greeting someone.
+
+  Usage:  greet [flags]
+
+  Flags:
+    -name    the name of the person to greet (prompted if not provided)
+    -yell    greet in uppercase
+
+  Examples:
+    greet
+    greet -name=Jane
+    greet -name=Jane -yell
+  `
+)
+
+// Command is the cli.Command implementation for greet command.
+type Command struct {
+	ui     cli.Ui
+	config config.Config
+}
+
+// NewCommand creates a greet command.
+func NewCommand(ui cli.Ui, config config.Config) (*Command, error) {
+	return &Command{
+		ui:     ui,
+		config: config,
+	}, nil
+}
+
+// Synopsis returns a short one-line synopsis of the command.
+func (c *Command) Synopsis() string {
+	return greetSynopsis
+}
+
+// Help returns a long help text including usage, description, and list of flags for the command.
+func (c *Command) Help() string {
+	return greetHelp
+}
+
+// Run runs the actual command with the given command-line arguments.
+func (c *Command) Run(args []string) int {
+	flags := struct {
+		name string
+		yell bool
+	}{}
+
+	fs := flag.NewFlagSet("greet", flag.ContinueOnError)
+	fs.StringVar(&flags.name, "name", "", "")
+	fs.BoolVar(&flags.yell, "yell", false, "")
+	fs.Usage = func() {
+		c.ui.Output(c.Help())
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return command.FlagError
+	}
+
+	if flags.name == "" {
+		name, err := c.ui.Ask("Name:")
+		if err != nil {
+			c.ui.Error(fmt.Sprintf("invalid name: %s", err))
+			return command.InputError
+		}
+		flags.name = name
+	}
+
+	greeting, err := greet(c.config.Greeting, flags.name, flags.yell)
+	if err != nil {
+		c.ui.Error(err.Error())
+		return command.InputError
+	}
+
+	c.ui.Info(greeting)
+
+	return command.Success
+}
+
+// greet creates a greeting for a name.
+func greet(greeting, name string, yell bool) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+
+	s := fmt.Sprintf("%s, %s!", greeting, name)
+	if yell {
+		s = strings.ToUpper(s)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,133 @@
+package greeting
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+
+	"vertical/cli/internal/command"
+	"vertical/cli/internal/config"
+)
+
+func TestNewCommand(t *testing.T) {
+	ui := cli.NewMockUi()
+	c, err := NewCommand(ui, config.Default())
+
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestCommand_Synopsis(t *testing.T) {
+	c := new(Command)
+	synopsis := c.Synopsis()
+
+	assert.NotEmpty(t, synopsis)
+}
+
+func TestCommand_Help(t *testing.T) {
+	c := new(Command)
+	help := c.Help()
+
+	assert.NotEmpty(t, help)
+}
+
+func TestCommand_Run(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		inputs           string
+		expectedExitCode int
+		expectedOutput   string
+	}{
+		{
+			name:             "UndefinedFlag",
+			args:             []string{"-undefined"},
+			expectedExitCode: command.FlagError,
+		},
+		{
+			name:             "NoInput",
+			args:             []string{},
+			expectedExitCode: command.InputError,
+		},
+		{
+			name:             "EmptyName",
+			args:             []string{},
+			inputs:           "\n",
+			expectedExitCode: command.InputError,
+		},
+		{
+			name:             "Prompt",
+			args:             []string{},
+			inputs:           "Jane\n",
+			expectedExitCode: command.Success,
+			expectedOutput:   "Hello, Jane!\n",
+		},
+		{
+			name:             "Yell",
+			args:             []string{"-name=Jane", "-yell"},
+			expectedExitCode: command.Success,
+			expectedOutput:   "HELLO, JANE!\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			ui.InputReader = strings.NewReader(tc.inputs)
+
+			c, err := NewCommand(ui, config.Default())
+			assert.NoError(t, err)
+
+			exitCode := c.Run(tc.args)
+
+			assert.Equal(t, tc.expectedExitCode, exitCode)
+			assert.Contains(t, ui.OutputWriter.String(), tc.expectedOutput)
+		})
+	}
+}
+
+func TestGreet(t *testing.T) {
+	tests := []struct {
+		name             string
+		greeting         string
+		person           string
+		yell             bool
+		expectedGreeting string
+		expectedError    string
+	}{
+		{
+			name:          "NoName",
+			greeting:      "Hello",
+			person:        " ",
+			expectedError: "name is required",
+		},
+		{
+			name:             "Success",
+			greeting:         "Hi",
+			person:           "Jane",
+			expectedGreeting: "Hi, Jane!",
+		},
+		{
+			name:             "Yell",
+			greeting:         "Hi",
+			person:           "Jane",
+			yell:             true,
+			expectedGreeting: "HI, JANE!",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			greeting, err := greet(tc.greeting, tc.person, tc.yell)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedGreeting, greeting)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package library is intended to be used as a template for scaffolding a new Go library.
+//
+// A Greeter creates greetings for names:
+//
+//	g := library.NewGreeter("Hello")
+//	greeting, err := g.Greet("Jane")
+//
+// Rename this package after the last element of your module path.
+package library
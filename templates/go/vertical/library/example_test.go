@@ -0,0 +1,25 @@
+package library_test
+
+import (
+	"fmt"
+
+	"vertical/library"
+)
+
+func ExampleNewGreeter() {
+	g := library.NewGreeter("")
+	greeting, _ := g.Greet("Jane")
+	fmt.Println(greeting)
+	// Output: Hello, Jane!
+}
+
+func ExampleGreeter_Greet() {
+	g := library.NewGreeter("Hi")
+	greeting, err := g.Greet("")
+	fmt.Println(greeting == "", err)
+	greeting, _ = g.Greet("Jane")
+	fmt.Println(greeting)
+	// Output:
+	// true name is required
+	// Hi, Jane!
+}
@@ -0,0 +1,36 @@
+package library
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const defaultGreeting = "Hello"
+
+// Greeter creates greetings for names.
+type Greeter struct {
+	greeting string
+}
+
+// NewGreeter creates a new greeter.
+// If no greeting is given, the default greeting will be used.
+func NewGreeter(greeting string) *Greeter {
+	if greeting == "" {
+		greeting = defaultGreeting
+	}
+
+	return &Greeter{
+		greeting: greeting,
+	}
+}
+
+// Greet creates and returns a greeting for a given name.
+func (g *Greeter) Greet(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+
+	return fmt.Sprintf("%s, %s!", g.greeting, name), nil
+}
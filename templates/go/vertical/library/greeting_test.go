@@ -0,0 +1,71 @@
+package library
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGreeter(t *testing.T) {
+	tests := []struct {
+		name             string
+		greeting         string
+		expectedGreeting string
+	}{
+		{
+			name:             "Default",
+			greeting:         "",
+			expectedGreeting: "Hello",
+		},
+		{
+			name:             "Custom",
+			greeting:         "Hi",
+			expectedGreeting: "Hi",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGreeter(tc.greeting)
+
+			assert.NotNil(t, g)
+			assert.Equal(t, tc.expectedGreeting, g.greeting)
+		})
+	}
+}
+
+func TestGreeter_Greet(t *testing.T) {
+	tests := []struct {
+		name             string
+		greeter          *Greeter
+		person           string
+		expectedGreeting string
+		expectedError    string
+	}{
+		{
+			name:          "NoName",
+			greeter:       &Greeter{greeting: "Hello"},
+			person:        " ",
+			expectedError: "name is required",
+		},
+		{
+			name:             "Success",
+			greeter:          &Greeter{greeting: "Hello"},
+			person:           "Jane",
+			expectedGreeting: "Hello, Jane!",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			greeting, err := tc.greeter.Greet(tc.person)
+
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedGreeting, greeting)
+			}
+		})
+	}
+}
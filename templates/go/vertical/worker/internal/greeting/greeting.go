@@ -0,0 +1,54 @@
+package greeting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"vertical/worker/pkg/client"
+	"vertical/worker/pkg/queue"
+)
+
+// GreetRequest is the message (wire/transport protocol) model for a Greet request.
+type GreetRequest struct {
+	Name string `json:"name"`
+}
+
+// Service implements the message handler for Greeting messages.
+type Service struct {
+	dbClient        client.Client
+	translateClient client.Client
+}
+
+// NewService creates a new instance of Greeting service.
+func NewService(dbClient client.Client, translateClient client.Client) (*Service, error) {
+	return &Service{
+		dbClient:        dbClient,
+		translateClient: translateClient,
+	}, nil
+}
+
+// Handle is the handler for Greet messages.
+func (s *Service) Handle(ctx context.Context, msg *queue.Message) error {
+	req := new(GreetRequest)
+	if err := json.Unmarshal(msg.Body, req); err != nil {
+		return err
+	}
+
+	if req.Name == "" {
+		return errors.New("name cannot be empty")
+	}
+
+	// Call an external service through its client
+	// s.translateClient
+	hello := "Hello"
+
+	greeting := fmt.Sprintf("%s, %s!", hello, req.Name)
+
+	// Interact with a data store through its client
+	// s.dbClient
+	_ = greeting
+
+	return nil
+}
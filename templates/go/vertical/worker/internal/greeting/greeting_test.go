@@ -0,0 +1,89 @@
+package greeting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"vertical/worker/pkg/client"
+	"vertical/worker/pkg/queue"
+)
+
+func TestNewService(t *testing.T) {
+	dbClient, _ := client.New("db-client")
+	translateClient, _ := client.New("translate-client")
+
+	tests := []struct {
+		name            string
+		dbClient        client.Client
+		translateClient client.Client
+	}{
+		{
+			name:            "OK",
+			dbClient:        dbClient,
+			translateClient: translateClient,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service, err := NewService(tc.dbClient, tc.translateClient)
+
+			assert.NoError(t, err)
+			assert.NotNil(t, service)
+		})
+	}
+}
+
+func TestServiceHandle(t *testing.T) {
+	dbClient, _ := client.New("db-client")
+	translateClient, _ := client.New("translate-client")
+
+	tests := []struct {
+		name            string
+		dbClient        client.Client
+		translateClient client.Client
+		ctx             context.Context
+		msg             *queue.Message
+		expectedError   string
+	}{
+		{
+			name:          "MessageDecodingFails",
+			ctx:           context.Background(),
+			msg:           &queue.Message{Body: []byte(`{`)},
+			expectedError: "unexpected end of JSON input",
+		},
+		{
+			name:          "EmptyName",
+			ctx:           context.Background(),
+			msg:           &queue.Message{Body: []byte(`{ "name": "" }`)},
+			expectedError: "name cannot be empty",
+		},
+		{
+			name:            "Success",
+			dbClient:        dbClient,
+			translateClient: translateClient,
+			ctx:             context.Background(),
+			msg:             &queue.Message{Body: []byte(`{ "name": "Jane" }`)},
+			expectedError:   "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &Service{
+				dbClient:        tc.dbClient,
+				translateClient: tc.translateClient,
+			}
+
+			err := service.Handle(tc.ctx, tc.msg)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"vertical/worker/pkg/queue"
+)
+
+// mockQueue is a mock implementation of queue.Queue interface.
+// It wraps an in-memory queue and fails Ack and Nack calls if an error is set.
+type mockQueue struct {
+	*queue.MemoryQueue
+	AckError  error
+	NackError error
+}
+
+func (m *mockQueue) Ack(ctx context.Context, msg *queue.Message) error {
+	if m.AckError != nil {
+		return m.AckError
+	}
+	return m.MemoryQueue.Ack(ctx, msg)
+}
+
+func (m *mockQueue) Nack(ctx context.Context, msg *queue.Message) error {
+	if m.NackError != nil {
+		return m.NackError
+	}
+	return m.MemoryQueue.Nack(ctx, msg)
+}
+
+// mockHandler is a mock implementation of queue.Handler interface.
+type mockHandler struct {
+	sync.Mutex
+	Errors   []error
+	Messages []*queue.Message
+}
+
+func (m *mockHandler) Handle(ctx context.Context, msg *queue.Message) error {
+	m.Lock()
+	defer m.Unlock()
+
+	i := len(m.Messages)
+	m.Messages = append(m.Messages, msg)
+	if i < len(m.Errors) {
+		return m.Errors[i]
+	}
+	return nil
+}
+
+func TestNewWorker(t *testing.T) {
+	tests := []struct {
+		name                string
+		queue               queue.Queue
+		handler             queue.Handler
+		opts                WorkerOptions
+		expectedConcurrency int
+		expectedMaxRetries  int
+		expectedBackoff     time.Duration
+	}{
+		{
+			name:                "Defaults",
+			queue:               queue.NewMemoryQueue("test", 1),
+			handler:             &mockHandler{},
+			opts:                WorkerOptions{},
+			expectedConcurrency: 1,
+			expectedMaxRetries:  5,
+			expectedBackoff:     100 * time.Millisecond,
+		},
+		{
+			name:    "WithOptions",
+			queue:   queue.NewMemoryQueue("test", 1),
+			handler: &mockHandler{},
+			opts: WorkerOptions{
+				Concurrency: 4,
+				MaxRetries:  2,
+				Backoff:     time.Second,
+				Middleware: []queue.Middleware{
+					queue.MiddlewareFunc(func(h queue.Handler) queue.Handler {
+						return h
+					}),
+				},
+			},
+			expectedConcurrency: 4,
+			expectedMaxRetries:  2,
+			expectedBackoff:     time.Second,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			worker, err := NewWorker(tc.queue, tc.handler, tc.opts)
+
+			assert.NoError(t, err)
+			assert.NotNil(t, worker)
+			assert.Equal(t, tc.expectedConcurrency, worker.concurrency)
+			assert.Equal(t, tc.expectedMaxRetries, worker.maxRetries)
+			assert.Equal(t, tc.expectedBackoff, worker.backoff)
+		})
+	}
+}
+
+func TestWorkerString(t *testing.T) {
+	tests := []struct {
+		name           string
+		worker         *Worker
+		expectedString string
+	}{
+		{
+			name:           "OK",
+			worker:         &Worker{},
+			expectedString: "worker",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedString, tc.worker.String())
+		})
+	}
+}
+
+func TestWorkerListenAndServe(t *testing.T) {
+	tests := []struct {
+		name          string
+		queue         *mockQueue
+		handler       *mockHandler
+		messages      []*queue.Message
+		expectedError string
+	}{
+		{
+			name: "ReceiveFails",
+			queue: &mockQueue{
+				MemoryQueue: queue.NewMemoryQueue("test", 1),
+			},
+			handler:       &mockHandler{},
+			expectedError: "queue is closed",
+		},
+		{
+			name: "AckFails",
+			queue: &mockQueue{
+				MemoryQueue: queue.NewMemoryQueue("test", 1),
+				AckError:    errors.New("ack error"),
+			},
+			handler: &mockHandler{},
+			messages: []*queue.Message{
+				{Body: []byte("message")},
+			},
+			expectedError: "ack error",
+		},
+		{
+			name: "NackFails",
+			queue: &mockQueue{
+				MemoryQueue: queue.NewMemoryQueue("test", 1),
+				NackError:   errors.New("nack error"),
+			},
+			handler: &mockHandler{
+				Errors: []error{errors.New("handler error")},
+			},
+			messages: []*queue.Message{
+				{Body: []byte("message")},
+			},
+			expectedError: "nack error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, msg := range tc.messages {
+				assert.NoError(t, tc.queue.Send(ctx, msg))
+			}
+
+			// An empty queue is closed for making Receive fail
+			if len(tc.messages) == 0 {
+				assert.NoError(t, tc.queue.Disconnect(ctx))
+			}
+
+			worker, err := NewWorker(tc.queue, tc.handler, WorkerOptions{
+				Backoff: time.Millisecond,
+			})
+			assert.NoError(t, err)
+
+			err = worker.ListenAndServe()
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestWorker(t *testing.T) {
+	q := queue.NewMemoryQueue("test", 10)
+	handler := &mockHandler{
+		// The first message is failed and delivered again
+		Errors: []error{errors.New("handler error")},
+	}
+
+	worker, err := NewWorker(q, handler, WorkerOptions{
+		Concurrency: 2,
+		Backoff:     time.Millisecond,
+	})
+	assert.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- worker.ListenAndServe()
+	}()
+
+	ctx := context.Background()
+	assert.NoError(t, q.Send(ctx, &queue.Message{Body: []byte("first")}))
+	assert.NoError(t, q.Send(ctx, &queue.Message{Body: []byte("second")}))
+
+	assert.Eventually(t, func() bool {
+		handler.Lock()
+		defer handler.Unlock()
+		return len(handler.Messages) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, worker.Shutdown(ctx))
+	assert.NoError(t, <-errCh)
+}
+
+func TestWorker_MaxRetries(t *testing.T) {
+	q := queue.NewMemoryQueue("test", 10)
+	handler := &mockHandler{
+		// The message keeps failing and is dropped after two retries
+		Errors: []error{
+			errors.New("handler error"),
+			errors.New("handler error"),
+			errors.New("handler error"),
+			errors.New("handler error"),
+		},
+	}
+
+	worker, err := NewWorker(q, handler, WorkerOptions{
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+	})
+	assert.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- worker.ListenAndServe()
+	}()
+
+	ctx := context.Background()
+	assert.NoError(t, q.Send(ctx, &queue.Message{Body: []byte("message")}))
+
+	assert.Eventually(t, func() bool {
+		handler.Lock()
+		defer handler.Unlock()
+		return len(handler.Messages) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Never(t, func() bool {
+		handler.Lock()
+		defer handler.Unlock()
+		return len(handler.Messages) > 3
+	}, 50*time.Millisecond, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, worker.Shutdown(ctx))
+	assert.NoError(t, <-errCh)
+
+	assert.Empty(t, worker.failures)
+}
+
+func TestWorker_ShutdownWhileRetrying(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		backoff time.Duration
+		wait    time.Duration
+	}{
+		{
+			name:    "Backoff",
+			size:    10,
+			backoff: time.Hour,
+		},
+		{
+			// The failed message is not put back in the queue before another one fills it up
+			name:    "QueueFull",
+			size:    1,
+			backoff: 100 * time.Millisecond,
+			wait:    200 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := queue.NewMemoryQueue("test", tc.size)
+			handler := &mockHandler{
+				Errors: []error{errors.New("handler error")},
+			}
+
+			worker, err := NewWorker(q, handler, WorkerOptions{
+				Backoff: tc.backoff,
+			})
+			assert.NoError(t, err)
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- worker.ListenAndServe()
+			}()
+
+			ctx := context.Background()
+			assert.NoError(t, q.Send(ctx, &queue.Message{Body: []byte("first")}))
+
+			assert.Eventually(t, func() bool {
+				handler.Lock()
+				defer handler.Unlock()
+				return len(handler.Messages) == 1
+			}, time.Second, 10*time.Millisecond)
+
+			assert.NoError(t, q.Send(ctx, &queue.Message{Body: []byte("second")}))
+			time.Sleep(tc.wait)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			assert.NoError(t, worker.Shutdown(ctx))
+			assert.NoError(t, <-errCh)
+		})
+	}
+}
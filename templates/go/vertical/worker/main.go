@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path"
+	"runtime/debug"
+
+	"github.com/moorara/graceful"
+	"github.com/moorara/health"
+	"github.com/moorara/konfig"
+	"github.com/moorara/observer"
+	"go.uber.org/zap"
+
+	"vertical/worker/internal/greeting"
+	"vertical/worker/internal/server"
+	"vertical/worker/pkg/client"
+	"vertical/worker/pkg/queue"
+	"vertical/worker/version"
+)
+
+// Configurations
+var config = struct {
+	Name                 string
+	HTTPPort             uint16
+	Concurrency          int
+	QueueSize            int
+	Environment          string
+	Region               string
+	LogLevel             string
+	OpenTelemetryAddress string
+}{
+	Name:        "worker",
+	HTTPPort:    4000,    // default
+	Concurrency: 1,       // default
+	QueueSize:   100,     // default
+	Environment: "dev",   // default
+	Region:      "local", // default
+	LogLevel:    "debug", // default
+}
+
+func init() {
+	// The application name is the last element of the module path
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path != "" {
+		config.Name = path.Base(info.Main.Path)
+	}
+}
+
+func main() {
+	// Get configurations
+	_ = konfig.Pick(&config)
+	flag.Parse()
+
+	// CREATE AN OBSERVER
+
+	observerOpts := []observer.Option{
+		observer.WithMetadata(config.Name, version.Version, config.Environment, config.Region, map[string]string{}),
+		observer.WithLogger(config.LogLevel),
+	}
+
+	if config.OpenTelemetryAddress != "" {
+		observerOpts = append(observerOpts,
+			observer.WithOpenTelemetry(config.OpenTelemetryAddress, nil),
+		)
+	}
+
+	observer := observer.New(true, observerOpts...)
+
+	// CREATE QUEUES
+
+	// Replace the in-memory queue with a real message queue (SQS, Pub/Sub, RabbitMQ, Kafka, etc.)
+	greetingQueue := queue.NewMemoryQueue("greeting-queue", config.QueueSize)
+
+	// CREATE CLIENTS
+
+	dbClient, err := client.New("db-client")
+	if err != nil {
+		observer.Logger().Fatal("failed to create database client", zap.Error(err))
+	}
+
+	translateClient, err := client.New("translate-client")
+	if err != nil {
+		observer.Logger().Fatal("failed to create translate client", zap.Error(err))
+	}
+
+	// CREATE SERVICES
+
+	greetingService, err := greeting.NewService(dbClient, translateClient)
+	if err != nil {
+		observer.Logger().Fatal("failed to create greetting service", zap.Error(err))
+	}
+
+	// CREATE SERVERS
+
+	// Create an HTTP health handler for health checking the service by external systems
+	health.SetLogger(observer.Logger().Sugar())
+	health.RegisterChecker(greetingQueue, dbClient, translateClient)
+	healthHandler := health.HandlerFunc()
+
+	httpServer, err := server.NewHTTPServer(healthHandler, server.HTTPServerOptions{
+		Port: config.HTTPPort,
+	})
+
+	if err != nil {
+		observer.Logger().Fatal("failed to create http server", zap.Error(err))
+	}
+
+	worker, err := server.NewWorker(greetingQueue, greetingService, server.WorkerOptions{
+		Concurrency: config.Concurrency,
+	})
+
+	if err != nil {
+		observer.Logger().Fatal("failed to create worker", zap.Error(err))
+	}
+
+	// Gracefully, connect the clients and start the servers
+	// Gracefully, retry the lost connections
+	// Gracefully, disconnect the clients and shutdown the servers on termination signals
+	graceful.SetLogger(observer.Logger().Sugar())
+	graceful.RegisterClient(greetingQueue, dbClient, translateClient)
+	graceful.RegisterServer(httpServer, worker)
+	code := graceful.StartAndWait()
+
+	os.Exit(code)
+}
@@ -0,0 +1,138 @@
+package queue
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+const defaultMemoryQueueSize = 100
+
+// MemoryQueue is an in-memory queue implementing Queue interface.
+// It also implements graceful.Client and health.Checker interfaces.
+type MemoryQueue struct {
+	sync.Mutex
+	name     string
+	closed   bool
+	lastID   int
+	messages chan *Message
+	inflight map[string]*Message
+}
+
+// NewMemoryQueue creates a new in-memory queue.
+// size is the maximum number of messages waiting in the queue.
+func NewMemoryQueue(name string, size int) *MemoryQueue {
+	if size <= 0 {
+		size = defaultMemoryQueueSize
+	}
+
+	return &MemoryQueue{
+		name:     name,
+		messages: make(chan *Message, size),
+		inflight: make(map[string]*Message),
+	}
+}
+
+// String returns the name of the queue.
+func (q *MemoryQueue) String() string {
+	return q.name
+}
+
+// Connect opens the queue.
+func (q *MemoryQueue) Connect() error {
+	q.Lock()
+	defer q.Unlock()
+
+	q.closed = false
+	return nil
+}
+
+// Disconnect closes the queue.
+// The messages waiting in the queue are kept and can be received after the queue is opened again.
+func (q *MemoryQueue) Disconnect(ctx context.Context) error {
+	q.Lock()
+	defer q.Unlock()
+
+	q.closed = true
+	return nil
+}
+
+// CheckHealth checks the health of the queue.
+func (q *MemoryQueue) CheckHealth(ctx context.Context) error {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+func (q *MemoryQueue) isClosed() bool {
+	q.Lock()
+	defer q.Unlock()
+
+	return q.closed
+}
+
+// Send sends a new message to the queue.
+// It blocks if the queue is full until there is room for the message or the context is cancelled.
+func (q *MemoryQueue) Send(ctx context.Context, msg *Message) error {
+	q.Lock()
+	if q.closed {
+		q.Unlock()
+		return ErrClosed
+	}
+	if msg.ID == "" {
+		q.lastID++
+		msg.ID = strconv.Itoa(q.lastID)
+	}
+	q.Unlock()
+
+	select {
+	case q.messages <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive blocks until a message is available or the context is cancelled.
+func (q *MemoryQueue) Receive(ctx context.Context) (*Message, error) {
+	if q.isClosed() {
+		return nil, ErrClosed
+	}
+
+	select {
+	case msg := <-q.messages:
+		q.Lock()
+		q.inflight[msg.ID] = msg
+		q.Unlock()
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Ack removes a received message from the queue.
+func (q *MemoryQueue) Ack(ctx context.Context, msg *Message) error {
+	q.Lock()
+	defer q.Unlock()
+
+	delete(q.inflight, msg.ID)
+	return nil
+}
+
+// Nack puts a received message back in the queue, so it can be received again.
+func (q *MemoryQueue) Nack(ctx context.Context, msg *Message) error {
+	q.Lock()
+	delete(q.inflight, msg.ID)
+	q.Unlock()
+
+	select {
+	case q.messages <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,68 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerFunc(t *testing.T) {
+	tests := []struct {
+		name          string
+		f             HandlerFunc
+		ctx           context.Context
+		msg           *Message
+		expectedError error
+	}{
+		{
+			name: "OK",
+			f: func(ctx context.Context, msg *Message) error {
+				return errors.New("error")
+			},
+			ctx:           context.Background(),
+			msg:           &Message{},
+			expectedError: errors.New("error"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.f.Handle(tc.ctx, tc.msg)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestMiddlewareFunc(t *testing.T) {
+	tests := []struct {
+		name          string
+		f             MiddlewareFunc
+		handler       Handler
+		expectedError error
+	}{
+		{
+			name: "OK",
+			f: func(h Handler) Handler {
+				return HandlerFunc(func(ctx context.Context, msg *Message) error {
+					return errors.New("wrapped")
+				})
+			},
+			handler: HandlerFunc(func(ctx context.Context, msg *Message) error {
+				return nil
+			}),
+			expectedError: errors.New("wrapped"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tc.f.Wrap(tc.handler)
+			err := h.Handle(context.Background(), &Message{})
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}